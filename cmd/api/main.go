@@ -30,13 +30,17 @@ import (
 	"go-zakat-be/internal/delivery/http/handler"
 	"go-zakat-be/internal/delivery/http/middleware"
 	domainValidator "go-zakat-be/internal/delivery/http/validator"
+	"go-zakat-be/internal/domain/service"
+	"go-zakat-be/internal/infrastructure/document"
 	"go-zakat-be/internal/infrastructure/jwt"
 	"go-zakat-be/internal/infrastructure/oauth"
+	"go-zakat-be/internal/infrastructure/storage"
 	"go-zakat-be/internal/repository/postgres"
 	"go-zakat-be/internal/usecase"
 
 	"go-zakat-be/pkg/config"
 	"go-zakat-be/pkg/database"
+	"go-zakat-be/pkg/receiptnumber"
 )
 
 func main() {
@@ -101,24 +105,137 @@ func main() {
 
 	// Mustahiq dependencies
 	mustahiqRepo := postgres.NewMustahiqRepository(dbPool, logr)
-	mustahiqUC := usecase.NewMustahiqUseCase(mustahiqRepo, val)
+	mustahiqAssessmentRepo := postgres.NewMustahiqAssessmentRepository(dbPool, logr)
+	mustahiqUC := usecase.NewMustahiqUseCase(mustahiqRepo, mustahiqAssessmentRepo, val)
 	mustahiqHandler := handler.NewMustahiqHandler(mustahiqUC)
 
+	// Mustahiq assessment dependencies
+	assessmentQuestionnaireRepo := postgres.NewAssessmentQuestionnaireRepository(dbPool, logr)
+	assessmentQuestionnaireUC := usecase.NewAssessmentQuestionnaireUseCase(assessmentQuestionnaireRepo, asnafRepo, val)
+	assessmentQuestionnaireHandler := handler.NewAssessmentQuestionnaireHandler(assessmentQuestionnaireUC)
+	mustahiqAssessmentUC := usecase.NewMustahiqAssessmentUseCase(mustahiqAssessmentRepo, assessmentQuestionnaireRepo, mustahiqRepo, val)
+	mustahiqAssessmentHandler := handler.NewMustahiqAssessmentHandler(mustahiqAssessmentUC)
+
+	// Household dependencies
+	householdRepo := postgres.NewHouseholdRepository(dbPool, logr)
+	householdUC := usecase.NewHouseholdUseCase(householdRepo, mustahiqRepo, val)
+	householdHandler := handler.NewHouseholdHandler(householdUC)
+
+	// Duplicate candidate dependencies
+	duplicateDismissalRepo := postgres.NewDuplicateDismissalRepository(dbPool, logr)
+	duplicateCandidateUC := usecase.NewDuplicateCandidateUseCase(mustahiqRepo, muzakkiRepo, duplicateDismissalRepo, val)
+	duplicateCandidateHandler := handler.NewDuplicateCandidateHandler(duplicateCandidateUC)
+
 	// Program dependencies
 	programRepo := postgres.NewProgramRepository(dbPool, logr)
 	programUC := usecase.NewProgramUseCase(programRepo, val)
 	programHandler := handler.NewProgramHandler(programUC)
 
+	// Aid rule dependencies
+	aidRuleRepo := postgres.NewAidRuleRepository(dbPool, logr)
+	aidRuleUC := usecase.NewAidRuleUseCase(aidRuleRepo, val)
+	aidRuleHandler := handler.NewAidRuleHandler(aidRuleUC)
+
+	// Commodity price dependencies
+	commodityPriceRepo := postgres.NewCommodityPriceRepository(dbPool, logr)
+	commodityPriceUC := usecase.NewCommodityPriceUseCase(commodityPriceRepo, val)
+	commodityPriceHandler := handler.NewCommodityPriceHandler(commodityPriceUC)
+
+	// Zakat calculator dependencies
+	zakatCalculationRepo := postgres.NewZakatCalculationRepository(dbPool, logr)
+	zakatUC := usecase.NewZakatCalculatorUseCase(zakatCalculationRepo, muzakkiRepo, commodityPriceRepo, val)
+	zakatHandler := handler.NewZakatHandler(zakatUC)
+
+	// Fitrah rate dependencies
+	fitrahRateRepo := postgres.NewFitrahRateRepository(dbPool, logr)
+	fitrahRateUC := usecase.NewFitrahRateUseCase(fitrahRateRepo, val)
+	fitrahRateHandler := handler.NewFitrahRateHandler(fitrahRateUC)
+
+	// Amil rate dependencies
+	amilRateRepo := postgres.NewAmilRateRepository(dbPool, logr)
+	amilRateUC := usecase.NewAmilRateUseCase(amilRateRepo, val)
+	amilRateHandler := handler.NewAmilRateHandler(amilRateUC)
+
+	// Financial account dependencies
+	financialAccountRepo := postgres.NewFinancialAccountRepository(dbPool, logr)
+	financialAccountUC := usecase.NewFinancialAccountUseCase(financialAccountRepo, val)
+	financialAccountHandler := handler.NewFinancialAccountHandler(financialAccountUC)
+
+	// Fiscal period dependencies
+	fiscalPeriodRepo := postgres.NewFiscalPeriodRepository(dbPool, logr)
+	fiscalPeriodUC := usecase.NewFiscalPeriodUseCase(fiscalPeriodRepo, val)
+	fiscalPeriodHandler := handler.NewFiscalPeriodHandler(fiscalPeriodUC)
+
 	// DonationReceipt dependencies
-	donationReceiptRepo := postgres.NewDonationReceiptRepository(dbPool, logr)
-	donationReceiptUC := usecase.NewDonationReceiptUseCase(donationReceiptRepo, muzakkiRepo, val)
+	receiptNumberPattern, err := receiptnumber.Parse(cfg.ReceiptNumberPattern)
+	if err != nil {
+		logr.Fatalf("RECEIPT_NUMBER_PATTERN tidak valid: %v", err)
+	}
+	donationReceiptRepo := postgres.NewDonationReceiptRepository(dbPool, logr, receiptNumberPattern)
+	receiptRenderer, err := document.NewReceiptRenderer(document.ReceiptConfig{
+		OrgName:        cfg.OrgName,
+		OrgAddress:     cfg.OrgAddress,
+		LetterheadPath: cfg.ReceiptLetterheadPath,
+		SignaturePath:  cfg.ReceiptSignaturePath,
+		SignerName:     cfg.ReceiptSignerName,
+		SignerTitle:    cfg.ReceiptSignerTitle,
+	})
+	if err != nil {
+		logr.Fatalf("gagal init receipt renderer: %v", err)
+	}
+	donationReceiptUC := usecase.NewDonationReceiptUseCase(
+		donationReceiptRepo, muzakkiRepo, financialAccountRepo, fiscalPeriodRepo, zakatCalculationRepo, fitrahRateRepo, commodityPriceRepo,
+		receiptRenderer, receiptNumberPattern, cfg.FitrahDefaultRegion, cfg.ApprovalThreshold, val,
+	)
 	donationReceiptHandler := handler.NewDonationReceiptHandler(donationReceiptUC)
 
 	// Distribution dependencies
 	distributionRepo := postgres.NewDistributionRepository(dbPool, logr)
-	distributionUC := usecase.NewDistributionUseCase(distributionRepo, mustahiqRepo, val)
+	distributionUC := usecase.NewDistributionUseCase(
+		distributionRepo, mustahiqRepo, aidRuleRepo, householdRepo, financialAccountRepo, fiscalPeriodRepo, cfg.ApprovalThreshold, val,
+	)
 	distributionHandler := handler.NewDistributionHandler(distributionUC)
 
+	// Distribution delivery dependencies (voucher, foto & tanda tangan serah terima)
+	var fileStorage service.FileStorage
+	switch cfg.FileStorageDriver {
+	case "local":
+		fileStorage, err = storage.NewLocalStorage(cfg.FileStorageDir)
+		if err != nil {
+			logr.Fatalf("gagal init file storage: %v", err)
+		}
+	default:
+		logr.Fatalf("FILE_STORAGE_DRIVER tidak dikenal: %s", cfg.FileStorageDriver)
+	}
+	distributionDeliveryUC := usecase.NewDistributionDeliveryUseCase(distributionRepo, fileStorage, receiptRenderer, val)
+	distributionDeliveryHandler := handler.NewDistributionDeliveryHandler(distributionDeliveryUC)
+
+	// Opening balance dependencies
+	openingBalanceRepo := postgres.NewOpeningBalanceRepository(dbPool, logr)
+	openingBalanceUC := usecase.NewOpeningBalanceUseCase(openingBalanceRepo, financialAccountRepo, fiscalPeriodRepo, val)
+	openingBalanceHandler := handler.NewOpeningBalanceHandler(openingBalanceUC)
+
+	// Stock (in-kind inventory) dependencies
+	stockRepo := postgres.NewStockRepository(dbPool, logr)
+	stockUC := usecase.NewStockUseCase(stockRepo, fiscalPeriodRepo, val)
+	stockHandler := handler.NewStockHandler(stockUC)
+
+	// Journal (general ledger) dependencies
+	journalRepo := postgres.NewJournalRepository(dbPool, logr)
+	journalUC := usecase.NewJournalUseCase(journalRepo)
+	journalHandler := handler.NewJournalHandler(journalUC)
+
+	// Bank reconciliation dependencies
+	bankStatementFormatRepo := postgres.NewBankStatementFormatRepository(dbPool, logr)
+	bankStatementFormatUC := usecase.NewBankStatementFormatUseCase(bankStatementFormatRepo, val)
+	bankStatementFormatHandler := handler.NewBankStatementFormatHandler(bankStatementFormatUC)
+
+	bankStatementRepo := postgres.NewBankStatementRepository(dbPool, logr)
+	bankStatementUC := usecase.NewBankStatementUseCase(
+		bankStatementRepo, bankStatementFormatRepo, financialAccountRepo, cfg.ReconciliationDateWindowDays, val,
+	)
+	bankStatementHandler := handler.NewBankStatementHandler(bankStatementUC)
+
 	// Report dependencies
 	reportRepo := postgres.NewReportRepository(dbPool, logr)
 	reportUC := usecase.NewReportUseCase(reportRepo, val)
@@ -175,13 +292,15 @@ func main() {
 			// GET - All authenticated users (viewer, staf, admin)
 			muzakki.GET("", muzakkiHandler.FindAll)
 			muzakki.GET("/:id", muzakkiHandler.FindByID)
+			muzakki.GET("/:id/merges", muzakkiHandler.Merges)
 
 			// POST, PUT - Staf and Admin only
 			muzakki.POST("", authMiddleware.RequireStafOrAdmin(), muzakkiHandler.Create)
 			muzakki.PUT("/:id", authMiddleware.RequireStafOrAdmin(), muzakkiHandler.Update)
 
-			// DELETE - Admin only
+			// DELETE, merge - Admin only
 			muzakki.DELETE("/:id", authMiddleware.RequireAdmin(), muzakkiHandler.Delete)
+			muzakki.POST("/:id/merge", authMiddleware.RequireAdmin(), muzakkiHandler.Merge)
 		}
 
 		// Asnaf routes (protected)
@@ -205,13 +324,71 @@ func main() {
 			// GET - All authenticated users (viewer, staf, admin)
 			mustahiq.GET("", mustahiqHandler.FindAll)
 			mustahiq.GET("/:id", mustahiqHandler.FindByID)
+			mustahiq.GET("/:id/status-history", mustahiqHandler.StatusHistory)
+			mustahiq.GET("/:id/merges", mustahiqHandler.Merges)
 
 			// POST, PUT - Staf and Admin only
 			mustahiq.POST("", authMiddleware.RequireStafOrAdmin(), mustahiqHandler.Create)
 			mustahiq.PUT("/:id", authMiddleware.RequireStafOrAdmin(), mustahiqHandler.Update)
+			mustahiq.POST("/:id/activate", authMiddleware.RequireStafOrAdmin(), mustahiqHandler.Activate)
+			mustahiq.POST("/:id/deactivate", authMiddleware.RequireStafOrAdmin(), mustahiqHandler.Deactivate)
 
-			// DELETE - Admin only
+			// DELETE, merge - Admin only
 			mustahiq.DELETE("/:id", authMiddleware.RequireAdmin(), mustahiqHandler.Delete)
+			mustahiq.POST("/:id/merge", authMiddleware.RequireAdmin(), mustahiqHandler.Merge)
+		}
+
+		// Assessment questionnaire routes (protected)
+		assessmentQuestionnaires := v1.Group("/assessment-questionnaires")
+		assessmentQuestionnaires.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (field staff need the questions)
+			assessmentQuestionnaires.GET("", assessmentQuestionnaireHandler.FindAll)
+			assessmentQuestionnaires.GET("/:id", assessmentQuestionnaireHandler.FindByID)
+
+			// POST, PUT, DELETE - Admin only
+			assessmentQuestionnaires.POST("", authMiddleware.RequireAdmin(), assessmentQuestionnaireHandler.Create)
+			assessmentQuestionnaires.PUT("/:id", authMiddleware.RequireAdmin(), assessmentQuestionnaireHandler.Update)
+			assessmentQuestionnaires.POST("/:id/activate", authMiddleware.RequireAdmin(), assessmentQuestionnaireHandler.Activate)
+			assessmentQuestionnaires.POST("/:id/deactivate", authMiddleware.RequireAdmin(), assessmentQuestionnaireHandler.Deactivate)
+			assessmentQuestionnaires.DELETE("/:id", authMiddleware.RequireAdmin(), assessmentQuestionnaireHandler.Delete)
+		}
+
+		// Mustahiq assessment routes (protected)
+		mustahiqAssessments := v1.Group("/mustahiq-assessments")
+		mustahiqAssessments.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			mustahiqAssessments.GET("", mustahiqAssessmentHandler.FindAll)
+			mustahiqAssessments.GET("/:id", mustahiqAssessmentHandler.FindByID)
+
+			// POST - Staf and Admin only
+			mustahiqAssessments.POST("", authMiddleware.RequireStafOrAdmin(), mustahiqAssessmentHandler.Create)
+		}
+
+		// Household routes (protected)
+		households := v1.Group("/households")
+		households.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			households.GET("", householdHandler.FindAll)
+			households.GET("/:id", householdHandler.FindByID)
+
+			// POST, PUT - Staf and Admin only
+			households.POST("", authMiddleware.RequireStafOrAdmin(), householdHandler.Create)
+			households.PUT("/:id", authMiddleware.RequireStafOrAdmin(), householdHandler.Update)
+
+			// DELETE - Admin only
+			households.DELETE("/:id", authMiddleware.RequireAdmin(), householdHandler.Delete)
+		}
+
+		// Duplicate candidate routes (protected)
+		duplicateCandidates := v1.Group("/duplicate-candidates")
+		duplicateCandidates.Use(authMiddleware.RequireAuth())
+		{
+			// GET, POST - Staf and Admin only
+			duplicateCandidates.GET("", authMiddleware.RequireStafOrAdmin(), duplicateCandidateHandler.FindAll)
+			duplicateCandidates.POST("/dismiss", authMiddleware.RequireStafOrAdmin(), duplicateCandidateHandler.Dismiss)
 		}
 
 		// Program routes (protected)
@@ -228,6 +405,117 @@ func main() {
 			programs.DELETE("/:id", authMiddleware.RequireAdmin(), programHandler.Delete)
 		}
 
+		// Aid rule routes (protected)
+		aidRules := v1.Group("/aid-rules")
+		aidRules.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			aidRules.GET("", aidRuleHandler.FindAll)
+			aidRules.GET("/:id", aidRuleHandler.FindByID)
+
+			// POST, PUT, DELETE - Admin only
+			aidRules.POST("", authMiddleware.RequireAdmin(), aidRuleHandler.Create)
+			aidRules.PUT("/:id", authMiddleware.RequireAdmin(), aidRuleHandler.Update)
+			aidRules.DELETE("/:id", authMiddleware.RequireAdmin(), aidRuleHandler.Delete)
+		}
+
+		// Commodity price routes (protected)
+		commodityPrices := v1.Group("/commodity-prices")
+		commodityPrices.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			commodityPrices.GET("", commodityPriceHandler.FindAll)
+			commodityPrices.GET("/effective", commodityPriceHandler.FindEffective)
+			commodityPrices.GET("/:id", commodityPriceHandler.FindByID)
+
+			// POST, PUT, DELETE, import CSV - Admin only
+			commodityPrices.POST("", authMiddleware.RequireAdmin(), commodityPriceHandler.Create)
+			commodityPrices.POST("/import", authMiddleware.RequireAdmin(), commodityPriceHandler.Import)
+			commodityPrices.PUT("/:id", authMiddleware.RequireAdmin(), commodityPriceHandler.Update)
+			commodityPrices.DELETE("/:id", authMiddleware.RequireAdmin(), commodityPriceHandler.Delete)
+		}
+
+		// Zakat calculator routes (protected)
+		zakat := v1.Group("/zakat")
+		zakat.Use(authMiddleware.RequireAuth())
+		{
+			// Calculate only (not stored) - All authenticated users
+			zakat.POST("/calculate", zakatHandler.Calculate)
+
+			// GET - All authenticated users (viewer, staf, admin)
+			zakat.GET("/calculations", zakatHandler.FindAll)
+			zakat.GET("/calculations/:id", zakatHandler.FindByID)
+
+			// POST - Staf and Admin only
+			zakat.POST("/calculations", authMiddleware.RequireStafOrAdmin(), zakatHandler.Save)
+		}
+
+		// Fitrah rate routes (protected)
+		fitrahRates := v1.Group("/fitrah-rates")
+		fitrahRates.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			fitrahRates.GET("", fitrahRateHandler.FindAll)
+			fitrahRates.GET("/:id", fitrahRateHandler.FindByID)
+
+			// POST, PUT, DELETE - Admin only
+			fitrahRates.POST("", authMiddleware.RequireAdmin(), fitrahRateHandler.Create)
+			fitrahRates.PUT("/:id", authMiddleware.RequireAdmin(), fitrahRateHandler.Update)
+			fitrahRates.DELETE("/:id", authMiddleware.RequireAdmin(), fitrahRateHandler.Delete)
+		}
+
+		// Amil rate routes (protected)
+		amilRates := v1.Group("/amil-rates")
+		amilRates.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			amilRates.GET("", amilRateHandler.FindAll)
+			amilRates.GET("/effective", amilRateHandler.FindEffective)
+			amilRates.GET("/:id", amilRateHandler.FindByID)
+
+			// POST, PUT, DELETE - Admin only
+			amilRates.POST("", authMiddleware.RequireAdmin(), amilRateHandler.Create)
+			amilRates.PUT("/:id", authMiddleware.RequireAdmin(), amilRateHandler.Update)
+			amilRates.DELETE("/:id", authMiddleware.RequireAdmin(), amilRateHandler.Delete)
+		}
+
+		// Financial account routes
+		financialAccounts := v1.Group("/financial-accounts")
+		financialAccounts.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			financialAccounts.GET("", financialAccountHandler.FindAll)
+			financialAccounts.GET("/:id", financialAccountHandler.FindByID)
+
+			// POST, PUT, DELETE - Admin only
+			financialAccounts.POST("", authMiddleware.RequireAdmin(), financialAccountHandler.Create)
+			financialAccounts.PUT("/:id", authMiddleware.RequireAdmin(), financialAccountHandler.Update)
+			financialAccounts.DELETE("/:id", authMiddleware.RequireAdmin(), financialAccountHandler.Delete)
+		}
+
+		// Fiscal period routes (protected)
+		fiscalPeriods := v1.Group("/fiscal-periods")
+		fiscalPeriods.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			fiscalPeriods.GET("", fiscalPeriodHandler.FindAll)
+			fiscalPeriods.GET("/:id", fiscalPeriodHandler.FindByID)
+
+			// Tutup & buka kembali periode - Admin only
+			fiscalPeriods.POST("/close", authMiddleware.RequireAdmin(), fiscalPeriodHandler.Close)
+			fiscalPeriods.POST("/:id/reopen", authMiddleware.RequireAdmin(), fiscalPeriodHandler.Reopen)
+		}
+
+		// Opening balance routes (protected) - Admin only
+		openingBalances := v1.Group("/opening-balances")
+		openingBalances.Use(authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+		{
+			openingBalances.GET("", openingBalanceHandler.FindAll)
+			openingBalances.GET("/:id", openingBalanceHandler.FindByID)
+			openingBalances.POST("", openingBalanceHandler.Create)
+			openingBalances.POST("/:id/void", openingBalanceHandler.Void)
+		}
+
 		// DonationReceipt routes (protected)
 		donationReceipts := v1.Group("/donation-receipts")
 		donationReceipts.Use(authMiddleware.RequireAuth())
@@ -235,12 +523,22 @@ func main() {
 			// GET - All authenticated users (viewer, staf, admin)
 			donationReceipts.GET("", donationReceiptHandler.FindAll)
 			donationReceipts.GET("/:id", donationReceiptHandler.FindByID)
+			donationReceipts.GET("/:id/pdf", donationReceiptHandler.DownloadPDF)
 
 			// POST, PUT - Staf and Admin only
 			donationReceipts.POST("", authMiddleware.RequireStafOrAdmin(), donationReceiptHandler.Create)
 			donationReceipts.PUT("/:id", authMiddleware.RequireStafOrAdmin(), donationReceiptHandler.Update)
+			donationReceipts.POST("/:id/post", authMiddleware.RequireStafOrAdmin(), donationReceiptHandler.Post)
 
-			// DELETE - Admin only
+			// Maker-checker kwitansi di atas ambang batas - Approver and Admin only
+			donationReceipts.POST("/:id/approve", authMiddleware.RequireApproverOrAdmin(), donationReceiptHandler.Approve)
+			donationReceipts.POST("/:id/reject", authMiddleware.RequireApproverOrAdmin(), donationReceiptHandler.Reject)
+
+			// Void & reverse kwitansi posted - Admin only
+			donationReceipts.POST("/:id/void", authMiddleware.RequireAdmin(), donationReceiptHandler.Void)
+			donationReceipts.POST("/:id/reverse", authMiddleware.RequireAdmin(), donationReceiptHandler.Reverse)
+
+			// DELETE (draft only) - Admin only
 			donationReceipts.DELETE("/:id", authMiddleware.RequireAdmin(), donationReceiptHandler.Delete)
 		}
 
@@ -251,15 +549,107 @@ func main() {
 			// GET - All authenticated users (viewer, staf, admin)
 			distributions.GET("", distributionHandler.FindAll)
 			distributions.GET("/:id", distributionHandler.FindByID)
+			distributions.GET("/vouchers/:code", distributionDeliveryHandler.FindByVoucherCode)
+			distributions.GET("/:id/items/:item_id/photo", distributionDeliveryHandler.DownloadPhoto)
+			distributions.GET("/:id/items/:item_id/signature", distributionDeliveryHandler.DownloadSignature)
 
 			// POST, PUT - Staf and Admin only
 			distributions.POST("", authMiddleware.RequireStafOrAdmin(), distributionHandler.Create)
+			distributions.POST("/plan", authMiddleware.RequireStafOrAdmin(), distributionHandler.Plan)
+			distributions.POST("/plan/commit", authMiddleware.RequireStafOrAdmin(), distributionHandler.CommitPlan)
 			distributions.PUT("/:id", authMiddleware.RequireStafOrAdmin(), distributionHandler.Update)
+			distributions.POST("/:id/post", authMiddleware.RequireStafOrAdmin(), distributionHandler.Post)
 
-			// DELETE - Admin only
+			// Voucher penyaluran: cetak dan tukar saat serah terima - Staf and Admin only
+			distributions.GET("/:id/vouchers/pdf", authMiddleware.RequireStafOrAdmin(), distributionDeliveryHandler.DownloadVouchers)
+			distributions.POST("/vouchers/:code/redeem", authMiddleware.RequireStafOrAdmin(), distributionDeliveryHandler.Redeem)
+
+			// Maker-checker penyaluran di atas ambang batas - Approver and Admin only
+			distributions.POST("/:id/approve", authMiddleware.RequireApproverOrAdmin(), distributionHandler.Approve)
+			distributions.POST("/:id/reject", authMiddleware.RequireApproverOrAdmin(), distributionHandler.Reject)
+
+			// Void & revert ke draft penyaluran posted - Admin only
+			distributions.POST("/:id/void", authMiddleware.RequireAdmin(), distributionHandler.Void)
+			distributions.POST("/:id/revert-to-draft", authMiddleware.RequireAdmin(), distributionHandler.RevertToDraft)
+
+			// DELETE (draft only) - Admin only
 			distributions.DELETE("/:id", authMiddleware.RequireAdmin(), distributionHandler.Delete)
 		}
 
+		// Stock routes (protected; receipt and distribution movements are posted automatically)
+		stockMovements := v1.Group("/stock-movements")
+		stockMovements.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			stockMovements.GET("", stockHandler.FindAll)
+			stockMovements.GET("/:id", stockHandler.FindByID)
+
+			// Penyesuaian stok manual - Admin only
+			stockMovements.POST("/adjustments", authMiddleware.RequireAdmin(), stockHandler.CreateAdjustment)
+		}
+
+		// Ledger routes (protected, read-only; journals are posted automatically)
+		ledgerAccounts := v1.Group("/ledger-accounts")
+		ledgerAccounts.Use(authMiddleware.RequireAuth())
+		{
+			ledgerAccounts.GET("", journalHandler.FindAccounts)
+		}
+
+		journalEntries := v1.Group("/journal-entries")
+		journalEntries.Use(authMiddleware.RequireAuth())
+		{
+			journalEntries.GET("", journalHandler.FindAllEntries)
+			journalEntries.GET("/:id", journalHandler.FindEntryByID)
+		}
+
+		// Bank statement format routes
+		bankStatementFormats := v1.Group("/bank-statement-formats")
+		bankStatementFormats.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			bankStatementFormats.GET("", bankStatementFormatHandler.FindAll)
+			bankStatementFormats.GET("/:id", bankStatementFormatHandler.FindByID)
+
+			// POST, PUT, DELETE - Admin only
+			bankStatementFormats.POST("", authMiddleware.RequireAdmin(), bankStatementFormatHandler.Create)
+			bankStatementFormats.PUT("/:id", authMiddleware.RequireAdmin(), bankStatementFormatHandler.Update)
+			bankStatementFormats.DELETE("/:id", authMiddleware.RequireAdmin(), bankStatementFormatHandler.Delete)
+		}
+
+		// Bank statement routes (protected)
+		bankStatements := v1.Group("/bank-statements")
+		bankStatements.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			bankStatements.GET("", bankStatementHandler.FindAll)
+			bankStatements.GET("/:id", bankStatementHandler.FindByID)
+
+			// Import & pencocokan ulang - Staf and Admin only
+			bankStatements.POST("/import", authMiddleware.RequireStafOrAdmin(), bankStatementHandler.Import)
+			bankStatements.POST("/:id/auto-match", authMiddleware.RequireStafOrAdmin(), bankStatementHandler.AutoMatch)
+
+			// DELETE - Admin only
+			bankStatements.DELETE("/:id", authMiddleware.RequireAdmin(), bankStatementHandler.Delete)
+		}
+
+		// Reconciliation queue routes (protected)
+		bankStatementLines := v1.Group("/bank-statement-lines")
+		bankStatementLines.Use(authMiddleware.RequireAuth())
+		{
+			// GET - All authenticated users (viewer, staf, admin)
+			bankStatementLines.GET("", bankStatementHandler.FindLines)
+			bankStatementLines.GET("/:id", bankStatementHandler.FindLineByID)
+			bankStatementLines.GET("/:id/candidates", bankStatementHandler.FindCandidates)
+
+			// Konfirmasi, pencocokan manual & flag - Staf and Admin only
+			bankStatementLines.POST("/:id/confirm", authMiddleware.RequireStafOrAdmin(), bankStatementHandler.Confirm)
+			bankStatementLines.POST("/:id/match", authMiddleware.RequireStafOrAdmin(), bankStatementHandler.Match)
+			bankStatementLines.POST("/:id/flag", authMiddleware.RequireStafOrAdmin(), bankStatementHandler.Flag)
+
+			// Reset baris yang sudah dikonfirmasi - Admin only
+			bankStatementLines.POST("/:id/reset", authMiddleware.RequireAdmin(), bankStatementHandler.Reset)
+		}
+
 		// Report routes (protected, read-only - All authenticated users)
 		reports := v1.Group("/reports")
 		reports.Use(authMiddleware.RequireAuth())
@@ -267,7 +657,14 @@ func main() {
 			reports.GET("/income-summary", reportHandler.GetIncomeSummary)
 			reports.GET("/distribution-summary", reportHandler.GetDistributionSummary)
 			reports.GET("/fund-balance", reportHandler.GetFundBalance)
+			reports.GET("/trial-balance", reportHandler.GetTrialBalance)
+			reports.GET("/account-balance", reportHandler.GetAccountBalance)
+			reports.GET("/account-movements/:financial_account_id", reportHandler.GetAccountMovements)
+			reports.GET("/unreconciled", reportHandler.GetUnreconciled)
+			reports.GET("/stock-on-hand", reportHandler.GetStockOnHand)
 			reports.GET("/mustahiq-history/:mustahiq_id", reportHandler.GetMustahiqHistory)
+			reports.GET("/household-history/:household_id", reportHandler.GetHouseholdHistory)
+			reports.GET("/undelivered-items", reportHandler.GetUndeliveredItems)
 		}
 
 		// User Management routes (Admin only)
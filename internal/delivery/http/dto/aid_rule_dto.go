@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type SaveAidRuleRequest struct {
+	Name           string   `json:"name" binding:"required"`
+	Scope          string   `json:"scope" binding:"required,oneof=mustahiq household"`
+	AsnafID        *string  `json:"asnaf_id"`         // kosong = semua asnaf
+	ProgramID      *string  `json:"program_id"`       // kosong = semua program
+	SourceFundType string   `json:"source_fund_type"` // kosong = semua jenis dana
+	MaxAmount      *float64 `json:"max_amount"`       // nominal maksimal dalam jendela
+	MaxCount       *int     `json:"max_count"`        // jumlah penyaluran maksimal dalam jendela
+	WindowDays     int      `json:"window_days" binding:"required"`
+	Active         bool     `json:"active"`
+	Description    string   `json:"description"`
+}
+
+type AidRuleResponse struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	Scope          string       `json:"scope"`
+	Asnaf          *AsnafInfo   `json:"asnaf"`
+	Program        *ProgramInfo `json:"program"`
+	SourceFundType string       `json:"source_fund_type"`
+	MaxAmount      *float64     `json:"max_amount"`
+	MaxCount       *int         `json:"max_count"`
+	WindowDays     int          `json:"window_days"`
+	Active         bool         `json:"active"`
+	Description    string       `json:"description"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type CreateAmilRateRequest struct {
+	FundType      string  `json:"fund_type" binding:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
+	EffectiveDate string  `json:"effective_date" binding:"required"`  // YYYY-MM-DD
+	Percentage    float64 `json:"percentage" binding:"gte=0,lte=100"` // zakat maks 12.5
+	Notes         string  `json:"notes"`
+}
+
+type UpdateAmilRateRequest struct {
+	FundType      string  `json:"fund_type" binding:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
+	EffectiveDate string  `json:"effective_date" binding:"required"` // YYYY-MM-DD
+	Percentage    float64 `json:"percentage" binding:"gte=0,lte=100"`
+	Notes         string  `json:"notes"`
+}
+
+type AmilRateResponse struct {
+	ID            string    `json:"id"`
+	FundType      string    `json:"fund_type"`
+	EffectiveDate string    `json:"effective_date"`
+	Percentage    float64   `json:"percentage"`
+	Notes         string    `json:"notes"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,97 @@
+package dto
+
+import "time"
+
+type AssessmentOptionRequest struct {
+	Label string  `json:"label" binding:"required"`
+	Score float64 `json:"score" binding:"gte=0"` // skor lebih tinggi = lebih membutuhkan
+}
+
+type AssessmentQuestionRequest struct {
+	Category string                    `json:"category" binding:"required,oneof=income dependants housing debts other"`
+	Question string                    `json:"question" binding:"required"`
+	Weight   float64                   `json:"weight" binding:"required,gt=0"`
+	Options  []AssessmentOptionRequest `json:"options" binding:"required,min=2,dive"`
+}
+
+type SaveAssessmentQuestionnaireRequest struct {
+	AsnafID       string                      `json:"asnaf_id" binding:"required"`
+	Name          string                      `json:"name" binding:"required"`
+	PassThreshold float64                     `json:"pass_threshold" binding:"gte=0,lte=100"` // skor minimal (0-100) agar layak
+	IsActive      bool                        `json:"is_active"`                              // jika true, kuesioner aktif lain asnaf ini dinonaktifkan
+	Notes         string                      `json:"notes"`
+	Questions     []AssessmentQuestionRequest `json:"questions" binding:"required,min=1,dive"`
+}
+
+type AssessmentOptionResponse struct {
+	ID    string  `json:"id"`
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+type AssessmentQuestionResponse struct {
+	ID       string                     `json:"id"`
+	Category string                     `json:"category"`
+	Question string                     `json:"question"`
+	Weight   float64                    `json:"weight"`
+	Options  []AssessmentOptionResponse `json:"options"`
+}
+
+type AssessmentQuestionnaireResponse struct {
+	ID              string                       `json:"id"`
+	Asnaf           AsnafInfo                    `json:"asnaf"`
+	Name            string                       `json:"name"`
+	PassThreshold   float64                      `json:"pass_threshold"`
+	IsActive        bool                         `json:"is_active"`
+	Notes           string                       `json:"notes"`
+	AssessmentCount int64                        `json:"assessment_count"` // > 0 = tidak bisa diubah atau dihapus
+	Questions       []AssessmentQuestionResponse `json:"questions,omitempty"`
+	CreatedAt       time.Time                    `json:"created_at"`
+	UpdatedAt       time.Time                    `json:"updated_at"`
+}
+
+type AssessmentAnswerRequest struct {
+	QuestionID string `json:"question_id" binding:"required"`
+	OptionID   string `json:"option_id" binding:"required"`
+}
+
+type CreateMustahiqAssessmentRequest struct {
+	MustahiqID      string                    `json:"mustahiq_id" binding:"required"`
+	QuestionnaireID string                    `json:"questionnaire_id"`                   // opsional, default kuesioner aktif asnaf mustahiq
+	AssessmentDate  string                    `json:"assessment_date" binding:"required"` // YYYY-MM-DD
+	Notes           string                    `json:"notes"`
+	Answers         []AssessmentAnswerRequest `json:"answers" binding:"required,min=1,dive"`
+}
+
+type MustahiqInfo struct {
+	ID       string `json:"id"`
+	FullName string `json:"full_name"`
+}
+
+type MustahiqAssessmentAnswerResponse struct {
+	QuestionID string  `json:"question_id"`
+	OptionID   string  `json:"option_id"`
+	Category   string  `json:"category"`
+	Question   string  `json:"question"`
+	Answer     string  `json:"answer"`
+	Weight     float64 `json:"weight"`
+	Score      float64 `json:"score"`
+	MaxScore   float64 `json:"max_score"`
+}
+
+type MustahiqAssessmentResponse struct {
+	ID                string                             `json:"id"`
+	Mustahiq          MustahiqInfo                       `json:"mustahiq"`
+	QuestionnaireID   string                             `json:"questionnaire_id"`
+	QuestionnaireName string                             `json:"questionnaire_name"`
+	AssessmentDate    string                             `json:"assessment_date"`
+	Score             float64                            `json:"score"`
+	PassThreshold     float64                            `json:"pass_threshold"`
+	Result            string                             `json:"result"` // eligible, not_eligible
+	PreviousStatus    string                             `json:"previous_status"`
+	NewStatus         string                             `json:"new_status"`
+	Notes             string                             `json:"notes"`
+	AssessedByUser    UserInfo                           `json:"assessed_by_user"`
+	Answers           []MustahiqAssessmentAnswerResponse `json:"answers,omitempty"`
+	CreatedAt         time.Time                          `json:"created_at"`
+}
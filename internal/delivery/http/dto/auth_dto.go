@@ -52,5 +52,5 @@ type AuthResponse struct {
 
 // UpdateRoleRequest for updating user role
 type UpdateRoleRequest struct {
-	Role string `json:"role" validate:"required,oneof=admin staf viewer"`
+	Role string `json:"role" validate:"required,oneof=admin staf viewer approver"`
 }
@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type MatchSourceRequest struct {
+	SourceType string `json:"source_type" binding:"required,oneof=donation_receipt distribution"`
+	SourceID   string `json:"source_id" binding:"required"`
+}
+
+// MatchBankStatementLineRequest memasangkan baris dengan satu atau beberapa transaksi (split)
+type MatchBankStatementLineRequest struct {
+	Sources []MatchSourceRequest `json:"sources" binding:"required,min=1,dive"`
+}
+
+type FlagBankStatementLineRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// Response DTOs
+type BankStatementMatchResponse struct {
+	ID           string  `json:"id"`
+	SourceType   string  `json:"source_type"`
+	SourceID     string  `json:"source_id"`
+	SourceNumber string  `json:"source_number"`
+	SourceDate   string  `json:"source_date"`
+	SourceLabel  string  `json:"source_label"`
+	Amount       float64 `json:"amount"`
+}
+
+type BankStatementLineResponse struct {
+	ID                 string                       `json:"id"`
+	StatementID        string                       `json:"statement_id"`
+	FinancialAccountID string                       `json:"financial_account_id"`
+	LineNumber         int                          `json:"line_number"`
+	TransactionDate    string                       `json:"transaction_date"`
+	Description        string                       `json:"description"`
+	Reference          string                       `json:"reference"`
+	Amount             float64                      `json:"amount"` // positive = money in, negative = money out
+	Status             string                       `json:"status"`
+	FlagReason         string                       `json:"flag_reason"`
+	ReviewedByUser     *UserInfo                    `json:"reviewed_by_user"`
+	ReviewedAt         *time.Time                   `json:"reviewed_at"`
+	Matches            []BankStatementMatchResponse `json:"matches"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	UpdatedAt          time.Time                    `json:"updated_at"`
+}
+
+type BankStatementResponse struct {
+	ID               string                      `json:"id"`
+	FinancialAccount FinancialAccountInfo        `json:"financial_account"`
+	FormatID         string                      `json:"format_id"`
+	FormatName       string                      `json:"format_name"`
+	FileName         string                      `json:"file_name"`
+	DateFrom         *string                     `json:"date_from"`
+	DateTo           *string                     `json:"date_to"`
+	LineCount        int                         `json:"line_count"`
+	SkippedCount     int                         `json:"skipped_count"` // lines already imported by an earlier statement
+	MatchedCount     int                         `json:"matched_count"` // lines auto-matched by this request
+	TotalIn          float64                     `json:"total_in"`
+	TotalOut         float64                     `json:"total_out"`
+	ImportedByUser   *UserInfo                   `json:"imported_by_user"`
+	Lines            []BankStatementLineResponse `json:"lines,omitempty"`
+	CreatedAt        time.Time                   `json:"created_at"`
+}
+
+type MatchCandidateResponse struct {
+	SourceType string  `json:"source_type"`
+	SourceID   string  `json:"source_id"`
+	Number     string  `json:"number"`
+	Date       string  `json:"date"`
+	Amount     float64 `json:"amount"`
+	Label      string  `json:"label"`
+}
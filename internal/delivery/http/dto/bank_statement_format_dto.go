@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type BankStatementFormatRequest struct {
+	Name              string `json:"name" binding:"required,max=100"`
+	Delimiter         string `json:"delimiter"` // default ,
+	SkipRows          int    `json:"skip_rows" binding:"gte=0"`
+	FooterRows        int    `json:"footer_rows" binding:"gte=0"`
+	DateColumn        string `json:"date_column" binding:"required"`
+	DateLayout        string `json:"date_layout"` // Go layout, default 2006-01-02
+	DescriptionColumn string `json:"description_column" binding:"required"`
+	ReferenceColumn   string `json:"reference_column"`
+	AmountColumn      string `json:"amount_column"` // signed amount, or with CR/DB suffix
+	CreditColumn      string `json:"credit_column"` // used with debit_column when amount_column is empty
+	DebitColumn       string `json:"debit_column"`
+	DecimalSeparator  string `json:"decimal_separator" binding:"omitempty,oneof=. ,"` // default .
+	Notes             string `json:"notes"`
+}
+
+type BankStatementFormatResponse struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	SkipRows          int       `json:"skip_rows"`
+	FooterRows        int       `json:"footer_rows"`
+	DateColumn        string    `json:"date_column"`
+	DateLayout        string    `json:"date_layout"`
+	DescriptionColumn string    `json:"description_column"`
+	ReferenceColumn   string    `json:"reference_column"`
+	AmountColumn      string    `json:"amount_column"`
+	CreditColumn      string    `json:"credit_column"`
+	DebitColumn       string    `json:"debit_column"`
+	DecimalSeparator  string    `json:"decimal_separator"`
+	Notes             string    `json:"notes"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
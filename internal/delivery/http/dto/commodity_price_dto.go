@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type CreateCommodityPriceRequest struct {
+	Commodity string  `json:"commodity" binding:"required,oneof=gold silver rice"`
+	PriceDate string  `json:"price_date" binding:"required"` // YYYY-MM-DD
+	Price     float64 `json:"price" binding:"required,gt=0"` // per gram (gold, silver) atau per kg (rice)
+	Source    string  `json:"source"`
+	Notes     string  `json:"notes"`
+}
+
+type UpdateCommodityPriceRequest struct {
+	Commodity string  `json:"commodity" binding:"required,oneof=gold silver rice"`
+	PriceDate string  `json:"price_date" binding:"required"` // YYYY-MM-DD
+	Price     float64 `json:"price" binding:"required,gt=0"`
+	Source    string  `json:"source"`
+	Notes     string  `json:"notes"`
+}
+
+type CommodityPriceResponse struct {
+	ID        string    `json:"id"`
+	Commodity string    `json:"commodity"`
+	PriceDate string    `json:"price_date"`
+	Price     float64   `json:"price"`
+	Unit      string    `json:"unit"`
+	Source    string    `json:"source"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CommodityPriceImportResponse struct {
+	Imported int `json:"imported"`
+}
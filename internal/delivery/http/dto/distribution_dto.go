@@ -4,36 +4,143 @@ import "time"
 
 // Request DTOs
 type CreateDistributionItemRequest struct {
-	MustahiqID string  `json:"mustahiq_id" binding:"required"`
-	Amount     float64 `json:"amount" binding:"required,gt=0"`
-	Notes      string  `json:"notes"`
+	MustahiqID string   `json:"mustahiq_id" binding:"required"`
+	Amount     float64  `json:"amount" binding:"gte=0"`                               // 0 allowed for in-kind items
+	Commodity  string   `json:"commodity" binding:"omitempty,oneof=gold silver rice"` // in-kind item
+	Quantity   *float64 `json:"quantity" binding:"omitempty,gt=0"`                    // in commodity unit (kg for rice)
+	Notes      string   `json:"notes"`
 }
 
 type CreateDistributionRequest struct {
-	DistributionDate string                          `json:"distribution_date" binding:"required"` // YYYY-MM-DD
-	ProgramID        *string                         `json:"program_id"`                           // optional
-	SourceFundType   string                          `json:"source_fund_type" binding:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
-	Notes            string                          `json:"notes"`
-	Items            []CreateDistributionItemRequest `json:"items" binding:"required,min=1,dive"`
+	DistributionDate       string                          `json:"distribution_date" binding:"required"` // YYYY-MM-DD
+	ProgramID              *string                         `json:"program_id"`                           // optional
+	SourceFundType         string                          `json:"source_fund_type" binding:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID     string                          `json:"financial_account_id" binding:"required"`
+	Notes                  string                          `json:"notes"`
+	Status                 string                          `json:"status" binding:"omitempty,oneof=draft posted"` // default posted
+	OverdraftJustification string                          `json:"overdraft_justification"`                       // admin only, jika melebihi saldo dana
+	AidRuleJustification   string                          `json:"aid_rule_justification"`                        // admin only, jika item melanggar aturan bantuan
+	Items                  []CreateDistributionItemRequest `json:"items" binding:"required,min=1,dive"`
 }
 
 type UpdateDistributionRequest struct {
-	DistributionDate string                          `json:"distribution_date" binding:"required"`
-	ProgramID        *string                         `json:"program_id"`
-	SourceFundType   string                          `json:"source_fund_type" binding:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
-	Notes            string                          `json:"notes"`
-	Items            []CreateDistributionItemRequest `json:"items" binding:"required,min=1,dive"`
+	DistributionDate     string                          `json:"distribution_date" binding:"required"`
+	ProgramID            *string                         `json:"program_id"`
+	SourceFundType       string                          `json:"source_fund_type" binding:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID   string                          `json:"financial_account_id" binding:"required"`
+	Notes                string                          `json:"notes"`
+	AidRuleJustification string                          `json:"aid_rule_justification"` // admin only, jika item melanggar aturan bantuan
+	Items                []CreateDistributionItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// PostDistributionRequest bersifat opsional (body boleh kosong)
+type PostDistributionRequest struct {
+	OverdraftJustification string `json:"overdraft_justification"` // admin only
+	AidRuleJustification   string `json:"aid_rule_justification"`  // admin only
+}
+
+// ApproveDistributionRequest bersifat opsional (body boleh kosong)
+type ApproveDistributionRequest struct {
+	Comment                string `json:"comment"`
+	OverdraftJustification string `json:"overdraft_justification"` // admin only, replaces the submitter's justification
+}
+
+// DistributionStatusChangeRequest dipakai untuk void dan revert ke draft
+type DistributionStatusChangeRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// PlanDistributionRequest memilih mustahiq seperti filter daftar mustahiq dan menghitung
+// nominal per orang atau per rumah tangga
+type PlanDistributionRequest struct {
+	AsnafID            string  `json:"asnaf_id"`
+	MustahiqStatus     string  `json:"mustahiq_status" binding:"omitempty,oneof=active inactive pending"` // default active
+	Region             string  `json:"region"`                                                            // part of the address
+	HouseholdID        string  `json:"household_id"`
+	Basis              string  `json:"basis" binding:"required,oneof=per_person per_household"`
+	Amount             float64 `json:"amount" binding:"gt=0"`
+	AmountPerMember    float64 `json:"amount_per_member" binding:"gte=0"` // per_household only
+	ItemNotes          string  `json:"item_notes"`
+	DistributionDate   string  `json:"distribution_date" binding:"required"` // YYYY-MM-DD
+	ProgramID          *string `json:"program_id"`
+	SourceFundType     string  `json:"source_fund_type" binding:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID string  `json:"financial_account_id" binding:"required"`
+	Notes              string  `json:"notes"`
+}
+
+type CommitDistributionPlanRequest struct {
+	PlanDistributionRequest
+	Status                 string  `json:"status" binding:"omitempty,oneof=draft posted"` // default posted
+	OverdraftJustification string  `json:"overdraft_justification"`                       // admin only
+	AidRuleJustification   string  `json:"aid_rule_justification"`                        // admin only
+	ExpectedItemCount      int     `json:"expected_item_count" binding:"gt=0"`            // from the preview
+	ExpectedTotalAmount    float64 `json:"expected_total_amount" binding:"gt=0"`          // from the preview
 }
 
 // Response DTOs
 type DistributionItemResponse struct {
-	ID           string  `json:"id"`
+	ID                   string     `json:"id"`
+	MustahiqID           string     `json:"mustahiq_id"`
+	MustahiqName         string     `json:"mustahiq_name"`
+	AsnafName            string     `json:"asnaf_name"`
+	Address              string     `json:"address"`
+	Amount               float64    `json:"amount"`
+	Commodity            *string    `json:"commodity"`
+	Quantity             *float64   `json:"quantity"`
+	Notes                string     `json:"notes"`
+	VoucherCode          string     `json:"voucher_code"` // XXXXX-XXXXX
+	DeliveredAt          *time.Time `json:"delivered_at"` // null = not yet delivered
+	DeliveredByUser      *UserInfo  `json:"delivered_by_user"`
+	HasDeliveryPhoto     bool       `json:"has_delivery_photo"`
+	HasDeliverySignature bool       `json:"has_delivery_signature"`
+	DeliveryNotes        string     `json:"delivery_notes"`
+}
+
+// AidRuleViolationResponse adalah batas aturan bantuan yang terlampaui dan di-override admin
+type AidRuleViolationResponse struct {
+	AidRuleID   string  `json:"aid_rule_id"`
+	AidRuleName string  `json:"aid_rule_name"`
+	MustahiqID  string  `json:"mustahiq_id"`
+	HouseholdID *string `json:"household_id"`
+	LimitType   string  `json:"limit_type"` // amount, count
+	Allowed     float64 `json:"allowed"`
+	Used        float64 `json:"used"`  // sudah diterima dalam jendela sebelum penyaluran ini
+	Total       float64 `json:"total"` // termasuk penyaluran ini
+	WindowStart string  `json:"window_start"`
+	WindowEnd   string  `json:"window_end"`
+}
+
+type DistributionPlanItemResponse struct {
 	MustahiqID   string  `json:"mustahiq_id"`
 	MustahiqName string  `json:"mustahiq_name"`
 	AsnafName    string  `json:"asnaf_name"`
-	Address      string  `json:"address"`
+	HouseholdID  *string `json:"household_id"`
+	MemberCount  int     `json:"member_count"`
 	Amount       float64 `json:"amount"`
-	Notes        string  `json:"notes"`
+}
+
+type DistributionPlanSkipResponse struct {
+	MustahiqID   string `json:"mustahiq_id"`
+	MustahiqName string `json:"mustahiq_name"`
+	Reason       string `json:"reason"`
+}
+
+// DistributionPlanViolationResponse adalah pelanggaran aturan bantuan pada item ke-item_index
+type DistributionPlanViolationResponse struct {
+	ItemIndex int `json:"item_index"`
+	AidRuleViolationResponse
+}
+
+// DistributionPlanResponse adalah pratinjau penyaluran massal yang belum disimpan
+type DistributionPlanResponse struct {
+	Basis             string                              `json:"basis"`
+	ItemCount         int                                 `json:"item_count"`
+	HouseholdCount    int                                 `json:"household_count"`
+	PersonCount       int                                 `json:"person_count"`
+	TotalAmount       float64                             `json:"total_amount"`
+	Items             []DistributionPlanItemResponse      `json:"items"`
+	Skipped           []DistributionPlanSkipResponse      `json:"skipped"`
+	AidRuleViolations []DistributionPlanViolationResponse `json:"aid_rule_violations"`
 }
 
 type ProgramInfo struct {
@@ -42,28 +149,65 @@ type ProgramInfo struct {
 }
 
 type DistributionResponse struct {
-	ID               string                     `json:"id"`
-	DistributionDate string                     `json:"distribution_date"`
-	Program          *ProgramInfo               `json:"program,omitempty"`
-	SourceFundType   string                     `json:"source_fund_type"`
-	TotalAmount      float64                    `json:"total_amount"`
-	Notes            string                     `json:"notes"`
-	CreatedByUser    UserInfo                   `json:"created_by_user"`
-	Items            []DistributionItemResponse `json:"items"`
-	CreatedAt        time.Time                  `json:"created_at"`
-	UpdatedAt        time.Time                  `json:"updated_at"`
+	ID                     string                     `json:"id"`
+	DistributionDate       string                     `json:"distribution_date"`
+	Program                *ProgramInfo               `json:"program,omitempty"`
+	SourceFundType         string                     `json:"source_fund_type"`
+	FinancialAccount       FinancialAccountInfo       `json:"financial_account"`
+	TotalAmount            float64                    `json:"total_amount"`
+	Notes                  string                     `json:"notes"`
+	Status                 string                     `json:"status"`          // draft, pending_approval, posted, voided
+	DeliveryStatus         string                     `json:"delivery_status"` // undelivered, partial, delivered; empty unless posted
+	DeliveredItemCount     int                        `json:"delivered_item_count"`
+	PostedAt               *time.Time                 `json:"posted_at"`
+	VoidReason             string                     `json:"void_reason"`
+	VoidedByUser           *UserInfo                  `json:"voided_by_user"`
+	VoidedAt               *time.Time                 `json:"voided_at"`
+	RevertReason           string                     `json:"revert_reason"`
+	RevertedByUser         *UserInfo                  `json:"reverted_by_user"`
+	RevertedAt             *time.Time                 `json:"reverted_at"`
+	OverdraftJustification string                     `json:"overdraft_justification"`
+	OverdraftApprovedByID  *string                    `json:"overdraft_approved_by_user_id"`
+	OverdraftApprovedAt    *time.Time                 `json:"overdraft_approved_at"`
+	AidRuleJustification   string                     `json:"aid_rule_justification"`
+	AidRuleOverrideByID    *string                    `json:"aid_rule_override_by_user_id"`
+	AidRuleOverrideAt      *time.Time                 `json:"aid_rule_override_at"`
+	AidRuleViolations      []AidRuleViolationResponse `json:"aid_rule_violations"` // pelanggaran yang di-override
+	CreatedByUser          UserInfo                   `json:"created_by_user"`
+	Items                  []DistributionItemResponse `json:"items"`
+	Approvals              []ApprovalEventResponse    `json:"approvals"` // maker-checker history
+	CreatedAt              time.Time                  `json:"created_at"`
+	UpdatedAt              time.Time                  `json:"updated_at"`
 }
 
 // List item response (simplified with beneficiary_count)
 type DistributionListItemResponse struct {
-	ID               string    `json:"id"`
-	DistributionDate string    `json:"distribution_date"`
-	ProgramID        *string   `json:"program_id,omitempty"`
-	ProgramName      string    `json:"program_name,omitempty"`
-	SourceFundType   string    `json:"source_fund_type"`
-	TotalAmount      float64   `json:"total_amount"`
-	BeneficiaryCount int64     `json:"beneficiary_count"`
-	Notes            string    `json:"notes"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                   string    `json:"id"`
+	DistributionDate     string    `json:"distribution_date"`
+	ProgramID            *string   `json:"program_id,omitempty"`
+	ProgramName          string    `json:"program_name,omitempty"`
+	SourceFundType       string    `json:"source_fund_type"`
+	FinancialAccountID   string    `json:"financial_account_id"`
+	FinancialAccountName string    `json:"financial_account_name"`
+	TotalAmount          float64   `json:"total_amount"`
+	BeneficiaryCount     int64     `json:"beneficiary_count"`
+	Notes                string    `json:"notes"`
+	Status               string    `json:"status"`
+	DeliveryStatus       string    `json:"delivery_status"`
+	DeliveredItemCount   int       `json:"delivered_item_count"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// VoucherResponse adalah hasil pencarian atau penukaran voucher beserta item dan penyalurannya
+type VoucherResponse struct {
+	VoucherCode        string                   `json:"voucher_code"`
+	Redeemable         bool                     `json:"redeemable"` // distribution posted and item not yet delivered
+	DistributionID     string                   `json:"distribution_id"`
+	DistributionDate   string                   `json:"distribution_date"`
+	DistributionStatus string                   `json:"distribution_status"`
+	DeliveryStatus     string                   `json:"delivery_status"`
+	Program            *ProgramInfo             `json:"program,omitempty"`
+	SourceFundType     string                   `json:"source_fund_type"`
+	Item               DistributionItemResponse `json:"item"`
 }
@@ -4,41 +4,74 @@ import "time"
 
 // Request DTOs
 type CreateDonationReceiptItemRequest struct {
-	FundType    string   `json:"fund_type" binding:"required,oneof=zakat infaq sadaqah"`
-	ZakatType   *string  `json:"zakat_type" binding:"omitempty,oneof=fitrah maal"`
-	PersonCount *int     `json:"person_count" binding:"omitempty,min=1"`
-	Amount      float64  `json:"amount" binding:"required,gt=0"`
-	RiceKG      *float64 `json:"rice_kg" binding:"omitempty,gt=0"`
-	Notes       string   `json:"notes"`
+	FundType           string   `json:"fund_type" binding:"required,oneof=zakat infaq sadaqah"`
+	ZakatType          *string  `json:"zakat_type" binding:"omitempty,oneof=fitrah maal"`
+	PersonCount        *int     `json:"person_count" binding:"omitempty,min=1"`
+	Amount             float64  `json:"amount" binding:"gte=0"` // 0 on zakat fitrah items = auto-fill from fitrah rate
+	RiceKG             *float64 `json:"rice_kg" binding:"omitempty,gt=0"`
+	ZakatCalculationID *string  `json:"zakat_calculation_id"` // optional, zakat maal only
+	Notes              string   `json:"notes"`
 }
 
 type CreateDonationReceiptRequest struct {
-	MuzakkiID     string                             `json:"muzakki_id" binding:"required"`
-	ReceiptNumber string                             `json:"receipt_number" binding:"required"`
-	ReceiptDate   string                             `json:"receipt_date" binding:"required"` // YYYY-MM-DD
-	PaymentMethod string                             `json:"payment_method" binding:"required"`
-	Notes         string                             `json:"notes"`
-	Items         []CreateDonationReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+	MuzakkiID          string                             `json:"muzakki_id" binding:"required"`
+	ReceiptNumber      string                             `json:"receipt_number"`                  // optional, auto-generated from RECEIPT_NUMBER_PATTERN
+	ReceiptDate        string                             `json:"receipt_date" binding:"required"` // YYYY-MM-DD
+	FinancialAccountID string                             `json:"financial_account_id" binding:"required"`
+	FitrahRegion       string                             `json:"fitrah_region"` // optional, default from config
+	Notes              string                             `json:"notes"`
+	Status             string                             `json:"status" binding:"omitempty,oneof=draft posted"` // default posted
+	Items              []CreateDonationReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
 }
 
 type UpdateDonationReceiptRequest struct {
-	MuzakkiID     string                             `json:"muzakki_id" binding:"required"`
-	ReceiptNumber string                             `json:"receipt_number" binding:"required"`
-	ReceiptDate   string                             `json:"receipt_date" binding:"required"`
-	PaymentMethod string                             `json:"payment_method" binding:"required"`
-	Notes         string                             `json:"notes"`
-	Items         []CreateDonationReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+	MuzakkiID          string                             `json:"muzakki_id" binding:"required"`
+	ReceiptNumber      string                             `json:"receipt_number"` // optional, keeps the current number when empty
+	ReceiptDate        string                             `json:"receipt_date" binding:"required"`
+	FinancialAccountID string                             `json:"financial_account_id" binding:"required"`
+	FitrahRegion       string                             `json:"fitrah_region"` // optional, default from config
+	Notes              string                             `json:"notes"`
+	Items              []CreateDonationReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type VoidDonationReceiptRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ReverseDonationReceiptRequest berisi alasan pembatalan dan isi kwitansi koreksi
+type ReverseDonationReceiptRequest struct {
+	Reason             string                             `json:"reason" binding:"required"`
+	MuzakkiID          string                             `json:"muzakki_id" binding:"required"`
+	ReceiptNumber      string                             `json:"receipt_number"` // optional, auto-generated from RECEIPT_NUMBER_PATTERN
+	ReceiptDate        string                             `json:"receipt_date" binding:"required"`
+	FinancialAccountID string                             `json:"financial_account_id" binding:"required"`
+	FitrahRegion       string                             `json:"fitrah_region"` // optional, default from the voided receipt
+	Notes              string                             `json:"notes"`
+	Items              []CreateDonationReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// ApprovalRequest dipakai approver untuk menyetujui atau menolak kwitansi/penyaluran
+type ApprovalRequest struct {
+	Comment string `json:"comment"` // required when rejecting
 }
 
 // Response DTOs
 type DonationReceiptItemResponse struct {
-	ID          string   `json:"id"`
-	FundType    string   `json:"fund_type"`
-	ZakatType   *string  `json:"zakat_type"`
-	PersonCount *int     `json:"person_count"`
-	Amount      float64  `json:"amount"`
-	RiceKG      *float64 `json:"rice_kg"`
-	Notes       string   `json:"notes"`
+	ID                 string                              `json:"id"`
+	FundType           string                              `json:"fund_type"`
+	ZakatType          *string                             `json:"zakat_type"`
+	PersonCount        *int                                `json:"person_count"`
+	Amount             float64                             `json:"amount"`
+	RiceKG             *float64                            `json:"rice_kg"`
+	ZakatCalculationID *string                             `json:"zakat_calculation_id"`
+	Notes              string                              `json:"notes"`
+	Allocations        []DonationReceiptAllocationResponse `json:"allocations"` // pembagian sub-ledger, kosong selama draft
+}
+
+type DonationReceiptAllocationResponse struct {
+	Ledger     string  `json:"ledger"` // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	Percentage float64 `json:"percentage"`
+	Amount     float64 `json:"amount"`
 }
 
 type MuzakkiInfo struct {
@@ -51,31 +84,50 @@ type UserInfo struct {
 	FullName string `json:"full_name"`
 }
 
+// ApprovalEventResponse adalah satu langkah riwayat maker-checker
+type ApprovalEventResponse struct {
+	Action    string    `json:"action"` // submit, approve, reject
+	Comment   string    `json:"comment"`
+	User      UserInfo  `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type DonationReceiptResponse struct {
-	ID            string                        `json:"id"`
-	ReceiptNumber string                        `json:"receipt_number"`
-	ReceiptDate   string                        `json:"receipt_date"`
-	Muzakki       MuzakkiInfo                   `json:"muzakki"`
-	PaymentMethod string                        `json:"payment_method"`
-	TotalAmount   float64                       `json:"total_amount"`
-	Notes         string                        `json:"notes"`
-	CreatedByUser UserInfo                      `json:"created_by_user"`
-	Items         []DonationReceiptItemResponse `json:"items"`
-	CreatedAt     time.Time                     `json:"created_at"`
-	UpdatedAt     time.Time                     `json:"updated_at"`
+	ID                  string                        `json:"id"`
+	ReceiptNumber       string                        `json:"receipt_number"`
+	ReceiptDate         string                        `json:"receipt_date"`
+	Muzakki             MuzakkiInfo                   `json:"muzakki"`
+	FinancialAccount    FinancialAccountInfo          `json:"financial_account"`
+	FitrahRegion        string                        `json:"fitrah_region"`
+	TotalAmount         float64                       `json:"total_amount"`
+	Notes               string                        `json:"notes"`
+	Status              string                        `json:"status"` // draft, pending_approval, posted, voided
+	PostedAt            *time.Time                    `json:"posted_at"`
+	VoidReason          string                        `json:"void_reason"`
+	VoidedByUser        *UserInfo                     `json:"voided_by_user"`
+	VoidedAt            *time.Time                    `json:"voided_at"`
+	ReversalOfReceiptID *string                       `json:"reversal_of_receipt_id"`
+	ReversedByReceiptID *string                       `json:"reversed_by_receipt_id"`
+	CreatedByUser       UserInfo                      `json:"created_by_user"`
+	Items               []DonationReceiptItemResponse `json:"items"`
+	Approvals           []ApprovalEventResponse       `json:"approvals"` // maker-checker history
+	CreatedAt           time.Time                     `json:"created_at"`
+	UpdatedAt           time.Time                     `json:"updated_at"`
 }
 
 // List item response (simplified)
 type DonationReceiptListItemResponse struct {
-	ID              string    `json:"id"`
-	ReceiptNumber   string    `json:"receipt_number"`
-	ReceiptDate     string    `json:"receipt_date"`
-	MuzakkiID       string    `json:"muzakki_id"`
-	MuzakkiName     string    `json:"muzakki_name"`
-	PaymentMethod   string    `json:"payment_method"`
-	TotalAmount     float64   `json:"total_amount"`
-	Notes           string    `json:"notes"`
-	CreatedByUserID string    `json:"created_by_user_id"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID                   string    `json:"id"`
+	ReceiptNumber        string    `json:"receipt_number"`
+	ReceiptDate          string    `json:"receipt_date"`
+	MuzakkiID            string    `json:"muzakki_id"`
+	MuzakkiName          string    `json:"muzakki_name"`
+	FinancialAccountID   string    `json:"financial_account_id"`
+	FinancialAccountName string    `json:"financial_account_name"`
+	TotalAmount          float64   `json:"total_amount"`
+	Notes                string    `json:"notes"`
+	Status               string    `json:"status"`
+	CreatedByUserID      string    `json:"created_by_user_id"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
 }
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+type DismissDuplicateRequest struct {
+	EntityType string `json:"entity_type" binding:"required,oneof=mustahiq muzakki"`
+	RecordAID  string `json:"record_a_id" binding:"required"`
+	RecordBID  string `json:"record_b_id" binding:"required"`
+	Reason     string `json:"reason" binding:"required"` // mis. "ayah dan anak dengan nama sama"
+}
+
+type DuplicateRecordResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	NIK         string    `json:"nik"`
+	PhoneNumber string    `json:"phone_number"`
+	Address     string    `json:"address"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type DuplicateCandidateResponse struct {
+	EntityType        string                  `json:"entity_type"`
+	RecordA           DuplicateRecordResponse `json:"record_a"` // data yang lebih dulu terdaftar
+	RecordB           DuplicateRecordResponse `json:"record_b"`
+	Score             float64                 `json:"score"`      // 0-100
+	Confidence        string                  `json:"confidence"` // high, medium
+	Reasons           []string                `json:"reasons"`    // same_nik, same_phone, similar_name_address
+	NameSimilarity    float64                 `json:"name_similarity"`
+	AddressSimilarity float64                 `json:"address_similarity"`
+}
+
+type DuplicateDismissalResponse struct {
+	ID                string    `json:"id"`
+	EntityType        string    `json:"entity_type"`
+	RecordAID         string    `json:"record_a_id"`
+	RecordBID         string    `json:"record_b_id"`
+	Reason            string    `json:"reason"`
+	DismissedByUserID string    `json:"dismissed_by_user_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+type CreateFinancialAccountRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	AccountType   string `json:"account_type" binding:"required,oneof=cash bank digital"`
+	BankName      string `json:"bank_name"`
+	AccountNumber string `json:"account_number"`
+	IsActive      bool   `json:"is_active"`
+	Notes         string `json:"notes"`
+}
+
+type UpdateFinancialAccountRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	AccountType   string `json:"account_type" binding:"required,oneof=cash bank digital"`
+	BankName      string `json:"bank_name"`
+	AccountNumber string `json:"account_number"`
+	IsActive      bool   `json:"is_active"`
+	Notes         string `json:"notes"`
+}
+
+type FinancialAccountResponse struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	AccountType       string    `json:"account_type"`
+	BankName          string    `json:"bank_name"`
+	AccountNumber     string    `json:"account_number"`
+	LedgerAccountCode string    `json:"ledger_account_code"`
+	IsActive          bool      `json:"is_active"`
+	Notes             string    `json:"notes"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// FinancialAccountInfo adalah ringkasan rekening pada kwitansi dan penyaluran
+type FinancialAccountInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+type CloseFiscalPeriodRequest struct {
+	PeriodType string `json:"period_type" binding:"required,oneof=month year"`
+	Year       int    `json:"year" binding:"required"`
+	Month      int    `json:"month" binding:"omitempty,min=1,max=12"` // required for period_type month
+	Notes      string `json:"notes"`
+}
+
+type ReopenFiscalPeriodRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type FiscalPeriodBalanceResponse struct {
+	LedgerAccountID    string  `json:"ledger_account_id"`
+	AccountCode        string  `json:"account_code"`
+	AccountName        string  `json:"account_name"`
+	AccountType        string  `json:"account_type"`
+	FundLedger         *string `json:"fund_ledger"`
+	FinancialAccountID *string `json:"financial_account_id"`
+	OpeningBalance     float64 `json:"opening_balance"`
+	TotalDebit         float64 `json:"total_debit"`
+	TotalCredit        float64 `json:"total_credit"`
+	ClosingBalance     float64 `json:"closing_balance"`
+}
+
+type FiscalPeriodEventResponse struct {
+	Action    string    `json:"action"` // close, reopen
+	Reason    string    `json:"reason"`
+	User      UserInfo  `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FiscalPeriodResponse struct {
+	ID             string                        `json:"id"`
+	PeriodType     string                        `json:"period_type"`
+	PeriodStart    string                        `json:"period_start"`
+	PeriodEnd      string                        `json:"period_end"`
+	Status         string                        `json:"status"`
+	Notes          string                        `json:"notes"`
+	ClosedByUser   *UserInfo                     `json:"closed_by_user"`
+	ClosedAt       *time.Time                    `json:"closed_at"`
+	ReopenedByUser *UserInfo                     `json:"reopened_by_user"`
+	ReopenedAt     *time.Time                    `json:"reopened_at"`
+	ReopenReason   string                        `json:"reopen_reason"`
+	Balances       []FiscalPeriodBalanceResponse `json:"balances,omitempty"` // snapshot taken when last closed
+	Events         []FiscalPeriodEventResponse   `json:"events,omitempty"`
+	CreatedAt      time.Time                     `json:"created_at"`
+	UpdatedAt      time.Time                     `json:"updated_at"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type CreateFitrahRateRequest struct {
+	HijriYear       int     `json:"hijri_year" binding:"required,min=1400"`
+	Region          string  `json:"region" binding:"required"`
+	RiceKGPerPerson float64 `json:"rice_kg_per_person" binding:"required,gt=0"`
+	CashPerPerson   float64 `json:"cash_per_person" binding:"gte=0"` // 0 = ikut harga beras pada tanggal penerimaan
+	Notes           string  `json:"notes"`
+}
+
+type UpdateFitrahRateRequest struct {
+	HijriYear       int     `json:"hijri_year" binding:"required,min=1400"`
+	Region          string  `json:"region" binding:"required"`
+	RiceKGPerPerson float64 `json:"rice_kg_per_person" binding:"required,gt=0"`
+	CashPerPerson   float64 `json:"cash_per_person" binding:"gte=0"` // 0 = ikut harga beras pada tanggal penerimaan
+	Notes           string  `json:"notes"`
+}
+
+type FitrahRateResponse struct {
+	ID              string    `json:"id"`
+	HijriYear       int       `json:"hijri_year"`
+	Region          string    `json:"region"`
+	RiceKGPerPerson float64   `json:"rice_kg_per_person"`
+	CashPerPerson   float64   `json:"cash_per_person"`
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
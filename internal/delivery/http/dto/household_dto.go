@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type HouseholdMemberRequest struct {
+	MustahiqID   string `json:"mustahiq_id"` // opsional, anggota yang terdaftar sebagai mustahiq
+	Name         string `json:"name"`        // wajib jika mustahiq_id kosong
+	Relationship string `json:"relationship" binding:"required,oneof=head spouse child parent sibling grandchild other"`
+	BirthDate    string `json:"birth_date"` // YYYY-MM-DD, opsional
+}
+
+type SaveHouseholdRequest struct {
+	FamilyCardNumber string                   `json:"family_card_number"` // nomor KK 16 digit, opsional
+	Address          string                   `json:"address" binding:"required"`
+	Notes            string                   `json:"notes"`
+	Members          []HouseholdMemberRequest `json:"members" binding:"required,min=1,dive"` // tepat satu anggota dengan relationship head
+}
+
+type HouseholdMemberResponse struct {
+	ID             string  `json:"id"`
+	MustahiqID     *string `json:"mustahiq_id"`
+	MustahiqStatus string  `json:"mustahiq_status,omitempty"`
+	Name           string  `json:"name"`
+	Relationship   string  `json:"relationship"`
+	BirthDate      *string `json:"birth_date"`
+}
+
+type HouseholdResponse struct {
+	ID               string                    `json:"id"`
+	FamilyCardNumber string                    `json:"family_card_number"`
+	Address          string                    `json:"address"`
+	Notes            string                    `json:"notes"`
+	HeadName         string                    `json:"head_name"`
+	MemberCount      int                       `json:"member_count"` // ukuran rumah tangga
+	Members          []HouseholdMemberResponse `json:"members,omitempty"`
+	CreatedAt        time.Time                 `json:"created_at"`
+	UpdatedAt        time.Time                 `json:"updated_at"`
+}
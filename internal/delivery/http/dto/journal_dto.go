@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type LedgerAccountResponse struct {
+	ID          string  `json:"id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	AccountType string  `json:"account_type"` // asset, liability
+	FundLedger  *string `json:"fund_ledger"`  // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+}
+
+type JournalLineResponse struct {
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
+
+type JournalEntryResponse struct {
+	ID                string                `json:"id"`
+	EntryDate         string                `json:"entry_date"`
+	Description       string                `json:"description"`
+	SourceType        string                `json:"source_type"`
+	SourceID          string                `json:"source_id"`
+	ReversalOfEntryID *string               `json:"reversal_of_entry_id"`
+	Lines             []JournalLineResponse `json:"lines"`
+	CreatedAt         time.Time             `json:"created_at"`
+}
@@ -4,22 +4,29 @@ import "time"
 
 type CreateMustahiqRequest struct {
 	Name        string `json:"name" binding:"required"`
+	NIK         string `json:"nik"` // opsional, 16 digit
 	PhoneNumber string `json:"phoneNumber" binding:"required"`
 	Address     string `json:"address" binding:"required"`
 	AsnafID     string `json:"asnafID" binding:"required"`
-	Status      string `json:"status" binding:"omitempty,oneof=active inactive pending"`
 	Description string `json:"description"`
 }
 
 type UpdateMustahiqRequest struct {
 	Name        string `json:"name" binding:"required"`
+	NIK         string `json:"nik"` // opsional, 16 digit
 	PhoneNumber string `json:"phoneNumber" binding:"required"`
 	Address     string `json:"address" binding:"required"`
 	AsnafID     string `json:"asnafID" binding:"required"`
-	Status      string `json:"status" binding:"required,oneof=active inactive pending"`
 	Description string `json:"description"`
 }
 
+// ChangeMustahiqStatusRequest dipakai endpoint activate dan deactivate
+type ChangeMustahiqStatusRequest struct {
+	Reason        string `json:"reason" binding:"required"`
+	EffectiveDate string `json:"effective_date"` // YYYY-MM-DD, default hari ini
+	AssessmentID  string `json:"assessment_id"`  // penilaian ulang yang layak, wajib untuk inactive -> active
+}
+
 type AsnafInfo struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -28,11 +35,50 @@ type AsnafInfo struct {
 type MustahiqResponse struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
+	NIK         string    `json:"nik"`
 	PhoneNumber string    `json:"phoneNumber"`
 	Address     string    `json:"address"`
 	Asnaf       AsnafInfo `json:"asnaf"`
 	Status      string    `json:"status"`
+	HouseholdID *string   `json:"householdID"`
 	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
+
+type MustahiqStatusChangeResponse struct {
+	ID            string    `json:"id"`
+	FromStatus    string    `json:"from_status"` // kosong = status awal
+	ToStatus      string    `json:"to_status"`
+	EffectiveDate string    `json:"effective_date"`
+	Reason        string    `json:"reason"`
+	AssessmentID  *string   `json:"assessment_id"`
+	ChangedByUser *UserInfo `json:"changed_by_user"`
+	// MergedFromMustahiqID terisi untuk riwayat yang dipindah dari mustahiq yang digabung
+	MergedFromMustahiqID *string   `json:"merged_from_mustahiq_id"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// MergeMustahiqRequest menggabungkan source_id ke mustahiq pada path
+type MergeMustahiqRequest struct {
+	SourceID string            `json:"source_id" binding:"required"`
+	Fields   map[string]string `json:"fields"` // name, nik, phone_number, address, asnaf_id, description -> source|target; wajib untuk field yang berbeda
+	Reason   string            `json:"reason" binding:"required"`
+}
+
+type MustahiqMergeResponse struct {
+	ID                  string                     `json:"id"`
+	SourceMustahiqID    string                     `json:"source_mustahiq_id"`
+	TargetMustahiqID    string                     `json:"target_mustahiq_id"`
+	Reason              string                     `json:"reason"`
+	ItemsMoved          int                        `json:"items_moved"`
+	AmountMoved         float64                    `json:"amount_moved"`
+	AssessmentsMoved    int                        `json:"assessments_moved"`
+	StatusChangesMoved  int                        `json:"status_changes_moved"`
+	SharedDistributions int                        `json:"shared_distributions"`
+	TotalReceivedBefore float64                    `json:"total_received_before"`
+	TotalReceivedAfter  float64                    `json:"total_received_after"`
+	Fields              []MergeFieldChoiceResponse `json:"fields"`
+	MergedByUser        UserInfo                   `json:"merged_by_user"`
+	CreatedAt           time.Time                  `json:"created_at"`
+}
@@ -4,6 +4,7 @@ import "time"
 
 type CreateMuzakkiRequest struct {
 	Name        string `json:"name" binding:"required"`
+	NIK         string `json:"nik"` // opsional, 16 digit
 	PhoneNumber string `json:"phoneNumber" binding:"required"`
 	Address     string `json:"address" binding:"required"`
 	Notes       string `json:"notes"`
@@ -11,6 +12,7 @@ type CreateMuzakkiRequest struct {
 
 type UpdateMuzakkiRequest struct {
 	Name        string `json:"name" binding:"required"`
+	NIK         string `json:"nik"` // opsional, 16 digit
 	PhoneNumber string `json:"phoneNumber" binding:"required"`
 	Address     string `json:"address" binding:"required"`
 	Notes       string `json:"notes"`
@@ -19,9 +21,37 @@ type UpdateMuzakkiRequest struct {
 type MuzakkiResponse struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
+	NIK         string    `json:"nik"`
 	PhoneNumber string    `json:"phoneNumber"`
 	Address     string    `json:"address"`
 	Notes       string    `json:"notes"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
+
+// MergeMuzakkiRequest menggabungkan source_id ke muzakki pada path
+type MergeMuzakkiRequest struct {
+	SourceID string            `json:"source_id" binding:"required"`
+	Fields   map[string]string `json:"fields"` // name, nik, phone_number, address, notes -> source|target; wajib untuk field yang berbeda
+	Reason   string            `json:"reason" binding:"required"`
+}
+
+type MergeFieldChoiceResponse struct {
+	Field       string `json:"field"`
+	Choice      string `json:"choice"`
+	SourceValue string `json:"source_value"`
+	TargetValue string `json:"target_value"`
+	FinalValue  string `json:"final_value"`
+}
+
+type MuzakkiMergeResponse struct {
+	ID                string                     `json:"id"`
+	SourceMuzakkiID   string                     `json:"source_muzakki_id"`
+	TargetMuzakkiID   string                     `json:"target_muzakki_id"`
+	Reason            string                     `json:"reason"`
+	ReceiptsMoved     int                        `json:"receipts_moved"`
+	CalculationsMoved int                        `json:"calculations_moved"`
+	Fields            []MergeFieldChoiceResponse `json:"fields"`
+	MergedByUser      UserInfo                   `json:"merged_by_user"`
+	CreatedAt         time.Time                  `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type CreateOpeningBalanceRequest struct {
+	EffectiveDate      string  `json:"effective_date" binding:"required"` // YYYY-MM-DD
+	FundLedger         string  `json:"fund_ledger" binding:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID string  `json:"financial_account_id" binding:"required"`
+	Amount             float64 `json:"amount" binding:"required,gt=0"`
+	Notes              string  `json:"notes"` // asal saldo, misalnya nama sistem atau buku lama
+}
+
+type VoidOpeningBalanceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type OpeningBalanceResponse struct {
+	ID               string               `json:"id"`
+	EffectiveDate    string               `json:"effective_date"`
+	FundLedger       string               `json:"fund_ledger"`
+	FinancialAccount FinancialAccountInfo `json:"financial_account"`
+	Amount           float64              `json:"amount"`
+	Notes            string               `json:"notes"`
+	Status           string               `json:"status"`
+	VoidReason       string               `json:"void_reason"`
+	VoidedByUser     *UserInfo            `json:"voided_by_user"`
+	VoidedAt         *time.Time           `json:"voided_at"`
+	CreatedByUser    UserInfo             `json:"created_by_user"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
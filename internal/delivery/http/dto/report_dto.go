@@ -7,13 +7,16 @@ type IncomeSummaryResponse struct {
 	ZakatMaal   float64 `json:"zakat_maal"`
 	Infaq       float64 `json:"infaq"`
 	Sadaqah     float64 `json:"sadaqah"`
+	Amil        float64 `json:"amil"`
 	Total       float64 `json:"total"`
+	CarriedOver float64 `json:"carried_over"` // opening balances migrated from the old system, not in total
 }
 
 // Distribution Summary Responses
 type DistributionSummaryByAsnafResponse struct {
 	AsnafName        string  `json:"asnaf_name"`
 	BeneficiaryCount int64   `json:"beneficiary_count"`
+	HouseholdCount   int64   `json:"household_count"` // mustahiq tanpa rumah tangga dihitung sebagai satu rumah tangga
 	TotalAmount      float64 `json:"total_amount"`
 }
 
@@ -21,15 +24,115 @@ type DistributionSummaryByProgramResponse struct {
 	ProgramName      string  `json:"program_name"`
 	SourceFundType   string  `json:"source_fund_type"`
 	BeneficiaryCount int64   `json:"beneficiary_count"`
+	HouseholdCount   int64   `json:"household_count"` // mustahiq tanpa rumah tangga dihitung sebagai satu rumah tangga
 	TotalAmount      float64 `json:"total_amount"`
 }
 
 // Fund Balance Response
 type FundBalanceResponse struct {
-	FundType string  `json:"fund_type"`
-	TotalIn  float64 `json:"total_in"`
-	TotalOut float64 `json:"total_out"`
-	Balance  float64 `json:"balance"`
+	FundType       string  `json:"fund_type"`
+	OpeningBalance float64 `json:"opening_balance"`
+	CarriedOver    float64 `json:"carried_over"` // opening balances migrated from the old system
+	TotalIn        float64 `json:"total_in"`
+	TotalOut       float64 `json:"total_out"`
+	Balance        float64 `json:"balance"` // carried_over + total_in - total_out in the period
+	ClosingBalance float64 `json:"closing_balance"`
+}
+
+// Trial Balance Response
+type TrialBalanceAccountResponse struct {
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	AccountType string  `json:"account_type"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
+
+type TrialBalanceResponse struct {
+	DateTo      string                        `json:"date_to"`
+	Accounts    []TrialBalanceAccountResponse `json:"accounts"`
+	TotalDebit  float64                       `json:"total_debit"`
+	TotalCredit float64                       `json:"total_credit"`
+	Difference  float64                       `json:"difference"` // selalu 0
+}
+
+// Account Balance Response
+type AccountBalanceResponse struct {
+	FinancialAccountID string  `json:"financial_account_id"`
+	Name               string  `json:"name"`
+	AccountType        string  `json:"account_type"`
+	OpeningBalance     float64 `json:"opening_balance"`
+	CarriedOver        float64 `json:"carried_over"`
+	TotalIn            float64 `json:"total_in"`
+	TotalOut           float64 `json:"total_out"`
+	ClosingBalance     float64 `json:"closing_balance"`
+}
+
+// Account Movement Response
+type AccountMovementItemResponse struct {
+	EntryDate   string  `json:"entry_date"`
+	Description string  `json:"description"`
+	SourceType  string  `json:"source_type"`
+	SourceID    string  `json:"source_id"`
+	In          float64 `json:"in"`
+	Out         float64 `json:"out"`
+	Balance     float64 `json:"balance"`
+}
+
+type AccountMovementResponse struct {
+	FinancialAccount FinancialAccountInfo          `json:"financial_account"`
+	AccountType      string                        `json:"account_type"`
+	DateFrom         string                        `json:"date_from"`
+	DateTo           string                        `json:"date_to"`
+	OpeningBalance   float64                       `json:"opening_balance"`
+	Movements        []AccountMovementItemResponse `json:"movements"`
+	CarriedOver      float64                       `json:"carried_over"`
+	TotalIn          float64                       `json:"total_in"`
+	TotalOut         float64                       `json:"total_out"`
+	ClosingBalance   float64                       `json:"closing_balance"`
+}
+
+// Unreconciled Response
+type UnreconciledLineResponse struct {
+	LineID             string  `json:"line_id"`
+	StatementID        string  `json:"statement_id"`
+	FinancialAccountID string  `json:"financial_account_id"`
+	AccountName        string  `json:"account_name"`
+	TransactionDate    string  `json:"transaction_date"`
+	Description        string  `json:"description"`
+	Reference          string  `json:"reference"`
+	Amount             float64 `json:"amount"`
+	Issue              string  `json:"issue"` // unmatched, matched, flagged, source_voided
+	FlagReason         string  `json:"flag_reason"`
+}
+
+type UnreconciledTransactionResponse struct {
+	SourceType         string  `json:"source_type"`
+	SourceID           string  `json:"source_id"`
+	Number             string  `json:"number"`
+	Date               string  `json:"date"`
+	FinancialAccountID string  `json:"financial_account_id"`
+	AccountName        string  `json:"account_name"`
+	Label              string  `json:"label"`
+	Amount             float64 `json:"amount"` // positive = receipt, negative = distribution
+}
+
+type UnreconciledResponse struct {
+	Lines             []UnreconciledLineResponse        `json:"lines"`
+	Transactions      []UnreconciledTransactionResponse `json:"transactions"`
+	TotalLines        float64                           `json:"total_lines"`
+	TotalTransactions float64                           `json:"total_transactions"`
+}
+
+// Stock On Hand Response
+type StockOnHandResponse struct {
+	Commodity  string  `json:"commodity"`
+	Unit       string  `json:"unit"`
+	FundLedger string  `json:"fund_ledger"`
+	TotalIn    float64 `json:"total_in"`
+	TotalOut   float64 `json:"total_out"`
+	Adjustment float64 `json:"adjustment"`
+	OnHand     float64 `json:"on_hand"`
 }
 
 // Mustahiq History Response
@@ -52,3 +155,60 @@ type MustahiqHistoryResponse struct {
 	History       []MustahiqHistoryItemResponse `json:"history"`
 	TotalReceived float64                       `json:"total_received"`
 }
+
+// Household History Response
+type HouseholdHistoryItemResponse struct {
+	DistributionDate string  `json:"distribution_date"`
+	MustahiqID       string  `json:"mustahiq_id"`
+	MustahiqName     string  `json:"mustahiq_name"`
+	ProgramName      string  `json:"program_name"`
+	SourceFundType   string  `json:"source_fund_type"`
+	Amount           float64 `json:"amount"`
+}
+
+type HouseholdHistoryMemberResponse struct {
+	MustahiqID    string  `json:"mustahiq_id"`
+	Name          string  `json:"name"`
+	Relationship  string  `json:"relationship"`
+	TotalReceived float64 `json:"total_received"`
+}
+
+type HouseholdHistoryHouseholdInfo struct {
+	ID               string `json:"id"`
+	FamilyCardNumber string `json:"family_card_number"`
+	HeadName         string `json:"head_name"`
+	Address          string `json:"address"`
+	MemberCount      int64  `json:"member_count"`
+}
+
+type HouseholdHistoryResponse struct {
+	Household     HouseholdHistoryHouseholdInfo    `json:"household"`
+	Members       []HouseholdHistoryMemberResponse `json:"members"`
+	History       []HouseholdHistoryItemResponse   `json:"history"`
+	TotalReceived float64                          `json:"total_received"`
+}
+
+// Undelivered Items Response
+type UndeliveredItemResponse struct {
+	DistributionID   string   `json:"distribution_id"`
+	DistributionDate string   `json:"distribution_date"`
+	ProgramName      string   `json:"program_name"`
+	SourceFundType   string   `json:"source_fund_type"`
+	ItemID           string   `json:"item_id"`
+	VoucherCode      string   `json:"voucher_code"`
+	MustahiqID       string   `json:"mustahiq_id"`
+	MustahiqName     string   `json:"mustahiq_name"`
+	Address          string   `json:"address"`
+	PhoneNumber      string   `json:"phone_number"`
+	Amount           float64  `json:"amount"`
+	Commodity        *string  `json:"commodity"`
+	Quantity         *float64 `json:"quantity"`
+	DaysOutstanding  int      `json:"days_outstanding"` // days since the distribution date
+}
+
+type UndeliveredItemsResponse struct {
+	Items             []UndeliveredItemResponse `json:"items"`
+	ItemCount         int                       `json:"item_count"`
+	DistributionCount int                       `json:"distribution_count"`
+	TotalAmount       float64                   `json:"total_amount"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type CreateStockAdjustmentRequest struct {
+	MovementDate string  `json:"movement_date" binding:"required"` // YYYY-MM-DD
+	Commodity    string  `json:"commodity" binding:"required,oneof=gold silver rice"`
+	FundLedger   string  `json:"fund_ledger" binding:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	Quantity     float64 `json:"quantity" binding:"required"` // negative for spoilage or loss
+	Reason       string  `json:"reason" binding:"required"`
+}
+
+type StockMovementResponse struct {
+	ID                   string    `json:"id"`
+	MovementDate         string    `json:"movement_date"`
+	Commodity            string    `json:"commodity"`
+	Unit                 string    `json:"unit"`
+	FundLedger           string    `json:"fund_ledger"`
+	Quantity             float64   `json:"quantity"`
+	MovementType         string    `json:"movement_type"`
+	SourceID             *string   `json:"source_id"`
+	SourceItemID         *string   `json:"source_item_id"`
+	Reason               string    `json:"reason"`
+	ReversalOfMovementID *string   `json:"reversal_of_movement_id"`
+	CreatedByUser        *UserInfo `json:"created_by_user"`
+	CreatedAt            time.Time `json:"created_at"`
+}
@@ -93,6 +93,11 @@ type DistributionListResponseWrapper struct {
 	Data interface{} `json:"data"` // Contains pagination data
 }
 
+type DistributionPlanResponseWrapper struct {
+	ResponseSuccess
+	Data DistributionPlanResponse `json:"data"`
+}
+
 type ReportResponseWrapper struct {
 	ResponseSuccess
 	Data interface{} `json:"data"` // Generic for all reports
@@ -103,3 +108,218 @@ type ErrorResponseWrapper struct {
 	Message string      `json:"message" example:"Error message"`
 	Errors  interface{} `json:"errors,omitempty"`
 }
+
+type ZakatCalculationResponseWrapper struct {
+	ResponseSuccess
+	Data ZakatCalculationResponse `json:"data"`
+}
+
+type ZakatCalculationListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type FitrahRateResponseWrapper struct {
+	ResponseSuccess
+	Data FitrahRateResponse `json:"data"`
+}
+
+type FitrahRateListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type CommodityPriceResponseWrapper struct {
+	ResponseSuccess
+	Data CommodityPriceResponse `json:"data"`
+}
+
+type CommodityPriceListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type CommodityPriceImportResponseWrapper struct {
+	ResponseSuccess
+	Data CommodityPriceImportResponse `json:"data"`
+}
+
+type AmilRateResponseWrapper struct {
+	ResponseSuccess
+	Data AmilRateResponse `json:"data"`
+}
+
+type AmilRateListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type LedgerAccountListResponseWrapper struct {
+	ResponseSuccess
+	Data []LedgerAccountResponse `json:"data"`
+}
+
+type JournalEntryResponseWrapper struct {
+	ResponseSuccess
+	Data JournalEntryResponse `json:"data"`
+}
+
+type JournalEntryListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type FinancialAccountResponseWrapper struct {
+	ResponseSuccess
+	Data FinancialAccountResponse `json:"data"`
+}
+
+type FinancialAccountListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type BankStatementFormatResponseWrapper struct {
+	ResponseSuccess
+	Data BankStatementFormatResponse `json:"data"`
+}
+
+type BankStatementFormatListResponseWrapper struct {
+	ResponseSuccess
+	Data []BankStatementFormatResponse `json:"data"`
+}
+
+type BankStatementResponseWrapper struct {
+	ResponseSuccess
+	Data BankStatementResponse `json:"data"`
+}
+
+type BankStatementListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type BankStatementLineResponseWrapper struct {
+	ResponseSuccess
+	Data BankStatementLineResponse `json:"data"`
+}
+
+type BankStatementLineListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type MatchCandidateListResponseWrapper struct {
+	ResponseSuccess
+	Data []MatchCandidateResponse `json:"data"`
+}
+
+type FiscalPeriodResponseWrapper struct {
+	ResponseSuccess
+	Data FiscalPeriodResponse `json:"data"`
+}
+
+type FiscalPeriodListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type OpeningBalanceResponseWrapper struct {
+	ResponseSuccess
+	Data OpeningBalanceResponse `json:"data"`
+}
+
+type OpeningBalanceListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type StockMovementResponseWrapper struct {
+	ResponseSuccess
+	Data StockMovementResponse `json:"data"`
+}
+
+type StockMovementListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type AssessmentQuestionnaireResponseWrapper struct {
+	ResponseSuccess
+	Data AssessmentQuestionnaireResponse `json:"data"`
+}
+
+type AssessmentQuestionnaireListResponseWrapper struct {
+	ResponseSuccess
+	Data []AssessmentQuestionnaireResponse `json:"data"`
+}
+
+type MustahiqAssessmentResponseWrapper struct {
+	ResponseSuccess
+	Data MustahiqAssessmentResponse `json:"data"`
+}
+
+type MustahiqAssessmentListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type MustahiqStatusHistoryResponseWrapper struct {
+	ResponseSuccess
+	Data []MustahiqStatusChangeResponse `json:"data"`
+}
+
+type HouseholdResponseWrapper struct {
+	ResponseSuccess
+	Data HouseholdResponse `json:"data"`
+}
+
+type HouseholdListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type DuplicateCandidateListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type DuplicateDismissalResponseWrapper struct {
+	ResponseSuccess
+	Data DuplicateDismissalResponse `json:"data"`
+}
+
+type MuzakkiMergeResponseWrapper struct {
+	ResponseSuccess
+	Data MuzakkiMergeResponse `json:"data"`
+}
+
+type MuzakkiMergeListResponseWrapper struct {
+	ResponseSuccess
+	Data []MuzakkiMergeResponse `json:"data"`
+}
+
+type MustahiqMergeResponseWrapper struct {
+	ResponseSuccess
+	Data MustahiqMergeResponse `json:"data"`
+}
+
+type MustahiqMergeListResponseWrapper struct {
+	ResponseSuccess
+	Data []MustahiqMergeResponse `json:"data"`
+}
+
+type AidRuleResponseWrapper struct {
+	ResponseSuccess
+	Data AidRuleResponse `json:"data"`
+}
+
+type AidRuleListResponseWrapper struct {
+	ResponseSuccess
+	Data interface{} `json:"data"` // Contains pagination data
+}
+
+type VoucherResponseWrapper struct {
+	ResponseSuccess
+	Data VoucherResponse `json:"data"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type CalculateZakatRequest struct {
+	CalculationDate    string  `json:"calculation_date"` // YYYY-MM-DD, default today
+	HaulStartDate      *string `json:"haul_start_date"`  // YYYY-MM-DD, optional
+	Cash               float64 `json:"cash" binding:"gte=0"`
+	GoldGrams          float64 `json:"gold_grams" binding:"gte=0"`
+	GoldPricePerGram   float64 `json:"gold_price_per_gram" binding:"gte=0"`
+	SilverGrams        float64 `json:"silver_grams" binding:"gte=0"`
+	SilverPricePerGram float64 `json:"silver_price_per_gram" binding:"gte=0"`
+	TradeGoods         float64 `json:"trade_goods" binding:"gte=0"`
+	Receivables        float64 `json:"receivables" binding:"gte=0"`
+	Debts              float64 `json:"debts" binding:"gte=0"`
+	NisabBasis         string  `json:"nisab_basis" binding:"omitempty,oneof=gold silver"` // default gold
+}
+
+type SaveZakatCalculationRequest struct {
+	CalculateZakatRequest
+	MuzakkiID string `json:"muzakki_id" binding:"required"`
+	Notes     string `json:"notes"`
+}
+
+// Response DTOs
+type ZakatCalculationResponse struct {
+	ID                 string       `json:"id,omitempty"`
+	Muzakki            *MuzakkiInfo `json:"muzakki,omitempty"`
+	CalculationDate    string       `json:"calculation_date"`
+	HaulStartDate      *string      `json:"haul_start_date"`
+	HaulCompleted      bool         `json:"haul_completed"`
+	Cash               float64      `json:"cash"`
+	GoldGrams          float64      `json:"gold_grams"`
+	GoldPricePerGram   float64      `json:"gold_price_per_gram"`
+	SilverGrams        float64      `json:"silver_grams"`
+	SilverPricePerGram float64      `json:"silver_price_per_gram"`
+	TradeGoods         float64      `json:"trade_goods"`
+	Receivables        float64      `json:"receivables"`
+	Debts              float64      `json:"debts"`
+	TotalAssets        float64      `json:"total_assets"`
+	NetAssets          float64      `json:"net_assets"`
+	NisabBasis         string       `json:"nisab_basis"`
+	NisabValue         float64      `json:"nisab_value"`
+	IsObligatory       bool         `json:"is_obligatory"`
+	ZakatRate          float64      `json:"zakat_rate"`
+	ZakatAmount        float64      `json:"zakat_amount"`
+	Notes              string       `json:"notes"`
+	CreatedAt          *time.Time   `json:"created_at,omitempty"`
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AidRuleHandler struct {
+	aidRuleUC *usecase.AidRuleUseCase
+}
+
+func NewAidRuleHandler(aidRuleUC *usecase.AidRuleUseCase) *AidRuleHandler {
+	return &AidRuleHandler{aidRuleUC: aidRuleUC}
+}
+
+func toAidRuleResponse(rule *entity.AidRule) dto.AidRuleResponse {
+	res := dto.AidRuleResponse{
+		ID:             rule.ID,
+		Name:           rule.Name,
+		Scope:          rule.Scope,
+		SourceFundType: rule.SourceFundType,
+		MaxAmount:      rule.MaxAmount,
+		MaxCount:       rule.MaxCount,
+		WindowDays:     rule.WindowDays,
+		Active:         rule.Active,
+		Description:    rule.Description,
+		CreatedAt:      rule.CreatedAt,
+		UpdatedAt:      rule.UpdatedAt,
+	}
+
+	if rule.Asnaf != nil {
+		res.Asnaf = &dto.AsnafInfo{ID: rule.Asnaf.ID, Name: rule.Asnaf.Name}
+	}
+	if rule.Program != nil {
+		res.Program = &dto.ProgramInfo{ID: rule.Program.ID, Name: rule.Program.Name}
+	}
+
+	return res
+}
+
+func toSaveAidRuleInput(id string, req dto.SaveAidRuleRequest) usecase.SaveAidRuleInput {
+	return usecase.SaveAidRuleInput{
+		ID:             id,
+		Name:           req.Name,
+		Scope:          req.Scope,
+		AsnafID:        req.AsnafID,
+		ProgramID:      req.ProgramID,
+		SourceFundType: req.SourceFundType,
+		MaxAmount:      req.MaxAmount,
+		MaxCount:       req.MaxCount,
+		WindowDays:     req.WindowDays,
+		Active:         req.Active,
+		Description:    req.Description,
+	}
+}
+
+// Create godoc
+// @Summary Create aid rule
+// @Description Create an aid cap or frequency limit (admin only). A rule limits the cash amount (max_amount), the number of distributions (max_count) or both, received by a mustahiq or by the whole household in the last window_days days up to the distribution date. Empty asnaf_id, program_id or source_fund_type means the rule applies to all
+// @Tags Aid Rules
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.SaveAidRuleRequest true "Aid Rule Request Body"
+// @Success 201 {object} dto.AidRuleResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/aid-rules [post]
+func (h *AidRuleHandler) Create(c *gin.Context) {
+	var req dto.SaveAidRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.aidRuleUC.Create(toSaveAidRuleInput("", req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Aid rule created successfully", toAidRuleResponse(rule))
+}
+
+// FindAll godoc
+// @Summary Get all aid rules
+// @Description Get list of aid rules with pagination and filters
+// @Tags Aid Rules
+// @Security BearerAuth
+// @Produce json
+// @Param asnaf_id query string false "Filter by asnaf ID"
+// @Param program_id query string false "Filter by program ID"
+// @Param source_fund_type query string false "Filter by source fund type: amil, zakat_fitrah, zakat_maal, infaq, sadaqah"
+// @Param active query boolean false "Filter by active status"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.AidRuleListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/aid-rules [get]
+func (h *AidRuleHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	var active *bool
+	if activeStr := c.Query("active"); activeStr != "" {
+		activeBool := activeStr == "true"
+		active = &activeBool
+	}
+
+	rules, total, err := h.aidRuleUC.FindAll(repository.AidRuleFilter{
+		AsnafID:        c.Query("asnaf_id"),
+		ProgramID:      c.Query("program_id"),
+		SourceFundType: c.Query("source_fund_type"),
+		Active:         active,
+		Page:           page,
+		PerPage:        perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.AidRuleResponse
+	for _, rule := range rules {
+		data = append(data, toAidRuleResponse(rule))
+	}
+
+	response.Success(c, http.StatusOK, "Get all aid rules successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get aid rule by ID
+// @Description Get a single aid rule
+// @Tags Aid Rules
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Aid Rule ID"
+// @Success 200 {object} dto.AidRuleResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/aid-rules/{id} [get]
+func (h *AidRuleHandler) FindByID(c *gin.Context) {
+	rule, err := h.aidRuleUC.FindByID(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "Aid rule not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get aid rule successful", toAidRuleResponse(rule))
+}
+
+// Update godoc
+// @Summary Update aid rule
+// @Description Update an aid rule (admin only). Distributions already saved are not checked again until they are edited or posted
+// @Tags Aid Rules
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Aid Rule ID"
+// @Param request body dto.SaveAidRuleRequest true "Aid Rule Request Body"
+// @Success 200 {object} dto.AidRuleResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/aid-rules/{id} [put]
+func (h *AidRuleHandler) Update(c *gin.Context) {
+	var req dto.SaveAidRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.aidRuleUC.Update(toSaveAidRuleInput(c.Param("id"), req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Aid rule updated successfully", toAidRuleResponse(rule))
+}
+
+// Delete godoc
+// @Summary Delete aid rule
+// @Description Delete an aid rule (admin only). Overrides already recorded on distributions are kept
+// @Tags Aid Rules
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Aid Rule ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/aid-rules/{id} [delete]
+func (h *AidRuleHandler) Delete(c *gin.Context) {
+	if err := h.aidRuleUC.Delete(c.Param("id")); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Aid rule deleted successfully", nil)
+}
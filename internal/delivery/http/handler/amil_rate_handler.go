@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AmilRateHandler struct {
+	amilRateUC *usecase.AmilRateUseCase
+}
+
+func NewAmilRateHandler(amilRateUC *usecase.AmilRateUseCase) *AmilRateHandler {
+	return &AmilRateHandler{amilRateUC: amilRateUC}
+}
+
+func toAmilRateResponse(ar *entity.AmilRate) dto.AmilRateResponse {
+	return dto.AmilRateResponse{
+		ID:            ar.ID,
+		FundType:      ar.FundType,
+		EffectiveDate: ar.EffectiveDate,
+		Percentage:    ar.Percentage,
+		Notes:         ar.Notes,
+		CreatedAt:     ar.CreatedAt,
+		UpdatedAt:     ar.UpdatedAt,
+	}
+}
+
+// Create godoc
+// @Summary Create new amil rate
+// @Description Set the amil share (hak amil) percentage for a fund type from an effective date. Zakat is capped at 12.5% (1/8)
+// @Tags Amil Rates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAmilRateRequest true "Create Amil Rate Request Body"
+// @Success 201 {object} dto.AmilRateResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/amil-rates [post]
+func (h *AmilRateHandler) Create(c *gin.Context) {
+	var req dto.CreateAmilRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.amilRateUC.Create(usecase.CreateAmilRateInput{
+		FundType:      req.FundType,
+		EffectiveDate: req.EffectiveDate,
+		Percentage:    req.Percentage,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Amil rate created successfully", toAmilRateResponse(rate))
+}
+
+// FindAll godoc
+// @Summary Get all amil rates
+// @Description Get list of amil rates with pagination
+// @Tags Amil Rates
+// @Security BearerAuth
+// @Produce json
+// @Param fund_type query string false "Filter by fund type (zakat_fitrah, zakat_maal, infaq, sadaqah)"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.AmilRateListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/amil-rates [get]
+func (h *AmilRateHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	rates, total, err := h.amilRateUC.FindAll(repository.AmilRateFilter{
+		FundType: c.Query("fund_type"),
+		Page:     page,
+		PerPage:  perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.AmilRateResponse
+	for _, ar := range rates {
+		data = append(data, toAmilRateResponse(ar))
+	}
+
+	response.Success(c, http.StatusOK, "Get all amil rates successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindEffective godoc
+// @Summary Get effective amil rate
+// @Description Get the amil rate in effect on a date, i.e. the latest rate with effective_date on or before that date
+// @Tags Amil Rates
+// @Security BearerAuth
+// @Produce json
+// @Param fund_type query string true "Fund type (zakat_fitrah, zakat_maal, infaq, sadaqah)"
+// @Param date query string false "Date (YYYY-MM-DD), default today"
+// @Success 200 {object} dto.AmilRateResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/amil-rates/effective [get]
+func (h *AmilRateHandler) FindEffective(c *gin.Context) {
+	rate, err := h.amilRateUC.FindEffective(c.Query("fund_type"), c.Query("date"))
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get effective amil rate successful", toAmilRateResponse(rate))
+}
+
+// FindByID godoc
+// @Summary Get amil rate by ID
+// @Description Get a single amil rate by ID
+// @Tags Amil Rates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Amil Rate ID"
+// @Success 200 {object} dto.AmilRateResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/amil-rates/{id} [get]
+func (h *AmilRateHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	rate, err := h.amilRateUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Amil rate not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get amil rate successful", toAmilRateResponse(rate))
+}
+
+// Update godoc
+// @Summary Update amil rate
+// @Description Update an existing amil rate. Allocations of receipts already posted are not changed
+// @Tags Amil Rates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Amil Rate ID"
+// @Param request body dto.UpdateAmilRateRequest true "Update Amil Rate Request Body"
+// @Success 200 {object} dto.AmilRateResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/amil-rates/{id} [put]
+func (h *AmilRateHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.UpdateAmilRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.amilRateUC.Update(usecase.UpdateAmilRateInput{
+		ID:            id,
+		FundType:      req.FundType,
+		EffectiveDate: req.EffectiveDate,
+		Percentage:    req.Percentage,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Amil rate updated successfully", toAmilRateResponse(rate))
+}
+
+// Delete godoc
+// @Summary Delete amil rate
+// @Description Delete an amil rate
+// @Tags Amil Rates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Amil Rate ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/amil-rates/{id} [delete]
+func (h *AmilRateHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.amilRateUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Amil rate deleted successfully", nil)
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssessmentQuestionnaireHandler struct {
+	questionnaireUC *usecase.AssessmentQuestionnaireUseCase
+}
+
+func NewAssessmentQuestionnaireHandler(questionnaireUC *usecase.AssessmentQuestionnaireUseCase) *AssessmentQuestionnaireHandler {
+	return &AssessmentQuestionnaireHandler{questionnaireUC: questionnaireUC}
+}
+
+func toAssessmentQuestionnaireResponse(q *entity.AssessmentQuestionnaire) dto.AssessmentQuestionnaireResponse {
+	res := dto.AssessmentQuestionnaireResponse{
+		ID:              q.ID,
+		Name:            q.Name,
+		PassThreshold:   q.PassThreshold,
+		IsActive:        q.IsActive,
+		Notes:           q.Notes,
+		AssessmentCount: q.AssessmentCount,
+		CreatedAt:       q.CreatedAt,
+		UpdatedAt:       q.UpdatedAt,
+	}
+
+	if q.Asnaf != nil {
+		res.Asnaf = dto.AsnafInfo{ID: q.Asnaf.ID, Name: q.Asnaf.Name}
+	}
+
+	for _, question := range q.Questions {
+		item := dto.AssessmentQuestionResponse{
+			ID:       question.ID,
+			Category: question.Category,
+			Question: question.Question,
+			Weight:   question.Weight,
+		}
+		for _, o := range question.Options {
+			item.Options = append(item.Options, dto.AssessmentOptionResponse{ID: o.ID, Label: o.Label, Score: o.Score})
+		}
+		res.Questions = append(res.Questions, item)
+	}
+
+	return res
+}
+
+func toSaveAssessmentQuestionnaireInput(id string, req dto.SaveAssessmentQuestionnaireRequest) usecase.SaveAssessmentQuestionnaireInput {
+	input := usecase.SaveAssessmentQuestionnaireInput{
+		ID:            id,
+		AsnafID:       req.AsnafID,
+		Name:          req.Name,
+		PassThreshold: req.PassThreshold,
+		IsActive:      req.IsActive,
+		Notes:         req.Notes,
+	}
+
+	for _, q := range req.Questions {
+		question := usecase.AssessmentQuestionInput{
+			Category: q.Category,
+			Question: q.Question,
+			Weight:   q.Weight,
+		}
+		for _, o := range q.Options {
+			question.Options = append(question.Options, usecase.AssessmentOptionInput{Label: o.Label, Score: o.Score})
+		}
+		input.Questions = append(input.Questions, question)
+	}
+
+	return input
+}
+
+// Create godoc
+// @Summary Create assessment questionnaire
+// @Description Create an eligibility questionnaire for an asnaf with weighted questions (income, dependants, housing, debts, other) and scored answer options (admin only). An active questionnaire replaces the asnaf's previous active one
+// @Tags Assessment Questionnaires
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.SaveAssessmentQuestionnaireRequest true "Assessment Questionnaire Request Body"
+// @Success 201 {object} dto.AssessmentQuestionnaireResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/assessment-questionnaires [post]
+func (h *AssessmentQuestionnaireHandler) Create(c *gin.Context) {
+	var req dto.SaveAssessmentQuestionnaireRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	questionnaire, err := h.questionnaireUC.Create(toSaveAssessmentQuestionnaireInput("", req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Assessment questionnaire created successfully", toAssessmentQuestionnaireResponse(questionnaire))
+}
+
+// FindAll godoc
+// @Summary Get all assessment questionnaires
+// @Description Get list of eligibility questionnaires
+// @Tags Assessment Questionnaires
+// @Security BearerAuth
+// @Produce json
+// @Param asnaf_id query string false "Filter by asnaf ID"
+// @Param active query bool false "Only active questionnaires"
+// @Success 200 {object} dto.AssessmentQuestionnaireListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/assessment-questionnaires [get]
+func (h *AssessmentQuestionnaireHandler) FindAll(c *gin.Context) {
+	questionnaires, err := h.questionnaireUC.FindAll(repository.AssessmentQuestionnaireFilter{
+		AsnafID:    c.Query("asnaf_id"),
+		ActiveOnly: c.Query("active") == "true",
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	data := make([]dto.AssessmentQuestionnaireResponse, len(questionnaires))
+	for i, q := range questionnaires {
+		data[i] = toAssessmentQuestionnaireResponse(q)
+	}
+
+	response.Success(c, http.StatusOK, "Get all assessment questionnaires successful", data)
+}
+
+// FindByID godoc
+// @Summary Get assessment questionnaire by ID
+// @Description Get a questionnaire with its questions and answer options
+// @Tags Assessment Questionnaires
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Assessment Questionnaire ID"
+// @Success 200 {object} dto.AssessmentQuestionnaireResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/assessment-questionnaires/{id} [get]
+func (h *AssessmentQuestionnaireHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	questionnaire, err := h.questionnaireUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Assessment questionnaire not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get assessment questionnaire successful", toAssessmentQuestionnaireResponse(questionnaire))
+}
+
+// Update godoc
+// @Summary Update assessment questionnaire
+// @Description Replace a questionnaire and its questions (admin only). Questionnaires already used by assessments cannot be changed; deactivate them and create a new one instead
+// @Tags Assessment Questionnaires
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Assessment Questionnaire ID"
+// @Param request body dto.SaveAssessmentQuestionnaireRequest true "Assessment Questionnaire Request Body"
+// @Success 200 {object} dto.AssessmentQuestionnaireResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/assessment-questionnaires/{id} [put]
+func (h *AssessmentQuestionnaireHandler) Update(c *gin.Context) {
+	var req dto.SaveAssessmentQuestionnaireRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	questionnaire, err := h.questionnaireUC.Update(toSaveAssessmentQuestionnaireInput(c.Param("id"), req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Assessment questionnaire updated successfully", toAssessmentQuestionnaireResponse(questionnaire))
+}
+
+// Activate godoc
+// @Summary Activate assessment questionnaire
+// @Description Make the questionnaire the one used for new assessments of its asnaf (admin only). The asnaf's previous active questionnaire is deactivated
+// @Tags Assessment Questionnaires
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Assessment Questionnaire ID"
+// @Success 200 {object} dto.AssessmentQuestionnaireResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/assessment-questionnaires/{id}/activate [post]
+func (h *AssessmentQuestionnaireHandler) Activate(c *gin.Context) {
+	questionnaire, err := h.questionnaireUC.Activate(c.Param("id"))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Assessment questionnaire activated successfully", toAssessmentQuestionnaireResponse(questionnaire))
+}
+
+// Deactivate godoc
+// @Summary Deactivate assessment questionnaire
+// @Description Stop using the questionnaire for new assessments (admin only). Stored assessments are kept
+// @Tags Assessment Questionnaires
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Assessment Questionnaire ID"
+// @Success 200 {object} dto.AssessmentQuestionnaireResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/assessment-questionnaires/{id}/deactivate [post]
+func (h *AssessmentQuestionnaireHandler) Deactivate(c *gin.Context) {
+	questionnaire, err := h.questionnaireUC.Deactivate(c.Param("id"))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Assessment questionnaire deactivated successfully", toAssessmentQuestionnaireResponse(questionnaire))
+}
+
+// Delete godoc
+// @Summary Delete assessment questionnaire
+// @Description Delete a questionnaire that has not been used by any assessment (admin only)
+// @Tags Assessment Questionnaires
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Assessment Questionnaire ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/assessment-questionnaires/{id} [delete]
+func (h *AssessmentQuestionnaireHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.questionnaireUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Assessment questionnaire deleted successfully", nil)
+}
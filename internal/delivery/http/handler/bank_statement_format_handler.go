@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BankStatementFormatHandler struct {
+	formatUC *usecase.BankStatementFormatUseCase
+}
+
+func NewBankStatementFormatHandler(formatUC *usecase.BankStatementFormatUseCase) *BankStatementFormatHandler {
+	return &BankStatementFormatHandler{formatUC: formatUC}
+}
+
+func toBankStatementFormatResponse(f *entity.BankStatementFormat) dto.BankStatementFormatResponse {
+	return dto.BankStatementFormatResponse{
+		ID:                f.ID,
+		Name:              f.Name,
+		Delimiter:         f.Delimiter,
+		SkipRows:          f.SkipRows,
+		FooterRows:        f.FooterRows,
+		DateColumn:        f.DateColumn,
+		DateLayout:        f.DateLayout,
+		DescriptionColumn: f.DescriptionColumn,
+		ReferenceColumn:   f.ReferenceColumn,
+		AmountColumn:      f.AmountColumn,
+		CreditColumn:      f.CreditColumn,
+		DebitColumn:       f.DebitColumn,
+		DecimalSeparator:  f.DecimalSeparator,
+		Notes:             f.Notes,
+		CreatedAt:         f.CreatedAt,
+		UpdatedAt:         f.UpdatedAt,
+	}
+}
+
+func toBankStatementFormatInput(req dto.BankStatementFormatRequest) usecase.BankStatementFormatInput {
+	return usecase.BankStatementFormatInput{
+		Name:              req.Name,
+		Delimiter:         req.Delimiter,
+		SkipRows:          req.SkipRows,
+		FooterRows:        req.FooterRows,
+		DateColumn:        req.DateColumn,
+		DateLayout:        req.DateLayout,
+		DescriptionColumn: req.DescriptionColumn,
+		ReferenceColumn:   req.ReferenceColumn,
+		AmountColumn:      req.AmountColumn,
+		CreditColumn:      req.CreditColumn,
+		DebitColumn:       req.DebitColumn,
+		DecimalSeparator:  req.DecimalSeparator,
+		Notes:             req.Notes,
+	}
+}
+
+// Create godoc
+// @Summary Create bank statement format
+// @Description Create a CSV column mapping for one bank's statement export. Either amount_column (signed or CR/DB suffixed) or both credit_column and debit_column are required. date_layout uses Go layout notation, e.g. 02/01/2006
+// @Tags Bank Statement Formats
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.BankStatementFormatRequest true "Bank Statement Format Request Body"
+// @Success 201 {object} dto.BankStatementFormatResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-formats [post]
+func (h *BankStatementFormatHandler) Create(c *gin.Context) {
+	var req dto.BankStatementFormatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, err := h.formatUC.Create(toBankStatementFormatInput(req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Bank statement format created successfully", toBankStatementFormatResponse(format))
+}
+
+// FindAll godoc
+// @Summary Get all bank statement formats
+// @Description Get list of bank statement CSV formats
+// @Tags Bank Statement Formats
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.BankStatementFormatListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-formats [get]
+func (h *BankStatementFormatHandler) FindAll(c *gin.Context) {
+	formats, err := h.formatUC.FindAll()
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	data := make([]dto.BankStatementFormatResponse, len(formats))
+	for i, f := range formats {
+		data[i] = toBankStatementFormatResponse(f)
+	}
+
+	response.Success(c, http.StatusOK, "Get all bank statement formats successful", data)
+}
+
+// FindByID godoc
+// @Summary Get bank statement format by ID
+// @Description Get a single bank statement format by ID
+// @Tags Bank Statement Formats
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement Format ID"
+// @Success 200 {object} dto.BankStatementFormatResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-formats/{id} [get]
+func (h *BankStatementFormatHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	format, err := h.formatUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Bank statement format not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get bank statement format successful", toBankStatementFormatResponse(format))
+}
+
+// Update godoc
+// @Summary Update bank statement format
+// @Description Update a bank statement format. Statements already imported are not re-parsed
+// @Tags Bank Statement Formats
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Bank Statement Format ID"
+// @Param request body dto.BankStatementFormatRequest true "Bank Statement Format Request Body"
+// @Success 200 {object} dto.BankStatementFormatResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-formats/{id} [put]
+func (h *BankStatementFormatHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.BankStatementFormatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, err := h.formatUC.Update(usecase.UpdateBankStatementFormatInput{
+		ID:                       id,
+		BankStatementFormatInput: toBankStatementFormatInput(req),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement format updated successfully", toBankStatementFormatResponse(format))
+}
+
+// Delete godoc
+// @Summary Delete bank statement format
+// @Description Delete a bank statement format that has not been used by any imported statement
+// @Tags Bank Statement Formats
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement Format ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-formats/{id} [delete]
+func (h *BankStatementFormatHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.formatUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement format deleted successfully", nil)
+}
@@ -0,0 +1,488 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BankStatementHandler struct {
+	statementUC *usecase.BankStatementUseCase
+}
+
+func NewBankStatementHandler(statementUC *usecase.BankStatementUseCase) *BankStatementHandler {
+	return &BankStatementHandler{statementUC: statementUC}
+}
+
+func toBankStatementLineResponse(line *entity.BankStatementLine) dto.BankStatementLineResponse {
+	res := dto.BankStatementLineResponse{
+		ID:                 line.ID,
+		StatementID:        line.StatementID,
+		FinancialAccountID: line.FinancialAccountID,
+		LineNumber:         line.LineNumber,
+		TransactionDate:    line.TransactionDate,
+		Description:        line.Description,
+		Reference:          line.Reference,
+		Amount:             line.Amount,
+		Status:             line.Status,
+		FlagReason:         line.FlagReason,
+		ReviewedAt:         line.ReviewedAt,
+		Matches:            make([]dto.BankStatementMatchResponse, len(line.Matches)),
+		CreatedAt:          line.CreatedAt,
+		UpdatedAt:          line.UpdatedAt,
+	}
+
+	if line.ReviewedByUser != nil {
+		res.ReviewedByUser = &dto.UserInfo{ID: line.ReviewedByUser.ID, FullName: line.ReviewedByUser.Name}
+	}
+
+	for i, m := range line.Matches {
+		res.Matches[i] = dto.BankStatementMatchResponse{
+			ID:           m.ID,
+			SourceType:   m.SourceType,
+			SourceID:     m.SourceID,
+			SourceNumber: m.SourceNumber,
+			SourceDate:   m.SourceDate,
+			SourceLabel:  m.SourceLabel,
+			Amount:       m.Amount,
+		}
+	}
+
+	return res
+}
+
+func toBankStatementResponse(statement *entity.BankStatement) dto.BankStatementResponse {
+	res := dto.BankStatementResponse{
+		ID:           statement.ID,
+		FormatID:     statement.FormatID,
+		FormatName:   statement.FormatName,
+		FileName:     statement.FileName,
+		DateFrom:     statement.DateFrom,
+		DateTo:       statement.DateTo,
+		LineCount:    statement.LineCount,
+		SkippedCount: statement.SkippedCount,
+		MatchedCount: statement.MatchedCount,
+		TotalIn:      statement.TotalIn,
+		TotalOut:     statement.TotalOut,
+		CreatedAt:    statement.CreatedAt,
+	}
+
+	if statement.FinancialAccount != nil {
+		res.FinancialAccount = dto.FinancialAccountInfo{ID: statement.FinancialAccount.ID, Name: statement.FinancialAccount.Name}
+	}
+	if statement.ImportedByUser != nil {
+		res.ImportedByUser = &dto.UserInfo{ID: statement.ImportedByUser.ID, FullName: statement.ImportedByUser.Name}
+	}
+
+	for _, line := range statement.Lines {
+		res.Lines = append(res.Lines, toBankStatementLineResponse(line))
+	}
+
+	return res
+}
+
+// Import godoc
+// @Summary Import bank statement CSV
+// @Description Import a bank statement export for one financial account using a saved column format. Lines already imported for the same account are skipped. Each new line is auto-matched to a posted receipt (money in) or distribution (money out) with the exact amount within the configured date window; matched lines wait for confirmation
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Param financial_account_id formData string true "Financial Account ID"
+// @Param format_id formData string true "Bank Statement Format ID"
+// @Success 201 {object} dto.BankStatementResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statements/import [post]
+func (h *BankStatementHandler) Import(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+	defer file.Close()
+
+	statement, err := h.statementUC.Import(usecase.ImportBankStatementInput{
+		FinancialAccountID: c.PostForm("financial_account_id"),
+		FormatID:           c.PostForm("format_id"),
+		FileName:           fileHeader.Filename,
+		ImportedByUserID:   userID.(string),
+	}, file)
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Bank statement imported successfully", toBankStatementResponse(statement))
+}
+
+// FindAll godoc
+// @Summary Get all bank statements
+// @Description Get list of imported bank statements with pagination
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param financial_account_id query string false "Filter by financial account ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.BankStatementListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statements [get]
+func (h *BankStatementHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	statements, total, err := h.statementUC.FindAll(repository.BankStatementFilter{
+		FinancialAccountID: c.Query("financial_account_id"),
+		Page:               page,
+		PerPage:            perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.BankStatementResponse
+	for _, s := range statements {
+		data = append(data, toBankStatementResponse(s))
+	}
+
+	response.Success(c, http.StatusOK, "Get all bank statements successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get bank statement by ID
+// @Description Get a bank statement with all its lines and matches
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement ID"
+// @Success 200 {object} dto.BankStatementResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statements/{id} [get]
+func (h *BankStatementHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	statement, err := h.statementUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Bank statement not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get bank statement successful", toBankStatementResponse(statement))
+}
+
+// AutoMatch godoc
+// @Summary Re-run automatic matching
+// @Description Run automatic matching again for the statement's unmatched lines, e.g. after missing receipts were recorded
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement ID"
+// @Success 200 {object} dto.BankStatementResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statements/{id}/auto-match [post]
+func (h *BankStatementHandler) AutoMatch(c *gin.Context) {
+	id := c.Param("id")
+
+	statement, err := h.statementUC.AutoMatch(id)
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement auto-matched successfully", toBankStatementResponse(statement))
+}
+
+// Delete godoc
+// @Summary Delete bank statement
+// @Description Delete an imported statement with its lines. Rejected when any line has been confirmed
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statements/{id} [delete]
+func (h *BankStatementHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.statementUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement deleted successfully", nil)
+}
+
+// FindLines godoc
+// @Summary Get reconciliation queue
+// @Description Get bank statement lines with pagination and filters, e.g. status=matched for lines waiting for confirmation
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param statement_id query string false "Filter by bank statement ID"
+// @Param financial_account_id query string false "Filter by financial account ID"
+// @Param status query string false "Filter by status: unmatched, matched, confirmed, flagged"
+// @Param date_from query string false "Filter by transaction date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by transaction date to (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.BankStatementLineListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-lines [get]
+func (h *BankStatementHandler) FindLines(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	lines, total, err := h.statementUC.FindLines(repository.BankStatementLineFilter{
+		StatementID:        c.Query("statement_id"),
+		FinancialAccountID: c.Query("financial_account_id"),
+		Status:             c.Query("status"),
+		DateFrom:           c.Query("date_from"),
+		DateTo:             c.Query("date_to"),
+		Page:               page,
+		PerPage:            perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.BankStatementLineResponse
+	for _, line := range lines {
+		data = append(data, toBankStatementLineResponse(line))
+	}
+
+	response.Success(c, http.StatusOK, "Get bank statement lines successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindLineByID godoc
+// @Summary Get bank statement line by ID
+// @Description Get a single bank statement line with its matches
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement Line ID"
+// @Success 200 {object} dto.BankStatementLineResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-lines/{id} [get]
+func (h *BankStatementHandler) FindLineByID(c *gin.Context) {
+	id := c.Param("id")
+
+	line, err := h.statementUC.FindLineByID(id)
+	if err != nil {
+		response.BadRequest(c, "Bank statement line not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get bank statement line successful", toBankStatementLineResponse(line))
+}
+
+// FindCandidates godoc
+// @Summary Get match candidates for a line
+// @Description Get unmatched posted receipts (money in) or distributions (money out) on the line's account, within the date window and not above the line amount, for manual or split matching
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement Line ID"
+// @Success 200 {object} dto.MatchCandidateListResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-lines/{id}/candidates [get]
+func (h *BankStatementHandler) FindCandidates(c *gin.Context) {
+	id := c.Param("id")
+
+	candidates, err := h.statementUC.FindCandidates(id)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	data := make([]dto.MatchCandidateResponse, len(candidates))
+	for i, m := range candidates {
+		data[i] = dto.MatchCandidateResponse{
+			SourceType: m.SourceType,
+			SourceID:   m.SourceID,
+			Number:     m.Number,
+			Date:       m.Date,
+			Amount:     m.Amount,
+			Label:      m.Label,
+		}
+	}
+
+	response.Success(c, http.StatusOK, "Get match candidates successful", data)
+}
+
+// Confirm godoc
+// @Summary Confirm auto-matched line
+// @Description Confirm the match proposed by automatic matching
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement Line ID"
+// @Success 200 {object} dto.BankStatementLineResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-lines/{id}/confirm [post]
+func (h *BankStatementHandler) Confirm(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	line, err := h.statementUC.Confirm(c.Param("id"), userID.(string))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement line confirmed successfully", toBankStatementLineResponse(line))
+}
+
+// Match godoc
+// @Summary Match line manually
+// @Description Match a line to one or more posted transactions (split) and confirm it. The transaction amounts must add up to the line amount. Replaces any proposed match
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Bank Statement Line ID"
+// @Param request body dto.MatchBankStatementLineRequest true "Match Bank Statement Line Request Body"
+// @Success 200 {object} dto.BankStatementLineResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-lines/{id}/match [post]
+func (h *BankStatementHandler) Match(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.MatchBankStatementLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	sources := make([]usecase.MatchSourceInput, len(req.Sources))
+	for i, s := range req.Sources {
+		sources[i] = usecase.MatchSourceInput{SourceType: s.SourceType, SourceID: s.SourceID}
+	}
+
+	line, err := h.statementUC.Match(usecase.MatchBankStatementLineInput{
+		LineID:  c.Param("id"),
+		UserID:  userID.(string),
+		Sources: sources,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement line matched successfully", toBankStatementLineResponse(line))
+}
+
+// Flag godoc
+// @Summary Flag line for investigation
+// @Description Flag a line that has no matching transaction (e.g. bank fee or unknown transfer). Any match on the line is released
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Bank Statement Line ID"
+// @Param request body dto.FlagBankStatementLineRequest true "Flag Bank Statement Line Request Body"
+// @Success 200 {object} dto.BankStatementLineResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-lines/{id}/flag [post]
+func (h *BankStatementHandler) Flag(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.FlagBankStatementLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	line, err := h.statementUC.Flag(usecase.FlagBankStatementLineInput{
+		LineID: c.Param("id"),
+		Reason: req.Reason,
+		UserID: userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement line flagged successfully", toBankStatementLineResponse(line))
+}
+
+// Reset godoc
+// @Summary Reset line to unmatched
+// @Description Remove all matches from a line (including confirmed ones) and set it back to unmatched
+// @Tags Bank Statements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Bank Statement Line ID"
+// @Success 200 {object} dto.BankStatementLineResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/bank-statement-lines/{id}/reset [post]
+func (h *BankStatementHandler) Reset(c *gin.Context) {
+	line, err := h.statementUC.Reset(c.Param("id"))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Bank statement line reset successfully", toBankStatementLineResponse(line))
+}
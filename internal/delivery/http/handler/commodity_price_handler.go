@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommodityPriceHandler struct {
+	commodityPriceUC *usecase.CommodityPriceUseCase
+}
+
+func NewCommodityPriceHandler(commodityPriceUC *usecase.CommodityPriceUseCase) *CommodityPriceHandler {
+	return &CommodityPriceHandler{commodityPriceUC: commodityPriceUC}
+}
+
+func toCommodityPriceResponse(cp *entity.CommodityPrice) dto.CommodityPriceResponse {
+	return dto.CommodityPriceResponse{
+		ID:        cp.ID,
+		Commodity: cp.Commodity,
+		PriceDate: cp.PriceDate,
+		Price:     cp.Price,
+		Unit:      cp.Unit,
+		Source:    cp.Source,
+		Notes:     cp.Notes,
+		CreatedAt: cp.CreatedAt,
+		UpdatedAt: cp.UpdatedAt,
+	}
+}
+
+// Create godoc
+// @Summary Create new commodity price
+// @Description Record the gold, silver (per gram) or rice (per kg) price for a date
+// @Tags Commodity Prices
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateCommodityPriceRequest true "Create Commodity Price Request Body"
+// @Success 201 {object} dto.CommodityPriceResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/commodity-prices [post]
+func (h *CommodityPriceHandler) Create(c *gin.Context) {
+	var req dto.CreateCommodityPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	price, err := h.commodityPriceUC.Create(usecase.CreateCommodityPriceInput{
+		Commodity: req.Commodity,
+		PriceDate: req.PriceDate,
+		Price:     req.Price,
+		Source:    req.Source,
+		Notes:     req.Notes,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Commodity price created successfully", toCommodityPriceResponse(price))
+}
+
+// FindAll godoc
+// @Summary Get all commodity prices
+// @Description Get list of recorded commodity prices with pagination and filters
+// @Tags Commodity Prices
+// @Security BearerAuth
+// @Produce json
+// @Param commodity query string false "Filter by commodity (gold, silver, rice)"
+// @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.CommodityPriceListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/commodity-prices [get]
+func (h *CommodityPriceHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	prices, total, err := h.commodityPriceUC.FindAll(repository.CommodityPriceFilter{
+		Commodity: c.Query("commodity"),
+		DateFrom:  c.Query("date_from"),
+		DateTo:    c.Query("date_to"),
+		Page:      page,
+		PerPage:   perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.CommodityPriceResponse
+	for _, cp := range prices {
+		data = append(data, toCommodityPriceResponse(cp))
+	}
+
+	response.Success(c, http.StatusOK, "Get all commodity prices successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindEffective godoc
+// @Summary Get effective commodity price
+// @Description Get the price in effect on a date, i.e. the latest price recorded on or before that date
+// @Tags Commodity Prices
+// @Security BearerAuth
+// @Produce json
+// @Param commodity query string true "Commodity (gold, silver, rice)"
+// @Param date query string false "Date (YYYY-MM-DD), default today"
+// @Success 200 {object} dto.CommodityPriceResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/commodity-prices/effective [get]
+func (h *CommodityPriceHandler) FindEffective(c *gin.Context) {
+	price, err := h.commodityPriceUC.FindEffective(c.Query("commodity"), c.Query("date"))
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get effective commodity price successful", toCommodityPriceResponse(price))
+}
+
+// FindByID godoc
+// @Summary Get commodity price by ID
+// @Description Get a single commodity price by ID
+// @Tags Commodity Prices
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Commodity Price ID"
+// @Success 200 {object} dto.CommodityPriceResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/commodity-prices/{id} [get]
+func (h *CommodityPriceHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	price, err := h.commodityPriceUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Commodity price not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get commodity price successful", toCommodityPriceResponse(price))
+}
+
+// Update godoc
+// @Summary Update commodity price
+// @Description Update an existing commodity price
+// @Tags Commodity Prices
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Commodity Price ID"
+// @Param request body dto.UpdateCommodityPriceRequest true "Update Commodity Price Request Body"
+// @Success 200 {object} dto.CommodityPriceResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/commodity-prices/{id} [put]
+func (h *CommodityPriceHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.UpdateCommodityPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	price, err := h.commodityPriceUC.Update(usecase.UpdateCommodityPriceInput{
+		ID:        id,
+		Commodity: req.Commodity,
+		PriceDate: req.PriceDate,
+		Price:     req.Price,
+		Source:    req.Source,
+		Notes:     req.Notes,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Commodity price updated successfully", toCommodityPriceResponse(price))
+}
+
+// Delete godoc
+// @Summary Delete commodity price
+// @Description Delete a commodity price
+// @Tags Commodity Prices
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Commodity Price ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/commodity-prices/{id} [delete]
+func (h *CommodityPriceHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.commodityPriceUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Commodity price deleted successfully", nil)
+}
+
+// Import godoc
+// @Summary Import commodity prices from CSV
+// @Description Backfill prices from a CSV file with header commodity,price_date,price[,source,notes]. Existing prices for the same commodity and date are overwritten. Nothing is saved if any line is invalid.
+// @Tags Commodity Prices
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Success 200 {object} dto.CommodityPriceImportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/commodity-prices/import [post]
+func (h *CommodityPriceHandler) Import(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.BadRequest(c, "file is required", nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.commodityPriceUC.ImportCSV(file)
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Commodity prices imported successfully", dto.CommodityPriceImportResponse{
+		Imported: result.Imported,
+	})
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+	"go-zakat-be/pkg/voucher"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DistributionDeliveryHandler struct {
+	deliveryUC *usecase.DistributionDeliveryUseCase
+}
+
+func NewDistributionDeliveryHandler(deliveryUC *usecase.DistributionDeliveryUseCase) *DistributionDeliveryHandler {
+	return &DistributionDeliveryHandler{deliveryUC: deliveryUC}
+}
+
+func toVoucherResponse(distribution *entity.Distribution, item *entity.DistributionItem) dto.VoucherResponse {
+	resp := dto.VoucherResponse{
+		VoucherCode:        voucher.Format(item.VoucherCode),
+		Redeemable:         distribution.Status == entity.DistributionStatusPosted && item.DeliveredAt == nil,
+		DistributionID:     distribution.ID,
+		DistributionDate:   distribution.DistributionDate,
+		DistributionStatus: distribution.Status,
+		DeliveryStatus:     distribution.DeliveryStatus,
+		SourceFundType:     distribution.SourceFundType,
+		Item:               toDistributionItemResponse(item),
+	}
+	if distribution.Program != nil {
+		resp.Program = &dto.ProgramInfo{ID: distribution.Program.ID, Name: distribution.Program.Name}
+	}
+	return resp
+}
+
+// FindByVoucherCode godoc
+// @Summary Look up a distribution voucher
+// @Description Find the distribution item of a typed or scanned voucher code before handing over the aid. Dashes, spaces and case are ignored
+// @Tags Distribution Deliveries
+// @Security BearerAuth
+// @Produce json
+// @Param code path string true "Voucher code, e.g. 7KQ2M-9XH4D"
+// @Success 200 {object} dto.VoucherResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/vouchers/{code} [get]
+func (h *DistributionDeliveryHandler) FindByVoucherCode(c *gin.Context) {
+	distribution, item, err := h.deliveryUC.FindByVoucherCode(c.Param("code"))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get voucher successful", toVoucherResponse(distribution, item))
+}
+
+// Redeem godoc
+// @Summary Redeem a distribution voucher
+// @Description Mark the voucher's item as delivered by the current user, with an optional photo of the handover and the mustahiq's signature (JPEG/PNG, max 5 MB each). Only vouchers of posted distributions can be redeemed, each only once
+// @Tags Distribution Deliveries
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param code path string true "Voucher code, e.g. 7KQ2M-9XH4D"
+// @Param photo formData file false "Photo of the handover"
+// @Param signature formData file false "Signature of the mustahiq"
+// @Param notes formData string false "Delivery notes"
+// @Success 200 {object} dto.VoucherResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/vouchers/{code}/redeem [post]
+func (h *DistributionDeliveryHandler) Redeem(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	photo, err := readFormImage(c, "photo")
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+	signature, err := readFormImage(c, "signature")
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	distribution, item, err := h.deliveryUC.Redeem(usecase.RedeemVoucherInput{
+		VoucherCode: c.Param("code"),
+		UserID:      userID.(string),
+		Notes:       c.PostForm("notes"),
+		Photo:       photo,
+		Signature:   signature,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Voucher redeemed successfully", toVoucherResponse(distribution, item))
+}
+
+// DownloadVouchers godoc
+// @Summary Download voucher sheet PDF
+// @Description Render printable voucher cards with a QR code for every item of a posted distribution. Delivered items are stamped as received
+// @Tags Distribution Deliveries
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path string true "Distribution ID"
+// @Param undelivered query bool false "Only items not yet delivered"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/vouchers/pdf [get]
+func (h *DistributionDeliveryHandler) DownloadVouchers(c *gin.Context) {
+	distribution, pdf, err := h.deliveryUC.RenderVouchers(c.Param("id"), c.Query("undelivered") == "true")
+	if err != nil {
+		if distribution == nil {
+			response.BadRequest(c, err.Error(), nil)
+			return
+		}
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="voucher-`+distribution.ID+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// DownloadPhoto godoc
+// @Summary Download delivery photo
+// @Description Get the handover photo uploaded when the item's voucher was redeemed
+// @Tags Distribution Deliveries
+// @Security BearerAuth
+// @Produce image/jpeg,image/png
+// @Param id path string true "Distribution ID"
+// @Param item_id path string true "Distribution Item ID"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/items/{item_id}/photo [get]
+func (h *DistributionDeliveryHandler) DownloadPhoto(c *gin.Context) {
+	h.downloadProof(c, usecase.DeliveryProofPhoto)
+}
+
+// DownloadSignature godoc
+// @Summary Download delivery signature
+// @Description Get the mustahiq's signature uploaded when the item's voucher was redeemed
+// @Tags Distribution Deliveries
+// @Security BearerAuth
+// @Produce image/jpeg,image/png
+// @Param id path string true "Distribution ID"
+// @Param item_id path string true "Distribution Item ID"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/items/{item_id}/signature [get]
+func (h *DistributionDeliveryHandler) DownloadSignature(c *gin.Context) {
+	h.downloadProof(c, usecase.DeliveryProofSignature)
+}
+
+func (h *DistributionDeliveryHandler) downloadProof(c *gin.Context, kind string) {
+	data, contentType, err := h.deliveryUC.OpenProof(c.Param("id"), c.Param("item_id"), kind)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// readFormImage membaca file opsional dari form multipart; nil jika field tidak dikirim
+func readFormImage(c *gin.Context, field string) ([]byte, error) {
+	fileHeader, err := c.FormFile(field)
+	if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so the usecase can report oversized files
+	return io.ReadAll(io.LimitReader(file, usecase.MaxDeliveryImageSize+1))
+}
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
 	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 	"go-zakat-be/internal/usecase"
 	"go-zakat-be/pkg/response"
+	"go-zakat-be/pkg/voucher"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,9 +24,244 @@ func NewDistributionHandler(distributionUC *usecase.DistributionUseCase) *Distri
 	return &DistributionHandler{distributionUC: distributionUC}
 }
 
+func toDistributionResponse(distribution *entity.Distribution) dto.DistributionResponse {
+	items := make([]dto.DistributionItemResponse, len(distribution.Items))
+	for i, item := range distribution.Items {
+		items[i] = toDistributionItemResponse(item)
+	}
+
+	resp := dto.DistributionResponse{
+		ID:                     distribution.ID,
+		DistributionDate:       distribution.DistributionDate,
+		SourceFundType:         distribution.SourceFundType,
+		TotalAmount:            distribution.TotalAmount,
+		Notes:                  distribution.Notes,
+		Status:                 distribution.Status,
+		DeliveryStatus:         distribution.DeliveryStatus,
+		DeliveredItemCount:     distribution.DeliveredItemCount,
+		PostedAt:               distribution.PostedAt,
+		VoidReason:             distribution.VoidReason,
+		VoidedAt:               distribution.VoidedAt,
+		RevertReason:           distribution.RevertReason,
+		RevertedAt:             distribution.RevertedAt,
+		OverdraftJustification: distribution.OverdraftJustification,
+		OverdraftApprovedByID:  distribution.OverdraftApprovedByUserID,
+		OverdraftApprovedAt:    distribution.OverdraftApprovedAt,
+		AidRuleJustification:   distribution.AidRuleJustification,
+		AidRuleOverrideByID:    distribution.AidRuleOverrideByUserID,
+		AidRuleOverrideAt:      distribution.AidRuleOverrideAt,
+		AidRuleViolations:      toAidRuleViolationResponses(distribution.AidRuleViolations),
+		Items:                  items,
+		Approvals:              toApprovalEventResponses(distribution.Approvals),
+		CreatedAt:              distribution.CreatedAt,
+		UpdatedAt:              distribution.UpdatedAt,
+	}
+
+	if distribution.CreatedByUser != nil {
+		resp.CreatedByUser = dto.UserInfo{
+			ID:       distribution.CreatedByUser.ID,
+			FullName: distribution.CreatedByUser.Name,
+		}
+	}
+	if distribution.FinancialAccount != nil {
+		resp.FinancialAccount = dto.FinancialAccountInfo{ID: distribution.FinancialAccount.ID, Name: distribution.FinancialAccount.Name}
+	}
+	if distribution.Program != nil {
+		resp.Program = &dto.ProgramInfo{
+			ID:   distribution.Program.ID,
+			Name: distribution.Program.Name,
+		}
+	}
+	if distribution.VoidedByUser != nil {
+		resp.VoidedByUser = &dto.UserInfo{ID: distribution.VoidedByUser.ID, FullName: distribution.VoidedByUser.Name}
+	}
+	if distribution.RevertedByUser != nil {
+		resp.RevertedByUser = &dto.UserInfo{ID: distribution.RevertedByUser.ID, FullName: distribution.RevertedByUser.Name}
+	}
+
+	return resp
+}
+
+func toDistributionItemResponse(item *entity.DistributionItem) dto.DistributionItemResponse {
+	resp := dto.DistributionItemResponse{
+		ID:                   item.ID,
+		MustahiqID:           item.MustahiqID,
+		MustahiqName:         item.Mustahiq.Name,
+		AsnafName:            item.Mustahiq.Asnaf.Name,
+		Address:              item.Mustahiq.Address,
+		Amount:               item.Amount,
+		Commodity:            item.Commodity,
+		Quantity:             item.Quantity,
+		Notes:                item.Notes,
+		VoucherCode:          voucher.Format(item.VoucherCode),
+		DeliveredAt:          item.DeliveredAt,
+		HasDeliveryPhoto:     item.DeliveryPhotoPath != "",
+		HasDeliverySignature: item.DeliverySignaturePath != "",
+		DeliveryNotes:        item.DeliveryNotes,
+	}
+	if item.DeliveredByUser != nil {
+		resp.DeliveredByUser = &dto.UserInfo{ID: item.DeliveredByUser.ID, FullName: item.DeliveredByUser.Name}
+	}
+	return resp
+}
+
+func toAidRuleViolationResponses(violations []*entity.AidRuleViolation) []dto.AidRuleViolationResponse {
+	res := make([]dto.AidRuleViolationResponse, len(violations))
+	for i, v := range violations {
+		res[i] = dto.AidRuleViolationResponse{
+			AidRuleID:   v.AidRuleID,
+			AidRuleName: v.AidRuleName,
+			MustahiqID:  v.MustahiqID,
+			HouseholdID: v.HouseholdID,
+			LimitType:   v.LimitType,
+			Allowed:     v.Allowed,
+			Used:        v.Used,
+			Total:       v.Total,
+			WindowStart: v.WindowStart,
+			WindowEnd:   v.WindowEnd,
+		}
+	}
+	return res
+}
+
+func toDistributionPlanResponse(plan *entity.DistributionPlan) dto.DistributionPlanResponse {
+	res := dto.DistributionPlanResponse{
+		Basis:             plan.Basis,
+		ItemCount:         plan.ItemCount,
+		HouseholdCount:    plan.HouseholdCount,
+		PersonCount:       plan.PersonCount,
+		TotalAmount:       plan.TotalAmount,
+		Items:             make([]dto.DistributionPlanItemResponse, len(plan.Items)),
+		Skipped:           make([]dto.DistributionPlanSkipResponse, len(plan.Skipped)),
+		AidRuleViolations: make([]dto.DistributionPlanViolationResponse, len(plan.AidRuleViolations)),
+	}
+
+	for i, item := range plan.Items {
+		res.Items[i] = dto.DistributionPlanItemResponse{
+			MustahiqID:   item.MustahiqID,
+			MustahiqName: item.MustahiqName,
+			AsnafName:    item.AsnafName,
+			HouseholdID:  item.HouseholdID,
+			MemberCount:  item.MemberCount,
+			Amount:       item.Amount,
+		}
+	}
+	for i, skip := range plan.Skipped {
+		res.Skipped[i] = dto.DistributionPlanSkipResponse{
+			MustahiqID:   skip.MustahiqID,
+			MustahiqName: skip.MustahiqName,
+			Reason:       skip.Reason,
+		}
+	}
+	for i, v := range toAidRuleViolationResponses(plan.AidRuleViolations) {
+		res.AidRuleViolations[i] = dto.DistributionPlanViolationResponse{
+			ItemIndex:                plan.AidRuleViolations[i].ItemIndex,
+			AidRuleViolationResponse: v,
+		}
+	}
+
+	return res
+}
+
+func toPlanDistributionInput(req dto.PlanDistributionRequest) usecase.PlanDistributionInput {
+	return usecase.PlanDistributionInput{
+		AsnafID:            req.AsnafID,
+		MustahiqStatus:     req.MustahiqStatus,
+		Region:             req.Region,
+		HouseholdID:        req.HouseholdID,
+		Basis:              req.Basis,
+		Amount:             req.Amount,
+		AmountPerMember:    req.AmountPerMember,
+		ItemNotes:          req.ItemNotes,
+		DistributionDate:   req.DistributionDate,
+		ProgramID:          req.ProgramID,
+		SourceFundType:     req.SourceFundType,
+		FinancialAccountID: req.FinancialAccountID,
+		Notes:              req.Notes,
+	}
+}
+
+// Plan godoc
+// @Summary Preview bulk distribution
+// @Description Select mustahiq like the mustahiq list filter (asnaf, status, region, household) and compute one item per person (amount) or per household (amount + amount_per_member x household members, paid to the household head). Mustahiq not active on the distribution date are skipped. Returns totals and the aid rule violations per item; nothing is saved
+// @Tags Distributions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.PlanDistributionRequest true "Plan Distribution Request Body"
+// @Success 200 {object} dto.DistributionPlanResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/plan [post]
+func (h *DistributionHandler) Plan(c *gin.Context) {
+	var req dto.PlanDistributionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.distributionUC.Plan(toPlanDistributionInput(req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Distribution plan created", toDistributionPlanResponse(plan))
+}
+
+// CommitPlan godoc
+// @Summary Create distribution from plan
+// @Description Build the plan again and save it as one distribution with its items in one transaction, with the same checks as creating a distribution. Pass expected_item_count and expected_total_amount from the preview to reject the commit when the selection has changed
+// @Tags Distributions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CommitDistributionPlanRequest true "Commit Distribution Plan Request Body"
+// @Success 201 {object} dto.DistributionResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/plan/commit [post]
+func (h *DistributionHandler) CommitPlan(c *gin.Context) {
+	var req dto.CommitDistributionPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	distribution, err := h.distributionUC.CommitPlan(usecase.CommitDistributionPlanInput{
+		PlanDistributionInput:  toPlanDistributionInput(req.PlanDistributionRequest),
+		Status:                 req.Status,
+		CreatedByUserID:        userID.(string),
+		CreatedByRole:          c.GetString("user_role"),
+		OverdraftJustification: req.OverdraftJustification,
+		AidRuleJustification:   req.AidRuleJustification,
+		ExpectedItemCount:      req.ExpectedItemCount,
+		ExpectedTotalAmount:    req.ExpectedTotalAmount,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Distribution created", gin.H{
+		"id":                distribution.ID,
+		"distribution_date": distribution.DistributionDate,
+		"status":            distribution.Status,
+		"total_amount":      distribution.TotalAmount,
+		"item_count":        len(distribution.Items),
+	})
+}
+
 // Create godoc
 // @Summary Create new distribution
-// @Description Create a new distribution with items
+// @Description Create a new distribution with items. A posted distribution may not exceed the live balance of its source fund unless an admin gives overdraft_justification. Items may be in kind (commodity + quantity, amount 0); in-kind items may never exceed the stock on hand of the source fund. Every item is checked against the active aid rules (amount and count caps per mustahiq or household in a rolling window); violations are returned per item and only an admin can save them with aid_rule_justification. A posted distribution above APPROVAL_THRESHOLD is created as pending_approval and checked when approved
 // @Tags Distributions
 // @Security BearerAuth
 // @Accept json
@@ -39,12 +278,13 @@ func (h *DistributionHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Get user ID from context (set by auth middleware)
+	// Get user ID and role from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
 		response.Unauthorized(c, "User not authenticated", nil)
 		return
 	}
+	userRole := c.GetString("user_role")
 
 	// Convert items
 	items := make([]usecase.CreateDistributionItemInput, len(req.Items))
@@ -52,26 +292,34 @@ func (h *DistributionHandler) Create(c *gin.Context) {
 		items[i] = usecase.CreateDistributionItemInput{
 			MustahiqID: item.MustahiqID,
 			Amount:     item.Amount,
+			Commodity:  item.Commodity,
+			Quantity:   item.Quantity,
 			Notes:      item.Notes,
 		}
 	}
 
 	distribution, err := h.distributionUC.Create(usecase.CreateDistributionInput{
-		DistributionDate: req.DistributionDate,
-		ProgramID:        req.ProgramID,
-		SourceFundType:   req.SourceFundType,
-		Notes:            req.Notes,
-		CreatedByUserID:  userID.(string),
-		Items:            items,
+		DistributionDate:       req.DistributionDate,
+		ProgramID:              req.ProgramID,
+		SourceFundType:         req.SourceFundType,
+		FinancialAccountID:     req.FinancialAccountID,
+		Notes:                  req.Notes,
+		Status:                 req.Status,
+		CreatedByUserID:        userID.(string),
+		CreatedByRole:          userRole,
+		OverdraftJustification: req.OverdraftJustification,
+		AidRuleJustification:   req.AidRuleJustification,
+		Items:                  items,
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
 	response.Success(c, http.StatusCreated, "Distribution created", gin.H{
 		"id":                distribution.ID,
 		"distribution_date": distribution.DistributionDate,
+		"status":            distribution.Status,
 		"total_amount":      distribution.TotalAmount,
 	})
 }
@@ -84,8 +332,11 @@ func (h *DistributionHandler) Create(c *gin.Context) {
 // @Produce json
 // @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
 // @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
-// @Param source_fund_type query string false "Filter by source fund type: zakat_fitrah, zakat_maal, infaq, sadaqah"
+// @Param source_fund_type query string false "Filter by source fund type: amil, zakat_fitrah, zakat_maal, infaq, sadaqah"
+// @Param financial_account_id query string false "Filter by financial account ID"
 // @Param program_id query string false "Filter by program ID"
+// @Param status query string false "Filter by status: draft, pending_approval, posted, voided"
+// @Param delivery_status query string false "Filter posted distributions by delivery status: undelivered, partial, delivered"
 // @Param q query string false "Search in program name or notes"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(10)
@@ -98,13 +349,16 @@ func (h *DistributionHandler) FindAll(c *gin.Context) {
 	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
 
 	distributions, total, err := h.distributionUC.FindAll(repository.DistributionFilter{
-		DateFrom:       c.Query("date_from"),
-		DateTo:         c.Query("date_to"),
-		SourceFundType: c.Query("source_fund_type"),
-		ProgramID:      c.Query("program_id"),
-		Query:          c.Query("q"),
-		Page:           page,
-		PerPage:        perPage,
+		DateFrom:           c.Query("date_from"),
+		DateTo:             c.Query("date_to"),
+		SourceFundType:     c.Query("source_fund_type"),
+		FinancialAccountID: c.Query("financial_account_id"),
+		ProgramID:          c.Query("program_id"),
+		Status:             c.Query("status"),
+		DeliveryStatus:     c.Query("delivery_status"),
+		Query:              c.Query("q"),
+		Page:               page,
+		PerPage:            perPage,
 	})
 	if err != nil {
 		response.InternalServerError(c, err.Error(), nil)
@@ -114,14 +368,19 @@ func (h *DistributionHandler) FindAll(c *gin.Context) {
 	var data []dto.DistributionListItemResponse
 	for _, d := range distributions {
 		item := dto.DistributionListItemResponse{
-			ID:               d.ID,
-			DistributionDate: d.DistributionDate,
-			SourceFundType:   d.SourceFundType,
-			TotalAmount:      d.TotalAmount,
-			BeneficiaryCount: int64(len(d.Items)), // Count from items loaded
-			Notes:            d.Notes,
-			CreatedAt:        d.CreatedAt,
-			UpdatedAt:        d.UpdatedAt,
+			ID:                   d.ID,
+			DistributionDate:     d.DistributionDate,
+			SourceFundType:       d.SourceFundType,
+			FinancialAccountID:   d.FinancialAccountID,
+			FinancialAccountName: d.FinancialAccount.Name,
+			TotalAmount:          d.TotalAmount,
+			BeneficiaryCount:     int64(len(d.Items)), // Count from items loaded
+			Notes:                d.Notes,
+			Status:               d.Status,
+			DeliveryStatus:       d.DeliveryStatus,
+			DeliveredItemCount:   d.DeliveredItemCount,
+			CreatedAt:            d.CreatedAt,
+			UpdatedAt:            d.UpdatedAt,
 		}
 
 		if d.Program != nil {
@@ -145,7 +404,7 @@ func (h *DistributionHandler) FindAll(c *gin.Context) {
 
 // FindByID godoc
 // @Summary Get distribution by ID
-// @Description Get a single distribution with all items
+// @Description Get a single distribution with all items and its approval history (who, when, comment)
 // @Tags Distributions
 // @Security BearerAuth
 // @Produce json
@@ -163,48 +422,12 @@ func (h *DistributionHandler) FindByID(c *gin.Context) {
 		return
 	}
 
-	// Convert items
-	items := make([]dto.DistributionItemResponse, len(distribution.Items))
-	for i, item := range distribution.Items {
-		items[i] = dto.DistributionItemResponse{
-			ID:           item.ID,
-			MustahiqID:   item.MustahiqID,
-			MustahiqName: item.Mustahiq.Name,
-			AsnafName:    item.Mustahiq.Asnaf.Name,
-			Address:      item.Mustahiq.Address,
-			Amount:       item.Amount,
-			Notes:        item.Notes,
-		}
-	}
-
-	resp := dto.DistributionResponse{
-		ID:               distribution.ID,
-		DistributionDate: distribution.DistributionDate,
-		SourceFundType:   distribution.SourceFundType,
-		TotalAmount:      distribution.TotalAmount,
-		Notes:            distribution.Notes,
-		CreatedByUser: dto.UserInfo{
-			ID:       distribution.CreatedByUser.ID,
-			FullName: distribution.CreatedByUser.Name,
-		},
-		Items:     items,
-		CreatedAt: distribution.CreatedAt,
-		UpdatedAt: distribution.UpdatedAt,
-	}
-
-	if distribution.Program != nil {
-		resp.Program = &dto.ProgramInfo{
-			ID:   distribution.Program.ID,
-			Name: distribution.Program.Name,
-		}
-	}
-
-	response.Success(c, http.StatusOK, "Get distribution successful", resp)
+	response.Success(c, http.StatusOK, "Get distribution successful", toDistributionResponse(distribution))
 }
 
 // Update godoc
 // @Summary Update distribution
-// @Description Update an existing distribution
+// @Description Update a draft distribution. Posted distributions must be reverted to draft by an admin first. Items are checked against the active aid rules like on create
 // @Tags Distributions
 // @Security BearerAuth
 // @Accept json
@@ -223,39 +446,53 @@ func (h *DistributionHandler) Update(c *gin.Context) {
 		return
 	}
 
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	userRole := c.GetString("user_role")
+
 	// Convert items
 	items := make([]usecase.CreateDistributionItemInput, len(req.Items))
 	for i, item := range req.Items {
 		items[i] = usecase.CreateDistributionItemInput{
 			MustahiqID: item.MustahiqID,
 			Amount:     item.Amount,
+			Commodity:  item.Commodity,
+			Quantity:   item.Quantity,
 			Notes:      item.Notes,
 		}
 	}
 
 	distribution, err := h.distributionUC.Update(usecase.UpdateDistributionInput{
-		ID:               id,
-		DistributionDate: req.DistributionDate,
-		ProgramID:        req.ProgramID,
-		SourceFundType:   req.SourceFundType,
-		Notes:            req.Notes,
-		Items:            items,
+		ID:                   id,
+		DistributionDate:     req.DistributionDate,
+		ProgramID:            req.ProgramID,
+		SourceFundType:       req.SourceFundType,
+		FinancialAccountID:   req.FinancialAccountID,
+		Notes:                req.Notes,
+		UserID:               userID.(string),
+		UserRole:             userRole,
+		AidRuleJustification: req.AidRuleJustification,
+		Items:                items,
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "Distribution updated successfully", gin.H{
 		"id":                distribution.ID,
 		"distribution_date": distribution.DistributionDate,
+		"status":            distribution.Status,
 		"total_amount":      distribution.TotalAmount,
 	})
 }
 
 // Delete godoc
-// @Summary Delete distribution
-// @Description Delete a distribution
+// @Summary Delete draft distribution
+// @Description Delete a draft distribution. Posted distributions cannot be deleted, void them instead
 // @Tags Distributions
 // @Security BearerAuth
 // @Produce json
@@ -274,3 +511,205 @@ func (h *DistributionHandler) Delete(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Distribution deleted successfully", nil)
 }
+
+// Post godoc
+// @Summary Post draft distribution
+// @Description Post a draft distribution so it counts in fund balance and reports. Rejected when it exceeds the live balance of its source fund unless an admin gives overdraft_justification. In-kind items are checked against the stock on hand without override. Aid rules are checked again; violations need aid_rule_justification from an admin. A distribution above APPROVAL_THRESHOLD is submitted instead and stays pending_approval until another user approves it
+// @Tags Distributions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Distribution ID"
+// @Param request body dto.PostDistributionRequest false "Post Distribution Request Body"
+// @Success 200 {object} dto.DistributionResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/post [post]
+func (h *DistributionHandler) Post(c *gin.Context) {
+	id := c.Param("id")
+
+	// Body opsional, hanya untuk override saldo dan aturan bantuan oleh admin
+	var req dto.PostDistributionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	userRole := c.GetString("user_role")
+
+	distribution, err := h.distributionUC.Post(usecase.PostDistributionInput{
+		ID:                     id,
+		UserID:                 userID.(string),
+		UserRole:               userRole,
+		OverdraftJustification: req.OverdraftJustification,
+		AidRuleJustification:   req.AidRuleJustification,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	message := "Distribution posted successfully"
+	if distribution.Status == entity.DistributionStatusPendingApproval {
+		message = "Distribution submitted for approval"
+	}
+
+	response.Success(c, http.StatusOK, message, gin.H{
+		"id":                distribution.ID,
+		"distribution_date": distribution.DistributionDate,
+		"status":            distribution.Status,
+		"total_amount":      distribution.TotalAmount,
+	})
+}
+
+// Approve godoc
+// @Summary Approve pending distribution
+// @Description Approve a distribution that is pending_approval. It is posted in the same transaction with the same fund balance and stock checks as post; an admin approver may give overdraft_justification. The creator and the submitter cannot approve their own distribution
+// @Tags Distributions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Distribution ID"
+// @Param request body dto.ApproveDistributionRequest false "Approve Distribution Request Body"
+// @Success 200 {object} dto.DistributionResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/approve [post]
+func (h *DistributionHandler) Approve(c *gin.Context) {
+	id := c.Param("id")
+
+	// Body opsional, untuk komentar dan override saldo oleh admin
+	var req dto.ApproveDistributionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	userRole := c.GetString("user_role")
+
+	distribution, err := h.distributionUC.Approve(usecase.ApproveDistributionInput{
+		ApprovalInput: usecase.ApprovalInput{
+			ID:      id,
+			UserID:  userID.(string),
+			Comment: req.Comment,
+		},
+		UserRole:               userRole,
+		OverdraftJustification: req.OverdraftJustification,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Distribution approved successfully", toDistributionResponse(distribution))
+}
+
+// Reject godoc
+// @Summary Reject pending distribution
+// @Description Reject a distribution that is pending_approval. It goes back to draft so the creator can fix and post it again. A comment is required. The creator and the submitter cannot reject their own distribution
+// @Tags Distributions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Distribution ID"
+// @Param request body dto.ApprovalRequest true "Reject Request Body"
+// @Success 200 {object} dto.DistributionResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/reject [post]
+func (h *DistributionHandler) Reject(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.ApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	distribution, err := h.distributionUC.Reject(usecase.ApprovalInput{
+		ID:      id,
+		UserID:  userID.(string),
+		Comment: req.Comment,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Distribution rejected", toDistributionResponse(distribution))
+}
+
+// Void godoc
+// @Summary Void posted distribution
+// @Description Void a posted distribution. It is kept for audit with the reason, user and time recorded, but no longer counts in reports. Not allowed once any voucher has been redeemed
+// @Tags Distributions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Distribution ID"
+// @Param request body dto.DistributionStatusChangeRequest true "Void Distribution Request Body"
+// @Success 200 {object} dto.DistributionResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/void [post]
+func (h *DistributionHandler) Void(c *gin.Context) {
+	h.changeStatus(c, h.distributionUC.Void, "Distribution voided successfully")
+}
+
+// RevertToDraft godoc
+// @Summary Revert posted distribution to draft
+// @Description Revert a posted distribution to draft so it can be edited. The reason, user and time are recorded. Not allowed once any voucher has been redeemed
+// @Tags Distributions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Distribution ID"
+// @Param request body dto.DistributionStatusChangeRequest true "Revert Distribution Request Body"
+// @Success 200 {object} dto.DistributionResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/distributions/{id}/revert-to-draft [post]
+func (h *DistributionHandler) RevertToDraft(c *gin.Context) {
+	h.changeStatus(c, h.distributionUC.RevertToDraft, "Distribution reverted to draft successfully")
+}
+
+func (h *DistributionHandler) changeStatus(c *gin.Context, change func(usecase.DistributionStatusChangeInput) (*entity.Distribution, error), message string) {
+	var req dto.DistributionStatusChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	distribution, err := change(usecase.DistributionStatusChangeInput{
+		ID:     c.Param("id"),
+		Reason: req.Reason,
+		UserID: userID.(string),
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, message, toDistributionResponse(distribution))
+}
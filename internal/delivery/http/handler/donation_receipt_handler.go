@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 	"go-zakat-be/internal/usecase"
 	"go-zakat-be/pkg/response"
@@ -20,9 +24,82 @@ func NewDonationReceiptHandler(receiptUC *usecase.DonationReceiptUseCase) *Donat
 	return &DonationReceiptHandler{receiptUC: receiptUC}
 }
 
+func toDonationReceiptResponse(receipt *entity.DonationReceipt) dto.DonationReceiptResponse {
+	items := make([]dto.DonationReceiptItemResponse, len(receipt.Items))
+	for i, item := range receipt.Items {
+		allocations := make([]dto.DonationReceiptAllocationResponse, len(item.Allocations))
+		for j, allocation := range item.Allocations {
+			allocations[j] = dto.DonationReceiptAllocationResponse{
+				Ledger:     allocation.Ledger,
+				Percentage: allocation.Percentage,
+				Amount:     allocation.Amount,
+			}
+		}
+		items[i] = dto.DonationReceiptItemResponse{
+			ID:                 item.ID,
+			FundType:           item.FundType,
+			ZakatType:          item.ZakatType,
+			PersonCount:        item.PersonCount,
+			Amount:             item.Amount,
+			RiceKG:             item.RiceKG,
+			Notes:              item.Notes,
+			ZakatCalculationID: item.ZakatCalculationID,
+			Allocations:        allocations,
+		}
+	}
+
+	res := dto.DonationReceiptResponse{
+		ID:                  receipt.ID,
+		ReceiptNumber:       receipt.ReceiptNumber,
+		ReceiptDate:         receipt.ReceiptDate,
+		FitrahRegion:        receipt.FitrahRegion,
+		TotalAmount:         receipt.TotalAmount,
+		Notes:               receipt.Notes,
+		Status:              receipt.Status,
+		PostedAt:            receipt.PostedAt,
+		VoidReason:          receipt.VoidReason,
+		VoidedAt:            receipt.VoidedAt,
+		ReversalOfReceiptID: receipt.ReversalOfReceiptID,
+		ReversedByReceiptID: receipt.ReversedByReceiptID,
+		Items:               items,
+		Approvals:           toApprovalEventResponses(receipt.Approvals),
+		CreatedAt:           receipt.CreatedAt,
+		UpdatedAt:           receipt.UpdatedAt,
+	}
+	if receipt.FinancialAccount != nil {
+		res.FinancialAccount = dto.FinancialAccountInfo{ID: receipt.FinancialAccount.ID, Name: receipt.FinancialAccount.Name}
+	}
+	if receipt.Muzakki != nil {
+		res.Muzakki = dto.MuzakkiInfo{ID: receipt.Muzakki.ID, FullName: receipt.Muzakki.Name}
+	}
+	if receipt.CreatedByUser != nil {
+		res.CreatedByUser = dto.UserInfo{ID: receipt.CreatedByUser.ID, FullName: receipt.CreatedByUser.Name}
+	}
+	if receipt.VoidedByUser != nil {
+		res.VoidedByUser = &dto.UserInfo{ID: receipt.VoidedByUser.ID, FullName: receipt.VoidedByUser.Name}
+	}
+	return res
+}
+
+// toApprovalEventResponses dipakai juga oleh penyaluran
+func toApprovalEventResponses(events []*entity.ApprovalEvent) []dto.ApprovalEventResponse {
+	res := make([]dto.ApprovalEventResponse, len(events))
+	for i, e := range events {
+		res[i] = dto.ApprovalEventResponse{
+			Action:    e.Action,
+			Comment:   e.Comment,
+			CreatedAt: e.CreatedAt,
+		}
+		if e.User != nil {
+			res[i].User = dto.UserInfo{ID: e.User.ID, FullName: e.User.Name}
+		}
+	}
+	return res
+}
+
 // Create godoc
 // @Summary Create new donation receipt
-// @Description Create a new donation receipt with items
+// @Description Create a new donation receipt with items. A posted receipt above APPROVAL_THRESHOLD is created as pending_approval
 // @Tags Donation Receipts
 // @Security BearerAuth
 // @Accept json
@@ -50,26 +127,29 @@ func (h *DonationReceiptHandler) Create(c *gin.Context) {
 	items := make([]usecase.CreateDonationReceiptItemInput, len(req.Items))
 	for i, item := range req.Items {
 		items[i] = usecase.CreateDonationReceiptItemInput{
-			FundType:    item.FundType,
-			ZakatType:   item.ZakatType,
-			PersonCount: item.PersonCount,
-			Amount:      item.Amount,
-			RiceKG:      item.RiceKG,
-			Notes:       item.Notes,
+			FundType:           item.FundType,
+			ZakatType:          item.ZakatType,
+			PersonCount:        item.PersonCount,
+			Amount:             item.Amount,
+			RiceKG:             item.RiceKG,
+			Notes:              item.Notes,
+			ZakatCalculationID: item.ZakatCalculationID,
 		}
 	}
 
 	receipt, err := h.receiptUC.Create(usecase.CreateDonationReceiptInput{
-		MuzakkiID:       req.MuzakkiID,
-		ReceiptNumber:   req.ReceiptNumber,
-		ReceiptDate:     req.ReceiptDate,
-		PaymentMethod:   req.PaymentMethod,
-		Notes:           req.Notes,
-		CreatedByUserID: userID.(string),
-		Items:           items,
+		MuzakkiID:          req.MuzakkiID,
+		ReceiptNumber:      req.ReceiptNumber,
+		ReceiptDate:        req.ReceiptDate,
+		FinancialAccountID: req.FinancialAccountID,
+		FitrahRegion:       req.FitrahRegion,
+		Notes:              req.Notes,
+		Status:             req.Status,
+		CreatedByUserID:    userID.(string),
+		Items:              items,
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
@@ -77,6 +157,7 @@ func (h *DonationReceiptHandler) Create(c *gin.Context) {
 		"id":             receipt.ID,
 		"receipt_number": receipt.ReceiptNumber,
 		"receipt_date":   receipt.ReceiptDate,
+		"status":         receipt.Status,
 		"total_amount":   receipt.TotalAmount,
 	})
 }
@@ -91,8 +172,9 @@ func (h *DonationReceiptHandler) Create(c *gin.Context) {
 // @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
 // @Param fund_type query string false "Filter by fund type: zakat, infaq, sadaqah"
 // @Param zakat_type query string false "Filter by zakat type: fitrah, maal"
-// @Param payment_method query string false "Filter by payment method"
+// @Param financial_account_id query string false "Filter by financial account ID"
 // @Param muzakki_id query string false "Filter by muzakki ID"
+// @Param status query string false "Filter by status: draft, pending_approval, posted, voided"
 // @Param q query string false "Search in muzakki name or notes"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(10)
@@ -105,15 +187,16 @@ func (h *DonationReceiptHandler) FindAll(c *gin.Context) {
 	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
 
 	receipts, total, err := h.receiptUC.FindAll(repository.DonationReceiptFilter{
-		DateFrom:      c.Query("date_from"),
-		DateTo:        c.Query("date_to"),
-		FundType:      c.Query("fund_type"),
-		ZakatType:     c.Query("zakat_type"),
-		PaymentMethod: c.Query("payment_method"),
-		MuzakkiID:     c.Query("muzakki_id"),
-		Query:         c.Query("q"),
-		Page:          page,
-		PerPage:       perPage,
+		DateFrom:           c.Query("date_from"),
+		DateTo:             c.Query("date_to"),
+		FundType:           c.Query("fund_type"),
+		ZakatType:          c.Query("zakat_type"),
+		FinancialAccountID: c.Query("financial_account_id"),
+		MuzakkiID:          c.Query("muzakki_id"),
+		Status:             c.Query("status"),
+		Query:              c.Query("q"),
+		Page:               page,
+		PerPage:            perPage,
 	})
 	if err != nil {
 		response.InternalServerError(c, err.Error(), nil)
@@ -123,17 +206,19 @@ func (h *DonationReceiptHandler) FindAll(c *gin.Context) {
 	var data []dto.DonationReceiptListItemResponse
 	for _, r := range receipts {
 		data = append(data, dto.DonationReceiptListItemResponse{
-			ID:              r.ID,
-			ReceiptNumber:   r.ReceiptNumber,
-			ReceiptDate:     r.ReceiptDate,
-			MuzakkiID:       r.MuzakkiID,
-			MuzakkiName:     r.Muzakki.Name,
-			PaymentMethod:   r.PaymentMethod,
-			TotalAmount:     r.TotalAmount,
-			Notes:           r.Notes,
-			CreatedByUserID: r.CreatedByUserID,
-			CreatedAt:       r.CreatedAt,
-			UpdatedAt:       r.UpdatedAt,
+			ID:                   r.ID,
+			ReceiptNumber:        r.ReceiptNumber,
+			ReceiptDate:          r.ReceiptDate,
+			MuzakkiID:            r.MuzakkiID,
+			MuzakkiName:          r.Muzakki.Name,
+			FinancialAccountID:   r.FinancialAccountID,
+			FinancialAccountName: r.FinancialAccount.Name,
+			TotalAmount:          r.TotalAmount,
+			Notes:                r.Notes,
+			Status:               r.Status,
+			CreatedByUserID:      r.CreatedByUserID,
+			CreatedAt:            r.CreatedAt,
+			UpdatedAt:            r.UpdatedAt,
 		})
 	}
 
@@ -148,9 +233,40 @@ func (h *DonationReceiptHandler) FindAll(c *gin.Context) {
 	})
 }
 
+// DownloadPDF godoc
+// @Summary Download donation receipt PDF (kwitansi)
+// @Description Render a printable receipt with items, amount in words (terbilang), fund breakdown, issuing staff, letterhead and signature
+// @Tags Donation Receipts
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path string true "Donation Receipt ID"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/donation-receipts/{id}/pdf [get]
+func (h *DonationReceiptHandler) DownloadPDF(c *gin.Context) {
+	id := c.Param("id")
+
+	receipt, pdf, err := h.receiptUC.RenderPDF(id)
+	if err != nil {
+		if receipt == nil {
+			response.BadRequest(c, err.Error(), nil)
+			return
+		}
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	// Receipt number bisa mengandung "/" (mis. ZIS/2025/03/00001)
+	filename := "kwitansi-" + strings.NewReplacer("/", "-", "\\", "-", "\"", "").Replace(receipt.ReceiptNumber) + ".pdf"
+	c.Header("Content-Disposition", `inline; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
 // FindByID godoc
 // @Summary Get donation receipt by ID
-// @Description Get a single donation receipt with all items
+// @Description Get a single donation receipt with all items and its approval history (who, when, comment)
 // @Tags Donation Receipts
 // @Security BearerAuth
 // @Produce json
@@ -168,44 +284,12 @@ func (h *DonationReceiptHandler) FindByID(c *gin.Context) {
 		return
 	}
 
-	// Convert items
-	items := make([]dto.DonationReceiptItemResponse, len(receipt.Items))
-	for i, item := range receipt.Items {
-		items[i] = dto.DonationReceiptItemResponse{
-			ID:          item.ID,
-			FundType:    item.FundType,
-			ZakatType:   item.ZakatType,
-			PersonCount: item.PersonCount,
-			Amount:      item.Amount,
-			RiceKG:      item.RiceKG,
-			Notes:       item.Notes,
-		}
-	}
-
-	response.Success(c, http.StatusOK, "Get donation receipt successful", dto.DonationReceiptResponse{
-		ID:            receipt.ID,
-		ReceiptNumber: receipt.ReceiptNumber,
-		ReceiptDate:   receipt.ReceiptDate,
-		Muzakki: dto.MuzakkiInfo{
-			ID:       receipt.Muzakki.ID,
-			FullName: receipt.Muzakki.Name,
-		},
-		PaymentMethod: receipt.PaymentMethod,
-		TotalAmount:   receipt.TotalAmount,
-		Notes:         receipt.Notes,
-		CreatedByUser: dto.UserInfo{
-			ID:       receipt.CreatedByUser.ID,
-			FullName: receipt.CreatedByUser.Name,
-		},
-		Items:     items,
-		CreatedAt: receipt.CreatedAt,
-		UpdatedAt: receipt.UpdatedAt,
-	})
+	response.Success(c, http.StatusOK, "Get donation receipt successful", toDonationReceiptResponse(receipt))
 }
 
 // Update godoc
 // @Summary Update donation receipt
-// @Description Update an existing donation receipt
+// @Description Update a draft donation receipt. Posted receipts are corrected with reverse
 // @Tags Donation Receipts
 // @Security BearerAuth
 // @Accept json
@@ -228,26 +312,28 @@ func (h *DonationReceiptHandler) Update(c *gin.Context) {
 	items := make([]usecase.CreateDonationReceiptItemInput, len(req.Items))
 	for i, item := range req.Items {
 		items[i] = usecase.CreateDonationReceiptItemInput{
-			FundType:    item.FundType,
-			ZakatType:   item.ZakatType,
-			PersonCount: item.PersonCount,
-			Amount:      item.Amount,
-			RiceKG:      item.RiceKG,
-			Notes:       item.Notes,
+			FundType:           item.FundType,
+			ZakatType:          item.ZakatType,
+			PersonCount:        item.PersonCount,
+			Amount:             item.Amount,
+			RiceKG:             item.RiceKG,
+			Notes:              item.Notes,
+			ZakatCalculationID: item.ZakatCalculationID,
 		}
 	}
 
 	receipt, err := h.receiptUC.Update(usecase.UpdateDonationReceiptInput{
-		ID:            id,
-		MuzakkiID:     req.MuzakkiID,
-		ReceiptNumber: req.ReceiptNumber,
-		ReceiptDate:   req.ReceiptDate,
-		PaymentMethod: req.PaymentMethod,
-		Notes:         req.Notes,
-		Items:         items,
+		ID:                 id,
+		MuzakkiID:          req.MuzakkiID,
+		ReceiptNumber:      req.ReceiptNumber,
+		ReceiptDate:        req.ReceiptDate,
+		FinancialAccountID: req.FinancialAccountID,
+		FitrahRegion:       req.FitrahRegion,
+		Notes:              req.Notes,
+		Items:              items,
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
@@ -255,13 +341,14 @@ func (h *DonationReceiptHandler) Update(c *gin.Context) {
 		"id":             receipt.ID,
 		"receipt_number": receipt.ReceiptNumber,
 		"receipt_date":   receipt.ReceiptDate,
+		"status":         receipt.Status,
 		"total_amount":   receipt.TotalAmount,
 	})
 }
 
 // Delete godoc
-// @Summary Delete donation receipt
-// @Description Delete a donation receipt
+// @Summary Delete draft donation receipt
+// @Description Delete a draft donation receipt. Posted receipts cannot be deleted, void them instead
 // @Tags Donation Receipts
 // @Security BearerAuth
 // @Produce json
@@ -280,3 +367,237 @@ func (h *DonationReceiptHandler) Delete(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Donation receipt deleted successfully", nil)
 }
+
+// Post godoc
+// @Summary Post draft donation receipt
+// @Description Post a draft receipt: it gets a receipt number and starts counting in reports. A receipt above APPROVAL_THRESHOLD is submitted instead and stays pending_approval until another user approves it
+// @Tags Donation Receipts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Donation Receipt ID"
+// @Success 200 {object} dto.DonationReceiptResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/donation-receipts/{id}/post [post]
+func (h *DonationReceiptHandler) Post(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	receipt, err := h.receiptUC.Post(id, userID.(string))
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	message := "Donation receipt posted successfully"
+	if receipt.Status == entity.ReceiptStatusPendingApproval {
+		message = "Donation receipt submitted for approval"
+	}
+
+	response.Success(c, http.StatusOK, message, gin.H{
+		"id":             receipt.ID,
+		"receipt_number": receipt.ReceiptNumber,
+		"receipt_date":   receipt.ReceiptDate,
+		"status":         receipt.Status,
+		"total_amount":   receipt.TotalAmount,
+	})
+}
+
+// Approve godoc
+// @Summary Approve pending donation receipt
+// @Description Approve a receipt that is pending_approval. It is posted in the same transaction: it gets a receipt number and starts counting in reports. The creator and the submitter cannot approve their own receipt
+// @Tags Donation Receipts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Donation Receipt ID"
+// @Param request body dto.ApprovalRequest false "Approval Request Body"
+// @Success 200 {object} dto.DonationReceiptResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/donation-receipts/{id}/approve [post]
+func (h *DonationReceiptHandler) Approve(c *gin.Context) {
+	id := c.Param("id")
+
+	// Body opsional, hanya untuk komentar
+	var req dto.ApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	receipt, err := h.receiptUC.Approve(usecase.ApprovalInput{
+		ID:      id,
+		UserID:  userID.(string),
+		Comment: req.Comment,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Donation receipt approved successfully", toDonationReceiptResponse(receipt))
+}
+
+// Reject godoc
+// @Summary Reject pending donation receipt
+// @Description Reject a receipt that is pending_approval. It goes back to draft so the creator can fix and post it again. A comment is required. The creator and the submitter cannot reject their own receipt
+// @Tags Donation Receipts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Donation Receipt ID"
+// @Param request body dto.ApprovalRequest true "Reject Request Body"
+// @Success 200 {object} dto.DonationReceiptResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/donation-receipts/{id}/reject [post]
+func (h *DonationReceiptHandler) Reject(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.ApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	receipt, err := h.receiptUC.Reject(usecase.ApprovalInput{
+		ID:      id,
+		UserID:  userID.(string),
+		Comment: req.Comment,
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Donation receipt rejected", toDonationReceiptResponse(receipt))
+}
+
+// Void godoc
+// @Summary Void posted donation receipt
+// @Description Void a posted receipt. The receipt and its items are kept for audit, with the reason, user and time recorded, but no longer count in reports
+// @Tags Donation Receipts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Donation Receipt ID"
+// @Param request body dto.VoidDonationReceiptRequest true "Void Donation Receipt Request Body"
+// @Success 200 {object} dto.DonationReceiptResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/donation-receipts/{id}/void [post]
+func (h *DonationReceiptHandler) Void(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.VoidDonationReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	receipt, err := h.receiptUC.Void(usecase.VoidDonationReceiptInput{
+		ID:             id,
+		Reason:         req.Reason,
+		VoidedByUserID: userID.(string),
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Donation receipt voided successfully", toDonationReceiptResponse(receipt))
+}
+
+// Reverse godoc
+// @Summary Reverse posted donation receipt
+// @Description Void a posted receipt and issue a posted correction receipt linked to it, in one transaction. A correction above APPROVAL_THRESHOLD is issued as pending_approval
+// @Tags Donation Receipts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Donation Receipt ID"
+// @Param request body dto.ReverseDonationReceiptRequest true "Reverse Donation Receipt Request Body"
+// @Success 201 {object} dto.DonationReceiptResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/donation-receipts/{id}/reverse [post]
+func (h *DonationReceiptHandler) Reverse(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.ReverseDonationReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	items := make([]usecase.CreateDonationReceiptItemInput, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = usecase.CreateDonationReceiptItemInput{
+			FundType:           item.FundType,
+			ZakatType:          item.ZakatType,
+			PersonCount:        item.PersonCount,
+			Amount:             item.Amount,
+			RiceKG:             item.RiceKG,
+			Notes:              item.Notes,
+			ZakatCalculationID: item.ZakatCalculationID,
+		}
+	}
+
+	correction, err := h.receiptUC.Reverse(usecase.ReverseDonationReceiptInput{
+		VoidDonationReceiptInput: usecase.VoidDonationReceiptInput{
+			ID:             id,
+			Reason:         req.Reason,
+			VoidedByUserID: userID.(string),
+		},
+		Correction: usecase.CreateDonationReceiptInput{
+			MuzakkiID:          req.MuzakkiID,
+			ReceiptNumber:      req.ReceiptNumber,
+			ReceiptDate:        req.ReceiptDate,
+			FinancialAccountID: req.FinancialAccountID,
+			FitrahRegion:       req.FitrahRegion,
+			Notes:              req.Notes,
+			CreatedByUserID:    userID.(string),
+			Items:              items,
+		},
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Donation receipt reversed successfully", gin.H{
+		"id":                     correction.ID,
+		"receipt_number":         correction.ReceiptNumber,
+		"receipt_date":           correction.ReceiptDate,
+		"status":                 correction.Status,
+		"total_amount":           correction.TotalAmount,
+		"reversal_of_receipt_id": correction.ReversalOfReceiptID,
+	})
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateCandidateHandler struct {
+	duplicateUC *usecase.DuplicateCandidateUseCase
+}
+
+func NewDuplicateCandidateHandler(duplicateUC *usecase.DuplicateCandidateUseCase) *DuplicateCandidateHandler {
+	return &DuplicateCandidateHandler{duplicateUC: duplicateUC}
+}
+
+func toDuplicateRecordResponse(r entity.DuplicateRecord) dto.DuplicateRecordResponse {
+	return dto.DuplicateRecordResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		NIK:         r.NIK,
+		PhoneNumber: r.PhoneNumber,
+		Address:     r.Address,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+// FindAll godoc
+// @Summary Get duplicate candidates
+// @Description Find pairs of mustahiq or muzakki that are likely the same person, highest score first. Pairs are flagged by the same NIK, the same normalised phone number with a similar name, or a similar name and address; records with different NIKs are never paired. Dismissed pairs are not shown
+// @Tags Duplicate Candidates
+// @Security BearerAuth
+// @Produce json
+// @Param entity_type query string true "mustahiq or muzakki"
+// @Param confidence query string false "Filter by confidence: high, medium"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.DuplicateCandidateListResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/duplicate-candidates [get]
+func (h *DuplicateCandidateHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	candidates, total, err := h.duplicateUC.FindAll(usecase.DuplicateCandidateFilter{
+		EntityType: c.Query("entity_type"),
+		Confidence: c.Query("confidence"),
+		Page:       page,
+		PerPage:    perPage,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.DuplicateCandidateResponse
+	for _, candidate := range candidates {
+		data = append(data, dto.DuplicateCandidateResponse{
+			EntityType:        candidate.EntityType,
+			RecordA:           toDuplicateRecordResponse(candidate.RecordA),
+			RecordB:           toDuplicateRecordResponse(candidate.RecordB),
+			Score:             candidate.Score,
+			Confidence:        candidate.Confidence,
+			Reasons:           candidate.Reasons,
+			NameSimilarity:    candidate.NameSimilarity,
+			AddressSimilarity: candidate.AddressSimilarity,
+		})
+	}
+
+	response.Success(c, http.StatusOK, "Get duplicate candidates successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// Dismiss godoc
+// @Summary Dismiss duplicate candidate
+// @Description Record that a reviewed pair is not the same person so it is no longer flagged (staf/admin)
+// @Tags Duplicate Candidates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.DismissDuplicateRequest true "Dismiss Duplicate Request Body"
+// @Success 201 {object} dto.DuplicateDismissalResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/duplicate-candidates/dismiss [post]
+func (h *DuplicateCandidateHandler) Dismiss(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.DismissDuplicateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	dismissal, err := h.duplicateUC.Dismiss(usecase.DismissDuplicateInput{
+		EntityType: req.EntityType,
+		RecordAID:  req.RecordAID,
+		RecordBID:  req.RecordBID,
+		Reason:     req.Reason,
+		UserID:     userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Duplicate candidate dismissed successfully", dto.DuplicateDismissalResponse{
+		ID:                dismissal.ID,
+		EntityType:        dismissal.EntityType,
+		RecordAID:         dismissal.RecordAID,
+		RecordBID:         dismissal.RecordBID,
+		Reason:            dismissal.Reason,
+		DismissedByUserID: dismissal.DismissedByUserID,
+		CreatedAt:         dismissal.CreatedAt,
+	})
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"errors"
+
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondUseCaseError mengirim ValidationErrors sebagai error terstruktur,
+// error lain sebagai 400 Bad Request biasa
+func respondUseCaseError(c *gin.Context, err error) {
+	var validationErrs usecase.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		response.ValidationError(c, validationErrs)
+		return
+	}
+
+	response.BadRequest(c, err.Error(), nil)
+}
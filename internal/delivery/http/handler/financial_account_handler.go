@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FinancialAccountHandler struct {
+	accountUC *usecase.FinancialAccountUseCase
+}
+
+func NewFinancialAccountHandler(accountUC *usecase.FinancialAccountUseCase) *FinancialAccountHandler {
+	return &FinancialAccountHandler{accountUC: accountUC}
+}
+
+func toFinancialAccountResponse(fa *entity.FinancialAccount) dto.FinancialAccountResponse {
+	return dto.FinancialAccountResponse{
+		ID:                fa.ID,
+		Name:              fa.Name,
+		AccountType:       fa.AccountType,
+		BankName:          fa.BankName,
+		AccountNumber:     fa.AccountNumber,
+		LedgerAccountCode: fa.LedgerAccountCode,
+		IsActive:          fa.IsActive,
+		Notes:             fa.Notes,
+		CreatedAt:         fa.CreatedAt,
+		UpdatedAt:         fa.UpdatedAt,
+	}
+}
+
+// Create godoc
+// @Summary Create new financial account
+// @Description Create a cash box, bank account or digital (QRIS/e-wallet) account. A ledger asset account is created for it
+// @Tags Financial Accounts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateFinancialAccountRequest true "Create Financial Account Request Body"
+// @Success 201 {object} dto.FinancialAccountResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/financial-accounts [post]
+func (h *FinancialAccountHandler) Create(c *gin.Context) {
+	var req dto.CreateFinancialAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountUC.Create(usecase.CreateFinancialAccountInput{
+		Name:          req.Name,
+		AccountType:   req.AccountType,
+		BankName:      req.BankName,
+		AccountNumber: req.AccountNumber,
+		IsActive:      req.IsActive,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Financial account created successfully", toFinancialAccountResponse(account))
+}
+
+// FindAll godoc
+// @Summary Get all financial accounts
+// @Description Get list of financial accounts with pagination, search, and filters
+// @Tags Financial Accounts
+// @Security BearerAuth
+// @Produce json
+// @Param q query string false "Search by name"
+// @Param account_type query string false "Filter by type: cash, bank, digital"
+// @Param is_active query boolean false "Filter by active status"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.FinancialAccountListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/financial-accounts [get]
+func (h *FinancialAccountHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	var isActive *bool
+	if activeStr := c.Query("is_active"); activeStr != "" {
+		activeBool := activeStr == "true"
+		isActive = &activeBool
+	}
+
+	accounts, total, err := h.accountUC.FindAll(repository.FinancialAccountFilter{
+		AccountType: c.Query("account_type"),
+		IsActive:    isActive,
+		Query:       c.Query("q"),
+		Page:        page,
+		PerPage:     perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.FinancialAccountResponse
+	for _, fa := range accounts {
+		data = append(data, toFinancialAccountResponse(fa))
+	}
+
+	response.Success(c, http.StatusOK, "Get all financial accounts successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get financial account by ID
+// @Description Get a single financial account by ID
+// @Tags Financial Accounts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Financial Account ID"
+// @Success 200 {object} dto.FinancialAccountResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/financial-accounts/{id} [get]
+func (h *FinancialAccountHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	account, err := h.accountUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Financial account not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get financial account successful", toFinancialAccountResponse(account))
+}
+
+// Update godoc
+// @Summary Update financial account
+// @Description Update an existing financial account. Inactive accounts cannot be used on new receipts or distributions
+// @Tags Financial Accounts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Financial Account ID"
+// @Param request body dto.UpdateFinancialAccountRequest true "Update Financial Account Request Body"
+// @Success 200 {object} dto.FinancialAccountResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/financial-accounts/{id} [put]
+func (h *FinancialAccountHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.UpdateFinancialAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountUC.Update(usecase.UpdateFinancialAccountInput{
+		ID:            id,
+		Name:          req.Name,
+		AccountType:   req.AccountType,
+		BankName:      req.BankName,
+		AccountNumber: req.AccountNumber,
+		IsActive:      req.IsActive,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Financial account updated successfully", toFinancialAccountResponse(account))
+}
+
+// Delete godoc
+// @Summary Delete financial account
+// @Description Delete a financial account that has never been used
+// @Tags Financial Accounts
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Financial Account ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/financial-accounts/{id} [delete]
+func (h *FinancialAccountHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.accountUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Financial account deleted successfully", nil)
+}
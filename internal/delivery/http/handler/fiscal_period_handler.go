@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FiscalPeriodHandler struct {
+	periodUC *usecase.FiscalPeriodUseCase
+}
+
+func NewFiscalPeriodHandler(periodUC *usecase.FiscalPeriodUseCase) *FiscalPeriodHandler {
+	return &FiscalPeriodHandler{periodUC: periodUC}
+}
+
+func toFiscalPeriodResponse(period *entity.FiscalPeriod) dto.FiscalPeriodResponse {
+	res := dto.FiscalPeriodResponse{
+		ID:           period.ID,
+		PeriodType:   period.PeriodType,
+		PeriodStart:  period.PeriodStart,
+		PeriodEnd:    period.PeriodEnd,
+		Status:       period.Status,
+		Notes:        period.Notes,
+		ClosedAt:     period.ClosedAt,
+		ReopenedAt:   period.ReopenedAt,
+		ReopenReason: period.ReopenReason,
+		CreatedAt:    period.CreatedAt,
+		UpdatedAt:    period.UpdatedAt,
+	}
+
+	if period.ClosedByUser != nil {
+		res.ClosedByUser = &dto.UserInfo{ID: period.ClosedByUser.ID, FullName: period.ClosedByUser.Name}
+	}
+	if period.ReopenedByUser != nil {
+		res.ReopenedByUser = &dto.UserInfo{ID: period.ReopenedByUser.ID, FullName: period.ReopenedByUser.Name}
+	}
+
+	for _, b := range period.Balances {
+		res.Balances = append(res.Balances, dto.FiscalPeriodBalanceResponse{
+			LedgerAccountID:    b.LedgerAccountID,
+			AccountCode:        b.AccountCode,
+			AccountName:        b.AccountName,
+			AccountType:        b.AccountType,
+			FundLedger:         b.FundLedger,
+			FinancialAccountID: b.FinancialAccountID,
+			OpeningBalance:     b.OpeningBalance,
+			TotalDebit:         b.TotalDebit,
+			TotalCredit:        b.TotalCredit,
+			ClosingBalance:     b.ClosingBalance,
+		})
+	}
+
+	for _, e := range period.Events {
+		event := dto.FiscalPeriodEventResponse{
+			Action:    e.Action,
+			Reason:    e.Reason,
+			CreatedAt: e.CreatedAt,
+		}
+		if e.User != nil {
+			event.User = dto.UserInfo{ID: e.User.ID, FullName: e.User.Name}
+		}
+		res.Events = append(res.Events, event)
+	}
+
+	return res
+}
+
+// Close godoc
+// @Summary Close fiscal period
+// @Description Close a month or a year. Receipts and distributions dated in a closed period cannot be created, edited, posted, voided or deleted. Rejected while the period still has drafts or records pending approval, or while an earlier period is still open. A snapshot of every ledger account balance is stored and used as the opening balance of the following period in reports
+// @Tags Fiscal Periods
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CloseFiscalPeriodRequest true "Close Fiscal Period Request Body"
+// @Success 201 {object} dto.FiscalPeriodResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fiscal-periods/close [post]
+func (h *FiscalPeriodHandler) Close(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.CloseFiscalPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := h.periodUC.Close(usecase.CloseFiscalPeriodInput{
+		PeriodType: req.PeriodType,
+		Year:       req.Year,
+		Month:      req.Month,
+		Notes:      req.Notes,
+		UserID:     userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Fiscal period closed successfully", toFiscalPeriodResponse(period))
+}
+
+// Reopen godoc
+// @Summary Reopen fiscal period
+// @Description Reopen a closed period so its transactions can be changed again. The reason, admin and time are recorded. A month stays locked while its year is closed, and a period cannot be reopened while a later period is closed
+// @Tags Fiscal Periods
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Fiscal Period ID"
+// @Param request body dto.ReopenFiscalPeriodRequest true "Reopen Fiscal Period Request Body"
+// @Success 200 {object} dto.FiscalPeriodResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fiscal-periods/{id}/reopen [post]
+func (h *FiscalPeriodHandler) Reopen(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ReopenFiscalPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := h.periodUC.Reopen(usecase.ReopenFiscalPeriodInput{
+		ID:     c.Param("id"),
+		Reason: req.Reason,
+		UserID: userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Fiscal period reopened successfully", toFiscalPeriodResponse(period))
+}
+
+// FindAll godoc
+// @Summary Get all fiscal periods
+// @Description Get list of closed and reopened fiscal periods with pagination and filters
+// @Tags Fiscal Periods
+// @Security BearerAuth
+// @Produce json
+// @Param period_type query string false "Filter by type: month, year"
+// @Param status query string false "Filter by status: open, closed"
+// @Param year query int false "Filter by year"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.FiscalPeriodListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fiscal-periods [get]
+func (h *FiscalPeriodHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+	year, _ := strconv.Atoi(c.Query("year"))
+
+	periods, total, err := h.periodUC.FindAll(repository.FiscalPeriodFilter{
+		PeriodType: c.Query("period_type"),
+		Status:     c.Query("status"),
+		Year:       year,
+		Page:       page,
+		PerPage:    perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.FiscalPeriodResponse
+	for _, p := range periods {
+		data = append(data, toFiscalPeriodResponse(p))
+	}
+
+	response.Success(c, http.StatusOK, "Get all fiscal periods successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get fiscal period by ID
+// @Description Get a fiscal period with its balance snapshot and close/reopen audit trail
+// @Tags Fiscal Periods
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Fiscal Period ID"
+// @Success 200 {object} dto.FiscalPeriodResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fiscal-periods/{id} [get]
+func (h *FiscalPeriodHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	period, err := h.periodUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Fiscal period not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get fiscal period successful", toFiscalPeriodResponse(period))
+}
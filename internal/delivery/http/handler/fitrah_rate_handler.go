@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FitrahRateHandler struct {
+	fitrahRateUC *usecase.FitrahRateUseCase
+}
+
+func NewFitrahRateHandler(fitrahRateUC *usecase.FitrahRateUseCase) *FitrahRateHandler {
+	return &FitrahRateHandler{fitrahRateUC: fitrahRateUC}
+}
+
+func toFitrahRateResponse(fr *entity.FitrahRate) dto.FitrahRateResponse {
+	return dto.FitrahRateResponse{
+		ID:              fr.ID,
+		HijriYear:       fr.HijriYear,
+		Region:          fr.Region,
+		RiceKGPerPerson: fr.RiceKGPerPerson,
+		CashPerPerson:   fr.CashPerPerson,
+		Notes:           fr.Notes,
+		CreatedAt:       fr.CreatedAt,
+		UpdatedAt:       fr.UpdatedAt,
+	}
+}
+
+// Create godoc
+// @Summary Create new fitrah rate
+// @Description Create a zakat fitrah rate (rice kg and cash per person) for a Hijri year and region
+// @Tags Fitrah Rates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateFitrahRateRequest true "Create Fitrah Rate Request Body"
+// @Success 201 {object} dto.FitrahRateResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fitrah-rates [post]
+func (h *FitrahRateHandler) Create(c *gin.Context) {
+	var req dto.CreateFitrahRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.fitrahRateUC.Create(usecase.CreateFitrahRateInput{
+		HijriYear:       req.HijriYear,
+		Region:          req.Region,
+		RiceKGPerPerson: req.RiceKGPerPerson,
+		CashPerPerson:   req.CashPerPerson,
+		Notes:           req.Notes,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Fitrah rate created successfully", toFitrahRateResponse(rate))
+}
+
+// FindAll godoc
+// @Summary Get all fitrah rates
+// @Description Get list of fitrah rates with pagination and filters
+// @Tags Fitrah Rates
+// @Security BearerAuth
+// @Produce json
+// @Param hijri_year query int false "Filter by Hijri year"
+// @Param region query string false "Filter by region"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.FitrahRateListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fitrah-rates [get]
+func (h *FitrahRateHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+	hijriYear, _ := strconv.Atoi(c.Query("hijri_year"))
+
+	rates, total, err := h.fitrahRateUC.FindAll(repository.FitrahRateFilter{
+		HijriYear: hijriYear,
+		Region:    c.Query("region"),
+		Page:      page,
+		PerPage:   perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.FitrahRateResponse
+	for _, fr := range rates {
+		data = append(data, toFitrahRateResponse(fr))
+	}
+
+	response.Success(c, http.StatusOK, "Get all fitrah rates successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get fitrah rate by ID
+// @Description Get a single fitrah rate by ID
+// @Tags Fitrah Rates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Fitrah Rate ID"
+// @Success 200 {object} dto.FitrahRateResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fitrah-rates/{id} [get]
+func (h *FitrahRateHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	rate, err := h.fitrahRateUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Fitrah rate not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get fitrah rate successful", toFitrahRateResponse(rate))
+}
+
+// Update godoc
+// @Summary Update fitrah rate
+// @Description Update an existing fitrah rate
+// @Tags Fitrah Rates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Fitrah Rate ID"
+// @Param request body dto.UpdateFitrahRateRequest true "Update Fitrah Rate Request Body"
+// @Success 200 {object} dto.FitrahRateResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fitrah-rates/{id} [put]
+func (h *FitrahRateHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.UpdateFitrahRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.fitrahRateUC.Update(usecase.UpdateFitrahRateInput{
+		ID:              id,
+		HijriYear:       req.HijriYear,
+		Region:          req.Region,
+		RiceKGPerPerson: req.RiceKGPerPerson,
+		CashPerPerson:   req.CashPerPerson,
+		Notes:           req.Notes,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Fitrah rate updated successfully", toFitrahRateResponse(rate))
+}
+
+// Delete godoc
+// @Summary Delete fitrah rate
+// @Description Delete a fitrah rate
+// @Tags Fitrah Rates
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Fitrah Rate ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/fitrah-rates/{id} [delete]
+func (h *FitrahRateHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.fitrahRateUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Fitrah rate deleted successfully", nil)
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HouseholdHandler struct {
+	householdUC *usecase.HouseholdUseCase
+}
+
+func NewHouseholdHandler(householdUC *usecase.HouseholdUseCase) *HouseholdHandler {
+	return &HouseholdHandler{householdUC: householdUC}
+}
+
+func toHouseholdResponse(h *entity.Household) dto.HouseholdResponse {
+	res := dto.HouseholdResponse{
+		ID:               h.ID,
+		FamilyCardNumber: h.FamilyCardNumber,
+		Address:          h.Address,
+		Notes:            h.Notes,
+		HeadName:         h.HeadName,
+		MemberCount:      h.MemberCount,
+		CreatedAt:        h.CreatedAt,
+		UpdatedAt:        h.UpdatedAt,
+	}
+
+	for _, m := range h.Members {
+		res.Members = append(res.Members, dto.HouseholdMemberResponse{
+			ID:             m.ID,
+			MustahiqID:     m.MustahiqID,
+			MustahiqStatus: m.MustahiqStatus,
+			Name:           m.Name,
+			Relationship:   m.Relationship,
+			BirthDate:      m.BirthDate,
+		})
+	}
+
+	return res
+}
+
+func toSaveHouseholdInput(id string, req dto.SaveHouseholdRequest) usecase.SaveHouseholdInput {
+	input := usecase.SaveHouseholdInput{
+		ID:               id,
+		FamilyCardNumber: req.FamilyCardNumber,
+		Address:          req.Address,
+		Notes:            req.Notes,
+	}
+
+	for _, m := range req.Members {
+		input.Members = append(input.Members, usecase.HouseholdMemberInput{
+			MustahiqID:   m.MustahiqID,
+			Name:         m.Name,
+			Relationship: m.Relationship,
+			BirthDate:    m.BirthDate,
+		})
+	}
+
+	return input
+}
+
+// Create godoc
+// @Summary Create household
+// @Description Register a household (Kartu Keluarga) with its members (staf/admin). Exactly one member must be the head; members may be linked to a mustahiq, and a mustahiq can belong to one household only
+// @Tags Households
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.SaveHouseholdRequest true "Household Request Body"
+// @Success 201 {object} dto.HouseholdResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/households [post]
+func (h *HouseholdHandler) Create(c *gin.Context) {
+	var req dto.SaveHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := h.householdUC.Create(toSaveHouseholdInput("", req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Household created successfully", toHouseholdResponse(household))
+}
+
+// FindAll godoc
+// @Summary Get all households
+// @Description Get list of households with pagination and search
+// @Tags Households
+// @Security BearerAuth
+// @Produce json
+// @Param q query string false "Search by family card number, head name or address"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.HouseholdListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/households [get]
+func (h *HouseholdHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	households, total, err := h.householdUC.FindAll(repository.HouseholdFilter{
+		Query:   c.Query("q"),
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.HouseholdResponse
+	for _, household := range households {
+		data = append(data, toHouseholdResponse(household))
+	}
+
+	response.Success(c, http.StatusOK, "Get all households successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get household by ID
+// @Description Get a household with its members
+// @Tags Households
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Household ID"
+// @Success 200 {object} dto.HouseholdResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/households/{id} [get]
+func (h *HouseholdHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	household, err := h.householdUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Household not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get household successful", toHouseholdResponse(household))
+}
+
+// Update godoc
+// @Summary Update household
+// @Description Replace a household and its members (staf/admin)
+// @Tags Households
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID"
+// @Param request body dto.SaveHouseholdRequest true "Household Request Body"
+// @Success 200 {object} dto.HouseholdResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/households/{id} [put]
+func (h *HouseholdHandler) Update(c *gin.Context) {
+	var req dto.SaveHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := h.householdUC.Update(toSaveHouseholdInput(c.Param("id"), req))
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Household updated successfully", toHouseholdResponse(household))
+}
+
+// Delete godoc
+// @Summary Delete household
+// @Description Delete a household and its member list (admin only). Mustahiq records of the members are kept
+// @Tags Households
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Household ID"
+// @Success 200 {object} dto.ResponseSuccess
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/households/{id} [delete]
+func (h *HouseholdHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.householdUC.Delete(id); err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Household deleted successfully", nil)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JournalHandler struct {
+	journalUC *usecase.JournalUseCase
+}
+
+func NewJournalHandler(journalUC *usecase.JournalUseCase) *JournalHandler {
+	return &JournalHandler{journalUC: journalUC}
+}
+
+func toJournalEntryResponse(je *entity.JournalEntry) dto.JournalEntryResponse {
+	lines := make([]dto.JournalLineResponse, len(je.Lines))
+	for i, line := range je.Lines {
+		lines[i] = dto.JournalLineResponse{
+			AccountCode: line.AccountCode,
+			AccountName: line.AccountName,
+			Debit:       line.Debit,
+			Credit:      line.Credit,
+		}
+	}
+
+	return dto.JournalEntryResponse{
+		ID:                je.ID,
+		EntryDate:         je.EntryDate,
+		Description:       je.Description,
+		SourceType:        je.SourceType,
+		SourceID:          je.SourceID,
+		ReversalOfEntryID: je.ReversalOfEntryID,
+		Lines:             lines,
+		CreatedAt:         je.CreatedAt,
+	}
+}
+
+// FindAccounts godoc
+// @Summary Get chart of accounts
+// @Description Get all ledger accounts: cash, bank and one fund account per sub-ledger (amil, zakat_fitrah, zakat_maal, infaq, sadaqah)
+// @Tags Journal
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.LedgerAccountListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/ledger-accounts [get]
+func (h *JournalHandler) FindAccounts(c *gin.Context) {
+	accounts, err := h.journalUC.FindAccounts()
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	data := make([]dto.LedgerAccountResponse, len(accounts))
+	for i, la := range accounts {
+		data[i] = dto.LedgerAccountResponse{
+			ID:          la.ID,
+			Code:        la.Code,
+			Name:        la.Name,
+			AccountType: la.AccountType,
+			FundLedger:  la.FundLedger,
+		}
+	}
+
+	response.Success(c, http.StatusOK, "Get ledger accounts successful", data)
+}
+
+// FindAllEntries godoc
+// @Summary Get all journal entries
+// @Description Get journal entries with their lines. Entries are created automatically when receipts and distributions are posted, voided or reverted
+// @Tags Journal
+// @Security BearerAuth
+// @Produce json
+// @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
+// @Param source_type query string false "Filter by source type: donation_receipt, distribution, opening_balance"
+// @Param source_id query string false "Filter by source (receipt or distribution) ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.JournalEntryListResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/journal-entries [get]
+func (h *JournalHandler) FindAllEntries(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	entries, total, err := h.journalUC.FindAllEntries(repository.JournalEntryFilter{
+		DateFrom:   c.Query("date_from"),
+		DateTo:     c.Query("date_to"),
+		SourceType: c.Query("source_type"),
+		SourceID:   c.Query("source_id"),
+		Page:       page,
+		PerPage:    perPage,
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.JournalEntryResponse
+	for _, je := range entries {
+		data = append(data, toJournalEntryResponse(je))
+	}
+
+	response.Success(c, http.StatusOK, "Get all journal entries successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindEntryByID godoc
+// @Summary Get journal entry by ID
+// @Description Get a single journal entry with its lines
+// @Tags Journal
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Journal Entry ID"
+// @Success 200 {object} dto.JournalEntryResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/journal-entries/{id} [get]
+func (h *JournalHandler) FindEntryByID(c *gin.Context) {
+	id := c.Param("id")
+
+	entry, err := h.journalUC.FindEntryByID(id)
+	if err != nil {
+		response.BadRequest(c, "Journal entry not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get journal entry successful", toJournalEntryResponse(entry))
+}
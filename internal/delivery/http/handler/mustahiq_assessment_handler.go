@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MustahiqAssessmentHandler struct {
+	assessmentUC *usecase.MustahiqAssessmentUseCase
+}
+
+func NewMustahiqAssessmentHandler(assessmentUC *usecase.MustahiqAssessmentUseCase) *MustahiqAssessmentHandler {
+	return &MustahiqAssessmentHandler{assessmentUC: assessmentUC}
+}
+
+func toMustahiqAssessmentResponse(a *entity.MustahiqAssessment) dto.MustahiqAssessmentResponse {
+	res := dto.MustahiqAssessmentResponse{
+		ID:                a.ID,
+		QuestionnaireID:   a.QuestionnaireID,
+		QuestionnaireName: a.QuestionnaireName,
+		AssessmentDate:    a.AssessmentDate,
+		Score:             a.Score,
+		PassThreshold:     a.PassThreshold,
+		Result:            a.Result,
+		PreviousStatus:    a.PreviousStatus,
+		NewStatus:         a.NewStatus,
+		Notes:             a.Notes,
+		CreatedAt:         a.CreatedAt,
+	}
+
+	if a.Mustahiq != nil {
+		res.Mustahiq = dto.MustahiqInfo{ID: a.Mustahiq.ID, FullName: a.Mustahiq.Name}
+	}
+	if a.AssessedByUser != nil {
+		res.AssessedByUser = dto.UserInfo{ID: a.AssessedByUser.ID, FullName: a.AssessedByUser.Name}
+	}
+
+	for _, answer := range a.Answers {
+		res.Answers = append(res.Answers, dto.MustahiqAssessmentAnswerResponse{
+			QuestionID: answer.QuestionID,
+			OptionID:   answer.OptionID,
+			Category:   answer.Category,
+			Question:   answer.Question,
+			Answer:     answer.Answer,
+			Weight:     answer.Weight,
+			Score:      answer.Score,
+			MaxScore:   answer.MaxScore,
+		})
+	}
+
+	return res
+}
+
+// Create godoc
+// @Summary Submit mustahiq assessment
+// @Description Submit a field assessment for a mustahiq (staf/admin). Every question of the questionnaire must be answered once. The score is sum(weight x option score) / sum(weight x highest option score) x 100; a score at or above the questionnaire's pass_threshold makes the mustahiq active, otherwise inactive. The answers, score and status change are stored
+// @Tags Mustahiq Assessments
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateMustahiqAssessmentRequest true "Create Mustahiq Assessment Request Body"
+// @Success 201 {object} dto.MustahiqAssessmentResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq-assessments [post]
+func (h *MustahiqAssessmentHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.CreateMustahiqAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := usecase.CreateMustahiqAssessmentInput{
+		MustahiqID:       req.MustahiqID,
+		QuestionnaireID:  req.QuestionnaireID,
+		AssessmentDate:   req.AssessmentDate,
+		Notes:            req.Notes,
+		AssessedByUserID: userID.(string),
+	}
+	for _, a := range req.Answers {
+		input.Answers = append(input.Answers, usecase.AssessmentAnswerInput{QuestionID: a.QuestionID, OptionID: a.OptionID})
+	}
+
+	assessment, err := h.assessmentUC.Create(input)
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Mustahiq assessment submitted successfully", toMustahiqAssessmentResponse(assessment))
+}
+
+// FindAll godoc
+// @Summary Get all mustahiq assessments
+// @Description Get list of mustahiq assessments with pagination and filters
+// @Tags Mustahiq Assessments
+// @Security BearerAuth
+// @Produce json
+// @Param mustahiq_id query string false "Filter by mustahiq ID"
+// @Param questionnaire_id query string false "Filter by questionnaire ID"
+// @Param result query string false "Filter by result: eligible, not_eligible"
+// @Param date_from query string false "Filter from date (YYYY-MM-DD)"
+// @Param date_to query string false "Filter to date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.MustahiqAssessmentListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq-assessments [get]
+func (h *MustahiqAssessmentHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	assessments, total, err := h.assessmentUC.FindAll(repository.MustahiqAssessmentFilter{
+		MustahiqID:      c.Query("mustahiq_id"),
+		QuestionnaireID: c.Query("questionnaire_id"),
+		Result:          c.Query("result"),
+		DateFrom:        c.Query("date_from"),
+		DateTo:          c.Query("date_to"),
+		Page:            page,
+		PerPage:         perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.MustahiqAssessmentResponse
+	for _, a := range assessments {
+		data = append(data, toMustahiqAssessmentResponse(a))
+	}
+
+	response.Success(c, http.StatusOK, "Get all mustahiq assessments successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get mustahiq assessment by ID
+// @Description Get an assessment with its stored answers and scores
+// @Tags Mustahiq Assessments
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Mustahiq Assessment ID"
+// @Success 200 {object} dto.MustahiqAssessmentResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq-assessments/{id} [get]
+func (h *MustahiqAssessmentHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	assessment, err := h.assessmentUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Mustahiq assessment not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get mustahiq assessment successful", toMustahiqAssessmentResponse(assessment))
+}
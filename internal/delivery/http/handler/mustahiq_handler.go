@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 	"go-zakat-be/internal/usecase"
 	"go-zakat-be/pkg/response"
@@ -22,7 +23,7 @@ func NewMustahiqHandler(mustahiqUC *usecase.MustahiqUseCase) *MustahiqHandler {
 
 // Create godoc
 // @Summary Create new mustahiq
-// @Description Create a new mustahiq record
+// @Description Create a new mustahiq record. New mustahiq always start as pending; use the activate/deactivate endpoints or an eligibility assessment to change the status. The optional NIK must be a valid 16-digit NIK (region code and encoded birth date)
 // @Tags Mustahiq
 // @Security BearerAuth
 // @Accept json
@@ -33,6 +34,12 @@ func NewMustahiqHandler(mustahiqUC *usecase.MustahiqUseCase) *MustahiqHandler {
 // @Failure 401 {object} dto.ErrorResponseWrapper
 // @Router /api/v1/mustahiq [post]
 func (h *MustahiqHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
 	var req dto.CreateMustahiqRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		response.ValidationError(c, gin.H{"error": err.Error()})
@@ -40,21 +47,23 @@ func (h *MustahiqHandler) Create(c *gin.Context) {
 	}
 
 	mustahiq, err := h.mustahiqUC.Create(usecase.CreateMustahiqInput{
-		Name:        req.Name,
-		PhoneNumber: req.PhoneNumber,
-		Address:     req.Address,
-		AsnafID:     req.AsnafID,
-		Status:      req.Status,
-		Description: req.Description,
+		Name:            req.Name,
+		NIK:             req.NIK,
+		PhoneNumber:     req.PhoneNumber,
+		Address:         req.Address,
+		AsnafID:         req.AsnafID,
+		Description:     req.Description,
+		CreatedByUserID: userID.(string),
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
 	response.Success(c, http.StatusCreated, "Mustahiq created successfully", dto.MustahiqResponse{
 		ID:          mustahiq.ID,
 		Name:        mustahiq.Name,
+		NIK:         mustahiq.NIK,
 		PhoneNumber: mustahiq.PhoneNumber,
 		Address:     mustahiq.Address,
 		Asnaf: dto.AsnafInfo{
@@ -62,6 +71,7 @@ func (h *MustahiqHandler) Create(c *gin.Context) {
 			Name: mustahiq.Asnaf.Name,
 		},
 		Status:      mustahiq.Status,
+		HouseholdID: mustahiq.HouseholdID,
 		Description: mustahiq.Description,
 		CreatedAt:   mustahiq.CreatedAt,
 		UpdatedAt:   mustahiq.UpdatedAt,
@@ -77,6 +87,8 @@ func (h *MustahiqHandler) Create(c *gin.Context) {
 // @Param q query string false "Search by name or address"
 // @Param status query string false "Filter by status: active, inactive, pending"
 // @Param asnafID query string false "Filter by asnaf ID"
+// @Param region query string false "Filter by part of the address (kelurahan, kecamatan, ...)"
+// @Param householdID query string false "Filter by household ID"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(10)
 // @Success 200 {object} dto.MustahiqListResponseWrapper
@@ -91,11 +103,13 @@ func (h *MustahiqHandler) FindAll(c *gin.Context) {
 	asnafID := c.Query("asnafID")
 
 	mustahiqs, total, err := h.mustahiqUC.FindAll(repository.MustahiqFilter{
-		Query:   query,
-		Status:  status,
-		AsnafID: asnafID,
-		Page:    page,
-		PerPage: perPage,
+		Query:       query,
+		Status:      status,
+		AsnafID:     asnafID,
+		Region:      c.Query("region"),
+		HouseholdID: c.Query("householdID"),
+		Page:        page,
+		PerPage:     perPage,
 	})
 	if err != nil {
 		response.InternalServerError(c, err.Error(), nil)
@@ -107,6 +121,7 @@ func (h *MustahiqHandler) FindAll(c *gin.Context) {
 		data = append(data, dto.MustahiqResponse{
 			ID:          m.ID,
 			Name:        m.Name,
+			NIK:         m.NIK,
 			PhoneNumber: m.PhoneNumber,
 			Address:     m.Address,
 			Asnaf: dto.AsnafInfo{
@@ -114,6 +129,7 @@ func (h *MustahiqHandler) FindAll(c *gin.Context) {
 				Name: m.Asnaf.Name,
 			},
 			Status:      m.Status,
+			HouseholdID: m.HouseholdID,
 			Description: m.Description,
 			CreatedAt:   m.CreatedAt,
 			UpdatedAt:   m.UpdatedAt,
@@ -154,6 +170,7 @@ func (h *MustahiqHandler) FindByID(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Get mustahiq successful", dto.MustahiqResponse{
 		ID:          mustahiq.ID,
 		Name:        mustahiq.Name,
+		NIK:         mustahiq.NIK,
 		PhoneNumber: mustahiq.PhoneNumber,
 		Address:     mustahiq.Address,
 		Asnaf: dto.AsnafInfo{
@@ -161,6 +178,7 @@ func (h *MustahiqHandler) FindByID(c *gin.Context) {
 			Name: mustahiq.Asnaf.Name,
 		},
 		Status:      mustahiq.Status,
+		HouseholdID: mustahiq.HouseholdID,
 		Description: mustahiq.Description,
 		CreatedAt:   mustahiq.CreatedAt,
 		UpdatedAt:   mustahiq.UpdatedAt,
@@ -169,7 +187,7 @@ func (h *MustahiqHandler) FindByID(c *gin.Context) {
 
 // Update godoc
 // @Summary Update mustahiq
-// @Description Update an existing mustahiq record
+// @Description Update an existing mustahiq record. The status is not changed here; use the activate/deactivate endpoints
 // @Tags Mustahiq
 // @Security BearerAuth
 // @Accept json
@@ -191,20 +209,21 @@ func (h *MustahiqHandler) Update(c *gin.Context) {
 	mustahiq, err := h.mustahiqUC.Update(usecase.UpdateMustahiqInput{
 		ID:          id,
 		Name:        req.Name,
+		NIK:         req.NIK,
 		PhoneNumber: req.PhoneNumber,
 		Address:     req.Address,
 		AsnafID:     req.AsnafID,
-		Status:      req.Status,
 		Description: req.Description,
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "Mustahiq updated successfully", dto.MustahiqResponse{
 		ID:          mustahiq.ID,
 		Name:        mustahiq.Name,
+		NIK:         mustahiq.NIK,
 		PhoneNumber: mustahiq.PhoneNumber,
 		Address:     mustahiq.Address,
 		Asnaf: dto.AsnafInfo{
@@ -212,6 +231,7 @@ func (h *MustahiqHandler) Update(c *gin.Context) {
 			Name: mustahiq.Asnaf.Name,
 		},
 		Status:      mustahiq.Status,
+		HouseholdID: mustahiq.HouseholdID,
 		Description: mustahiq.Description,
 		CreatedAt:   mustahiq.CreatedAt,
 		UpdatedAt:   mustahiq.UpdatedAt,
@@ -239,3 +259,211 @@ func (h *MustahiqHandler) Delete(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Mustahiq deleted successfully", nil)
 }
+
+// Activate godoc
+// @Summary Activate mustahiq
+// @Description Move a pending or inactive mustahiq to active with a reason (staf/admin). Reactivating an inactive mustahiq requires assessment_id of an eligible assessment made after the deactivation. The change is stored in the status history
+// @Tags Mustahiq
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Mustahiq ID"
+// @Param request body dto.ChangeMustahiqStatusRequest true "Change Mustahiq Status Request Body"
+// @Success 200 {object} dto.MustahiqResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq/{id}/activate [post]
+func (h *MustahiqHandler) Activate(c *gin.Context) {
+	h.changeStatus(c, entity.MustahiqStatusActive, "Mustahiq activated successfully")
+}
+
+// Deactivate godoc
+// @Summary Deactivate mustahiq
+// @Description Move a pending or active mustahiq to inactive with a reason (staf/admin). Inactive mustahiq cannot receive distributions dated on or after the effective date. The change is stored in the status history
+// @Tags Mustahiq
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Mustahiq ID"
+// @Param request body dto.ChangeMustahiqStatusRequest true "Change Mustahiq Status Request Body"
+// @Success 200 {object} dto.MustahiqResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq/{id}/deactivate [post]
+func (h *MustahiqHandler) Deactivate(c *gin.Context) {
+	h.changeStatus(c, entity.MustahiqStatusInactive, "Mustahiq deactivated successfully")
+}
+
+func (h *MustahiqHandler) changeStatus(c *gin.Context, toStatus, message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ChangeMustahiqStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	mustahiq, err := h.mustahiqUC.ChangeStatus(usecase.ChangeMustahiqStatusInput{
+		ID:            c.Param("id"),
+		ToStatus:      toStatus,
+		Reason:        req.Reason,
+		EffectiveDate: req.EffectiveDate,
+		AssessmentID:  req.AssessmentID,
+		UserID:        userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, message, dto.MustahiqResponse{
+		ID:          mustahiq.ID,
+		Name:        mustahiq.Name,
+		NIK:         mustahiq.NIK,
+		PhoneNumber: mustahiq.PhoneNumber,
+		Address:     mustahiq.Address,
+		Asnaf: dto.AsnafInfo{
+			ID:   mustahiq.Asnaf.ID,
+			Name: mustahiq.Asnaf.Name,
+		},
+		Status:      mustahiq.Status,
+		HouseholdID: mustahiq.HouseholdID,
+		Description: mustahiq.Description,
+		CreatedAt:   mustahiq.CreatedAt,
+		UpdatedAt:   mustahiq.UpdatedAt,
+	})
+}
+
+// StatusHistory godoc
+// @Summary Get mustahiq status history
+// @Description Get every status change of a mustahiq (newest first) with effective date, reason, user and the assessment behind it
+// @Tags Mustahiq
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Mustahiq ID"
+// @Success 200 {object} dto.MustahiqStatusHistoryResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq/{id}/status-history [get]
+func (h *MustahiqHandler) StatusHistory(c *gin.Context) {
+	history, err := h.mustahiqUC.FindStatusHistory(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	data := make([]dto.MustahiqStatusChangeResponse, len(history))
+	for i, change := range history {
+		data[i] = dto.MustahiqStatusChangeResponse{
+			ID:                   change.ID,
+			FromStatus:           change.FromStatus,
+			ToStatus:             change.ToStatus,
+			EffectiveDate:        change.EffectiveDate,
+			Reason:               change.Reason,
+			AssessmentID:         change.AssessmentID,
+			MergedFromMustahiqID: change.MergedFromMustahiqID,
+			CreatedAt:            change.CreatedAt,
+		}
+		if change.ChangedByUser != nil {
+			data[i].ChangedByUser = &dto.UserInfo{ID: change.ChangedByUser.ID, FullName: change.ChangedByUser.Name}
+		}
+	}
+
+	response.Success(c, http.StatusOK, "Get mustahiq status history successful", data)
+}
+
+func toMustahiqMergeResponse(m *entity.MustahiqMerge) dto.MustahiqMergeResponse {
+	res := dto.MustahiqMergeResponse{
+		ID:                  m.ID,
+		SourceMustahiqID:    m.SourceMustahiqID,
+		TargetMustahiqID:    m.TargetMustahiqID,
+		Reason:              m.Reason,
+		ItemsMoved:          m.ItemsMoved,
+		AmountMoved:         m.AmountMoved,
+		AssessmentsMoved:    m.AssessmentsMoved,
+		StatusChangesMoved:  m.StatusChangesMoved,
+		SharedDistributions: m.SharedDistributions,
+		TotalReceivedBefore: m.TotalReceivedBefore,
+		TotalReceivedAfter:  m.TotalReceivedAfter,
+		Fields:              toMergeFieldChoiceResponses(m.Fields),
+		MergedByUser:        dto.UserInfo{ID: m.MergedByUserID},
+		CreatedAt:           m.CreatedAt,
+	}
+
+	if m.MergedByUser != nil {
+		res.MergedByUser.FullName = m.MergedByUser.Name
+	}
+
+	return res
+}
+
+// Merge godoc
+// @Summary Merge duplicate mustahiq
+// @Description Merge the source mustahiq into the mustahiq on the path (admin only). Distribution items, assessments, status history and household membership move to the target, the source is deleted and its ID keeps resolving to the target. The target keeps its own status; moved status history is kept for reference only. Fields whose values differ must be chosen explicitly (source or target); everything runs in one transaction and the merge log records the reviewer, the reason and the target's total received before and after
+// @Tags Mustahiq
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Target Mustahiq ID"
+// @Param request body dto.MergeMustahiqRequest true "Merge Mustahiq Request Body"
+// @Success 200 {object} dto.MustahiqMergeResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq/{id}/merge [post]
+func (h *MustahiqHandler) Merge(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.MergeMustahiqRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	merge, err := h.mustahiqUC.Merge(usecase.MergeMustahiqInput{
+		TargetID: c.Param("id"),
+		SourceID: req.SourceID,
+		Fields:   req.Fields,
+		Reason:   req.Reason,
+		UserID:   userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Mustahiq merged successfully", toMustahiqMergeResponse(merge))
+}
+
+// Merges godoc
+// @Summary Get mustahiq merge log
+// @Description Get the merges a mustahiq took part in, newest first, with the field choices and recomputed totals
+// @Tags Mustahiq
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Mustahiq ID"
+// @Success 200 {object} dto.MustahiqMergeListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/mustahiq/{id}/merges [get]
+func (h *MustahiqHandler) Merges(c *gin.Context) {
+	merges, err := h.mustahiqUC.FindMerges(c.Param("id"))
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	data := make([]dto.MustahiqMergeResponse, len(merges))
+	for i, m := range merges {
+		data[i] = toMustahiqMergeResponse(m)
+	}
+
+	response.Success(c, http.StatusOK, "Get mustahiq merges successful", data)
+}
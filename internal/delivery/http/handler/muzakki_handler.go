@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 	"go-zakat-be/internal/usecase"
 	"go-zakat-be/pkg/response"
@@ -22,7 +23,7 @@ func NewMuzakkiHandler(muzakkiUC *usecase.MuzakkiUseCase) *MuzakkiHandler {
 
 // Create godoc
 // @Summary Create new muzakki
-// @Description Create a new muzakki record
+// @Description Create a new muzakki record. The optional NIK must be a valid 16-digit NIK (region code and encoded birth date)
 // @Tags Muzakki
 // @Security BearerAuth
 // @Accept json
@@ -41,18 +42,20 @@ func (h *MuzakkiHandler) Create(c *gin.Context) {
 
 	muzakki, err := h.muzakkiUC.Create(usecase.CreateMuzakkiInput{
 		Name:        req.Name,
+		NIK:         req.NIK,
 		PhoneNumber: req.PhoneNumber,
 		Address:     req.Address,
 		Notes:       req.Notes,
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
 	response.Success(c, http.StatusCreated, "Muzakki created successfully", dto.MuzakkiResponse{
 		ID:          muzakki.ID,
 		Name:        muzakki.Name,
+		NIK:         muzakki.NIK,
 		PhoneNumber: muzakki.PhoneNumber,
 		Address:     muzakki.Address,
 		Notes:       muzakki.Notes,
@@ -94,6 +97,7 @@ func (h *MuzakkiHandler) FindAll(c *gin.Context) {
 		data = append(data, dto.MuzakkiResponse{
 			ID:          m.ID,
 			Name:        m.Name,
+			NIK:         m.NIK,
 			PhoneNumber: m.PhoneNumber,
 			Address:     m.Address,
 			Notes:       m.Notes,
@@ -136,6 +140,7 @@ func (h *MuzakkiHandler) FindByID(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Get muzakki successful", dto.MuzakkiResponse{
 		ID:          muzakki.ID,
 		Name:        muzakki.Name,
+		NIK:         muzakki.NIK,
 		PhoneNumber: muzakki.PhoneNumber,
 		Address:     muzakki.Address,
 		Notes:       muzakki.Notes,
@@ -168,18 +173,20 @@ func (h *MuzakkiHandler) Update(c *gin.Context) {
 	muzakki, err := h.muzakkiUC.Update(usecase.UpdateMuzakkiInput{
 		ID:          id,
 		Name:        req.Name,
+		NIK:         req.NIK,
 		PhoneNumber: req.PhoneNumber,
 		Address:     req.Address,
 		Notes:       req.Notes,
 	})
 	if err != nil {
-		response.BadRequest(c, err.Error(), nil)
+		respondUseCaseError(c, err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "Muzakki updated successfully", dto.MuzakkiResponse{
 		ID:          muzakki.ID,
 		Name:        muzakki.Name,
+		NIK:         muzakki.NIK,
 		PhoneNumber: muzakki.PhoneNumber,
 		Address:     muzakki.Address,
 		Notes:       muzakki.Notes,
@@ -209,3 +216,104 @@ func (h *MuzakkiHandler) Delete(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Muzakki deleted successfully", nil)
 }
+
+func toMergeFieldChoiceResponses(fields []*entity.MergeFieldChoice) []dto.MergeFieldChoiceResponse {
+	res := make([]dto.MergeFieldChoiceResponse, len(fields))
+	for i, f := range fields {
+		res[i] = dto.MergeFieldChoiceResponse{
+			Field:       f.Field,
+			Choice:      f.Choice,
+			SourceValue: f.SourceValue,
+			TargetValue: f.TargetValue,
+			FinalValue:  f.FinalValue,
+		}
+	}
+	return res
+}
+
+func toMuzakkiMergeResponse(m *entity.MuzakkiMerge) dto.MuzakkiMergeResponse {
+	res := dto.MuzakkiMergeResponse{
+		ID:                m.ID,
+		SourceMuzakkiID:   m.SourceMuzakkiID,
+		TargetMuzakkiID:   m.TargetMuzakkiID,
+		Reason:            m.Reason,
+		ReceiptsMoved:     m.ReceiptsMoved,
+		CalculationsMoved: m.CalculationsMoved,
+		Fields:            toMergeFieldChoiceResponses(m.Fields),
+		MergedByUser:      dto.UserInfo{ID: m.MergedByUserID},
+		CreatedAt:         m.CreatedAt,
+	}
+
+	if m.MergedByUser != nil {
+		res.MergedByUser.FullName = m.MergedByUser.Name
+	}
+
+	return res
+}
+
+// Merge godoc
+// @Summary Merge duplicate muzakki
+// @Description Merge the source muzakki into the muzakki on the path (admin only). All receipts and zakat calculations move to the target, the source is deleted and its ID keeps resolving to the target. Fields whose values differ must be chosen explicitly (source or target); everything runs in one transaction and is recorded in the merge log
+// @Tags Muzakki
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Target Muzakki ID"
+// @Param request body dto.MergeMuzakkiRequest true "Merge Muzakki Request Body"
+// @Success 200 {object} dto.MuzakkiMergeResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/muzakki/{id}/merge [post]
+func (h *MuzakkiHandler) Merge(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.MergeMuzakkiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	merge, err := h.muzakkiUC.Merge(usecase.MergeMuzakkiInput{
+		TargetID: c.Param("id"),
+		SourceID: req.SourceID,
+		Fields:   req.Fields,
+		Reason:   req.Reason,
+		UserID:   userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Muzakki merged successfully", toMuzakkiMergeResponse(merge))
+}
+
+// Merges godoc
+// @Summary Get muzakki merge log
+// @Description Get the merges a muzakki took part in, newest first, with the field choices
+// @Tags Muzakki
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Muzakki ID"
+// @Success 200 {object} dto.MuzakkiMergeListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/muzakki/{id}/merges [get]
+func (h *MuzakkiHandler) Merges(c *gin.Context) {
+	merges, err := h.muzakkiUC.FindMerges(c.Param("id"))
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	data := make([]dto.MuzakkiMergeResponse, len(merges))
+	for i, m := range merges {
+		data[i] = toMuzakkiMergeResponse(m)
+	}
+
+	response.Success(c, http.StatusOK, "Get muzakki merges successful", data)
+}
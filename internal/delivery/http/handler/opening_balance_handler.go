@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OpeningBalanceHandler struct {
+	openingBalanceUC *usecase.OpeningBalanceUseCase
+}
+
+func NewOpeningBalanceHandler(openingBalanceUC *usecase.OpeningBalanceUseCase) *OpeningBalanceHandler {
+	return &OpeningBalanceHandler{openingBalanceUC: openingBalanceUC}
+}
+
+func toOpeningBalanceResponse(ob *entity.OpeningBalance) dto.OpeningBalanceResponse {
+	res := dto.OpeningBalanceResponse{
+		ID:            ob.ID,
+		EffectiveDate: ob.EffectiveDate,
+		FundLedger:    ob.FundLedger,
+		Amount:        ob.Amount,
+		Notes:         ob.Notes,
+		Status:        ob.Status,
+		VoidReason:    ob.VoidReason,
+		VoidedAt:      ob.VoidedAt,
+		CreatedAt:     ob.CreatedAt,
+		UpdatedAt:     ob.UpdatedAt,
+	}
+
+	if ob.FinancialAccount != nil {
+		res.FinancialAccount = dto.FinancialAccountInfo{ID: ob.FinancialAccount.ID, Name: ob.FinancialAccount.Name}
+	}
+	if ob.CreatedByUser != nil {
+		res.CreatedByUser = dto.UserInfo{ID: ob.CreatedByUser.ID, FullName: ob.CreatedByUser.Name}
+	}
+	if ob.VoidedByUser != nil {
+		res.VoidedByUser = &dto.UserInfo{ID: ob.VoidedByUser.ID, FullName: ob.VoidedByUser.Name}
+	}
+
+	return res
+}
+
+// Create godoc
+// @Summary Create opening balance
+// @Description Record a balance carried over from the previous system for one fund sub-ledger and financial account (admin only). The balance is journaled on its effective date (debit account, credit fund) and shown as carried_over in balance reports
+// @Tags Opening Balances
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateOpeningBalanceRequest true "Create Opening Balance Request Body"
+// @Success 201 {object} dto.OpeningBalanceResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/opening-balances [post]
+func (h *OpeningBalanceHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.CreateOpeningBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	openingBalance, err := h.openingBalanceUC.Create(usecase.CreateOpeningBalanceInput{
+		EffectiveDate:      req.EffectiveDate,
+		FundLedger:         req.FundLedger,
+		FinancialAccountID: req.FinancialAccountID,
+		Amount:             req.Amount,
+		Notes:              req.Notes,
+		CreatedByUserID:    userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Opening balance created successfully", toOpeningBalanceResponse(openingBalance))
+}
+
+// Void godoc
+// @Summary Void opening balance
+// @Description Void a wrongly entered opening balance (admin only). Its journal is reversed; record a new opening balance to correct it
+// @Tags Opening Balances
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Opening Balance ID"
+// @Param request body dto.VoidOpeningBalanceRequest true "Void Opening Balance Request Body"
+// @Success 200 {object} dto.OpeningBalanceResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/opening-balances/{id}/void [post]
+func (h *OpeningBalanceHandler) Void(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.VoidOpeningBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	openingBalance, err := h.openingBalanceUC.Void(usecase.VoidOpeningBalanceInput{
+		ID:     c.Param("id"),
+		Reason: req.Reason,
+		UserID: userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Opening balance voided successfully", toOpeningBalanceResponse(openingBalance))
+}
+
+// FindAll godoc
+// @Summary Get all opening balances
+// @Description Get list of opening balances with pagination and filters (admin only)
+// @Tags Opening Balances
+// @Security BearerAuth
+// @Produce json
+// @Param fund_ledger query string false "Filter by fund sub-ledger: amil, zakat_fitrah, zakat_maal, infaq, sadaqah"
+// @Param financial_account_id query string false "Filter by financial account ID"
+// @Param status query string false "Filter by status: posted, voided"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.OpeningBalanceListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/opening-balances [get]
+func (h *OpeningBalanceHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	openingBalances, total, err := h.openingBalanceUC.FindAll(repository.OpeningBalanceFilter{
+		FundLedger:         c.Query("fund_ledger"),
+		FinancialAccountID: c.Query("financial_account_id"),
+		Status:             c.Query("status"),
+		Page:               page,
+		PerPage:            perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.OpeningBalanceResponse
+	for _, ob := range openingBalances {
+		data = append(data, toOpeningBalanceResponse(ob))
+	}
+
+	response.Success(c, http.StatusOK, "Get all opening balances successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get opening balance by ID
+// @Description Get an opening balance by ID (admin only)
+// @Tags Opening Balances
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Opening Balance ID"
+// @Success 200 {object} dto.OpeningBalanceResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/opening-balances/{id} [get]
+func (h *OpeningBalanceHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	openingBalance, err := h.openingBalanceUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Opening balance not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get opening balance successful", toOpeningBalanceResponse(openingBalance))
+}
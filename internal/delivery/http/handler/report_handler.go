@@ -7,6 +7,7 @@ import (
 	"go-zakat-be/internal/domain/repository"
 	"go-zakat-be/internal/usecase"
 	"go-zakat-be/pkg/response"
+	"go-zakat-be/pkg/voucher"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,7 +22,7 @@ func NewReportHandler(reportUC *usecase.ReportUseCase) *ReportHandler {
 
 // GetIncomeSummary godoc
 // @Summary Get income summary report
-// @Description Get income summary grouped by period with breakdown by fund type
+// @Description Get income summary from the ledger grouped by period with breakdown by fund sub-ledger (incl. amil share). Opening balances migrated from the old system are shown as carried_over and are not part of total
 // @Tags Reports
 // @Security BearerAuth
 // @Produce json
@@ -52,7 +53,9 @@ func (h *ReportHandler) GetIncomeSummary(c *gin.Context) {
 			ZakatMaal:   r.ZakatMaal,
 			Infaq:       r.Infaq,
 			Sadaqah:     r.Sadaqah,
+			Amil:        r.Amil,
 			Total:       r.Total,
+			CarriedOver: r.CarriedOver,
 		}
 	}
 
@@ -99,6 +102,7 @@ func (h *ReportHandler) GetDistributionSummary(c *gin.Context) {
 			asnafData[i] = dto.DistributionSummaryByAsnafResponse{
 				AsnafName:        r.AsnafName,
 				BeneficiaryCount: r.BeneficiaryCount,
+				HouseholdCount:   r.HouseholdCount,
 				TotalAmount:      r.TotalAmount,
 			}
 		}
@@ -111,6 +115,7 @@ func (h *ReportHandler) GetDistributionSummary(c *gin.Context) {
 				ProgramName:      r.ProgramName,
 				SourceFundType:   r.SourceFundType,
 				BeneficiaryCount: r.BeneficiaryCount,
+				HouseholdCount:   r.HouseholdCount,
 				TotalAmount:      r.TotalAmount,
 			}
 		}
@@ -122,7 +127,7 @@ func (h *ReportHandler) GetDistributionSummary(c *gin.Context) {
 
 // GetFundBalance godoc
 // @Summary Get fund balance report
-// @Description Get fund balance showing total in, total out, and balance for each fund type
+// @Description Get fund balance from the ledger showing opening balance, carried over opening balances, total in, total out, and balance for each sub-ledger (amil + fund types). The opening balance starts from the snapshot of the last closed fiscal period before date_from. Carried over shows opening balances migrated from the old system with an effective date in the period
 // @Tags Reports
 // @Security BearerAuth
 // @Produce json
@@ -138,7 +143,7 @@ func (h *ReportHandler) GetFundBalance(c *gin.Context) {
 
 	results, err := h.reportUC.GetFundBalance(dateFrom, dateTo)
 	if err != nil {
-		response.InternalServerError(c, err.Error(), nil)
+		response.BadRequest(c, err.Error(), nil)
 		return
 	}
 
@@ -146,16 +151,258 @@ func (h *ReportHandler) GetFundBalance(c *gin.Context) {
 	data := make([]dto.FundBalanceResponse, len(results))
 	for i, r := range results {
 		data[i] = dto.FundBalanceResponse{
-			FundType: r.FundType,
-			TotalIn:  r.TotalIn,
-			TotalOut: r.TotalOut,
-			Balance:  r.Balance,
+			FundType:       r.FundType,
+			OpeningBalance: r.OpeningBalance,
+			CarriedOver:    r.CarriedOver,
+			TotalIn:        r.TotalIn,
+			TotalOut:       r.TotalOut,
+			Balance:        r.Balance,
+			ClosingBalance: r.ClosingBalance,
 		}
 	}
 
 	response.Success(c, http.StatusOK, "Get fund balance successful", data)
 }
 
+// GetTrialBalance godoc
+// @Summary Get trial balance report
+// @Description Get total debit and credit per ledger account up to a date. Total debit always equals total credit
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param date_to query string false "As of date (YYYY-MM-DD), default all journals"
+// @Success 200 {object} dto.ReportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/reports/trial-balance [get]
+func (h *ReportHandler) GetTrialBalance(c *gin.Context) {
+	dateTo := c.Query("date_to")
+
+	result, err := h.reportUC.GetTrialBalance(dateTo)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	// Convert to DTO
+	accounts := make([]dto.TrialBalanceAccountResponse, len(result.Accounts))
+	for i, r := range result.Accounts {
+		accounts[i] = dto.TrialBalanceAccountResponse{
+			AccountCode: r.AccountCode,
+			AccountName: r.AccountName,
+			AccountType: r.AccountType,
+			Debit:       r.Debit,
+			Credit:      r.Credit,
+		}
+	}
+
+	data := dto.TrialBalanceResponse{
+		DateTo:      result.DateTo,
+		Accounts:    accounts,
+		TotalDebit:  result.TotalDebit,
+		TotalCredit: result.TotalCredit,
+		Difference:  result.Difference,
+	}
+
+	response.Success(c, http.StatusOK, "Get trial balance successful", data)
+}
+
+// GetAccountBalance godoc
+// @Summary Get financial account balance report
+// @Description Get opening balance, carried over opening balances, money in, money out and closing balance for each cash, bank and digital account
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
+// @Success 200 {object} dto.ReportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/reports/account-balance [get]
+func (h *ReportHandler) GetAccountBalance(c *gin.Context) {
+	dateFrom := c.Query("date_from")
+	dateTo := c.Query("date_to")
+
+	results, err := h.reportUC.GetAccountBalance(dateFrom, dateTo)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	// Convert to DTO
+	data := make([]dto.AccountBalanceResponse, len(results))
+	for i, r := range results {
+		data[i] = dto.AccountBalanceResponse{
+			FinancialAccountID: r.FinancialAccountID,
+			Name:               r.Name,
+			AccountType:        r.AccountType,
+			OpeningBalance:     r.OpeningBalance,
+			CarriedOver:        r.CarriedOver,
+			TotalIn:            r.TotalIn,
+			TotalOut:           r.TotalOut,
+			ClosingBalance:     r.ClosingBalance,
+		}
+	}
+
+	response.Success(c, http.StatusOK, "Get account balance successful", data)
+}
+
+// GetAccountMovements godoc
+// @Summary Get financial account movement report
+// @Description Get journal movements of one cash, bank or digital account with running balance
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param financial_account_id path string true "Financial Account ID"
+// @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
+// @Success 200 {object} dto.ReportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/reports/account-movements/{financial_account_id} [get]
+func (h *ReportHandler) GetAccountMovements(c *gin.Context) {
+	financialAccountID := c.Param("financial_account_id")
+	dateFrom := c.Query("date_from")
+	dateTo := c.Query("date_to")
+
+	result, err := h.reportUC.GetAccountMovements(financialAccountID, dateFrom, dateTo)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	// Convert to DTO
+	movements := make([]dto.AccountMovementItemResponse, len(result.Movements))
+	for i, m := range result.Movements {
+		movements[i] = dto.AccountMovementItemResponse{
+			EntryDate:   m.EntryDate,
+			Description: m.Description,
+			SourceType:  m.SourceType,
+			SourceID:    m.SourceID,
+			In:          m.In,
+			Out:         m.Out,
+			Balance:     m.Balance,
+		}
+	}
+
+	data := dto.AccountMovementResponse{
+		FinancialAccount: dto.FinancialAccountInfo{ID: result.FinancialAccountID, Name: result.Name},
+		AccountType:      result.AccountType,
+		DateFrom:         result.DateFrom,
+		DateTo:           result.DateTo,
+		OpeningBalance:   result.OpeningBalance,
+		Movements:        movements,
+		CarriedOver:      result.CarriedOver,
+		TotalIn:          result.TotalIn,
+		TotalOut:         result.TotalOut,
+		ClosingBalance:   result.ClosingBalance,
+	}
+
+	response.Success(c, http.StatusOK, "Get account movements successful", data)
+}
+
+// GetUnreconciled godoc
+// @Summary Get unreconciled items report
+// @Description Get bank statement lines not yet confirmed (or confirmed against a transaction voided afterwards) and posted receipts and distributions within imported statement periods that have no confirmed statement line
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param financial_account_id query string false "Filter by financial account ID"
+// @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
+// @Success 200 {object} dto.ReportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/reports/unreconciled [get]
+func (h *ReportHandler) GetUnreconciled(c *gin.Context) {
+	financialAccountID := c.Query("financial_account_id")
+	dateFrom := c.Query("date_from")
+	dateTo := c.Query("date_to")
+
+	result, err := h.reportUC.GetUnreconciled(financialAccountID, dateFrom, dateTo)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	// Convert to DTO
+	lines := make([]dto.UnreconciledLineResponse, len(result.Lines))
+	for i, l := range result.Lines {
+		lines[i] = dto.UnreconciledLineResponse{
+			LineID:             l.LineID,
+			StatementID:        l.StatementID,
+			FinancialAccountID: l.FinancialAccountID,
+			AccountName:        l.AccountName,
+			TransactionDate:    l.TransactionDate,
+			Description:        l.Description,
+			Reference:          l.Reference,
+			Amount:             l.Amount,
+			Issue:              l.Issue,
+			FlagReason:         l.FlagReason,
+		}
+	}
+
+	transactions := make([]dto.UnreconciledTransactionResponse, len(result.Transactions))
+	for i, t := range result.Transactions {
+		transactions[i] = dto.UnreconciledTransactionResponse{
+			SourceType:         t.SourceType,
+			SourceID:           t.SourceID,
+			Number:             t.Number,
+			Date:               t.Date,
+			FinancialAccountID: t.FinancialAccountID,
+			AccountName:        t.AccountName,
+			Label:              t.Label,
+			Amount:             t.Amount,
+		}
+	}
+
+	data := dto.UnreconciledResponse{
+		Lines:             lines,
+		Transactions:      transactions,
+		TotalLines:        result.TotalLines,
+		TotalTransactions: result.TotalTransactions,
+	}
+
+	response.Success(c, http.StatusOK, "Get unreconciled items successful", data)
+}
+
+// GetStockOnHand godoc
+// @Summary Get stock on hand report
+// @Description Get in-kind stock (rice and other commodities) per fund sub-ledger up to a date: received from receipts, distributed, manual adjustments and on hand
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param date query string false "As of date (YYYY-MM-DD), default all movements"
+// @Success 200 {object} dto.ReportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/reports/stock-on-hand [get]
+func (h *ReportHandler) GetStockOnHand(c *gin.Context) {
+	date := c.Query("date")
+
+	results, err := h.reportUC.GetStockOnHand(date)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	// Convert to DTO
+	data := make([]dto.StockOnHandResponse, len(results))
+	for i, r := range results {
+		data[i] = dto.StockOnHandResponse{
+			Commodity:  r.Commodity,
+			Unit:       r.Unit,
+			FundLedger: r.FundLedger,
+			TotalIn:    r.TotalIn,
+			TotalOut:   r.TotalOut,
+			Adjustment: r.Adjustment,
+			OnHand:     r.OnHand,
+		}
+	}
+
+	response.Success(c, http.StatusOK, "Get stock on hand successful", data)
+}
+
 // GetMustahiqHistory godoc
 // @Summary Get mustahiq history report
 // @Description Get distribution history for a specific mustahiq
@@ -200,3 +447,119 @@ func (h *ReportHandler) GetMustahiqHistory(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Get mustahiq history successful", data)
 }
+
+// GetHouseholdHistory godoc
+// @Summary Get household history report
+// @Description Get posted distributions received by all mustahiq members of a household, with totals per member and for the household
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param household_id path string true "Household ID"
+// @Success 200 {object} dto.ReportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/reports/household-history/{household_id} [get]
+func (h *ReportHandler) GetHouseholdHistory(c *gin.Context) {
+	householdID := c.Param("household_id")
+
+	result, err := h.reportUC.GetHouseholdHistory(householdID)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	// Convert to DTO
+	members := make([]dto.HouseholdHistoryMemberResponse, len(result.Members))
+	for i, m := range result.Members {
+		members[i] = dto.HouseholdHistoryMemberResponse{
+			MustahiqID:    m.MustahiqID,
+			Name:          m.Name,
+			Relationship:  m.Relationship,
+			TotalReceived: m.TotalReceived,
+		}
+	}
+
+	history := make([]dto.HouseholdHistoryItemResponse, len(result.History))
+	for i, h := range result.History {
+		history[i] = dto.HouseholdHistoryItemResponse{
+			DistributionDate: h.DistributionDate,
+			MustahiqID:       h.MustahiqID,
+			MustahiqName:     h.MustahiqName,
+			ProgramName:      h.ProgramName,
+			SourceFundType:   h.SourceFundType,
+			Amount:           h.Amount,
+		}
+	}
+
+	data := dto.HouseholdHistoryResponse{
+		Household: dto.HouseholdHistoryHouseholdInfo{
+			ID:               result.HouseholdID,
+			FamilyCardNumber: result.FamilyCardNumber,
+			HeadName:         result.HeadName,
+			Address:          result.Address,
+			MemberCount:      result.MemberCount,
+		},
+		Members:       members,
+		History:       history,
+		TotalReceived: result.TotalReceived,
+	}
+
+	response.Success(c, http.StatusOK, "Get household history successful", data)
+}
+
+// GetUndeliveredItems godoc
+// @Summary Get undelivered distribution items report
+// @Description Get items of posted distributions whose voucher has not been redeemed yet, oldest distribution first, with the mustahiq contact and days outstanding
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param date_from query string false "Filter by distribution date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by distribution date to (YYYY-MM-DD)"
+// @Param program_id query string false "Filter by program ID"
+// @Param source_fund_type query string false "Filter by source fund type: amil, zakat_fitrah, zakat_maal, infaq, sadaqah"
+// @Success 200 {object} dto.ReportResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/reports/undelivered-items [get]
+func (h *ReportHandler) GetUndeliveredItems(c *gin.Context) {
+	dateFrom := c.Query("date_from")
+	dateTo := c.Query("date_to")
+	programID := c.Query("program_id")
+	sourceFundType := c.Query("source_fund_type")
+
+	result, err := h.reportUC.GetUndeliveredItems(dateFrom, dateTo, programID, sourceFundType)
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	// Convert to DTO
+	items := make([]dto.UndeliveredItemResponse, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = dto.UndeliveredItemResponse{
+			DistributionID:   item.DistributionID,
+			DistributionDate: item.DistributionDate,
+			ProgramName:      item.ProgramName,
+			SourceFundType:   item.SourceFundType,
+			ItemID:           item.ItemID,
+			VoucherCode:      voucher.Format(item.VoucherCode),
+			MustahiqID:       item.MustahiqID,
+			MustahiqName:     item.MustahiqName,
+			Address:          item.Address,
+			PhoneNumber:      item.PhoneNumber,
+			Amount:           item.Amount,
+			Commodity:        item.Commodity,
+			Quantity:         item.Quantity,
+			DaysOutstanding:  item.DaysOutstanding,
+		}
+	}
+
+	data := dto.UndeliveredItemsResponse{
+		Items:             items,
+		ItemCount:         result.ItemCount,
+		DistributionCount: result.DistributionCount,
+		TotalAmount:       result.TotalAmount,
+	}
+
+	response.Success(c, http.StatusOK, "Get undelivered items successful", data)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockHandler struct {
+	stockUC *usecase.StockUseCase
+}
+
+func NewStockHandler(stockUC *usecase.StockUseCase) *StockHandler {
+	return &StockHandler{stockUC: stockUC}
+}
+
+func toStockMovementResponse(m *entity.StockMovement) dto.StockMovementResponse {
+	res := dto.StockMovementResponse{
+		ID:                   m.ID,
+		MovementDate:         m.MovementDate,
+		Commodity:            m.Commodity,
+		Unit:                 m.Unit,
+		FundLedger:           m.FundLedger,
+		Quantity:             m.Quantity,
+		MovementType:         m.MovementType,
+		SourceID:             m.SourceID,
+		SourceItemID:         m.SourceItemID,
+		Reason:               m.Reason,
+		ReversalOfMovementID: m.ReversalOfMovementID,
+		CreatedAt:            m.CreatedAt,
+	}
+
+	if m.CreatedByUser != nil {
+		res.CreatedByUser = &dto.UserInfo{ID: m.CreatedByUser.ID, FullName: m.CreatedByUser.Name}
+	}
+
+	return res
+}
+
+// CreateAdjustment godoc
+// @Summary Create stock adjustment
+// @Description Record a manual stock adjustment for an in-kind commodity (admin only). Use a negative quantity for spoilage or loss; it may not exceed the stock on hand of the fund
+// @Tags Stock
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateStockAdjustmentRequest true "Create Stock Adjustment Request Body"
+// @Success 201 {object} dto.StockMovementResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/stock-movements/adjustments [post]
+func (h *StockHandler) CreateAdjustment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.CreateStockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	movement, err := h.stockUC.CreateAdjustment(usecase.CreateStockAdjustmentInput{
+		MovementDate: req.MovementDate,
+		Commodity:    req.Commodity,
+		FundLedger:   req.FundLedger,
+		Quantity:     req.Quantity,
+		Reason:       req.Reason,
+		UserID:       userID.(string),
+	})
+	if err != nil {
+		respondUseCaseError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Stock adjustment created successfully", toStockMovementResponse(movement))
+}
+
+// FindAll godoc
+// @Summary Get stock movements
+// @Description Get the in-kind stock card (receipts in, distributions out, manual adjustments) with pagination and filters
+// @Tags Stock
+// @Security BearerAuth
+// @Produce json
+// @Param commodity query string false "Filter by commodity: gold, silver, rice"
+// @Param fund_ledger query string false "Filter by fund sub-ledger: amil, zakat_fitrah, zakat_maal, infaq, sadaqah"
+// @Param movement_type query string false "Filter by type: receipt, distribution, adjustment"
+// @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.StockMovementListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/stock-movements [get]
+func (h *StockHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	movements, total, err := h.stockUC.FindAllMovements(repository.StockMovementFilter{
+		Commodity:    c.Query("commodity"),
+		FundLedger:   c.Query("fund_ledger"),
+		MovementType: c.Query("movement_type"),
+		DateFrom:     c.Query("date_from"),
+		DateTo:       c.Query("date_to"),
+		Page:         page,
+		PerPage:      perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.StockMovementResponse
+	for _, m := range movements {
+		data = append(data, toStockMovementResponse(m))
+	}
+
+	response.Success(c, http.StatusOK, "Get all stock movements successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get stock movement by ID
+// @Description Get a single stock movement
+// @Tags Stock
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Stock Movement ID"
+// @Success 200 {object} dto.StockMovementResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/stock-movements/{id} [get]
+func (h *StockHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	movement, err := h.stockUC.FindMovementByID(id)
+	if err != nil {
+		response.BadRequest(c, "Stock movement not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get stock movement successful", toStockMovementResponse(movement))
+}
@@ -26,7 +26,7 @@ func NewUserHandler(userUC *usecase.UserUseCase) *UserHandler {
 // @Security BearerAuth
 // @Produce json
 // @Param q query string false "Search by name or email"
-// @Param role query string false "Filter by role (admin, staf, viewer)"
+// @Param role query string false "Filter by role (admin, staf, viewer, approver)"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(10)
 // @Success 200 {object} dto.UserListResponseWrapper
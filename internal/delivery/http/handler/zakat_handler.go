@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-zakat-be/internal/delivery/http/dto"
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/usecase"
+	"go-zakat-be/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ZakatHandler struct {
+	zakatUC *usecase.ZakatCalculatorUseCase
+}
+
+func NewZakatHandler(zakatUC *usecase.ZakatCalculatorUseCase) *ZakatHandler {
+	return &ZakatHandler{zakatUC: zakatUC}
+}
+
+func toCalculateZakatInput(req dto.CalculateZakatRequest) usecase.CalculateZakatInput {
+	return usecase.CalculateZakatInput{
+		CalculationDate:    req.CalculationDate,
+		HaulStartDate:      req.HaulStartDate,
+		Cash:               req.Cash,
+		GoldGrams:          req.GoldGrams,
+		GoldPricePerGram:   req.GoldPricePerGram,
+		SilverGrams:        req.SilverGrams,
+		SilverPricePerGram: req.SilverPricePerGram,
+		TradeGoods:         req.TradeGoods,
+		Receivables:        req.Receivables,
+		Debts:              req.Debts,
+		NisabBasis:         req.NisabBasis,
+	}
+}
+
+func toZakatCalculationResponse(zc *entity.ZakatCalculation) dto.ZakatCalculationResponse {
+	resp := dto.ZakatCalculationResponse{
+		ID:                 zc.ID,
+		CalculationDate:    zc.CalculationDate,
+		HaulStartDate:      zc.HaulStartDate,
+		HaulCompleted:      zc.HaulCompleted,
+		Cash:               zc.Cash,
+		GoldGrams:          zc.GoldGrams,
+		GoldPricePerGram:   zc.GoldPricePerGram,
+		SilverGrams:        zc.SilverGrams,
+		SilverPricePerGram: zc.SilverPricePerGram,
+		TradeGoods:         zc.TradeGoods,
+		Receivables:        zc.Receivables,
+		Debts:              zc.Debts,
+		TotalAssets:        zc.TotalAssets,
+		NetAssets:          zc.NetAssets,
+		NisabBasis:         zc.NisabBasis,
+		NisabValue:         zc.NisabValue,
+		IsObligatory:       zc.IsObligatory,
+		ZakatRate:          zc.ZakatRate,
+		ZakatAmount:        zc.ZakatAmount,
+		Notes:              zc.Notes,
+	}
+
+	if zc.Muzakki != nil {
+		resp.Muzakki = &dto.MuzakkiInfo{
+			ID:       zc.Muzakki.ID,
+			FullName: zc.Muzakki.Name,
+		}
+	}
+	if !zc.CreatedAt.IsZero() {
+		resp.CreatedAt = &zc.CreatedAt
+	}
+
+	return resp
+}
+
+// Calculate godoc
+// @Summary Calculate zakat maal
+// @Description Calculate obligatory zakat maal from declared assets using nisab (85 g gold / 595 g silver) and haul rules. The result is not stored.
+// @Tags Zakat
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CalculateZakatRequest true "Calculate Zakat Request Body"
+// @Success 200 {object} dto.ZakatCalculationResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/zakat/calculate [post]
+func (h *ZakatHandler) Calculate(c *gin.Context) {
+	var req dto.CalculateZakatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	calculation, err := h.zakatUC.Calculate(toCalculateZakatInput(req))
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Zakat calculated successfully", toZakatCalculationResponse(calculation))
+}
+
+// Save godoc
+// @Summary Save zakat maal calculation
+// @Description Calculate zakat maal for a muzakki and store the result so it can be linked to a donation receipt item
+// @Tags Zakat
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.SaveZakatCalculationRequest true "Save Zakat Calculation Request Body"
+// @Success 201 {object} dto.ZakatCalculationResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/zakat/calculations [post]
+func (h *ZakatHandler) Save(c *gin.Context) {
+	var req dto.SaveZakatCalculationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	calculation, err := h.zakatUC.Save(usecase.SaveZakatCalculationInput{
+		CalculateZakatInput: toCalculateZakatInput(req.CalculateZakatRequest),
+		MuzakkiID:           req.MuzakkiID,
+		Notes:               req.Notes,
+		CreatedByUserID:     userID.(string),
+	})
+	if err != nil {
+		response.BadRequest(c, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Zakat calculation saved", toZakatCalculationResponse(calculation))
+}
+
+// FindAll godoc
+// @Summary Get all zakat calculations
+// @Description Get list of stored zakat maal calculations with pagination and filters
+// @Tags Zakat
+// @Security BearerAuth
+// @Produce json
+// @Param muzakki_id query string false "Filter by muzakki ID"
+// @Param date_from query string false "Filter by date from (YYYY-MM-DD)"
+// @Param date_to query string false "Filter by date to (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} dto.ZakatCalculationListResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Failure 500 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/zakat/calculations [get]
+func (h *ZakatHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "10"))
+
+	calculations, total, err := h.zakatUC.FindAll(repository.ZakatCalculationFilter{
+		MuzakkiID: c.Query("muzakki_id"),
+		DateFrom:  c.Query("date_from"),
+		DateTo:    c.Query("date_to"),
+		Page:      page,
+		PerPage:   perPage,
+	})
+	if err != nil {
+		response.InternalServerError(c, err.Error(), nil)
+		return
+	}
+
+	var data []dto.ZakatCalculationResponse
+	for _, zc := range calculations {
+		data = append(data, toZakatCalculationResponse(zc))
+	}
+
+	response.Success(c, http.StatusOK, "Get all zakat calculations successful", gin.H{
+		"items": data,
+		"meta": gin.H{
+			"page":       page,
+			"per_page":   perPage,
+			"total":      total,
+			"total_page": (total + int64(perPage) - 1) / int64(perPage),
+		},
+	})
+}
+
+// FindByID godoc
+// @Summary Get zakat calculation by ID
+// @Description Get a single stored zakat maal calculation
+// @Tags Zakat
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Zakat Calculation ID"
+// @Success 200 {object} dto.ZakatCalculationResponseWrapper
+// @Failure 400 {object} dto.ErrorResponseWrapper
+// @Failure 401 {object} dto.ErrorResponseWrapper
+// @Router /api/v1/zakat/calculations/{id} [get]
+func (h *ZakatHandler) FindByID(c *gin.Context) {
+	id := c.Param("id")
+
+	calculation, err := h.zakatUC.FindByID(id)
+	if err != nil {
+		response.BadRequest(c, "Zakat calculation not found", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Get zakat calculation successful", toZakatCalculationResponse(calculation))
+}
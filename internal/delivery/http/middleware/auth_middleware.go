@@ -94,3 +94,8 @@ func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
 func (m *AuthMiddleware) RequireStafOrAdmin() gin.HandlerFunc {
 	return m.RequireRole("staf", "admin")
 }
+
+// RequireApproverOrAdmin adalah shortcut untuk require role approver atau admin (maker-checker)
+func (m *AuthMiddleware) RequireApproverOrAdmin() gin.HandlerFunc {
+	return m.RequireRole("approver", "admin")
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// Cakupan aturan bantuan
+const (
+	AidRuleScopeMustahiq  = "mustahiq"
+	AidRuleScopeHousehold = "household" // dijumlah untuk semua anggota rumah tangga
+)
+
+// Jenis batas aturan bantuan
+const (
+	AidRuleLimitAmount = "amount"
+	AidRuleLimitCount  = "count"
+)
+
+// AidRule membatasi bantuan yang diterima mustahiq (atau rumah tangganya) dalam jendela waktu
+// bergulir. Asnaf, program dan jenis dana yang kosong berarti berlaku untuk semua.
+type AidRule struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Scope          string    `json:"scope"` // mustahiq, household
+	AsnafID        *string   `json:"asnafID"`
+	Asnaf          *Asnaf    `json:"asnaf,omitempty"`
+	ProgramID      *string   `json:"programID"`
+	Program        *Program  `json:"program,omitempty"`
+	SourceFundType string    `json:"sourceFundType"`
+	MaxAmount      *float64  `json:"maxAmount"` // nominal uang maksimal dalam jendela
+	MaxCount       *int      `json:"maxCount"`  // jumlah penyaluran maksimal dalam jendela
+	WindowDays     int       `json:"windowDays"`
+	Active         bool      `json:"active"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// AidRuleViolation adalah batas aturan bantuan yang terlampaui oleh satu item penyaluran
+type AidRuleViolation struct {
+	ItemIndex   int     `json:"-"` // urutan item pada penyaluran yang dicek
+	AidRuleID   string  `json:"aidRuleID"`
+	AidRuleName string  `json:"aidRuleName"`
+	MustahiqID  string  `json:"mustahiqID"`
+	HouseholdID *string `json:"householdID"`
+	LimitType   string  `json:"limitType"` // amount, count
+	Allowed     float64 `json:"allowed"`
+	Used        float64 `json:"used"`  // sudah diterima dalam jendela dari penyaluran lain
+	Total       float64 `json:"total"` // termasuk penyaluran ini sampai item tersebut
+	WindowStart string  `json:"windowStart"`
+	WindowEnd   string  `json:"windowEnd"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Ledger dana: hak amil dan dana yang dapat disalurkan per jenis dana
+const (
+	LedgerAmil        = "amil"
+	LedgerZakatFitrah = "zakat_fitrah"
+	LedgerZakatMaal   = "zakat_maal"
+	LedgerInfaq       = "infaq"
+	LedgerSadaqah     = "sadaqah"
+)
+
+// MaxZakatAmilPercentage adalah batas hak amil dari zakat (1/8)
+const MaxZakatAmilPercentage = 12.5
+
+type AmilRate struct {
+	ID            string    `json:"id"`
+	FundType      string    `json:"fundType"`      // zakat_fitrah, zakat_maal, infaq, sadaqah
+	EffectiveDate string    `json:"effectiveDate"` // YYYY-MM-DD
+	Percentage    float64   `json:"percentage"`    // hak amil, 0-100 (zakat maks 12.5)
+	Notes         string    `json:"notes"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
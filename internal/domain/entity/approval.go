@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Approval action (maker-checker)
+const (
+	ApprovalActionSubmit  = "submit"
+	ApprovalActionApprove = "approve"
+	ApprovalActionReject  = "reject"
+)
+
+// ApprovalEvent mencatat siapa mengajukan, menyetujui atau menolak transaksi
+type ApprovalEvent struct {
+	ID        string    `json:"id"`
+	Action    string    `json:"action"` // submit, approve, reject
+	Comment   string    `json:"comment"`
+	UserID    string    `json:"userID"`
+	User      *User     `json:"user,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,86 @@
+package entity
+
+import "time"
+
+// Kategori pertanyaan kelayakan
+const (
+	AssessmentCategoryIncome     = "income"
+	AssessmentCategoryDependants = "dependants"
+	AssessmentCategoryHousing    = "housing"
+	AssessmentCategoryDebts      = "debts"
+	AssessmentCategoryOther      = "other"
+)
+
+// Hasil penilaian kelayakan
+const (
+	AssessmentResultEligible    = "eligible"
+	AssessmentResultNotEligible = "not_eligible"
+)
+
+// AssessmentQuestionnaire adalah kuesioner kelayakan untuk satu asnaf. Skor penilaian (0-100)
+// yang mencapai PassThreshold membuat mustahiq aktif.
+type AssessmentQuestionnaire struct {
+	ID              string                `json:"id"`
+	AsnafID         string                `json:"asnafID"`
+	Asnaf           *Asnaf                `json:"asnaf,omitempty"`
+	Name            string                `json:"name"`
+	PassThreshold   float64               `json:"passThreshold"`
+	IsActive        bool                  `json:"isActive"` // hanya satu kuesioner aktif per asnaf
+	Notes           string                `json:"notes"`
+	AssessmentCount int64                 `json:"assessmentCount"` // > 0 = tidak bisa diubah atau dihapus
+	Questions       []*AssessmentQuestion `json:"questions,omitempty"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+}
+
+type AssessmentQuestion struct {
+	ID              string              `json:"id"`
+	QuestionnaireID string              `json:"questionnaireID"`
+	Category        string              `json:"category"` // income, dependants, housing, debts, other
+	Question        string              `json:"question"`
+	Weight          float64             `json:"weight"`
+	SortOrder       int                 `json:"sortOrder"`
+	Options         []*AssessmentOption `json:"options,omitempty"`
+}
+
+// AssessmentOption adalah pilihan jawaban; skor lebih tinggi = lebih membutuhkan
+type AssessmentOption struct {
+	ID         string  `json:"id"`
+	QuestionID string  `json:"questionID"`
+	Label      string  `json:"label"`
+	Score      float64 `json:"score"`
+	SortOrder  int     `json:"sortOrder"`
+}
+
+// MustahiqAssessment adalah hasil survei kelayakan satu mustahiq beserta perubahan statusnya
+type MustahiqAssessment struct {
+	ID                string                      `json:"id"`
+	MustahiqID        string                      `json:"mustahiqID"`
+	Mustahiq          *Mustahiq                   `json:"mustahiq,omitempty"`
+	QuestionnaireID   string                      `json:"questionnaireID"`
+	QuestionnaireName string                      `json:"questionnaireName"`
+	AssessmentDate    string                      `json:"assessmentDate"` // YYYY-MM-DD
+	Score             float64                     `json:"score"`          // 0-100
+	PassThreshold     float64                     `json:"passThreshold"`  // ambang batas saat dinilai
+	Result            string                      `json:"result"`         // eligible, not_eligible
+	PreviousStatus    string                      `json:"previousStatus"`
+	NewStatus         string                      `json:"newStatus"`
+	Notes             string                      `json:"notes"`
+	AssessedByUserID  string                      `json:"assessedByUserID"`
+	AssessedByUser    *User                       `json:"assessedByUser,omitempty"`
+	Answers           []*MustahiqAssessmentAnswer `json:"answers,omitempty"`
+	CreatedAt         time.Time                   `json:"createdAt"`
+}
+
+// MustahiqAssessmentAnswer menyimpan salinan pertanyaan dan skor saat survei
+type MustahiqAssessmentAnswer struct {
+	ID         string  `json:"id"`
+	QuestionID string  `json:"questionID"`
+	OptionID   string  `json:"optionID"`
+	Category   string  `json:"category"`
+	Question   string  `json:"question"`
+	Answer     string  `json:"answer"`
+	Weight     float64 `json:"weight"`
+	Score      float64 `json:"score"`
+	MaxScore   float64 `json:"maxScore"` // skor tertinggi pertanyaan ini
+}
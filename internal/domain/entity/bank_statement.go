@@ -0,0 +1,103 @@
+package entity
+
+import "time"
+
+// Status baris mutasi rekening
+const (
+	StatementLineUnmatched = "unmatched" // belum ada pasangan
+	StatementLineMatched   = "matched"   // dipasangkan otomatis, menunggu konfirmasi
+	StatementLineConfirmed = "confirmed" // sudah direkonsiliasi petugas
+	StatementLineFlagged   = "flagged"   // ditandai untuk ditelusuri
+)
+
+// Sumber transaksi yang bisa dipasangkan dengan baris mutasi
+const (
+	MatchSourceDonationReceipt = "donation_receipt"
+	MatchSourceDistribution    = "distribution"
+)
+
+// BankStatementFormat memetakan kolom CSV mutasi dari satu bank
+type BankStatementFormat struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	SkipRows          int       `json:"skipRows"`   // baris sebelum header
+	FooterRows        int       `json:"footerRows"` // baris ringkasan di akhir file
+	DateColumn        string    `json:"dateColumn"`
+	DateLayout        string    `json:"dateLayout"` // layout tanggal Go, misalnya 02/01/2006
+	DescriptionColumn string    `json:"descriptionColumn"`
+	ReferenceColumn   string    `json:"referenceColumn"`
+	AmountColumn      string    `json:"amountColumn"` // nilai bertanda, atau dengan akhiran CR/DB
+	CreditColumn      string    `json:"creditColumn"` // dipakai jika AmountColumn kosong
+	DebitColumn       string    `json:"debitColumn"`
+	DecimalSeparator  string    `json:"decimalSeparator"` // . atau ,
+	Notes             string    `json:"notes"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// BankStatement adalah satu file mutasi yang diimpor untuk satu rekening
+type BankStatement struct {
+	ID                 string               `json:"id"`
+	FinancialAccountID string               `json:"financialAccountID"`
+	FinancialAccount   *FinancialAccount    `json:"financialAccount,omitempty"`
+	FormatID           string               `json:"formatID"`
+	FormatName         string               `json:"formatName"`
+	FileName           string               `json:"fileName"`
+	DateFrom           *string              `json:"dateFrom"` // YYYY-MM-DD
+	DateTo             *string              `json:"dateTo"`
+	LineCount          int                  `json:"lineCount"`
+	SkippedCount       int                  `json:"skippedCount"` // baris yang sudah pernah diimpor
+	MatchedCount       int                  `json:"matchedCount"` // dipasangkan otomatis saat impor
+	TotalIn            float64              `json:"totalIn"`
+	TotalOut           float64              `json:"totalOut"`
+	ImportedByUserID   string               `json:"importedByUserID"`
+	ImportedByUser     *User                `json:"importedByUser,omitempty"`
+	Lines              []*BankStatementLine `json:"lines,omitempty"`
+	CreatedAt          time.Time            `json:"createdAt"`
+}
+
+// BankStatementLine adalah satu baris mutasi; Amount positif = masuk, negatif = keluar
+type BankStatementLine struct {
+	ID                 string                `json:"id"`
+	StatementID        string                `json:"statementID"`
+	FinancialAccountID string                `json:"financialAccountID"`
+	LineNumber         int                   `json:"lineNumber"`
+	TransactionDate    string                `json:"transactionDate"` // YYYY-MM-DD
+	Description        string                `json:"description"`
+	Reference          string                `json:"reference"`
+	Amount             float64               `json:"amount"`
+	Status             string                `json:"status"` // unmatched, matched, confirmed, flagged
+	FlagReason         string                `json:"flagReason"`
+	ReviewedByUserID   *string               `json:"reviewedByUserID"`
+	ReviewedByUser     *User                 `json:"reviewedByUser,omitempty"`
+	ReviewedAt         *time.Time            `json:"reviewedAt"`
+	Matches            []*BankStatementMatch `json:"matches,omitempty"`
+	CreatedAt          time.Time             `json:"createdAt"`
+	UpdatedAt          time.Time             `json:"updatedAt"`
+}
+
+// BankStatementMatch memasangkan (sebagian) baris mutasi dengan kwitansi atau penyaluran
+type BankStatementMatch struct {
+	ID         string  `json:"id"`
+	LineID     string  `json:"lineID"`
+	SourceType string  `json:"sourceType"` // donation_receipt, distribution
+	SourceID   string  `json:"sourceID"`
+	Amount     float64 `json:"amount"`
+	// Ringkasan transaksi sumber untuk ditampilkan
+	SourceNumber string `json:"sourceNumber"` // nomor kwitansi, kosong untuk penyaluran
+	SourceDate   string `json:"sourceDate"`
+	SourceLabel  string `json:"sourceLabel"` // nama muzakki atau program
+}
+
+// MatchCandidate adalah kwitansi atau penyaluran yang bisa dipasangkan dengan baris mutasi
+type MatchCandidate struct {
+	SourceType         string  `json:"sourceType"`
+	SourceID           string  `json:"sourceID"`
+	FinancialAccountID string  `json:"financialAccountID"`
+	Status             string  `json:"status"`
+	Number             string  `json:"number"`
+	Date               string  `json:"date"` // YYYY-MM-DD
+	Amount             float64 `json:"amount"`
+	Label              string  `json:"label"`
+}
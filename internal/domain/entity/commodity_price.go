@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Commodity constants
+const (
+	CommodityGold   = "gold"
+	CommoditySilver = "silver"
+	CommodityRice   = "rice"
+)
+
+// CommodityUnits adalah satuan harga untuk setiap komoditas
+var CommodityUnits = map[string]string{
+	CommodityGold:   "gram",
+	CommoditySilver: "gram",
+	CommodityRice:   "kg",
+}
+
+type CommodityPrice struct {
+	ID        string    `json:"id"`
+	Commodity string    `json:"commodity"` // gold, silver, rice
+	PriceDate string    `json:"priceDate"` // YYYY-MM-DD
+	Price     float64   `json:"price"`     // per unit
+	Unit      string    `json:"unit"`      // gram, kg
+	Source    string    `json:"source"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
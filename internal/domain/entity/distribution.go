@@ -2,17 +2,54 @@ package entity
 
 import "time"
 
+// Distribution status
+const (
+	DistributionStatusDraft           = "draft"
+	DistributionStatusPendingApproval = "pending_approval"
+	DistributionStatusPosted          = "posted"
+	DistributionStatusVoided          = "voided"
+)
+
+// Status serah terima penyaluran posted, dihitung dari item yang vouchernya sudah ditukar
+const (
+	DeliveryStatusUndelivered = "undelivered"
+	DeliveryStatusPartial     = "partial"
+	DeliveryStatusDelivered   = "delivered"
+)
+
 type Distribution struct {
-	ID               string              `json:"id"`
-	DistributionDate string              `json:"distributionDate"` // YYYY-MM-DD
-	ProgramID        *string             `json:"programID"`        // nullable
-	Program          *Program            `json:"program,omitempty"`
-	SourceFundType   string              `json:"sourceFundType"` // zakat_fitrah, zakat_maal, infaq, sadaqah
-	TotalAmount      float64             `json:"totalAmount"`
-	Notes            string              `json:"notes"`
-	CreatedByUserID  string              `json:"createdByUserID"`
-	CreatedByUser    *User               `json:"createdByUser,omitempty"`
-	Items            []*DistributionItem `json:"items,omitempty"`
-	CreatedAt        time.Time           `json:"createdAt"`
-	UpdatedAt        time.Time           `json:"updatedAt"`
+	ID                        string              `json:"id"`
+	DistributionDate          string              `json:"distributionDate"` // YYYY-MM-DD
+	ProgramID                 *string             `json:"programID"`        // nullable
+	Program                   *Program            `json:"program,omitempty"`
+	SourceFundType            string              `json:"sourceFundType"`     // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	FinancialAccountID        string              `json:"financialAccountID"` // rekening yang membayar
+	FinancialAccount          *FinancialAccount   `json:"financialAccount,omitempty"`
+	TotalAmount               float64             `json:"totalAmount"`
+	Notes                     string              `json:"notes"`
+	Status                    string              `json:"status"`         // draft, pending_approval, posted, voided
+	DeliveryStatus            string              `json:"deliveryStatus"` // undelivered, partial, delivered; kosong jika belum posted
+	DeliveredItemCount        int                 `json:"deliveredItemCount"`
+	PostedAt                  *time.Time          `json:"postedAt"`
+	VoidReason                string              `json:"voidReason"`
+	VoidedByUserID            *string             `json:"voidedByUserID"`
+	VoidedByUser              *User               `json:"voidedByUser,omitempty"`
+	VoidedAt                  *time.Time          `json:"voidedAt"`
+	RevertReason              string              `json:"revertReason"` // alasan terakhir dikembalikan ke draft
+	RevertedByUserID          *string             `json:"revertedByUserID"`
+	RevertedByUser            *User               `json:"revertedByUser,omitempty"`
+	RevertedAt                *time.Time          `json:"revertedAt"`
+	OverdraftJustification    string              `json:"overdraftJustification"` // override admin jika melebihi saldo dana
+	OverdraftApprovedByUserID *string             `json:"overdraftApprovedByUserID"`
+	OverdraftApprovedAt       *time.Time          `json:"overdraftApprovedAt"`
+	AidRuleJustification      string              `json:"aidRuleJustification"` // override admin jika melanggar aturan bantuan
+	AidRuleOverrideByUserID   *string             `json:"aidRuleOverrideByUserID"`
+	AidRuleOverrideAt         *time.Time          `json:"aidRuleOverrideAt"`
+	AidRuleViolations         []*AidRuleViolation `json:"aidRuleViolations,omitempty"` // pelanggaran yang di-override
+	CreatedByUserID           string              `json:"createdByUserID"`
+	CreatedByUser             *User               `json:"createdByUser,omitempty"`
+	Items                     []*DistributionItem `json:"items,omitempty"`
+	Approvals                 []*ApprovalEvent    `json:"approvals,omitempty"` // riwayat maker-checker
+	CreatedAt                 time.Time           `json:"createdAt"`
+	UpdatedAt                 time.Time           `json:"updatedAt"`
 }
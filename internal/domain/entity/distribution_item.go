@@ -3,12 +3,21 @@ package entity
 import "time"
 
 type DistributionItem struct {
-	ID             string    `json:"id"`
-	DistributionID string    `json:"distributionID"`
-	MustahiqID     string    `json:"mustahiqID"`
-	Mustahiq       *Mustahiq `json:"mustahiq,omitempty"`
-	Amount         float64   `json:"amount"`
-	Notes          string    `json:"notes"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
+	ID                    string     `json:"id"`
+	DistributionID        string     `json:"distributionID"`
+	MustahiqID            string     `json:"mustahiqID"`
+	Mustahiq              *Mustahiq  `json:"mustahiq,omitempty"`
+	Amount                float64    `json:"amount"`    // boleh 0 untuk item natura
+	Commodity             *string    `json:"commodity"` // gold, silver, rice (item natura, nullable)
+	Quantity              *float64   `json:"quantity"`  // jumlah dalam satuan komoditas
+	Notes                 string     `json:"notes"`
+	VoucherCode           string     `json:"voucherCode"` // kode unik yang ditukar mustahiq saat menerima bantuan
+	DeliveredAt           *time.Time `json:"deliveredAt"` // nil = belum diserahkan
+	DeliveredByUserID     *string    `json:"deliveredByUserID"`
+	DeliveredByUser       *User      `json:"deliveredByUser,omitempty"`
+	DeliveryPhotoPath     string     `json:"deliveryPhotoPath"` // key di penyimpanan file, kosong = tanpa foto
+	DeliverySignaturePath string     `json:"deliverySignaturePath"`
+	DeliveryNotes         string     `json:"deliveryNotes"`
+	CreatedAt             time.Time  `json:"createdAt"`
+	UpdatedAt             time.Time  `json:"updatedAt"`
 }
@@ -0,0 +1,36 @@
+package entity
+
+// Dasar perhitungan nominal rencana penyaluran
+const (
+	DistributionPlanBasisPerPerson    = "per_person"
+	DistributionPlanBasisPerHousehold = "per_household" // satu item per rumah tangga
+)
+
+// DistributionPlan adalah pratinjau penyaluran massal yang belum disimpan
+type DistributionPlan struct {
+	Basis             string                  `json:"basis"`
+	ItemCount         int                     `json:"itemCount"`
+	HouseholdCount    int                     `json:"householdCount"` // rumah tangga yang menerima, mustahiq tanpa rumah tangga tidak dihitung
+	PersonCount       int                     `json:"personCount"`    // orang yang tercakup, termasuk anggota rumah tangga untuk per_household
+	TotalAmount       float64                 `json:"totalAmount"`
+	Items             []*DistributionPlanItem `json:"items"`
+	Skipped           []*DistributionPlanSkip `json:"skipped"`           // mustahiq terpilih yang tidak ikut disalurkan
+	AidRuleViolations []*AidRuleViolation     `json:"aidRuleViolations"` // per item, lihat ItemIndex
+}
+
+// DistributionPlanItem adalah satu penerima dalam rencana penyaluran
+type DistributionPlanItem struct {
+	MustahiqID   string  `json:"mustahiqID"`
+	MustahiqName string  `json:"mustahiqName"`
+	AsnafName    string  `json:"asnafName"`
+	HouseholdID  *string `json:"householdID"`
+	MemberCount  int     `json:"memberCount"` // anggota rumah tangga yang dihitung pada rumus
+	Amount       float64 `json:"amount"`
+}
+
+// DistributionPlanSkip adalah mustahiq terpilih yang tidak mendapat item beserta alasannya
+type DistributionPlanSkip struct {
+	MustahiqID   string `json:"mustahiqID"`
+	MustahiqName string `json:"mustahiqName"`
+	Reason       string `json:"reason"`
+}
@@ -2,18 +2,37 @@ package entity
 
 import "time"
 
+// Donation receipt status
+const (
+	ReceiptStatusDraft           = "draft"
+	ReceiptStatusPendingApproval = "pending_approval"
+	ReceiptStatusPosted          = "posted"
+	ReceiptStatusVoided          = "voided"
+)
+
 type DonationReceipt struct {
-	ID              string                 `json:"id"`
-	MuzakkiID       string                 `json:"muzakkiID"`
-	Muzakki         *Muzakki               `json:"muzakki,omitempty"`
-	ReceiptNumber   string                 `json:"receiptNumber"`
-	ReceiptDate     string                 `json:"receiptDate"` // YYYY-MM-DD
-	PaymentMethod   string                 `json:"paymentMethod"`
-	TotalAmount     float64                `json:"totalAmount"`
-	Notes           string                 `json:"notes"`
-	CreatedByUserID string                 `json:"createdByUserID"`
-	CreatedByUser   *User                  `json:"createdByUser,omitempty"`
-	Items           []*DonationReceiptItem `json:"items,omitempty"`
-	CreatedAt       time.Time              `json:"createdAt"`
-	UpdatedAt       time.Time              `json:"updatedAt"`
+	ID                  string                 `json:"id"`
+	MuzakkiID           string                 `json:"muzakkiID"`
+	Muzakki             *Muzakki               `json:"muzakki,omitempty"`
+	ReceiptNumber       string                 `json:"receiptNumber"` // kosong selama draft
+	ReceiptDate         string                 `json:"receiptDate"`   // YYYY-MM-DD
+	FinancialAccountID  string                 `json:"financialAccountID"`
+	FinancialAccount    *FinancialAccount      `json:"financialAccount,omitempty"`
+	FitrahRegion        string                 `json:"fitrahRegion"` // region for fitrah rate lookup
+	TotalAmount         float64                `json:"totalAmount"`
+	Notes               string                 `json:"notes"`
+	Status              string                 `json:"status"` // draft, pending_approval, posted, voided
+	PostedAt            *time.Time             `json:"postedAt"`
+	VoidReason          string                 `json:"voidReason"`
+	VoidedByUserID      *string                `json:"voidedByUserID"`
+	VoidedByUser        *User                  `json:"voidedByUser,omitempty"`
+	VoidedAt            *time.Time             `json:"voidedAt"`
+	ReversalOfReceiptID *string                `json:"reversalOfReceiptID"` // kwitansi yang dikoreksi oleh kwitansi ini
+	ReversedByReceiptID *string                `json:"reversedByReceiptID"` // kwitansi pengganti (jika sudah dikoreksi)
+	CreatedByUserID     string                 `json:"createdByUserID"`
+	CreatedByUser       *User                  `json:"createdByUser,omitempty"`
+	Items               []*DonationReceiptItem `json:"items,omitempty"`
+	Approvals           []*ApprovalEvent       `json:"approvals,omitempty"` // riwayat maker-checker
+	CreatedAt           time.Time              `json:"createdAt"`
+	UpdatedAt           time.Time              `json:"updatedAt"`
 }
@@ -3,14 +3,23 @@ package entity
 import "time"
 
 type DonationReceiptItem struct {
-	ID          string    `json:"id"`
-	ReceiptID   string    `json:"receiptID"`
-	FundType    string    `json:"fundType"`    // zakat, infaq, sadaqah
-	ZakatType   *string   `json:"zakatType"`   // fitrah, maal (nullable)
-	PersonCount *int      `json:"personCount"` // nullable
-	Amount      float64   `json:"amount"`
-	RiceKG      *float64  `json:"riceKG"` // nullable
-	Notes       string    `json:"notes"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID                 string                           `json:"id"`
+	ReceiptID          string                           `json:"receiptID"`
+	FundType           string                           `json:"fundType"`    // zakat, infaq, sadaqah
+	ZakatType          *string                          `json:"zakatType"`   // fitrah, maal (nullable)
+	PersonCount        *int                             `json:"personCount"` // nullable
+	Amount             float64                          `json:"amount"`
+	RiceKG             *float64                         `json:"riceKG"`             // nullable
+	ZakatCalculationID *string                          `json:"zakatCalculationID"` // nullable, only for zakat maal
+	Notes              string                           `json:"notes"`
+	Allocations        []*DonationReceiptItemAllocation `json:"allocations,omitempty"` // terisi setelah posted
+	CreatedAt          time.Time                        `json:"createdAt"`
+	UpdatedAt          time.Time                        `json:"updatedAt"`
+}
+
+// DonationReceiptItemAllocation adalah bagian item kwitansi pada satu sub-ledger
+type DonationReceiptItemAllocation struct {
+	Ledger     string  `json:"ledger"`     // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	Percentage float64 `json:"percentage"` // persentase hak amil yang dipakai
+	Amount     float64 `json:"amount"`
 }
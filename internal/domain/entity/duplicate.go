@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+// Jenis data yang diperiksa kandidat data gandanya
+const (
+	DuplicateEntityMustahiq = "mustahiq"
+	DuplicateEntityMuzakki  = "muzakki"
+)
+
+// Tingkat keyakinan kandidat data ganda
+const (
+	DuplicateConfidenceHigh   = "high"
+	DuplicateConfidenceMedium = "medium"
+)
+
+// Alasan sebuah pasangan ditandai sebagai kandidat data ganda
+const (
+	DuplicateReasonNIK         = "same_nik"
+	DuplicateReasonPhone       = "same_phone"
+	DuplicateReasonNameAddress = "similar_name_address"
+)
+
+// DuplicateRecord adalah ringkasan mustahiq/muzakki yang dibandingkan
+type DuplicateRecord struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	NIK         string    `json:"nik"`
+	PhoneNumber string    `json:"phoneNumber"`
+	Address     string    `json:"address"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// DuplicateCandidate adalah pasangan data yang kemungkinan orang yang sama.
+// RecordA selalu data yang lebih dulu terdaftar.
+type DuplicateCandidate struct {
+	EntityType        string          `json:"entityType"`
+	RecordA           DuplicateRecord `json:"recordA"`
+	RecordB           DuplicateRecord `json:"recordB"`
+	Score             float64         `json:"score"` // 0-100
+	Confidence        string          `json:"confidence"`
+	Reasons           []string        `json:"reasons"`
+	NameSimilarity    float64         `json:"nameSimilarity"`    // 0-1
+	AddressSimilarity float64         `json:"addressSimilarity"` // 0-1
+}
+
+// DuplicateDismissal mencatat pasangan kandidat yang sudah diperiksa dan dinyatakan bukan data ganda
+type DuplicateDismissal struct {
+	ID                string    `json:"id"`
+	EntityType        string    `json:"entityType"`
+	RecordAID         string    `json:"recordAID"`
+	RecordBID         string    `json:"recordBID"`
+	Reason            string    `json:"reason"`
+	DismissedByUserID string    `json:"dismissedByUserID"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
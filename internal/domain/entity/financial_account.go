@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Jenis rekening
+const (
+	FinancialAccountTypeCash    = "cash"
+	FinancialAccountTypeBank    = "bank"
+	FinancialAccountTypeDigital = "digital" // QRIS, e-wallet
+)
+
+// FinancialAccount adalah tempat uang disimpan (kas, rekening bank, QRIS).
+// Setiap rekening punya akun aset sendiri di buku besar.
+type FinancialAccount struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	AccountType       string    `json:"accountType"` // cash, bank, digital
+	BankName          string    `json:"bankName"`
+	AccountNumber     string    `json:"accountNumber"`
+	LedgerAccountID   string    `json:"ledgerAccountID"`
+	LedgerAccountCode string    `json:"ledgerAccountCode"`
+	IsActive          bool      `json:"isActive"`
+	Notes             string    `json:"notes"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,73 @@
+package entity
+
+import "time"
+
+// Jenis dan status periode buku
+const (
+	FiscalPeriodMonth = "month"
+	FiscalPeriodYear  = "year"
+
+	FiscalPeriodOpen   = "open"
+	FiscalPeriodClosed = "closed" // transaksi bertanggal di periode ini dikunci
+)
+
+// Aksi pada jejak audit periode
+const (
+	FiscalPeriodActionClose  = "close"
+	FiscalPeriodActionReopen = "reopen"
+)
+
+// FiscalPeriod adalah satu bulan atau tahun buku yang bisa ditutup admin
+type FiscalPeriod struct {
+	ID               string                 `json:"id"`
+	PeriodType       string                 `json:"periodType"`  // month, year
+	PeriodStart      string                 `json:"periodStart"` // YYYY-MM-DD
+	PeriodEnd        string                 `json:"periodEnd"`
+	Status           string                 `json:"status"` // open, closed
+	Notes            string                 `json:"notes"`
+	ClosedByUserID   *string                `json:"closedByUserID"`
+	ClosedByUser     *User                  `json:"closedByUser,omitempty"`
+	ClosedAt         *time.Time             `json:"closedAt"`
+	ReopenedByUserID *string                `json:"reopenedByUserID"`
+	ReopenedByUser   *User                  `json:"reopenedByUser,omitempty"`
+	ReopenedAt       *time.Time             `json:"reopenedAt"`
+	ReopenReason     string                 `json:"reopenReason"`
+	Balances         []*FiscalPeriodBalance `json:"balances,omitempty"` // snapshot saat terakhir ditutup
+	Events           []*FiscalPeriodEvent   `json:"events,omitempty"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+}
+
+// Label mengembalikan nama periode, misalnya 2024-11 atau 2024
+func (p *FiscalPeriod) Label() string {
+	if p.PeriodType == FiscalPeriodYear {
+		return p.PeriodStart[:4]
+	}
+	return p.PeriodStart[:7]
+}
+
+// FiscalPeriodBalance adalah snapshot saldo satu akun buku besar pada periode tertutup.
+// Saldo mengikuti sisi normal akun: aset = debit - kredit, dana = kredit - debit.
+type FiscalPeriodBalance struct {
+	LedgerAccountID    string  `json:"ledgerAccountID"`
+	AccountCode        string  `json:"accountCode"`
+	AccountName        string  `json:"accountName"`
+	AccountType        string  `json:"accountType"` // asset, liability
+	FundLedger         *string `json:"fundLedger"`  // diisi untuk akun dana
+	FinancialAccountID *string `json:"financialAccountID"`
+	OpeningBalance     float64 `json:"openingBalance"`
+	TotalDebit         float64 `json:"totalDebit"`
+	TotalCredit        float64 `json:"totalCredit"`
+	ClosingBalance     float64 `json:"closingBalance"`
+}
+
+// FiscalPeriodEvent mencatat siapa menutup atau membuka kembali periode
+type FiscalPeriodEvent struct {
+	ID        string    `json:"id"`
+	PeriodID  string    `json:"periodID"`
+	Action    string    `json:"action"` // close, reopen
+	Reason    string    `json:"reason"`
+	UserID    string    `json:"userID"`
+	User      *User     `json:"user,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// FitrahRate adalah tarif zakat fitrah yang ditetapkan per tahun hijriah dan wilayah
+type FitrahRate struct {
+	ID              string    `json:"id"`
+	HijriYear       int       `json:"hijriYear"`
+	Region          string    `json:"region"`
+	RiceKGPerPerson float64   `json:"riceKGPerPerson"`
+	CashPerPerson   float64   `json:"cashPerPerson"` // 0 = ikut harga beras pada tanggal penerimaan
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
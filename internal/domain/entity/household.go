@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Hubungan anggota dengan kepala keluarga
+const (
+	HouseholdRelationshipHead       = "head"
+	HouseholdRelationshipSpouse     = "spouse"
+	HouseholdRelationshipChild      = "child"
+	HouseholdRelationshipParent     = "parent"
+	HouseholdRelationshipSibling    = "sibling"
+	HouseholdRelationshipGrandchild = "grandchild"
+	HouseholdRelationshipOther      = "other"
+)
+
+// Household adalah satu rumah tangga sesuai Kartu Keluarga
+type Household struct {
+	ID               string             `json:"id"`
+	FamilyCardNumber string             `json:"familyCardNumber"` // nomor KK, boleh kosong
+	Address          string             `json:"address"`
+	Notes            string             `json:"notes"`
+	HeadName         string             `json:"headName"`                 // nama anggota dengan relationship head
+	HeadMustahiqID   *string            `json:"headMustahiqID,omitempty"` // terisi jika kepala keluarga terdaftar sebagai mustahiq
+	MemberCount      int                `json:"memberCount"`              // jumlah anggota rumah tangga
+	Members          []*HouseholdMember `json:"members,omitempty"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	UpdatedAt        time.Time          `json:"updatedAt"`
+}
+
+// HouseholdMember adalah anggota rumah tangga; MustahiqID terisi jika anggota terdaftar sebagai mustahiq
+type HouseholdMember struct {
+	ID             string    `json:"id"`
+	HouseholdID    string    `json:"householdID"`
+	MustahiqID     *string   `json:"mustahiqID,omitempty"`
+	MustahiqStatus string    `json:"mustahiqStatus,omitempty"`
+	Name           string    `json:"name"`
+	Relationship   string    `json:"relationship"`        // head, spouse, child, parent, sibling, grandchild, other
+	BirthDate      *string   `json:"birthDate,omitempty"` // YYYY-MM-DD
+	CreatedAt      time.Time `json:"createdAt"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Jenis akun buku besar
+const (
+	AccountTypeAsset     = "asset"
+	AccountTypeLiability = "liability"
+)
+
+// Sumber jurnal
+const (
+	JournalSourceDonationReceipt = "donation_receipt"
+	JournalSourceDistribution    = "distribution"
+	JournalSourceOpeningBalance  = "opening_balance"
+)
+
+// LedgerAccount adalah akun pada bagan akun (chart of accounts)
+type LedgerAccount struct {
+	ID          string    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	AccountType string    `json:"accountType"` // asset, liability
+	FundLedger  *string   `json:"fundLedger"`  // amil, zakat_fitrah, zakat_maal, infaq, sadaqah (nullable)
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// JournalEntry adalah satu jurnal double-entry; total debit selalu sama dengan total kredit
+type JournalEntry struct {
+	ID                string         `json:"id"`
+	EntryDate         string         `json:"entryDate"` // YYYY-MM-DD
+	Description       string         `json:"description"`
+	SourceType        string         `json:"sourceType"` // donation_receipt, distribution
+	SourceID          string         `json:"sourceID"`
+	ReversalOfEntryID *string        `json:"reversalOfEntryID"` // jurnal yang dibalik oleh jurnal ini
+	Lines             []*JournalLine `json:"lines,omitempty"`
+	CreatedAt         time.Time      `json:"createdAt"`
+}
+
+type JournalLine struct {
+	ID          string  `json:"id"`
+	EntryID     string  `json:"entryID"`
+	AccountID   string  `json:"accountID"`
+	AccountCode string  `json:"accountCode"`
+	AccountName string  `json:"accountName"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+}
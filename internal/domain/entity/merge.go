@@ -0,0 +1,16 @@
+package entity
+
+// Sisi yang dipilih untuk sebuah field saat dua data digabung
+const (
+	MergeChoiceSource = "source"
+	MergeChoiceTarget = "target"
+)
+
+// MergeFieldChoice mencatat nilai kedua data untuk satu field dan nilai yang dipakai
+type MergeFieldChoice struct {
+	Field       string `json:"field"`
+	Choice      string `json:"choice"` // source, target
+	SourceValue string `json:"sourceValue"`
+	TargetValue string `json:"targetValue"`
+	FinalValue  string `json:"finalValue"`
+}
@@ -12,12 +12,51 @@ const (
 type Mustahiq struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
+	NIK         string    `json:"nik"` // Nomor Induk Kependudukan, boleh kosong
 	PhoneNumber string    `json:"phoneNumber"`
 	Address     string    `json:"address"`
 	AsnafID     string    `json:"asnafID"`
 	Asnaf       *Asnaf    `json:"asnaf,omitempty"` // Nested asnaf object
 	Status      string    `json:"status"`
+	HouseholdID *string   `json:"householdID,omitempty"` // rumah tangga tempat mustahiq tercatat sebagai anggota
 	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
+
+// MustahiqStatusChange adalah satu baris riwayat status mustahiq
+type MustahiqStatusChange struct {
+	ID              string  `json:"id"`
+	MustahiqID      string  `json:"mustahiqID"`
+	FromStatus      string  `json:"fromStatus"` // kosong = status awal
+	ToStatus        string  `json:"toStatus"`
+	EffectiveDate   string  `json:"effectiveDate"` // YYYY-MM-DD
+	Reason          string  `json:"reason"`
+	AssessmentID    *string `json:"assessmentID,omitempty"` // penilaian yang mendasari perubahan
+	ChangedByUserID *string `json:"changedByUserID,omitempty"`
+	ChangedByUser   *User   `json:"changedByUser,omitempty"`
+	// MergedFromMustahiqID terisi untuk riwayat yang dipindah dari mustahiq yang digabung;
+	// baris ini tidak dipakai untuk menghitung status per tanggal
+	MergedFromMustahiqID *string   `json:"mergedFromMustahiqID,omitempty"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+// MustahiqMerge adalah jejak audit penggabungan mustahiq sumber ke mustahiq tujuan.
+// Mustahiq sumber dihapus dan ID-nya dialihkan ke tujuan.
+type MustahiqMerge struct {
+	ID                  string              `json:"id"`
+	SourceMustahiqID    string              `json:"sourceMustahiqID"`
+	TargetMustahiqID    string              `json:"targetMustahiqID"`
+	Reason              string              `json:"reason"`
+	ItemsMoved          int                 `json:"itemsMoved"`
+	AmountMoved         float64             `json:"amountMoved"` // nominal penyaluran posted yang dipindah
+	AssessmentsMoved    int                 `json:"assessmentsMoved"`
+	StatusChangesMoved  int                 `json:"statusChangesMoved"`
+	SharedDistributions int                 `json:"sharedDistributions"` // penyaluran yang berisi item untuk kedua data
+	TotalReceivedBefore float64             `json:"totalReceivedBefore"` // total diterima tujuan sebelum digabung
+	TotalReceivedAfter  float64             `json:"totalReceivedAfter"`
+	Fields              []*MergeFieldChoice `json:"fields"`
+	MergedByUserID      string              `json:"mergedByUserID"` // peninjau yang menggabungkan
+	MergedByUser        *User               `json:"mergedByUser,omitempty"`
+	CreatedAt           time.Time           `json:"createdAt"`
+}
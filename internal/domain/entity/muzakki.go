@@ -5,9 +5,25 @@ import "time"
 type Muzakki struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
+	NIK         string    `json:"nik"` // Nomor Induk Kependudukan, boleh kosong
 	PhoneNumber string    `json:"phoneNumber"`
 	Address     string    `json:"address"`
 	Notes       string    `json:"notes"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
+
+// MuzakkiMerge adalah jejak audit penggabungan muzakki sumber ke muzakki tujuan.
+// Muzakki sumber dihapus dan ID-nya dialihkan ke tujuan.
+type MuzakkiMerge struct {
+	ID                string              `json:"id"`
+	SourceMuzakkiID   string              `json:"sourceMuzakkiID"`
+	TargetMuzakkiID   string              `json:"targetMuzakkiID"`
+	Reason            string              `json:"reason"`
+	ReceiptsMoved     int                 `json:"receiptsMoved"`
+	CalculationsMoved int                 `json:"calculationsMoved"`
+	Fields            []*MergeFieldChoice `json:"fields"`
+	MergedByUserID    string              `json:"mergedByUserID"`
+	MergedByUser      *User               `json:"mergedByUser,omitempty"`
+	CreatedAt         time.Time           `json:"createdAt"`
+}
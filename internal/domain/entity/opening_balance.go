@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Opening balance status
+const (
+	OpeningBalanceStatusPosted = "posted"
+	OpeningBalanceStatusVoided = "voided"
+)
+
+// OpeningBalance adalah saldo yang dibawa dari sistem lama untuk satu sub-ledger dana
+// di satu rekening, berlaku mulai tanggal efektif
+type OpeningBalance struct {
+	ID                 string            `json:"id"`
+	EffectiveDate      string            `json:"effectiveDate"` // YYYY-MM-DD
+	FundLedger         string            `json:"fundLedger"`    // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	FinancialAccountID string            `json:"financialAccountID"`
+	FinancialAccount   *FinancialAccount `json:"financialAccount,omitempty"`
+	Amount             float64           `json:"amount"`
+	Notes              string            `json:"notes"`
+	Status             string            `json:"status"` // posted, voided
+	VoidReason         string            `json:"voidReason"`
+	VoidedByUserID     *string           `json:"voidedByUserID"`
+	VoidedByUser       *User             `json:"voidedByUser,omitempty"`
+	VoidedAt           *time.Time        `json:"voidedAt"`
+	CreatedByUserID    string            `json:"createdByUserID"`
+	CreatedByUser      *User             `json:"createdByUser,omitempty"`
+	CreatedAt          time.Time         `json:"createdAt"`
+	UpdatedAt          time.Time         `json:"updatedAt"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Jenis mutasi stok
+const (
+	StockMovementReceipt      = "receipt"
+	StockMovementDistribution = "distribution"
+	StockMovementAdjustment   = "adjustment"
+)
+
+// StockMovement adalah satu baris kartu stok barang (natura) per komoditas dan sub-ledger dana.
+// Quantity positif = masuk, negatif = keluar, dalam satuan komoditas (lihat CommodityUnits).
+type StockMovement struct {
+	ID                   string    `json:"id"`
+	MovementDate         string    `json:"movementDate"` // YYYY-MM-DD
+	Commodity            string    `json:"commodity"`    // gold, silver, rice
+	Unit                 string    `json:"unit"`
+	FundLedger           string    `json:"fundLedger"` // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	Quantity             float64   `json:"quantity"`
+	MovementType         string    `json:"movementType"` // receipt, distribution, adjustment
+	SourceID             *string   `json:"sourceID"`     // kwitansi atau penyaluran
+	SourceItemID         *string   `json:"sourceItemID"`
+	Reason               string    `json:"reason"`
+	ReversalOfMovementID *string   `json:"reversalOfMovementID"`
+	CreatedByUserID      *string   `json:"createdByUserID"`
+	CreatedByUser        *User     `json:"createdByUser,omitempty"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
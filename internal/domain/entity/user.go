@@ -3,9 +3,10 @@ package entity
 import "time"
 
 const (
-	RoleAdmin  = "admin"
-	RoleStaf   = "staf"
-	RoleViewer = "viewer"
+	RoleAdmin    = "admin"
+	RoleStaf     = "staf"
+	RoleViewer   = "viewer"
+	RoleApprover = "approver" // menyetujui kwitansi/penyaluran di atas ambang batas
 )
 
 type User struct {
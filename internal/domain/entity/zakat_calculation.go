@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// Zakat maal rules
+const (
+	NisabGoldGrams   = 85.0
+	NisabSilverGrams = 595.0
+	ZakatMaalRate    = 0.025
+	HaulDays         = 354 // satu tahun hijriah
+
+	NisabBasisGold   = "gold"
+	NisabBasisSilver = "silver"
+)
+
+type ZakatCalculation struct {
+	ID                 string    `json:"id"`
+	MuzakkiID          string    `json:"muzakkiID"`
+	Muzakki            *Muzakki  `json:"muzakki,omitempty"`
+	CalculationDate    string    `json:"calculationDate"` // YYYY-MM-DD
+	HaulStartDate      *string   `json:"haulStartDate"`   // YYYY-MM-DD (nullable)
+	HaulCompleted      bool      `json:"haulCompleted"`
+	Cash               float64   `json:"cash"`
+	GoldGrams          float64   `json:"goldGrams"`
+	GoldPricePerGram   float64   `json:"goldPricePerGram"`
+	SilverGrams        float64   `json:"silverGrams"`
+	SilverPricePerGram float64   `json:"silverPricePerGram"`
+	TradeGoods         float64   `json:"tradeGoods"`
+	Receivables        float64   `json:"receivables"`
+	Debts              float64   `json:"debts"`
+	TotalAssets        float64   `json:"totalAssets"`
+	NetAssets          float64   `json:"netAssets"`
+	NisabBasis         string    `json:"nisabBasis"` // gold, silver
+	NisabValue         float64   `json:"nisabValue"`
+	IsObligatory       bool      `json:"isObligatory"`
+	ZakatRate          float64   `json:"zakatRate"`
+	ZakatAmount        float64   `json:"zakatAmount"`
+	Notes              string    `json:"notes"`
+	CreatedByUserID    string    `json:"createdByUserID"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,42 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type AidRuleFilter struct {
+	AsnafID        string
+	ProgramID      string
+	SourceFundType string
+	Active         *bool
+	Page           int
+	PerPage        int // 0 = semua
+}
+
+// AidUsageFilter memilih bantuan yang sudah diterima sekumpulan penerima untuk dihitung
+// terhadap satu aturan. Hanya penyaluran posted dan pending_approval yang dihitung.
+type AidUsageFilter struct {
+	MustahiqIDs           []string // dihitung per mustahiq
+	HouseholdIDs          []string // dihitung per rumah tangga, untuk semua anggotanya
+	ProgramID             *string
+	SourceFundType        string
+	DateFrom              string // YYYY-MM-DD
+	DateTo                string // YYYY-MM-DD
+	ExcludeDistributionID string // penyaluran yang sedang diubah
+}
+
+// AidUsage adalah bantuan yang sudah diterima pada satu tanggal penyaluran
+type AidUsage struct {
+	Date   string  // YYYY-MM-DD
+	Count  int     // jumlah penyaluran
+	Amount float64 // total nominal uang
+}
+
+type AidRuleRepository interface {
+	FindAll(filter AidRuleFilter) ([]*entity.AidRule, int64, error)
+	FindByID(id string) (*entity.AidRule, error)
+	Create(rule *entity.AidRule) error
+	Update(rule *entity.AidRule) error
+	Delete(id string) error
+	// GetUsages mengembalikan bantuan per tanggal (urut tanggal) untuk setiap ID mustahiq atau
+	// rumah tangga dari filter; penerima yang belum menerima bantuan tidak ada di map
+	GetUsages(filter AidUsageFilter) (map[string][]*AidUsage, error)
+}
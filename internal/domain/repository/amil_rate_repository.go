@@ -0,0 +1,20 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type AmilRateFilter struct {
+	FundType string // zakat_fitrah, zakat_maal, infaq, sadaqah
+	Page     int
+	PerPage  int
+}
+
+type AmilRateRepository interface {
+	FindAll(filter AmilRateFilter) ([]*entity.AmilRate, int64, error)
+	FindByID(id string) (*entity.AmilRate, error)
+	// FindEffective mengembalikan persentase yang berlaku pada tanggal (YYYY-MM-DD),
+	// nil (tanpa error) jika belum ada
+	FindEffective(fundType, date string) (*entity.AmilRate, error)
+	Create(rate *entity.AmilRate) error
+	Update(rate *entity.AmilRate) error
+	Delete(id string) error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type AssessmentQuestionnaireFilter struct {
+	AsnafID    string
+	ActiveOnly bool
+}
+
+type MustahiqAssessmentFilter struct {
+	MustahiqID      string
+	QuestionnaireID string
+	Result          string // eligible, not_eligible
+	DateFrom        string // YYYY-MM-DD
+	DateTo          string // YYYY-MM-DD
+	Page            int
+	PerPage         int
+}
+
+// Kuesioner yang sudah dipakai penilaian tidak bisa diubah atau dihapus, hanya dinonaktifkan.
+// Mengaktifkan kuesioner menonaktifkan kuesioner aktif lain untuk asnaf yang sama.
+type AssessmentQuestionnaireRepository interface {
+	FindAll(filter AssessmentQuestionnaireFilter) ([]*entity.AssessmentQuestionnaire, error)
+	FindByID(id string) (*entity.AssessmentQuestionnaire, error) // termasuk pertanyaan dan pilihan jawaban
+	// FindActiveByAsnaf mengembalikan nil jika asnaf belum punya kuesioner aktif
+	FindActiveByAsnaf(asnafID string) (*entity.AssessmentQuestionnaire, error)
+	Create(questionnaire *entity.AssessmentQuestionnaire) error
+	Update(questionnaire *entity.AssessmentQuestionnaire) error
+	SetActive(id string, active bool) error
+	Delete(id string) error
+}
+
+type MustahiqAssessmentRepository interface {
+	FindAll(filter MustahiqAssessmentFilter) ([]*entity.MustahiqAssessment, int64, error)
+	FindByID(id string) (*entity.MustahiqAssessment, error) // termasuk jawaban
+	// Create menyimpan penilaian dan jawabannya lalu mengubah status mustahiq (beserta riwayatnya)
+	// dalam satu transaksi
+	Create(assessment *entity.MustahiqAssessment) error
+}
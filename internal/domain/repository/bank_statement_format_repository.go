@@ -0,0 +1,11 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type BankStatementFormatRepository interface {
+	FindAll() ([]*entity.BankStatementFormat, error)
+	FindByID(id string) (*entity.BankStatementFormat, error)
+	Create(format *entity.BankStatementFormat) error
+	Update(format *entity.BankStatementFormat) error
+	Delete(id string) error
+}
@@ -0,0 +1,50 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type BankStatementFilter struct {
+	FinancialAccountID string
+	Page               int
+	PerPage            int
+}
+
+// BankStatementLineFilter dipakai untuk antrean rekonsiliasi
+type BankStatementLineFilter struct {
+	StatementID        string
+	FinancialAccountID string
+	Status             string // unmatched, matched, confirmed, flagged
+	DateFrom           string // YYYY-MM-DD
+	DateTo             string // YYYY-MM-DD
+	Page               int
+	PerPage            int
+}
+
+// MatchCandidateFilter mencari kwitansi (Amount > 0) atau penyaluran posted
+// pada satu rekening yang belum dipasangkan dengan baris mutasi mana pun
+type MatchCandidateFilter struct {
+	FinancialAccountID string
+	SourceType         string // donation_receipt, distribution
+	DateFrom           string
+	DateTo             string
+	ExactAmount        float64 // > 0 = hanya nominal yang sama persis
+	MaxAmount          float64 // > 0 = nominal tidak lebih dari ini (untuk split)
+}
+
+type BankStatementRepository interface {
+	FindAll(filter BankStatementFilter) ([]*entity.BankStatement, int64, error)
+	FindByID(id string) (*entity.BankStatement, error)
+	// Create menyimpan mutasi beserta barisnya; baris yang sama persis dengan baris
+	// yang sudah diimpor untuk rekening yang sama dilewati (SkippedCount)
+	Create(statement *entity.BankStatement) error
+	// Delete ditolak jika ada baris yang sudah dikonfirmasi
+	Delete(id string) error
+
+	FindLines(filter BankStatementLineFilter) ([]*entity.BankStatementLine, int64, error)
+	FindLineByID(id string) (*entity.BankStatementLine, error)
+	FindMatchCandidates(filter MatchCandidateFilter) ([]*entity.MatchCandidate, error)
+	// FindMatchSource mengambil satu kwitansi/penyaluran apa pun statusnya
+	FindMatchSource(sourceType, sourceID string) (*entity.MatchCandidate, error)
+	// SetLineMatches mengganti pasangan baris dan mengubah statusnya dalam satu transaksi
+	SetLineMatches(lineID, status string, matches []*entity.BankStatementMatch, reviewedByUserID *string) error
+	FlagLine(lineID, reason, userID string) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type CommodityPriceFilter struct {
+	Commodity string // gold, silver, rice
+	DateFrom  string // YYYY-MM-DD
+	DateTo    string // YYYY-MM-DD
+	Page      int
+	PerPage   int
+}
+
+type CommodityPriceRepository interface {
+	FindAll(filter CommodityPriceFilter) ([]*entity.CommodityPrice, int64, error)
+	FindByID(id string) (*entity.CommodityPrice, error)
+	// FindEffective mengembalikan harga terakhir pada atau sebelum tanggal (YYYY-MM-DD),
+	// nil (tanpa error) jika belum ada harga
+	FindEffective(commodity, date string) (*entity.CommodityPrice, error)
+	Create(price *entity.CommodityPrice) error
+	Update(price *entity.CommodityPrice) error
+	Delete(id string) error
+	// UpsertMany menyimpan banyak harga dalam satu transaksi (insert atau update per commodity + tanggal)
+	UpsertMany(prices []*entity.CommodityPrice) error
+}
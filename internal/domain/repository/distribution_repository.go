@@ -1,21 +1,57 @@
 package repository
 
-import "go-zakat-be/internal/domain/entity"
+import (
+	"fmt"
+
+	"go-zakat-be/internal/domain/entity"
+)
 
 type DistributionFilter struct {
-	DateFrom       string // YYYY-MM-DD
-	DateTo         string // YYYY-MM-DD
-	SourceFundType string // zakat_fitrah, zakat_maal, infaq, sadaqah
-	ProgramID      string
-	Query          string // search in program name or notes
-	Page           int
-	PerPage        int
+	DateFrom           string // YYYY-MM-DD
+	DateTo             string // YYYY-MM-DD
+	SourceFundType     string // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	FinancialAccountID string
+	ProgramID          string
+	Status             string // draft, pending_approval, posted, voided
+	DeliveryStatus     string // undelivered, partial, delivered (posted only)
+	Query              string // search in program name or notes
+	Page               int
+	PerPage            int
+}
+
+// InsufficientFundError dikembalikan saat penyaluran yang akan diposting melebihi saldo dana
+type InsufficientFundError struct {
+	FundType  string
+	Balance   float64
+	Requested float64
+}
+
+func (e *InsufficientFundError) Error() string {
+	return fmt.Sprintf("insufficient %s balance: available %.2f, requested %.2f", e.FundType, e.Balance, e.Requested)
 }
 
+// Create, Post dan Approve mengecek saldo dana dalam transaksi yang sama untuk penyaluran posted,
+// kecuali OverdraftJustification diisi (override admin). Saldo kurang -> *InsufficientFundError.
+// Item natura juga dicek terhadap stok dana sumber (tanpa override) -> *InsufficientStockError.
+// Create, Update, Post, Submit dan Approve menyimpan AidRuleJustification dan AidRuleViolations
+// apa adanya; pengecekan aturan bantuan dilakukan di usecase.
 type DistributionRepository interface {
 	FindAll(filter DistributionFilter) ([]*entity.Distribution, int64, error)
 	FindByID(id string) (*entity.Distribution, error)
 	Create(distribution *entity.Distribution) error
-	Update(distribution *entity.Distribution) error
-	Delete(id string) error
+	Update(distribution *entity.Distribution) error // draft only
+	Delete(id string) error                         // draft only
+	Post(distribution *entity.Distribution) error
+	// Submit mengajukan draft untuk disetujui, termasuk justifikasi overdraft-nya
+	Submit(distribution *entity.Distribution, submittedByUserID string) error
+	Approve(distribution *entity.Distribution, approvedByUserID, comment string) error
+	Reject(id, rejectedByUserID, comment string) error // kembali ke draft
+	// Void dan RevertToDraft gagal jika ada item yang sudah diserahkan
+	Void(id, reason, voidedByUserID string) error
+	RevertToDraft(id, reason, revertedByUserID string) error
+	// FindByVoucherCode mengembalikan penyaluran yang memiliki item dengan kode voucher tersebut
+	FindByVoucherCode(code string) (*entity.Distribution, error)
+	// MarkDelivered mencatat serah terima item; gagal jika voucher sudah ditukar atau
+	// penyaluran sudah tidak posted
+	MarkDelivered(item *entity.DistributionItem) error
 }
@@ -3,21 +3,34 @@ package repository
 import "go-zakat-be/internal/domain/entity"
 
 type DonationReceiptFilter struct {
-	DateFrom      string // YYYY-MM-DD
-	DateTo        string // YYYY-MM-DD
-	FundType      string // zakat, infaq, sadaqah (filter by item's fund_type)
-	ZakatType     string // fitrah, maal
-	PaymentMethod string
-	MuzakkiID     string
-	Query         string // search in muzakki.full_name or notes
-	Page          int
-	PerPage       int
+	DateFrom           string // YYYY-MM-DD
+	DateTo             string // YYYY-MM-DD
+	FundType           string // zakat, infaq, sadaqah (filter by item's fund_type)
+	ZakatType          string // fitrah, maal
+	FinancialAccountID string
+	MuzakkiID          string
+	Status             string // draft, pending_approval, posted, voided
+	Query              string // search in muzakki.full_name or notes
+	Page               int
+	PerPage            int
 }
 
 type DonationReceiptRepository interface {
 	FindAll(filter DonationReceiptFilter) ([]*entity.DonationReceipt, int64, error)
 	FindByID(id string) (*entity.DonationReceipt, error)
 	Create(receipt *entity.DonationReceipt) error
-	Update(receipt *entity.DonationReceipt) error
-	Delete(id string) error
+	Update(receipt *entity.DonationReceipt) error // draft only
+	Delete(id string) error                       // draft only
+	// Post mengubah draft menjadi posted dan mengisi nomor kwitansi
+	Post(receipt *entity.DonationReceipt) error
+	// Submit mengajukan draft untuk disetujui (pending_approval)
+	Submit(id, submittedByUserID string) error
+	// Approve menyetujui kwitansi pending_approval dan mempostingnya seperti Post
+	Approve(receipt *entity.DonationReceipt, approvedByUserID, comment string) error
+	// Reject mengembalikan kwitansi pending_approval ke draft
+	Reject(id, rejectedByUserID, comment string) error
+	// Void membatalkan kwitansi posted
+	Void(id, reason, voidedByUserID string) error
+	// Reverse membatalkan kwitansi posted dan menyimpan kwitansi pengganti secara atomik
+	Reverse(originalID, reason, voidedByUserID string, correction *entity.DonationReceipt) error
 }
@@ -0,0 +1,10 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type DuplicateDismissalRepository interface {
+	// FindByEntityType mengembalikan semua pasangan yang sudah dinyatakan bukan data ganda
+	FindByEntityType(entityType string) ([]*entity.DuplicateDismissal, error)
+	// Create menyimpan pasangan; RecordAID harus lebih kecil dari RecordBID
+	Create(dismissal *entity.DuplicateDismissal) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type FinancialAccountFilter struct {
+	AccountType string // cash, bank, digital
+	IsActive    *bool  // pointer to allow nil/true/false
+	Query       string // search by name
+	Page        int
+	PerPage     int
+}
+
+// Create juga membuat akun aset buku besar untuk rekening tersebut;
+// Delete ditolak jika rekening sudah dipakai kwitansi, penyaluran atau jurnal
+type FinancialAccountRepository interface {
+	FindAll(filter FinancialAccountFilter) ([]*entity.FinancialAccount, int64, error)
+	FindByID(id string) (*entity.FinancialAccount, error)
+	Create(account *entity.FinancialAccount) error
+	Update(account *entity.FinancialAccount) error
+	Delete(id string) error
+}
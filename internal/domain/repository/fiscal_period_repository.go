@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+
+	"go-zakat-be/internal/domain/entity"
+)
+
+type FiscalPeriodFilter struct {
+	PeriodType string // month, year
+	Status     string // open, closed
+	Year       int
+	Page       int
+	PerPage    int
+}
+
+// FiscalPeriodOrderError dikembalikan saat periode ditutup sebelum periode sebelumnya tertutup,
+// atau dibuka kembali sebelum periode sesudahnya dibuka
+type FiscalPeriodOrderError struct {
+	Action string // close, reopen
+	Period string // periode yang harus ditutup atau dibuka lebih dulu, misalnya 2024-11
+}
+
+func (e *FiscalPeriodOrderError) Error() string {
+	if e.Action == entity.FiscalPeriodActionReopen {
+		return fmt.Sprintf("fiscal period %s is closed, reopen later periods first", e.Period)
+	}
+	return fmt.Sprintf("fiscal period %s is still open, close earlier periods first", e.Period)
+}
+
+// FiscalPeriodDraftError dikembalikan saat periode yang akan ditutup masih memiliki draft
+// atau pengajuan yang belum disetujui
+type FiscalPeriodDraftError struct {
+	DonationReceipts int
+	Distributions    int
+}
+
+func (e *FiscalPeriodDraftError) Error() string {
+	return fmt.Sprintf("period still has %d draft or pending donation receipts and %d draft or pending distributions, post, approve or delete them first",
+		e.DonationReceipts, e.Distributions)
+}
+
+type FiscalPeriodRepository interface {
+	FindAll(filter FiscalPeriodFilter) ([]*entity.FiscalPeriod, int64, error)
+	// FindByID memuat periode beserta snapshot saldo dan jejak auditnya
+	FindByID(id string) (*entity.FiscalPeriod, error)
+	// FindByStart mengembalikan nil tanpa error jika periode belum pernah ditutup
+	FindByStart(periodType, periodStart string) (*entity.FiscalPeriod, error)
+	// FindClosedByDate mengembalikan periode tertutup yang mencakup tanggal, atau periode tertutup
+	// sesudahnya jika tanggal mendahuluinya; nil jika tanggal masih terbuka
+	FindClosedByDate(date string) (*entity.FiscalPeriod, error)
+	// Close menutup periode (dibuat jika belum ada), menyimpan snapshot saldo buku besar
+	// dan mencatat jejak audit dalam satu transaksi. Ditolak jika masih ada draft di periode tersebut
+	// (*FiscalPeriodDraftError) atau periode sebelumnya masih terbuka (*FiscalPeriodOrderError).
+	Close(period *entity.FiscalPeriod, userID string) error
+	// Reopen ditolak jika periode sesudahnya masih tertutup (*FiscalPeriodOrderError)
+	Reopen(id, reason, userID string) error
+}
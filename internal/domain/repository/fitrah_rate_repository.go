@@ -0,0 +1,20 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type FitrahRateFilter struct {
+	HijriYear int
+	Region    string
+	Page      int
+	PerPage   int
+}
+
+type FitrahRateRepository interface {
+	FindAll(filter FitrahRateFilter) ([]*entity.FitrahRate, int64, error)
+	FindByID(id string) (*entity.FitrahRate, error)
+	// FindByYearAndRegion mengembalikan nil (tanpa error) jika tarif belum ditetapkan
+	FindByYearAndRegion(hijriYear int, region string) (*entity.FitrahRate, error)
+	Create(rate *entity.FitrahRate) error
+	Update(rate *entity.FitrahRate) error
+	Delete(id string) error
+}
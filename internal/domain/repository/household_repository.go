@@ -0,0 +1,20 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type HouseholdFilter struct {
+	Query   string   // Search by family card number, head name or address
+	IDs     []string // Filter by household IDs
+	Page    int
+	PerPage int
+}
+
+type HouseholdRepository interface {
+	FindAll(filter HouseholdFilter) ([]*entity.Household, int64, error)
+	FindByID(id string) (*entity.Household, error) // termasuk anggota
+	// Create dan Update menyimpan rumah tangga beserta seluruh anggotanya dalam satu transaksi;
+	// Update mengganti daftar anggota
+	Create(household *entity.Household) error
+	Update(household *entity.Household) error
+	Delete(id string) error
+}
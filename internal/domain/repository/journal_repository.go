@@ -0,0 +1,20 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type JournalEntryFilter struct {
+	DateFrom   string // YYYY-MM-DD
+	DateTo     string // YYYY-MM-DD
+	SourceType string // donation_receipt, distribution
+	SourceID   string
+	Page       int
+	PerPage    int
+}
+
+// Jurnal hanya dibuat oleh repository kwitansi dan penyaluran (posting, void, revert),
+// sehingga repository ini hanya untuk membaca
+type JournalRepository interface {
+	FindAccounts() ([]*entity.LedgerAccount, error)
+	FindAllEntries(filter JournalEntryFilter) ([]*entity.JournalEntry, int64, error)
+	FindEntryByID(id string) (*entity.JournalEntry, error)
+}
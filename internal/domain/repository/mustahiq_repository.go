@@ -3,17 +3,34 @@ package repository
 import "go-zakat-be/internal/domain/entity"
 
 type MustahiqFilter struct {
-	Query   string // Search by name or address
-	Status  string // Filter by status: active, inactive, pending
-	AsnafID string // Filter by asnaf ID
-	Page    int
-	PerPage int
+	Query       string // Search by name or address
+	Status      string // Filter by status: active, inactive, pending
+	AsnafID     string // Filter by asnaf ID
+	Region      string // Filter by part of the address, e.g. kelurahan or kecamatan
+	HouseholdID string // Filter by household membership
+	Page        int
+	PerPage     int
 }
 
 type MustahiqRepository interface {
 	FindAll(filter MustahiqFilter) ([]*entity.Mustahiq, int64, error)
+	// FindByID juga menerima ID mustahiq yang sudah digabung dan mengembalikan mustahiq tujuannya
 	FindByID(id string) (*entity.Mustahiq, error)
-	Create(mustahiq *entity.Mustahiq) error
+	// Create menyimpan mustahiq beserta status awalnya di riwayat status
+	Create(mustahiq *entity.Mustahiq, createdByUserID string) error
+	// Update mengubah data mustahiq kecuali status
 	Update(mustahiq *entity.Mustahiq) error
 	Delete(id string) error
+	// ChangeStatus mengubah status dan mencatat riwayatnya dalam satu transaksi;
+	// gagal jika status mustahiq sudah bukan change.FromStatus
+	ChangeStatus(change *entity.MustahiqStatusChange) error
+	FindStatusHistory(mustahiqID string) ([]*entity.MustahiqStatusChange, error) // terbaru dulu
+	// FindStatusesOnDate mengembalikan status setiap mustahiq pada tanggal tersebut;
+	// mustahiq yang belum terdaftar pada tanggal itu tidak ada di map
+	FindStatusesOnDate(mustahiqIDs []string, date string) (map[string]string, error)
+	// Merge memindahkan item penyaluran, penilaian, riwayat status dan keanggotaan rumah tangga
+	// dari source ke target, menghapus source, menyimpan nilai akhir target dan mencatat merge
+	// dalam satu transaksi. Gagal jika salah satu mustahiq sudah berubah sejak dibaca.
+	Merge(merge *entity.MustahiqMerge, source, target *entity.Mustahiq) error
+	FindMerges(mustahiqID string) ([]*entity.MustahiqMerge, error) // sebagai sumber atau tujuan, terbaru dulu
 }
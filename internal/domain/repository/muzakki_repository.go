@@ -10,8 +10,14 @@ type MuzakkiFilter struct {
 
 type MuzakkiRepository interface {
 	FindAll(filter MuzakkiFilter) ([]*entity.Muzakki, int64, error)
+	// FindByID juga menerima ID muzakki yang sudah digabung dan mengembalikan muzakki tujuannya
 	FindByID(id string) (*entity.Muzakki, error)
 	Create(muzakki *entity.Muzakki) error
 	Update(muzakki *entity.Muzakki) error
 	Delete(id string) error
+	// Merge memindahkan kwitansi dan perhitungan zakat dari source ke target, menghapus source,
+	// menyimpan nilai akhir target dan mencatat merge dalam satu transaksi. Gagal jika salah
+	// satu muzakki sudah berubah sejak dibaca (UpdatedAt berbeda).
+	Merge(merge *entity.MuzakkiMerge, source, target *entity.Muzakki) error
+	FindMerges(muzakkiID string) ([]*entity.MuzakkiMerge, error) // sebagai sumber atau tujuan, terbaru dulu
 }
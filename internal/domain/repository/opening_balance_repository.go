@@ -0,0 +1,19 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type OpeningBalanceFilter struct {
+	FundLedger         string // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	FinancialAccountID string
+	Status             string // posted, voided
+	Page               int
+	PerPage            int
+}
+
+// Create langsung menjurnal saldo awal; Void membatalkannya dengan jurnal pembalik
+type OpeningBalanceRepository interface {
+	FindAll(filter OpeningBalanceFilter) ([]*entity.OpeningBalance, int64, error)
+	FindByID(id string) (*entity.OpeningBalance, error)
+	Create(openingBalance *entity.OpeningBalance) error
+	Void(id, reason, voidedByUserID string) error
+}
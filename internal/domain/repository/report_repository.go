@@ -7,12 +7,15 @@ type IncomeSummaryResult struct {
 	ZakatMaal   float64
 	Infaq       float64
 	Sadaqah     float64
+	Amil        float64 // hak amil dari seluruh jenis dana
 	Total       float64
+	CarriedOver float64 // saldo awal migrasi bertanggal efektif di periode ini, di luar Total
 }
 
 type DistributionSummaryByAsnafResult struct {
 	AsnafName        string
 	BeneficiaryCount int64
+	HouseholdCount   int64 // mustahiq tanpa rumah tangga dihitung sebagai satu rumah tangga
 	TotalAmount      float64
 }
 
@@ -20,14 +23,118 @@ type DistributionSummaryByProgramResult struct {
 	ProgramName      string
 	SourceFundType   string
 	BeneficiaryCount int64
+	HouseholdCount   int64 // mustahiq tanpa rumah tangga dihitung sebagai satu rumah tangga
 	TotalAmount      float64
 }
 
 type FundBalanceResult struct {
-	FundType string
-	TotalIn  float64
-	TotalOut float64
-	Balance  float64
+	FundType       string
+	OpeningBalance float64 // saldo sebelum dateFrom, dari snapshot periode tertutup terakhir
+	CarriedOver    float64 // saldo awal migrasi dari sistem lama dalam periode
+	TotalIn        float64
+	TotalOut       float64
+	Balance        float64 // CarriedOver + TotalIn - TotalOut dalam periode
+	ClosingBalance float64
+}
+
+// TrialBalanceRow adalah total debit dan kredit satu akun buku besar
+type TrialBalanceRow struct {
+	AccountCode string
+	AccountName string
+	AccountType string // asset, liability
+	Debit       float64
+	Credit      float64
+}
+
+type TrialBalanceResult struct {
+	DateTo      string // YYYY-MM-DD, kosong = semua jurnal
+	Accounts    []TrialBalanceRow
+	TotalDebit  float64
+	TotalCredit float64
+	Difference  float64 // TotalDebit - TotalCredit, selalu 0 jika jurnal seimbang
+}
+
+// AccountBalanceResult adalah saldo satu rekening (kas/bank/digital) dalam suatu periode
+type AccountBalanceResult struct {
+	FinancialAccountID string
+	Name               string
+	AccountType        string // cash, bank, digital
+	OpeningBalance     float64
+	CarriedOver        float64 // saldo awal migrasi dalam periode
+	TotalIn            float64
+	TotalOut           float64
+	ClosingBalance     float64
+}
+
+// AccountMovementItem adalah satu mutasi rekening dari jurnal
+type AccountMovementItem struct {
+	EntryDate   string // YYYY-MM-DD
+	Description string
+	SourceType  string // donation_receipt, distribution, opening_balance
+	SourceID    string
+	In          float64
+	Out         float64
+	Balance     float64 // saldo berjalan setelah mutasi ini
+}
+
+type AccountMovementResult struct {
+	FinancialAccountID string
+	Name               string
+	AccountType        string
+	DateFrom           string
+	DateTo             string
+	OpeningBalance     float64
+	Movements          []AccountMovementItem
+	CarriedOver        float64
+	TotalIn            float64
+	TotalOut           float64
+	ClosingBalance     float64
+}
+
+// UnreconciledLine adalah baris mutasi yang belum dikonfirmasi, atau yang
+// transaksi pasangannya sudah dibatalkan setelah dikonfirmasi
+type UnreconciledLine struct {
+	LineID             string
+	StatementID        string
+	FinancialAccountID string
+	AccountName        string
+	TransactionDate    string
+	Description        string
+	Reference          string
+	Amount             float64
+	Issue              string // unmatched, matched, flagged, source_voided
+	FlagReason         string
+}
+
+// UnreconciledTransaction adalah kwitansi (Amount positif) atau penyaluran (negatif)
+// dalam periode mutasi yang sudah diimpor tetapi belum terkonfirmasi di mutasi
+type UnreconciledTransaction struct {
+	SourceType         string // donation_receipt, distribution
+	SourceID           string
+	Number             string
+	Date               string
+	FinancialAccountID string
+	AccountName        string
+	Label              string
+	Amount             float64
+}
+
+type UnreconciledResult struct {
+	Lines             []UnreconciledLine
+	Transactions      []UnreconciledTransaction
+	TotalLines        float64
+	TotalTransactions float64
+}
+
+// StockOnHandResult adalah stok barang satu komoditas di satu sub-ledger dana per tanggal
+type StockOnHandResult struct {
+	Commodity  string
+	Unit       string
+	FundLedger string
+	TotalIn    float64 // dari kwitansi
+	TotalOut   float64 // ke penyaluran
+	Adjustment float64 // penyesuaian manual (bersih)
+	OnHand     float64
 }
 
 type MustahiqHistoryItem struct {
@@ -46,9 +153,69 @@ type MustahiqHistoryResult struct {
 	TotalReceived float64
 }
 
+type HouseholdHistoryItem struct {
+	DistributionDate string
+	MustahiqID       string
+	MustahiqName     string
+	ProgramName      string
+	SourceFundType   string
+	Amount           float64
+}
+
+// HouseholdMemberAid adalah total bantuan yang diterima satu anggota rumah tangga
+type HouseholdMemberAid struct {
+	MustahiqID    string
+	Name          string
+	Relationship  string
+	TotalReceived float64
+}
+
+type HouseholdHistoryResult struct {
+	HouseholdID      string
+	FamilyCardNumber string
+	HeadName         string
+	Address          string
+	MemberCount      int64
+	Members          []HouseholdMemberAid // hanya anggota yang terdaftar sebagai mustahiq
+	History          []HouseholdHistoryItem
+	TotalReceived    float64
+}
+
+// UndeliveredItem adalah item penyaluran posted yang vouchernya belum ditukar
+type UndeliveredItem struct {
+	DistributionID   string
+	DistributionDate string
+	ProgramName      string
+	SourceFundType   string
+	ItemID           string
+	VoucherCode      string
+	MustahiqID       string
+	MustahiqName     string
+	Address          string
+	PhoneNumber      string
+	Amount           float64
+	Commodity        *string
+	Quantity         *float64
+	DaysOutstanding  int // hari sejak tanggal penyaluran
+}
+
+type UndeliveredItemsResult struct {
+	Items             []UndeliveredItem
+	ItemCount         int
+	DistributionCount int
+	TotalAmount       float64
+}
+
 type ReportRepository interface {
 	GetIncomeSummary(dateFrom, dateTo, groupBy string) ([]IncomeSummaryResult, error)
 	GetDistributionSummary(dateFrom, dateTo, groupBy, sourceFundType string) (interface{}, error)
 	GetFundBalance(dateFrom, dateTo string) ([]FundBalanceResult, error)
+	GetTrialBalance(dateTo string) ([]TrialBalanceRow, error)
+	GetAccountBalance(dateFrom, dateTo string) ([]AccountBalanceResult, error)
+	GetAccountMovements(financialAccountID, dateFrom, dateTo string) (*AccountMovementResult, error)
+	GetUnreconciled(financialAccountID, dateFrom, dateTo string) (*UnreconciledResult, error)
+	GetStockOnHand(date string) ([]StockOnHandResult, error)
 	GetMustahiqHistory(mustahiqID string) (*MustahiqHistoryResult, error)
+	GetHouseholdHistory(householdID string) (*HouseholdHistoryResult, error)
+	GetUndeliveredItems(dateFrom, dateTo, programID, sourceFundType string) (*UndeliveredItemsResult, error)
 }
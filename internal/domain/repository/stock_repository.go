@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"fmt"
+
+	"go-zakat-be/internal/domain/entity"
+)
+
+type StockMovementFilter struct {
+	Commodity    string // gold, silver, rice
+	FundLedger   string // amil, zakat_fitrah, zakat_maal, infaq, sadaqah
+	MovementType string // receipt, distribution, adjustment
+	DateFrom     string // YYYY-MM-DD
+	DateTo       string // YYYY-MM-DD
+	Page         int
+	PerPage      int
+}
+
+// InsufficientStockError dikembalikan saat barang yang keluar melebihi stok yang ada
+type InsufficientStockError struct {
+	Commodity string
+	FundType  string
+	OnHand    float64
+	Requested float64
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient %s stock in %s: on hand %.2f, requested %.2f", e.Commodity, e.FundType, e.OnHand, e.Requested)
+}
+
+// Mutasi kwitansi dan penyaluran dicatat oleh repository masing-masing;
+// repository ini hanya membaca kartu stok dan mencatat penyesuaian manual
+type StockRepository interface {
+	FindAllMovements(filter StockMovementFilter) ([]*entity.StockMovement, int64, error)
+	FindMovementByID(id string) (*entity.StockMovement, error)
+	// CreateAdjustment menolak penyesuaian negatif yang melebihi stok (*InsufficientStockError)
+	CreateAdjustment(movement *entity.StockMovement) error
+}
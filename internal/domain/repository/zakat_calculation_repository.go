@@ -0,0 +1,17 @@
+package repository
+
+import "go-zakat-be/internal/domain/entity"
+
+type ZakatCalculationFilter struct {
+	MuzakkiID string
+	DateFrom  string // YYYY-MM-DD
+	DateTo    string // YYYY-MM-DD
+	Page      int
+	PerPage   int
+}
+
+type ZakatCalculationRepository interface {
+	FindAll(filter ZakatCalculationFilter) ([]*entity.ZakatCalculation, int64, error)
+	FindByID(id string) (*entity.ZakatCalculation, error)
+	Create(calculation *entity.ZakatCalculation) error
+}
@@ -0,0 +1,13 @@
+package service
+
+import "go-zakat-be/internal/domain/entity"
+
+type ReceiptRenderer interface {
+	// RenderReceipt menghasilkan kwitansi PDF untuk penerimaan dana (beserta item, muzakki dan petugas)
+	RenderReceipt(receipt *entity.DonationReceipt) ([]byte, error)
+}
+
+type VoucherRenderer interface {
+	// RenderVouchers menghasilkan lembar voucher PDF (dengan QR) untuk item penyaluran yang dipilih
+	RenderVouchers(distribution *entity.Distribution, items []*entity.DistributionItem) ([]byte, error)
+}
@@ -0,0 +1,10 @@
+package service
+
+type FileStorage interface {
+	// Save menyimpan data dengan key berupa path relatif, mis. "deliveries/<id>/<item>-photo.jpg"
+	Save(key string, data []byte) error
+	// Open membaca file yang sudah disimpan
+	Open(key string) ([]byte, error)
+	// Delete menghapus file, tidak error jika file tidak ada
+	Delete(key string) error
+}
@@ -0,0 +1,416 @@
+package document
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/pkg/pdf"
+	"go-zakat-be/pkg/terbilang"
+)
+
+// ReceiptConfig menyimpan identitas lembaga yang dicetak pada kwitansi
+type ReceiptConfig struct {
+	OrgName        string // nama lembaga, dipakai jika letterhead kosong
+	OrgAddress     string
+	LetterheadPath string // gambar kop surat (JPEG/PNG), optional
+	SignaturePath  string // gambar tanda tangan (JPEG/PNG), optional
+	SignerName     string // nama penandatangan, default nama petugas penerima
+	SignerTitle    string // jabatan penandatangan
+}
+
+// ReceiptRenderer mengimplementasikan service.ReceiptRenderer dengan pkg/pdf
+type ReceiptRenderer struct {
+	cfg        ReceiptConfig
+	letterhead []byte
+	signature  []byte
+}
+
+// NewReceiptRenderer membaca gambar kop surat dan tanda tangan sekali saat startup
+func NewReceiptRenderer(cfg ReceiptConfig) (*ReceiptRenderer, error) {
+	r := &ReceiptRenderer{cfg: cfg}
+
+	var err error
+	if cfg.LetterheadPath != "" {
+		if r.letterhead, err = os.ReadFile(cfg.LetterheadPath); err != nil {
+			return nil, fmt.Errorf("read letterhead: %w", err)
+		}
+	}
+	if cfg.SignaturePath != "" {
+		if r.signature, err = os.ReadFile(cfg.SignaturePath); err != nil {
+			return nil, fmt.Errorf("read signature: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+const (
+	marginX      = 40.0
+	marginBottom = 60.0
+)
+
+var monthNames = []string{
+	"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+}
+
+var fundTypeLabels = map[string]string{
+	"zakat_fitrah": "Zakat Fitrah",
+	"zakat_maal":   "Zakat Maal",
+	"zakat":        "Zakat",
+	"infaq":        "Infaq",
+	"sadaqah":      "Sadaqah",
+}
+
+// receiptPage membantu menulis baris demi baris dan pindah halaman otomatis
+type receiptPage struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func (p *receiptPage) ensure(height float64) {
+	if p.y+height > p.doc.Size().Height-marginBottom {
+		p.page = p.doc.AddPage()
+		p.y = 50
+	}
+}
+
+func (r *ReceiptRenderer) RenderReceipt(receipt *entity.DonationReceipt) ([]byte, error) {
+	doc := pdf.New(pdf.A4)
+	width := doc.Size().Width
+	contentWidth := width - 2*marginX
+	p := &receiptPage{doc: doc, page: doc.AddPage(), y: 40}
+
+	// Kop surat
+	if err := r.drawLetterhead(p, contentWidth); err != nil {
+		return nil, err
+	}
+
+	// Judul
+	p.y += 30
+	p.page.SetFont(pdf.HelveticaBold, 15)
+	p.page.TextCenter(width/2, p.y, "KWITANSI PENERIMAAN ZAKAT, INFAQ & SADAQAH")
+	p.y += 16
+	p.page.SetFont(pdf.Helvetica, 10)
+	switch receipt.Status {
+	case entity.ReceiptStatusDraft:
+		p.page.TextCenter(width/2, p.y, "DRAFT - belum diterbitkan")
+	case entity.ReceiptStatusVoided:
+		p.page.TextCenter(width/2, p.y, "No. "+receipt.ReceiptNumber)
+		p.y += 14
+		p.page.SetFont(pdf.HelveticaBold, 11)
+		p.page.TextCenter(width/2, p.y, "DIBATALKAN")
+	default:
+		p.page.TextCenter(width/2, p.y, "No. "+receipt.ReceiptNumber)
+	}
+	p.y += 28
+
+	// Identitas muzakki & transaksi
+	muzakkiName, muzakkiAddress, muzakkiPhone := "", "", ""
+	if receipt.Muzakki != nil {
+		muzakkiName = receipt.Muzakki.Name
+		muzakkiAddress = receipt.Muzakki.Address
+		muzakkiPhone = receipt.Muzakki.PhoneNumber
+	}
+	staffName := ""
+	if receipt.CreatedByUser != nil {
+		staffName = receipt.CreatedByUser.Name
+	}
+
+	labelX, valueX := marginX, marginX+130
+	row := func(label, value string, font pdf.Font) {
+		lines := wrapText(font, 10, value, contentWidth-130)
+		p.ensure(float64(len(lines)) * 14)
+		p.page.SetFont(pdf.Helvetica, 10)
+		p.page.Text(labelX, p.y, label)
+		p.page.Text(valueX-10, p.y, ":")
+		p.page.SetFont(font, 10)
+		for _, line := range lines {
+			p.page.Text(valueX, p.y, line)
+			p.y += 14
+		}
+	}
+
+	row("Telah terima dari", muzakkiName, pdf.HelveticaBold)
+	if muzakkiAddress != "" {
+		row("Alamat", muzakkiAddress, pdf.Helvetica)
+	}
+	if muzakkiPhone != "" {
+		row("No. Telepon", muzakkiPhone, pdf.Helvetica)
+	}
+	row("Tanggal", formatDate(receipt.ReceiptDate), pdf.Helvetica)
+	if receipt.FinancialAccount != nil {
+		row("Diterima melalui", receipt.FinancialAccount.Name, pdf.Helvetica)
+	}
+	row("Sejumlah", formatRupiah(receipt.TotalAmount), pdf.HelveticaBold)
+	row("Terbilang", capitalize(terbilang.Rupiah(receipt.TotalAmount)), pdf.HelveticaOblique)
+	p.y += 12
+
+	// Tabel item
+	r.drawItems(p, receipt, contentWidth)
+
+	// Rincian per jenis dana
+	r.drawFundBreakdown(p, receipt)
+
+	if receipt.Notes != "" {
+		p.y += 6
+		row("Catatan", receipt.Notes, pdf.Helvetica)
+	}
+	if receipt.Status == entity.ReceiptStatusVoided {
+		p.y += 6
+		row("Alasan Pembatalan", receipt.VoidReason, pdf.Helvetica)
+	}
+
+	// Tanda tangan
+	if err := r.drawSignature(p, receipt, muzakkiName, staffName, contentWidth); err != nil {
+		return nil, err
+	}
+
+	// Footer di halaman terakhir
+	p.page.SetFont(pdf.Helvetica, 7)
+	p.page.Text(marginX, doc.Size().Height-30, fmt.Sprintf("Diterbitkan oleh %s - dicetak %s",
+		staffName, time.Now().Format("02-01-2006 15:04")))
+
+	return doc.Bytes()
+}
+
+func (r *ReceiptRenderer) drawLetterhead(p *receiptPage, contentWidth float64) error {
+	if len(r.letterhead) > 0 {
+		img, err := p.doc.AddImage(r.letterhead)
+		if err != nil {
+			return fmt.Errorf("letterhead: %w", err)
+		}
+		w, h := fitImage(img, contentWidth, 110)
+		p.page.DrawImage(img, marginX+(contentWidth-w)/2, p.y, w, h)
+		p.y += h + 6
+	} else {
+		p.y += 14
+		p.page.SetFont(pdf.HelveticaBold, 16)
+		p.page.TextCenter(p.doc.Size().Width/2, p.y, r.cfg.OrgName)
+		p.page.SetFont(pdf.Helvetica, 9)
+		for _, line := range wrapText(pdf.Helvetica, 9, r.cfg.OrgAddress, contentWidth) {
+			p.y += 13
+			p.page.TextCenter(p.doc.Size().Width/2, p.y, line)
+		}
+		p.y += 8
+	}
+
+	p.page.Line(marginX, p.y, marginX+contentWidth, p.y, 1.2)
+	p.page.Line(marginX, p.y+2.5, marginX+contentWidth, p.y+2.5, 0.4)
+	p.y += 2.5
+
+	return nil
+}
+
+func (r *ReceiptRenderer) drawItems(p *receiptPage, receipt *entity.DonationReceipt, contentWidth float64) {
+	colNo := marginX + 6
+	colFund := marginX + 32
+	colDesc := marginX + 150
+	colAmount := marginX + contentWidth - 6
+	descWidth := colAmount - 110 - colDesc
+
+	header := func() {
+		p.ensure(22)
+		p.page.FillRect(marginX, p.y, contentWidth, 18, 0.88)
+		p.page.SetFont(pdf.HelveticaBold, 9)
+		p.page.Text(colNo, p.y+12.5, "No")
+		p.page.Text(colFund, p.y+12.5, "Jenis Dana")
+		p.page.Text(colDesc, p.y+12.5, "Keterangan")
+		p.page.TextRight(colAmount, p.y+12.5, "Jumlah")
+		p.y += 18
+	}
+	header()
+
+	for i, item := range receipt.Items {
+		lines := wrapText(pdf.Helvetica, 9, itemDescription(item), descWidth)
+		rowHeight := math.Max(float64(len(lines))*12+6, 18)
+
+		if p.y+rowHeight > p.doc.Size().Height-marginBottom {
+			p.ensure(rowHeight + 18)
+			header()
+		}
+
+		p.page.SetFont(pdf.Helvetica, 9)
+		p.page.Text(colNo, p.y+12.5, strconv.Itoa(i+1))
+		p.page.Text(colFund, p.y+12.5, fundTypeLabels[fundTypeKey(item)])
+		for j, line := range lines {
+			p.page.Text(colDesc, p.y+12.5+float64(j)*12, line)
+		}
+		p.page.TextRight(colAmount, p.y+12.5, formatRupiah(item.Amount))
+		p.y += rowHeight
+		p.page.Line(marginX, p.y, marginX+contentWidth, p.y, 0.3)
+	}
+
+	p.ensure(20)
+	p.page.SetFont(pdf.HelveticaBold, 10)
+	p.page.Text(colDesc, p.y+14, "Total")
+	p.page.TextRight(colAmount, p.y+14, formatRupiah(receipt.TotalAmount))
+	p.y += 20
+	p.page.Line(marginX, p.y, marginX+contentWidth, p.y, 0.8)
+	p.y += 18
+}
+
+func (r *ReceiptRenderer) drawFundBreakdown(p *receiptPage, receipt *entity.DonationReceipt) {
+	order := []string{"zakat_fitrah", "zakat_maal", "zakat", "infaq", "sadaqah"}
+	totals := make(map[string]float64)
+	for _, item := range receipt.Items {
+		totals[fundTypeKey(item)] += item.Amount
+	}
+
+	p.ensure(16)
+	p.page.SetFont(pdf.HelveticaBold, 10)
+	p.page.Text(marginX, p.y, "Rincian per Jenis Dana")
+	p.y += 15
+
+	for _, key := range order {
+		amount, ok := totals[key]
+		if !ok {
+			continue
+		}
+		p.ensure(13)
+		p.page.SetFont(pdf.Helvetica, 9)
+		p.page.Text(marginX+10, p.y, fundTypeLabels[key])
+		p.page.TextRight(marginX+260, p.y, formatRupiah(amount))
+		p.y += 13
+	}
+}
+
+func (r *ReceiptRenderer) drawSignature(p *receiptPage, receipt *entity.DonationReceipt, muzakkiName, staffName string, contentWidth float64) error {
+	p.ensure(130)
+	p.y += 24
+
+	leftX := marginX + 80
+	rightX := marginX + contentWidth - 110
+
+	p.page.SetFont(pdf.Helvetica, 10)
+	p.page.TextCenter(rightX, p.y, formatDate(receipt.ReceiptDate))
+	p.y += 14
+	p.page.TextCenter(leftX, p.y, "Muzakki")
+	signerTitle := r.cfg.SignerTitle
+	if signerTitle == "" {
+		signerTitle = "Petugas Penerima"
+	}
+	p.page.TextCenter(rightX, p.y, signerTitle)
+
+	signatureTop := p.y + 6
+	if len(r.signature) > 0 {
+		img, err := p.doc.AddImage(r.signature)
+		if err != nil {
+			return fmt.Errorf("signature: %w", err)
+		}
+		w, h := fitImage(img, 140, 60)
+		p.page.DrawImage(img, rightX-w/2, signatureTop, w, h)
+	}
+	p.y += 76
+
+	signerName := r.cfg.SignerName
+	if signerName == "" {
+		signerName = staffName
+	}
+
+	p.page.SetFont(pdf.HelveticaBold, 10)
+	p.page.TextCenter(leftX, p.y, "( "+muzakkiName+" )")
+	p.page.TextCenter(rightX, p.y, "( "+signerName+" )")
+	p.y += 14
+
+	return nil
+}
+
+// fundTypeKey mengubah fund_type + zakat_type menjadi zakat_fitrah / zakat_maal
+func fundTypeKey(item *entity.DonationReceiptItem) string {
+	if item.FundType == "zakat" && item.ZakatType != nil && *item.ZakatType != "" {
+		return "zakat_" + *item.ZakatType
+	}
+	return item.FundType
+}
+
+func itemDescription(item *entity.DonationReceiptItem) string {
+	var parts []string
+	if item.PersonCount != nil {
+		parts = append(parts, fmt.Sprintf("%d jiwa", *item.PersonCount))
+	}
+	if item.RiceKG != nil {
+		parts = append(parts, strings.TrimSuffix(strings.TrimRight(strconv.FormatFloat(*item.RiceKG, 'f', 2, 64), "0"), ".")+" kg beras")
+	}
+	if item.Notes != "" {
+		parts = append(parts, item.Notes)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fitImage menghitung ukuran gambar agar muat di kotak tanpa mengubah rasio
+func fitImage(img *pdf.Image, maxW, maxH float64) (float64, float64) {
+	w, h := float64(img.Width()), float64(img.Height())
+	scale := math.Min(maxW/w, maxH/h)
+	return w * scale, h * scale
+}
+
+// wrapText memecah teks menjadi beberapa baris sesuai lebar maksimum
+func wrapText(font pdf.Font, size float64, s string, maxWidth float64) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	current := words[0]
+	for _, word := range words[1:] {
+		candidate := current + " " + word
+		if pdf.TextWidth(font, size, candidate) > maxWidth {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current = candidate
+	}
+
+	return append(lines, current)
+}
+
+// formatRupiah memformat nominal, mis. 1250000 -> "Rp 1.250.000"
+func formatRupiah(amount float64) string {
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	digits := strconv.FormatInt(cents/100, 10)
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	if sen := cents % 100; sen > 0 {
+		fmt.Fprintf(&b, ",%02d", sen)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	return sign + "Rp " + b.String()
+}
+
+// formatDate mengubah YYYY-MM-DD menjadi "5 Maret 2025"
+func formatDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return fmt.Sprintf("%d %s %d", t.Day(), monthNames[t.Month()-1], t.Year())
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
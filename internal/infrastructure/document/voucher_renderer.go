@@ -0,0 +1,195 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/pkg/pdf"
+	"go-zakat-be/pkg/qrcode"
+	"go-zakat-be/pkg/voucher"
+)
+
+// Tata letak lembar voucher: 2 kolom x 4 baris kartu per halaman A4
+const (
+	voucherColumns  = 2
+	voucherRows     = 4
+	voucherMargin   = 30.0
+	voucherGap      = 10.0
+	voucherQRSize   = 110.0
+	voucherQuietPad = 4 // modul kosong di sekeliling QR
+)
+
+var commodityLabels = map[string]string{
+	entity.CommodityGold:   "Emas",
+	entity.CommoditySilver: "Perak",
+	entity.CommodityRice:   "Beras",
+}
+
+// RenderVouchers mengimplementasikan service.VoucherRenderer. Setiap item dicetak sebagai
+// kartu berisi QR kode voucher; item yang sudah diserahkan diberi cap "SUDAH DITERIMA".
+func (r *ReceiptRenderer) RenderVouchers(distribution *entity.Distribution, items []*entity.DistributionItem) ([]byte, error) {
+	doc := pdf.New(pdf.A4)
+	size := doc.Size()
+	cardW := (size.Width - 2*voucherMargin - float64(voucherColumns-1)*voucherGap) / voucherColumns
+	cardH := (size.Height - 2*voucherMargin - float64(voucherRows-1)*voucherGap) / voucherRows
+
+	var page *pdf.Page
+	for i, item := range items {
+		slot := i % (voucherColumns * voucherRows)
+		if slot == 0 {
+			page = doc.AddPage()
+		}
+		x := voucherMargin + float64(slot%voucherColumns)*(cardW+voucherGap)
+		y := voucherMargin + float64(slot/voucherColumns)*(cardH+voucherGap)
+
+		if err := r.drawVoucher(page, distribution, item, x, y, cardW, cardH); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(items) == 0 {
+		page = doc.AddPage()
+		page.SetFont(pdf.Helvetica, 10)
+		page.TextCenter(size.Width/2, 80, "Tidak ada voucher untuk dicetak")
+	}
+
+	return doc.Bytes()
+}
+
+func (r *ReceiptRenderer) drawVoucher(page *pdf.Page, distribution *entity.Distribution, item *entity.DistributionItem, x, y, w, h float64) error {
+	page.StrokeRect(x, y, w, h, 0.6)
+
+	// Judul kartu, dengan cap di kanan untuk voucher yang sudah ditukar
+	titleW := w - 16
+	if item.DeliveredAt != nil {
+		stampW, stampH := 96.0, 24.0
+		stampX := x + w - 8 - stampW
+		page.FillRect(stampX, y+6, stampW, stampH, 0.85)
+		page.StrokeRect(stampX, y+6, stampW, stampH, 0.8)
+		page.SetFont(pdf.HelveticaBold, 8.5)
+		page.TextCenter(stampX+stampW/2, y+16, "SUDAH DITERIMA")
+		page.SetFont(pdf.Helvetica, 7)
+		page.TextCenter(stampX+stampW/2, y+26, formatDate(item.DeliveredAt.Format("2006-01-02")))
+		titleW -= stampW + 6
+	}
+	page.SetFont(pdf.HelveticaBold, 8)
+	page.Text(x+8, y+14, truncateText(pdf.HelveticaBold, 8, r.cfg.OrgName, titleW))
+	page.SetFont(pdf.HelveticaBold, 10)
+	page.Text(x+8, y+27, "VOUCHER PENYALURAN")
+	page.Line(x+8, y+33, x+w-8, y+33, 0.4)
+
+	// QR kode voucher
+	qrY := y + 40
+	if err := drawQRCode(page, item.VoucherCode, x+8, qrY, voucherQRSize); err != nil {
+		return fmt.Errorf("voucher %s: %w", item.VoucherCode, err)
+	}
+	page.SetFont(pdf.HelveticaBold, 11)
+	page.TextCenter(x+8+voucherQRSize/2, qrY+voucherQRSize+14, voucher.Format(item.VoucherCode))
+
+	// Rincian penerima & bantuan
+	textX := x + voucherQRSize + 18
+	textW := x + w - 8 - textX
+	textY := qrY + 6
+	field := func(label, value string, font pdf.Font, maxLines int) {
+		page.SetFont(pdf.Helvetica, 6.5)
+		page.Text(textX, textY, label)
+		textY += 9
+		lines := wrapText(font, 8.5, value, textW)
+		if len(lines) > maxLines {
+			lines = lines[:maxLines]
+			lines[maxLines-1] = truncateText(font, 8.5, lines[maxLines-1]+"...", textW)
+		}
+		page.SetFont(font, 8.5)
+		for _, line := range lines {
+			page.Text(textX, textY, line)
+			textY += 10
+		}
+		textY += 3
+	}
+
+	mustahiqName, mustahiqAddress := "", ""
+	if item.Mustahiq != nil {
+		mustahiqName = item.Mustahiq.Name
+		mustahiqAddress = item.Mustahiq.Address
+	}
+	programName := "-"
+	if distribution.Program != nil {
+		programName = distribution.Program.Name
+	}
+	fund := fundTypeLabels[distribution.SourceFundType]
+	if fund == "" {
+		fund = capitalize(distribution.SourceFundType)
+	}
+
+	field("Penerima", mustahiqName, pdf.HelveticaBold, 2)
+	if mustahiqAddress != "" {
+		field("Alamat", mustahiqAddress, pdf.Helvetica, 2)
+	}
+	field("Bantuan", voucherAid(item), pdf.HelveticaBold, 1)
+	field("Tanggal", formatDate(distribution.DistributionDate), pdf.Helvetica, 1)
+	field("Program / Sumber Dana", programName+" / "+fund, pdf.Helvetica, 1)
+
+	page.SetFont(pdf.HelveticaOblique, 6.5)
+	page.Text(x+8, y+h-7, "Tunjukkan voucher ini kepada petugas saat menerima bantuan.")
+
+	return nil
+}
+
+// drawQRCode menggambar QR berukuran size x size termasuk quiet zone
+func drawQRCode(page *pdf.Page, text string, x, y, size float64) error {
+	code, err := qrcode.Encode(text, qrcode.M)
+	if err != nil {
+		return err
+	}
+
+	modules := code.Size()
+	module := size / float64(modules+2*voucherQuietPad)
+	originX := x + voucherQuietPad*module
+	originY := y + voucherQuietPad*module
+
+	// Draw runs of dark modules per row to keep the content stream small
+	for row := 0; row < modules; row++ {
+		for col := 0; col < modules; {
+			if !code.Dark(col, row) {
+				col++
+				continue
+			}
+			start := col
+			for col < modules && code.Dark(col, row) {
+				col++
+			}
+			page.FillRect(originX+float64(start)*module, originY+float64(row)*module,
+				float64(col-start)*module, module, 0)
+		}
+	}
+
+	return nil
+}
+
+// voucherAid menampilkan nominal atau jumlah komoditas item
+func voucherAid(item *entity.DistributionItem) string {
+	if item.Commodity == nil || item.Quantity == nil {
+		return formatRupiah(item.Amount)
+	}
+
+	label := commodityLabels[*item.Commodity]
+	if label == "" {
+		label = *item.Commodity
+	}
+	quantity := strings.TrimSuffix(strings.TrimRight(strconv.FormatFloat(*item.Quantity, 'f', 2, 64), "0"), ".")
+	return fmt.Sprintf("%s %s %s", label, quantity, entity.CommodityUnits[*item.Commodity])
+}
+
+// truncateText memotong teks agar muat dalam satu baris
+func truncateText(font pdf.Font, size float64, s string, maxWidth float64) string {
+	if pdf.TextWidth(font, size, s) <= maxWidth {
+		return s
+	}
+	runes := []rune(strings.TrimSuffix(s, "..."))
+	for len(runes) > 0 && pdf.TextWidth(font, size, string(runes)+"...") > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -23,7 +23,7 @@ type TokenService struct {
 // CustomClaims adalah payload tambahan dalam JWT kita
 type CustomClaims struct {
 	UserID string `json:"user_id"` // ID user yang terkait token
-	Role   string `json:"role"`    // Role user (admin, staf, viewer)
+	Role   string `json:"role"`    // Role user (admin, staf, viewer, approver)
 	jwt.RegisteredClaims
 }
 
@@ -0,0 +1,277 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type AidRuleRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewAidRuleRepository(db *pgxpool.Pool, log *logrus.Logger) *AidRuleRepository {
+	return &AidRuleRepository{db: db, log: log}
+}
+
+const aidRuleSelectSQL = `
+		SELECT r.id, r.name, r.scope, r.asnaf_id, a.name, r.program_id, p.name, COALESCE(r.source_fund_type, ''),
+		       r.max_amount, r.max_count, r.window_days, r.active, r.description, r.created_at, r.updated_at
+		FROM aid_rules r
+		LEFT JOIN asnaf a ON a.id = r.asnaf_id
+		LEFT JOIN programs p ON p.id = r.program_id
+	`
+
+func scanAidRule(row rowScanner) (*entity.AidRule, error) {
+	rule := &entity.AidRule{}
+	var asnafName, programName *string
+	err := row.Scan(
+		&rule.ID, &rule.Name, &rule.Scope, &rule.AsnafID, &asnafName, &rule.ProgramID, &programName, &rule.SourceFundType,
+		&rule.MaxAmount, &rule.MaxCount, &rule.WindowDays, &rule.Active, &rule.Description, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.AsnafID != nil && asnafName != nil {
+		rule.Asnaf = &entity.Asnaf{ID: *rule.AsnafID, Name: *asnafName}
+	}
+	if rule.ProgramID != nil && programName != nil {
+		rule.Program = &entity.Program{ID: *rule.ProgramID, Name: *programName}
+	}
+
+	return rule, nil
+}
+
+func (r *AidRuleRepository) FindAll(filter repository.AidRuleFilter) ([]*entity.AidRule, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := aidRuleSelectSQL
+	countQuery := `SELECT COUNT(*) FROM aid_rules r`
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by asnaf (rules for all asnaf are not included)
+	if filter.AsnafID != "" {
+		conditions = append(conditions, fmt.Sprintf("r.asnaf_id = $%d", argIdx))
+		args = append(args, filter.AsnafID)
+		argIdx++
+	}
+
+	// Filter by program
+	if filter.ProgramID != "" {
+		conditions = append(conditions, fmt.Sprintf("r.program_id = $%d", argIdx))
+		args = append(args, filter.ProgramID)
+		argIdx++
+	}
+
+	// Filter by source fund type
+	if filter.SourceFundType != "" {
+		conditions = append(conditions, fmt.Sprintf("r.source_fund_type = $%d", argIdx))
+		args = append(args, filter.SourceFundType)
+		argIdx++
+	}
+
+	// Filter by active status
+	if filter.Active != nil {
+		conditions = append(conditions, fmt.Sprintf("r.active = $%d", argIdx))
+		args = append(args, *filter.Active)
+		argIdx++
+	}
+
+	// Add WHERE clause if there are conditions
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count first
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY r.name, r.created_at"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var rules []*entity.AidRule
+	for rows.Next() {
+		rule, err := scanAidRule(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, total, nil
+}
+
+func (r *AidRuleRepository) FindByID(id string) (*entity.AidRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	rule, err := scanAidRule(r.db.QueryRow(ctx, aidRuleSelectSQL+` WHERE r.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("aid rule not found")
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *AidRuleRepository) Create(rule *entity.AidRule) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO aid_rules (
+			id, name, scope, asnaf_id, program_id, source_fund_type, max_amount, max_count, window_days,
+			active, description, created_at, updated_at
+		)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		rule.Name, rule.Scope, rule.AsnafID, rule.ProgramID, rule.SourceFundType, rule.MaxAmount, rule.MaxCount, rule.WindowDays,
+		rule.Active, rule.Description,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("asnaf or program not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *AidRuleRepository) Update(rule *entity.AidRule) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		UPDATE aid_rules
+		SET name = $1, scope = $2, asnaf_id = $3, program_id = $4, source_fund_type = NULLIF($5, ''),
+		    max_amount = $6, max_count = $7, window_days = $8, active = $9, description = $10, updated_at = NOW()
+		WHERE id = $11
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		rule.Name, rule.Scope, rule.AsnafID, rule.ProgramID, rule.SourceFundType,
+		rule.MaxAmount, rule.MaxCount, rule.WindowDays, rule.Active, rule.Description, rule.ID,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("aid rule not found")
+		}
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("asnaf or program not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *AidRuleRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	ct, err := r.db.Exec(ctx, `DELETE FROM aid_rules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("aid rule not found")
+	}
+
+	return nil
+}
+
+func (r *AidRuleRepository) GetUsages(filter repository.AidUsageFilter) (map[string][]*repository.AidUsage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Every receiver is expanded to its mustahiq: itself, or every member of the household.
+	// Drafts are not counted until they are posted or submitted for approval.
+	query := `
+		WITH receivers AS (
+			SELECT id as receiver_id, id as mustahiq_id FROM unnest($4::uuid[]) id
+			UNION ALL
+			SELECT household_id, mustahiq_id FROM household_members
+			WHERE household_id = ANY($5::uuid[]) AND mustahiq_id IS NOT NULL
+		)
+		SELECT r.receiver_id, d.distribution_date, COUNT(DISTINCT d.id), COALESCE(SUM(di.amount), 0)
+		FROM receivers r
+		INNER JOIN distribution_items di ON di.mustahiq_id = r.mustahiq_id
+		INNER JOIN distributions d ON d.id = di.distribution_id
+		WHERE d.status IN ('posted', 'pending_approval')
+		  AND d.distribution_date BETWEEN $1 AND $2
+		  AND d.id::text <> $3
+	`
+	args := []interface{}{filter.DateFrom, filter.DateTo, filter.ExcludeDistributionID, filter.MustahiqIDs, filter.HouseholdIDs}
+	argIdx := 6
+
+	if filter.ProgramID != nil {
+		query += fmt.Sprintf(" AND d.program_id = $%d", argIdx)
+		args = append(args, *filter.ProgramID)
+		argIdx++
+	}
+
+	if filter.SourceFundType != "" {
+		query += fmt.Sprintf(" AND d.source_fund_type = $%d", argIdx)
+		args = append(args, filter.SourceFundType)
+	}
+
+	query += " GROUP BY r.receiver_id, d.distribution_date ORDER BY d.distribution_date"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usages := make(map[string][]*repository.AidUsage)
+	for rows.Next() {
+		var receiverID string
+		var distributionDate time.Time
+		usage := &repository.AidUsage{}
+		if err := rows.Scan(&receiverID, &distributionDate, &usage.Count, &usage.Amount); err != nil {
+			return nil, err
+		}
+		usage.Date = distributionDate.Format("2006-01-02")
+		usages[receiverID] = append(usages[receiverID], usage)
+	}
+
+	return usages, rows.Err()
+}
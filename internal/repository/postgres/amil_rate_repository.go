@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type AmilRateRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewAmilRateRepository(db *pgxpool.Pool, log *logrus.Logger) *AmilRateRepository {
+	return &AmilRateRepository{db: db, log: log}
+}
+
+const amilRateColumns = `id, fund_type, effective_date, percentage, COALESCE(notes, ''), created_at, updated_at`
+
+func scanAmilRate(row rowScanner) (*entity.AmilRate, error) {
+	ar := &entity.AmilRate{}
+	var effectiveDate time.Time
+	err := row.Scan(&ar.ID, &ar.FundType, &effectiveDate, &ar.Percentage, &ar.Notes, &ar.CreatedAt, &ar.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	ar.EffectiveDate = effectiveDate.Format("2006-01-02")
+
+	return ar, nil
+}
+
+func (r *AmilRateRepository) FindAll(filter repository.AmilRateFilter) ([]*entity.AmilRate, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + amilRateColumns + ` FROM amil_rates`
+	countQuery := `SELECT COUNT(*) FROM amil_rates`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by fund_type
+	if filter.FundType != "" {
+		conditions = append(conditions, fmt.Sprintf("fund_type = $%d", argIdx))
+		args = append(args, filter.FundType)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY effective_date DESC, fund_type ASC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var rates []*entity.AmilRate
+	for rows.Next() {
+		ar, err := scanAmilRate(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		rates = append(rates, ar)
+	}
+
+	return rates, total, nil
+}
+
+func (r *AmilRateRepository) FindByID(id string) (*entity.AmilRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + amilRateColumns + ` FROM amil_rates WHERE id = $1 LIMIT 1`
+
+	return scanAmilRate(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *AmilRateRepository) FindEffective(fundType, date string) (*entity.AmilRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		SELECT ` + amilRateColumns + `
+		FROM amil_rates
+		WHERE fund_type = $1 AND effective_date <= $2
+		ORDER BY effective_date DESC
+		LIMIT 1
+	`
+
+	ar, err := scanAmilRate(r.db.QueryRow(ctx, query, fundType, date))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ar, nil
+}
+
+func (r *AmilRateRepository) Create(rate *entity.AmilRate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO amil_rates (id, fund_type, effective_date, percentage, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, rate.FundType, rate.EffectiveDate, rate.Percentage, rate.Notes).
+		Scan(&rate.ID, &rate.CreatedAt, &rate.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("amil rate for this fund type and effective date already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *AmilRateRepository) Update(rate *entity.AmilRate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		UPDATE amil_rates
+		SET fund_type = $1, effective_date = $2, percentage = $3, notes = $4, updated_at = NOW()
+		WHERE id = $5
+	`
+
+	ct, err := r.db.Exec(ctx, query, rate.FundType, rate.EffectiveDate, rate.Percentage, rate.Notes, rate.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("amil rate for this fund type and effective date already exists")
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("amil rate not found")
+	}
+
+	return nil
+}
+
+func (r *AmilRateRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `DELETE FROM amil_rates WHERE id = $1`
+
+	ct, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("amil rate not found")
+	}
+
+	return nil
+}
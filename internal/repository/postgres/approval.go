@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+
+	"go-zakat-be/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// approvalTable adalah tabel riwayat maker-checker dan kolom yang menunjuk ke transaksinya
+type approvalTable struct {
+	name         string
+	sourceColumn string
+}
+
+var (
+	receiptApprovals      = approvalTable{name: "donation_receipt_approvals", sourceColumn: "receipt_id"}
+	distributionApprovals = approvalTable{name: "distribution_approvals", sourceColumn: "distribution_id"}
+)
+
+// insertApproval mencatat pengajuan, persetujuan atau penolakan di dalam transaksi tx
+func insertApproval(ctx context.Context, tx pgx.Tx, table approvalTable, sourceID, action, userID, comment string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO `+table.name+` (id, `+table.sourceColumn+`, action, comment, user_id, created_at)
+		VALUES (gen_random_uuid(), $1, $2, NULLIF($3, ''), $4, NOW())
+	`, sourceID, action, comment, userID)
+	return err
+}
+
+// findApprovals mengambil riwayat maker-checker satu transaksi, urut dari yang paling lama
+func findApprovals(ctx context.Context, db *pgxpool.Pool, table approvalTable, sourceID string) ([]*entity.ApprovalEvent, error) {
+	rows, err := db.Query(ctx, `
+		SELECT a.id, a.action, COALESCE(a.comment, ''), a.user_id, u.name, a.created_at
+		FROM `+table.name+` a
+		INNER JOIN users u ON u.id = a.user_id
+		WHERE a.`+table.sourceColumn+` = $1
+		ORDER BY a.created_at
+	`, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entity.ApprovalEvent
+	for rows.Next() {
+		e := &entity.ApprovalEvent{User: &entity.User{}}
+		if err := rows.Scan(&e.ID, &e.Action, &e.Comment, &e.UserID, &e.User.Name, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.User.ID = e.UserID
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,390 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type AssessmentQuestionnaireRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewAssessmentQuestionnaireRepository(db *pgxpool.Pool, log *logrus.Logger) *AssessmentQuestionnaireRepository {
+	return &AssessmentQuestionnaireRepository{db: db, log: log}
+}
+
+const assessmentQuestionnaireSelectSQL = `
+	SELECT q.id, q.asnaf_id, a.name, q.name, q.pass_threshold, q.is_active, COALESCE(q.notes, ''),
+	       (SELECT COUNT(*) FROM mustahiq_assessments ma WHERE ma.questionnaire_id = q.id),
+	       q.created_at, q.updated_at
+	FROM assessment_questionnaires q
+	INNER JOIN asnaf a ON a.id = q.asnaf_id
+`
+
+func scanAssessmentQuestionnaire(row rowScanner) (*entity.AssessmentQuestionnaire, error) {
+	q := &entity.AssessmentQuestionnaire{Asnaf: &entity.Asnaf{}}
+	err := row.Scan(
+		&q.ID, &q.AsnafID, &q.Asnaf.Name, &q.Name, &q.PassThreshold, &q.IsActive, &q.Notes,
+		&q.AssessmentCount, &q.CreatedAt, &q.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	q.Asnaf.ID = q.AsnafID
+
+	return q, nil
+}
+
+func (r *AssessmentQuestionnaireRepository) FindAll(filter repository.AssessmentQuestionnaireFilter) ([]*entity.AssessmentQuestionnaire, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := assessmentQuestionnaireSelectSQL
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	if filter.AsnafID != "" {
+		conditions = append(conditions, fmt.Sprintf("q.asnaf_id = $%d", argIdx))
+		args = append(args, filter.AsnafID)
+		argIdx++
+	}
+	if filter.ActiveOnly {
+		conditions = append(conditions, "q.is_active")
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY a.name ASC, q.is_active DESC, q.created_at DESC"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questionnaires []*entity.AssessmentQuestionnaire
+	for rows.Next() {
+		q, err := scanAssessmentQuestionnaire(rows)
+		if err != nil {
+			return nil, err
+		}
+		questionnaires = append(questionnaires, q)
+	}
+
+	return questionnaires, nil
+}
+
+func (r *AssessmentQuestionnaireRepository) FindByID(id string) (*entity.AssessmentQuestionnaire, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	q, err := scanAssessmentQuestionnaire(r.db.QueryRow(ctx, assessmentQuestionnaireSelectSQL+` WHERE q.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("assessment questionnaire not found")
+		}
+		return nil, err
+	}
+
+	if err := r.loadQuestions(ctx, q); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (r *AssessmentQuestionnaireRepository) FindActiveByAsnaf(asnafID string) (*entity.AssessmentQuestionnaire, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	q, err := scanAssessmentQuestionnaire(r.db.QueryRow(ctx,
+		assessmentQuestionnaireSelectSQL+` WHERE q.asnaf_id = $1 AND q.is_active LIMIT 1`, asnafID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := r.loadQuestions(ctx, q); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// loadQuestions mengisi pertanyaan dan pilihan jawaban kuesioner sesuai urutan tampil
+func (r *AssessmentQuestionnaireRepository) loadQuestions(ctx context.Context, q *entity.AssessmentQuestionnaire) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, questionnaire_id, category, question, weight, sort_order
+		FROM assessment_questions
+		WHERE questionnaire_id = $1
+		ORDER BY sort_order, id
+	`, q.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	questionByID := make(map[string]*entity.AssessmentQuestion)
+	for rows.Next() {
+		question := &entity.AssessmentQuestion{}
+		err := rows.Scan(&question.ID, &question.QuestionnaireID, &question.Category, &question.Question, &question.Weight, &question.SortOrder)
+		if err != nil {
+			return err
+		}
+		q.Questions = append(q.Questions, question)
+		questionByID[question.ID] = question
+	}
+	rows.Close()
+
+	optionRows, err := r.db.Query(ctx, `
+		SELECT o.id, o.question_id, o.label, o.score, o.sort_order
+		FROM assessment_question_options o
+		INNER JOIN assessment_questions aq ON aq.id = o.question_id
+		WHERE aq.questionnaire_id = $1
+		ORDER BY o.sort_order, o.id
+	`, q.ID)
+	if err != nil {
+		return err
+	}
+	defer optionRows.Close()
+
+	for optionRows.Next() {
+		option := &entity.AssessmentOption{}
+		if err := optionRows.Scan(&option.ID, &option.QuestionID, &option.Label, &option.Score, &option.SortOrder); err != nil {
+			return err
+		}
+		if question, ok := questionByID[option.QuestionID]; ok {
+			question.Options = append(question.Options, option)
+		}
+	}
+
+	return nil
+}
+
+func (r *AssessmentQuestionnaireRepository) Create(questionnaire *entity.AssessmentQuestionnaire) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if questionnaire.IsActive {
+		if err := deactivateQuestionnaires(ctx, tx, questionnaire.AsnafID); err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO assessment_questionnaires (id, asnaf_id, name, pass_threshold, is_active, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, NULLIF($5, ''), NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`, questionnaire.AsnafID, questionnaire.Name, questionnaire.PassThreshold, questionnaire.IsActive, questionnaire.Notes,
+	).Scan(&questionnaire.ID, &questionnaire.CreatedAt, &questionnaire.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("asnaf not found")
+		}
+		return err
+	}
+
+	if err := insertAssessmentQuestions(ctx, tx, questionnaire); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Update mengganti seluruh isi kuesioner yang belum pernah dipakai penilaian
+func (r *AssessmentQuestionnaireRepository) Update(questionnaire *entity.AssessmentQuestionnaire) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUnusedQuestionnaire(ctx, tx, questionnaire.ID); err != nil {
+		return err
+	}
+
+	if questionnaire.IsActive {
+		if err := deactivateQuestionnaires(ctx, tx, questionnaire.AsnafID); err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE assessment_questionnaires
+		SET asnaf_id = $1, name = $2, pass_threshold = $3, is_active = $4, notes = NULLIF($5, ''), updated_at = NOW()
+		WHERE id = $6
+		RETURNING updated_at
+	`, questionnaire.AsnafID, questionnaire.Name, questionnaire.PassThreshold, questionnaire.IsActive, questionnaire.Notes, questionnaire.ID,
+	).Scan(&questionnaire.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("asnaf not found")
+		}
+		return err
+	}
+
+	// Replace questions (options are deleted by cascade)
+	if _, err := tx.Exec(ctx, "DELETE FROM assessment_questions WHERE questionnaire_id = $1", questionnaire.ID); err != nil {
+		return err
+	}
+
+	if err := insertAssessmentQuestions(ctx, tx, questionnaire); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// SetActive mengaktifkan atau menonaktifkan kuesioner, termasuk yang sudah dipakai penilaian
+func (r *AssessmentQuestionnaireRepository) SetActive(id string, active bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var asnafID string
+	err = tx.QueryRow(ctx, `SELECT asnaf_id FROM assessment_questionnaires WHERE id = $1 FOR UPDATE`, id).Scan(&asnafID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("assessment questionnaire not found")
+		}
+		return err
+	}
+
+	if active {
+		if err := deactivateQuestionnaires(ctx, tx, asnafID); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE assessment_questionnaires SET is_active = $1, updated_at = NOW() WHERE id = $2
+	`, active, id)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+func (r *AssessmentQuestionnaireRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUnusedQuestionnaire(ctx, tx, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM assessment_questionnaires WHERE id = $1", id); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// lockUnusedQuestionnaire mengunci kuesioner dan menolak perubahan jika sudah dipakai penilaian
+func lockUnusedQuestionnaire(ctx context.Context, tx pgx.Tx, id string) error {
+	var used bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM mustahiq_assessments WHERE questionnaire_id = q.id)
+		FROM assessment_questionnaires q
+		WHERE q.id = $1
+		FOR UPDATE
+	`, id).Scan(&used)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("assessment questionnaire not found")
+		}
+		return err
+	}
+
+	if used {
+		return errors.New("assessment questionnaire is already used by assessments, deactivate it and create a new one instead")
+	}
+
+	return nil
+}
+
+// deactivateQuestionnaires menonaktifkan kuesioner aktif asnaf sebelum kuesioner lain diaktifkan
+func deactivateQuestionnaires(ctx context.Context, tx pgx.Tx, asnafID string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE assessment_questionnaires SET is_active = FALSE, updated_at = NOW()
+		WHERE asnaf_id = $1 AND is_active
+	`, asnafID)
+	return err
+}
+
+func insertAssessmentQuestions(ctx context.Context, tx pgx.Tx, questionnaire *entity.AssessmentQuestionnaire) error {
+	questionQuery := `
+		INSERT INTO assessment_questions (id, questionnaire_id, category, question, weight, sort_order)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	optionQuery := `
+		INSERT INTO assessment_question_options (id, question_id, label, score, sort_order)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4)
+		RETURNING id
+	`
+
+	for _, question := range questionnaire.Questions {
+		err := tx.QueryRow(ctx, questionQuery,
+			questionnaire.ID, question.Category, question.Question, question.Weight, question.SortOrder,
+		).Scan(&question.ID)
+		if err != nil {
+			return err
+		}
+		question.QuestionnaireID = questionnaire.ID
+
+		for _, option := range question.Options {
+			err := tx.QueryRow(ctx, optionQuery, question.ID, option.Label, option.Score, option.SortOrder).Scan(&option.ID)
+			if err != nil {
+				return err
+			}
+			option.QuestionID = question.ID
+		}
+	}
+
+	return nil
+}
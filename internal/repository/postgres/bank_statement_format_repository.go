@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type BankStatementFormatRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewBankStatementFormatRepository(db *pgxpool.Pool, log *logrus.Logger) *BankStatementFormatRepository {
+	return &BankStatementFormatRepository{db: db, log: log}
+}
+
+const bankStatementFormatColumns = `id, name, delimiter, skip_rows, footer_rows, date_column, date_layout, description_column,
+		COALESCE(reference_column, ''), COALESCE(amount_column, ''), COALESCE(credit_column, ''), COALESCE(debit_column, ''),
+		decimal_separator, COALESCE(notes, ''), created_at, updated_at`
+
+func scanBankStatementFormat(row rowScanner) (*entity.BankStatementFormat, error) {
+	f := &entity.BankStatementFormat{}
+	err := row.Scan(
+		&f.ID, &f.Name, &f.Delimiter, &f.SkipRows, &f.FooterRows, &f.DateColumn, &f.DateLayout, &f.DescriptionColumn,
+		&f.ReferenceColumn, &f.AmountColumn, &f.CreditColumn, &f.DebitColumn,
+		&f.DecimalSeparator, &f.Notes, &f.CreatedAt, &f.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (r *BankStatementFormatRepository) FindAll() ([]*entity.BankStatementFormat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `SELECT `+bankStatementFormatColumns+` FROM bank_statement_formats ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var formats []*entity.BankStatementFormat
+	for rows.Next() {
+		f, err := scanBankStatementFormat(rows)
+		if err != nil {
+			return nil, err
+		}
+		formats = append(formats, f)
+	}
+
+	return formats, nil
+}
+
+func (r *BankStatementFormatRepository) FindByID(id string) (*entity.BankStatementFormat, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + bankStatementFormatColumns + ` FROM bank_statement_formats WHERE id = $1 LIMIT 1`
+
+	f, err := scanBankStatementFormat(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("bank statement format not found")
+		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (r *BankStatementFormatRepository) Create(format *entity.BankStatementFormat) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO bank_statement_formats (id, name, delimiter, skip_rows, footer_rows, date_column, date_layout, description_column,
+		                                    reference_column, amount_column, credit_column, debit_column, decimal_separator, notes,
+		                                    created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), NULLIF($11, ''), $12, $13,
+		        NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		format.Name, format.Delimiter, format.SkipRows, format.FooterRows, format.DateColumn, format.DateLayout, format.DescriptionColumn,
+		format.ReferenceColumn, format.AmountColumn, format.CreditColumn, format.DebitColumn, format.DecimalSeparator, format.Notes,
+	).Scan(&format.ID, &format.CreatedAt, &format.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("bank statement format name already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *BankStatementFormatRepository) Update(format *entity.BankStatementFormat) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		UPDATE bank_statement_formats
+		SET name = $1, delimiter = $2, skip_rows = $3, footer_rows = $4, date_column = $5, date_layout = $6, description_column = $7,
+		    reference_column = NULLIF($8, ''), amount_column = NULLIF($9, ''), credit_column = NULLIF($10, ''), debit_column = NULLIF($11, ''),
+		    decimal_separator = $12, notes = $13, updated_at = NOW()
+		WHERE id = $14
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		format.Name, format.Delimiter, format.SkipRows, format.FooterRows, format.DateColumn, format.DateLayout, format.DescriptionColumn,
+		format.ReferenceColumn, format.AmountColumn, format.CreditColumn, format.DebitColumn, format.DecimalSeparator, format.Notes,
+		format.ID,
+	).Scan(&format.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("bank statement format not found")
+		}
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("bank statement format name already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *BankStatementFormatRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	ct, err := r.db.Exec(ctx, `DELETE FROM bank_statement_formats WHERE id = $1`, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("bank statement format is used by imported statements")
+		}
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return errors.New("bank statement format not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,658 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type BankStatementRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewBankStatementRepository(db *pgxpool.Pool, log *logrus.Logger) *BankStatementRepository {
+	return &BankStatementRepository{db: db, log: log}
+}
+
+const bankStatementSelectSQL = `
+		SELECT s.id, s.financial_account_id, fa.name, s.format_id, f.name, s.file_name, s.date_from, s.date_to,
+		       s.line_count, s.skipped_count, s.total_in, s.total_out, s.imported_by_user_id, u.name, s.created_at
+		FROM bank_statements s
+		INNER JOIN financial_accounts fa ON fa.id = s.financial_account_id
+		INNER JOIN bank_statement_formats f ON f.id = s.format_id
+		INNER JOIN users u ON u.id = s.imported_by_user_id
+	`
+
+func scanBankStatement(row rowScanner) (*entity.BankStatement, error) {
+	s := &entity.BankStatement{
+		FinancialAccount: &entity.FinancialAccount{},
+		ImportedByUser:   &entity.User{},
+	}
+	var dateFrom, dateTo *time.Time
+	err := row.Scan(
+		&s.ID, &s.FinancialAccountID, &s.FinancialAccount.Name, &s.FormatID, &s.FormatName, &s.FileName, &dateFrom, &dateTo,
+		&s.LineCount, &s.SkippedCount, &s.TotalIn, &s.TotalOut, &s.ImportedByUserID, &s.ImportedByUser.Name, &s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.FinancialAccount.ID = s.FinancialAccountID
+	s.ImportedByUser.ID = s.ImportedByUserID
+	s.DateFrom = formatNullableDate(dateFrom)
+	s.DateTo = formatNullableDate(dateTo)
+
+	return s, nil
+}
+
+// formatNullableDate mengubah kolom DATE nullable menjadi YYYY-MM-DD
+func formatNullableDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
+
+func (r *BankStatementRepository) FindAll(filter repository.BankStatementFilter) ([]*entity.BankStatement, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := bankStatementSelectSQL
+	countQuery := `SELECT COUNT(*) FROM bank_statements s`
+
+	var args []interface{}
+	argIdx := 1
+
+	// Filter by financial_account_id
+	if filter.FinancialAccountID != "" {
+		whereClause := fmt.Sprintf(" WHERE s.financial_account_id = $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.FinancialAccountID)
+		argIdx++
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY s.created_at DESC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var statements []*entity.BankStatement
+	for rows.Next() {
+		s, err := scanBankStatement(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		statements = append(statements, s)
+	}
+
+	return statements, total, nil
+}
+
+func (r *BankStatementRepository) FindByID(id string) (*entity.BankStatement, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	s, err := scanBankStatement(r.db.QueryRow(ctx, bankStatementSelectSQL+` WHERE s.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("bank statement not found")
+		}
+		return nil, err
+	}
+
+	lines, _, err := r.findLines(ctx, repository.BankStatementLineFilter{StatementID: id})
+	if err != nil {
+		return nil, err
+	}
+	s.Lines = lines
+
+	return s, nil
+}
+
+// Create menyimpan mutasi dan barisnya dalam satu transaksi. Baris yang sama persis
+// (tanggal, nominal, keterangan, referensi) dengan baris mutasi lain pada rekening
+// yang sama dianggap sudah pernah diimpor dan dilewati.
+func (r *BankStatementRepository) Create(statement *entity.BankStatement) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize imports per account so overlapping files cannot insert the same line twice
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('bank_statements:' || $1::text))", statement.FinancialAccountID); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO bank_statements (id, financial_account_id, format_id, file_name, imported_by_user_id, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`, statement.FinancialAccountID, statement.FormatID, statement.FileName, statement.ImportedByUserID,
+	).Scan(&statement.ID, &statement.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("financial account, format or user not found")
+		}
+		return err
+	}
+
+	lineQuery := `
+		INSERT INTO bank_statement_lines (id, statement_id, line_number, transaction_date, description, reference, amount,
+		                                  status, created_at, updated_at)
+		SELECT gen_random_uuid(), $1, $2, $3::date, $4::text, $5::text, $6::numeric, $7, NOW(), NOW()
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM bank_statement_lines l
+			INNER JOIN bank_statements s ON s.id = l.statement_id
+			WHERE s.financial_account_id = $8 AND s.id <> $1
+			  AND l.transaction_date = $3::date AND l.amount = $6::numeric
+			  AND l.description = $4::text AND l.reference = $5::text
+		)
+		RETURNING id, created_at, updated_at
+	`
+
+	var inserted []*entity.BankStatementLine
+	for _, line := range statement.Lines {
+		line.StatementID = statement.ID
+		line.FinancialAccountID = statement.FinancialAccountID
+		line.Status = entity.StatementLineUnmatched
+
+		err := tx.QueryRow(ctx, lineQuery,
+			statement.ID, line.LineNumber, line.TransactionDate, line.Description, line.Reference, line.Amount,
+			line.Status, statement.FinancialAccountID,
+		).Scan(&line.ID, &line.CreatedAt, &line.UpdatedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				statement.SkippedCount++
+				continue
+			}
+			return err
+		}
+		inserted = append(inserted, line)
+	}
+
+	if len(inserted) == 0 {
+		return errors.New("all lines in this statement were already imported")
+	}
+	statement.Lines = inserted
+
+	var dateFrom, dateTo *time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE bank_statements s
+		SET date_from = agg.date_from, date_to = agg.date_to, line_count = agg.line_count, skipped_count = $2,
+		    total_in = agg.total_in, total_out = agg.total_out
+		FROM (
+			SELECT MIN(transaction_date) as date_from, MAX(transaction_date) as date_to, COUNT(*) as line_count,
+			       COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0) as total_in,
+			       COALESCE(-SUM(amount) FILTER (WHERE amount < 0), 0) as total_out
+			FROM bank_statement_lines
+			WHERE statement_id = $1
+		) agg
+		WHERE s.id = $1
+		RETURNING s.date_from, s.date_to, s.line_count, s.total_in, s.total_out
+	`, statement.ID, statement.SkippedCount).Scan(&dateFrom, &dateTo, &statement.LineCount, &statement.TotalIn, &statement.TotalOut)
+	if err != nil {
+		return err
+	}
+	statement.DateFrom = formatNullableDate(dateFrom)
+	statement.DateTo = formatNullableDate(dateTo)
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Delete menghapus mutasi yang belum punya baris terkonfirmasi (baris dan pasangannya ikut terhapus)
+func (r *BankStatementRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	ct, err := r.db.Exec(ctx, `
+		DELETE FROM bank_statements s
+		WHERE s.id = $1
+		  AND NOT EXISTS (SELECT 1 FROM bank_statement_lines l WHERE l.statement_id = s.id AND l.status = $2)
+	`, id, entity.StatementLineConfirmed)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		var exists bool
+		if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM bank_statements WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return errors.New("bank statement not found")
+		}
+		return errors.New("bank statement has confirmed lines and cannot be deleted")
+	}
+
+	return nil
+}
+
+func (r *BankStatementRepository) FindLines(filter repository.BankStatementLineFilter) ([]*entity.BankStatementLine, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	return r.findLines(ctx, filter)
+}
+
+func (r *BankStatementRepository) FindLineByID(id string) (*entity.BankStatementLine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := bankStatementLineSelectSQL + ` WHERE l.id = $1 LIMIT 1`
+
+	line, err := scanBankStatementLine(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("bank statement line not found")
+		}
+		return nil, err
+	}
+
+	if err := r.loadMatches(ctx, []*entity.BankStatementLine{line}); err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+const bankStatementLineSelectSQL = `
+		SELECT l.id, l.statement_id, s.financial_account_id, l.line_number, l.transaction_date, l.description, l.reference,
+		       l.amount, l.status, COALESCE(l.flag_reason, ''), l.reviewed_by_user_id, ru.name, l.reviewed_at,
+		       l.created_at, l.updated_at
+		FROM bank_statement_lines l
+		INNER JOIN bank_statements s ON s.id = l.statement_id
+		LEFT JOIN users ru ON ru.id = l.reviewed_by_user_id
+	`
+
+func scanBankStatementLine(row rowScanner) (*entity.BankStatementLine, error) {
+	line := &entity.BankStatementLine{}
+	var transactionDate time.Time
+	var reviewedByName *string
+	err := row.Scan(
+		&line.ID, &line.StatementID, &line.FinancialAccountID, &line.LineNumber, &transactionDate, &line.Description, &line.Reference,
+		&line.Amount, &line.Status, &line.FlagReason, &line.ReviewedByUserID, &reviewedByName, &line.ReviewedAt,
+		&line.CreatedAt, &line.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	line.TransactionDate = transactionDate.Format("2006-01-02")
+	if line.ReviewedByUserID != nil && reviewedByName != nil {
+		line.ReviewedByUser = &entity.User{ID: *line.ReviewedByUserID, Name: *reviewedByName}
+	}
+
+	return line, nil
+}
+
+func (r *BankStatementRepository) findLines(ctx context.Context, filter repository.BankStatementLineFilter) ([]*entity.BankStatementLine, int64, error) {
+	query := bankStatementLineSelectSQL
+	countQuery := `
+		SELECT COUNT(*)
+		FROM bank_statement_lines l
+		INNER JOIN bank_statements s ON s.id = l.statement_id
+	`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by statement_id
+	if filter.StatementID != "" {
+		conditions = append(conditions, fmt.Sprintf("l.statement_id = $%d", argIdx))
+		args = append(args, filter.StatementID)
+		argIdx++
+	}
+
+	// Filter by financial_account_id
+	if filter.FinancialAccountID != "" {
+		conditions = append(conditions, fmt.Sprintf("s.financial_account_id = $%d", argIdx))
+		args = append(args, filter.FinancialAccountID)
+		argIdx++
+	}
+
+	// Filter by status
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("l.status = $%d", argIdx))
+		args = append(args, filter.Status)
+		argIdx++
+	}
+
+	// Filter by date range
+	if filter.DateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("l.transaction_date >= $%d", argIdx))
+		args = append(args, filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, fmt.Sprintf("l.transaction_date <= $%d", argIdx))
+		args = append(args, filter.DateTo)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY l.transaction_date ASC, s.created_at ASC, l.line_number ASC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var lines []*entity.BankStatementLine
+	for rows.Next() {
+		line, err := scanBankStatementLine(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		lines = append(lines, line)
+	}
+	rows.Close()
+
+	if err := r.loadMatches(ctx, lines); err != nil {
+		return nil, 0, err
+	}
+
+	return lines, total, nil
+}
+
+// loadMatches mengisi pasangan kwitansi/penyaluran untuk baris-baris yang diberikan
+func (r *BankStatementRepository) loadMatches(ctx context.Context, lines []*entity.BankStatementLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	lineByID := make(map[string]*entity.BankStatementLine, len(lines))
+	ids := make([]string, len(lines))
+	for i, line := range lines {
+		lineByID[line.ID] = line
+		ids[i] = line.ID
+	}
+
+	query := `
+		SELECT m.id, m.line_id, m.source_type, m.source_id, m.amount,
+		       COALESCE(dr.receipt_number, ''), COALESCE(dr.receipt_date, d.distribution_date),
+		       COALESCE(mz.name, p.name, d.source_fund_type, '')
+		FROM bank_statement_matches m
+		LEFT JOIN donation_receipts dr ON m.source_type = 'donation_receipt' AND dr.id = m.source_id
+		LEFT JOIN muzakki mz ON mz.id = dr.muzakki_id
+		LEFT JOIN distributions d ON m.source_type = 'distribution' AND d.id = m.source_id
+		LEFT JOIN programs p ON p.id = d.program_id
+		WHERE m.line_id = ANY($1::uuid[])
+		ORDER BY m.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		match := &entity.BankStatementMatch{}
+		var sourceDate *time.Time
+		err := rows.Scan(&match.ID, &match.LineID, &match.SourceType, &match.SourceID, &match.Amount,
+			&match.SourceNumber, &sourceDate, &match.SourceLabel)
+		if err != nil {
+			return err
+		}
+		if sourceDate != nil {
+			match.SourceDate = sourceDate.Format("2006-01-02")
+		}
+		if line, ok := lineByID[match.LineID]; ok {
+			line.Matches = append(line.Matches, match)
+		}
+	}
+
+	return nil
+}
+
+func (r *BankStatementRepository) FindMatchCandidates(filter repository.MatchCandidateFilter) ([]*entity.MatchCandidate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var query string
+	switch filter.SourceType {
+	case entity.MatchSourceDonationReceipt:
+		query = `
+			SELECT dr.id, dr.financial_account_id, dr.status, COALESCE(dr.receipt_number, ''), dr.receipt_date, dr.total_amount, mz.name
+			FROM donation_receipts dr
+			INNER JOIN muzakki mz ON mz.id = dr.muzakki_id
+			WHERE dr.status = 'posted' AND dr.financial_account_id = $1
+			  AND dr.receipt_date BETWEEN $2 AND $3
+			  AND NOT EXISTS (
+			      SELECT 1 FROM bank_statement_matches m WHERE m.source_type = 'donation_receipt' AND m.source_id = dr.id
+			  )
+		`
+	case entity.MatchSourceDistribution:
+		query = `
+			SELECT d.id, d.financial_account_id, d.status, '', d.distribution_date, d.total_amount, COALESCE(p.name, d.source_fund_type)
+			FROM distributions d
+			LEFT JOIN programs p ON p.id = d.program_id
+			WHERE d.status = 'posted' AND d.financial_account_id = $1
+			  AND d.distribution_date BETWEEN $2 AND $3
+			  AND NOT EXISTS (
+			      SELECT 1 FROM bank_statement_matches m WHERE m.source_type = 'distribution' AND m.source_id = d.id
+			  )
+		`
+	default:
+		return nil, errors.New("invalid match source type")
+	}
+
+	// Alias tabel sumber: dr untuk kwitansi, d untuk penyaluran
+	amountColumn := "dr.total_amount"
+	dateColumn := "dr.receipt_date"
+	if filter.SourceType == entity.MatchSourceDistribution {
+		amountColumn = "d.total_amount"
+		dateColumn = "d.distribution_date"
+	}
+
+	args := []interface{}{filter.FinancialAccountID, filter.DateFrom, filter.DateTo}
+	argIdx := 4
+	if filter.ExactAmount > 0 {
+		query += fmt.Sprintf(" AND %s = $%d", amountColumn, argIdx)
+		args = append(args, filter.ExactAmount)
+		argIdx++
+	}
+	if filter.MaxAmount > 0 {
+		query += fmt.Sprintf(" AND %s <= $%d", amountColumn, argIdx)
+		args = append(args, filter.MaxAmount)
+	}
+	query += " ORDER BY " + dateColumn + " ASC"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*entity.MatchCandidate
+	for rows.Next() {
+		c, err := scanMatchCandidate(rows, filter.SourceType)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, nil
+}
+
+func scanMatchCandidate(row rowScanner, sourceType string) (*entity.MatchCandidate, error) {
+	c := &entity.MatchCandidate{SourceType: sourceType}
+	var date time.Time
+	if err := row.Scan(&c.SourceID, &c.FinancialAccountID, &c.Status, &c.Number, &date, &c.Amount, &c.Label); err != nil {
+		return nil, err
+	}
+	c.Date = date.Format("2006-01-02")
+
+	return c, nil
+}
+
+func (r *BankStatementRepository) FindMatchSource(sourceType, sourceID string) (*entity.MatchCandidate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var query string
+	switch sourceType {
+	case entity.MatchSourceDonationReceipt:
+		query = `
+			SELECT dr.id, dr.financial_account_id, dr.status, COALESCE(dr.receipt_number, ''), dr.receipt_date, dr.total_amount, mz.name
+			FROM donation_receipts dr
+			INNER JOIN muzakki mz ON mz.id = dr.muzakki_id
+			WHERE dr.id = $1
+		`
+	case entity.MatchSourceDistribution:
+		query = `
+			SELECT d.id, d.financial_account_id, d.status, '', d.distribution_date, d.total_amount, COALESCE(p.name, d.source_fund_type)
+			FROM distributions d
+			LEFT JOIN programs p ON p.id = d.program_id
+			WHERE d.id = $1
+		`
+	default:
+		return nil, errors.New("invalid match source type")
+	}
+
+	c, err := scanMatchCandidate(r.db.QueryRow(ctx, query, sourceID), sourceType)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s %s not found", sourceType, sourceID)
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// SetLineMatches mengganti seluruh pasangan baris dan mengubah statusnya.
+// Transaksi yang sudah dipasangkan ke baris lain ditolak oleh UNIQUE (source_type, source_id).
+func (r *BankStatementRepository) SetLineMatches(lineID, status string, matches []*entity.BankStatementMatch, reviewedByUserID *string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `
+		UPDATE bank_statement_lines
+		SET status = $1, flag_reason = NULL, reviewed_by_user_id = $2,
+		    reviewed_at = CASE WHEN $2::uuid IS NOT NULL THEN NOW() END, updated_at = NOW()
+		WHERE id = $3
+	`, status, reviewedByUserID, lineID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return errors.New("bank statement line not found")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM bank_statement_matches WHERE line_id = $1`, lineID); err != nil {
+		return err
+	}
+
+	for _, match := range matches {
+		match.LineID = lineID
+		err := tx.QueryRow(ctx, `
+			INSERT INTO bank_statement_matches (id, line_id, source_type, source_id, amount, created_at)
+			VALUES (gen_random_uuid(), $1, $2, $3, $4, NOW())
+			RETURNING id
+		`, lineID, match.SourceType, match.SourceID, match.Amount).Scan(&match.ID)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return fmt.Errorf("%s %s is already matched to another statement line", match.SourceType, match.SourceID)
+			}
+			return err
+		}
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// FlagLine menandai baris untuk ditelusuri dan melepas pasangannya
+func (r *BankStatementRepository) FlagLine(lineID, reason, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `
+		UPDATE bank_statement_lines
+		SET status = $1, flag_reason = $2, reviewed_by_user_id = $3, reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $4
+	`, entity.StatementLineFlagged, reason, userID, lineID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return errors.New("bank statement line not found")
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM bank_statement_matches WHERE line_id = $1`, lineID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type CommodityPriceRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewCommodityPriceRepository(db *pgxpool.Pool, log *logrus.Logger) *CommodityPriceRepository {
+	return &CommodityPriceRepository{db: db, log: log}
+}
+
+const commodityPriceColumns = `id, commodity, price_date, price, unit, COALESCE(source, ''), COALESCE(notes, ''), created_at, updated_at`
+
+func scanCommodityPrice(row rowScanner) (*entity.CommodityPrice, error) {
+	cp := &entity.CommodityPrice{}
+	var priceDate time.Time
+	err := row.Scan(&cp.ID, &cp.Commodity, &priceDate, &cp.Price, &cp.Unit, &cp.Source, &cp.Notes, &cp.CreatedAt, &cp.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	cp.PriceDate = priceDate.Format("2006-01-02")
+
+	return cp, nil
+}
+
+func (r *CommodityPriceRepository) FindAll(filter repository.CommodityPriceFilter) ([]*entity.CommodityPrice, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + commodityPriceColumns + ` FROM commodity_prices`
+	countQuery := `SELECT COUNT(*) FROM commodity_prices`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by commodity
+	if filter.Commodity != "" {
+		conditions = append(conditions, fmt.Sprintf("commodity = $%d", argIdx))
+		args = append(args, filter.Commodity)
+		argIdx++
+	}
+
+	// Filter by date range
+	if filter.DateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("price_date >= $%d", argIdx))
+		args = append(args, filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, fmt.Sprintf("price_date <= $%d", argIdx))
+		args = append(args, filter.DateTo)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY price_date DESC, commodity ASC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var prices []*entity.CommodityPrice
+	for rows.Next() {
+		cp, err := scanCommodityPrice(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, cp)
+	}
+
+	return prices, total, nil
+}
+
+func (r *CommodityPriceRepository) FindByID(id string) (*entity.CommodityPrice, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + commodityPriceColumns + ` FROM commodity_prices WHERE id = $1 LIMIT 1`
+
+	return scanCommodityPrice(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *CommodityPriceRepository) FindEffective(commodity, date string) (*entity.CommodityPrice, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		SELECT ` + commodityPriceColumns + `
+		FROM commodity_prices
+		WHERE commodity = $1 AND price_date <= $2
+		ORDER BY price_date DESC
+		LIMIT 1
+	`
+
+	cp, err := scanCommodityPrice(r.db.QueryRow(ctx, query, commodity, date))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+func (r *CommodityPriceRepository) Create(price *entity.CommodityPrice) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO commodity_prices (id, commodity, price_date, price, unit, source, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, price.Commodity, price.PriceDate, price.Price, price.Unit, price.Source, price.Notes).
+		Scan(&price.ID, &price.CreatedAt, &price.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("price for this commodity and date already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommodityPriceRepository) Update(price *entity.CommodityPrice) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		UPDATE commodity_prices
+		SET commodity = $1, price_date = $2, price = $3, unit = $4, source = $5, notes = $6, updated_at = NOW()
+		WHERE id = $7
+	`
+
+	ct, err := r.db.Exec(ctx, query, price.Commodity, price.PriceDate, price.Price, price.Unit, price.Source, price.Notes, price.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("price for this commodity and date already exists")
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("commodity price not found")
+	}
+
+	return nil
+}
+
+func (r *CommodityPriceRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `DELETE FROM commodity_prices WHERE id = $1`
+
+	ct, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("commodity price not found")
+	}
+
+	return nil
+}
+
+func (r *CommodityPriceRepository) UpsertMany(prices []*entity.CommodityPrice) error {
+	// Import bisa berisi ribuan baris, beri waktu lebih dari dbTimeout
+	ctx, cancel := context.WithTimeout(context.Background(), 6*dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO commodity_prices (id, commodity, price_date, price, unit, source, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (commodity, price_date) DO UPDATE
+		SET price = EXCLUDED.price, unit = EXCLUDED.unit, source = EXCLUDED.source,
+		    notes = EXCLUDED.notes, updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+
+	for _, price := range prices {
+		err = tx.QueryRow(ctx, query, price.Commodity, price.PriceDate, price.Price, price.Unit, price.Source, price.Notes).
+			Scan(&price.ID, &price.CreatedAt, &price.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
@@ -9,7 +9,9 @@ import (
 
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/pkg/voucher"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
@@ -30,11 +32,13 @@ func (r *DistributionRepository) FindAll(filter repository.DistributionFilter) (
 	// Base query with JOINs and beneficiary count subquery
 	query := `
 		SELECT d.id, d.distribution_date, d.program_id, COALESCE(p.name, '') as program_name,
-		       d.source_fund_type, d.total_amount, d.notes,
+		       d.source_fund_type, d.financial_account_id, fa.name, d.total_amount, d.notes, d.status,
 		       (SELECT COUNT(*) FROM distribution_items WHERE distribution_id = d.id) as beneficiary_count,
+		       (SELECT COUNT(*) FROM distribution_items WHERE distribution_id = d.id AND delivered_at IS NOT NULL) as delivered_count,
 		       d.created_at, d.updated_at
 		FROM distributions d
 		LEFT JOIN programs p ON d.program_id = p.id
+		INNER JOIN financial_accounts fa ON d.financial_account_id = fa.id
 	`
 
 	countQuery := `
@@ -66,6 +70,13 @@ func (r *DistributionRepository) FindAll(filter repository.DistributionFilter) (
 		argIdx++
 	}
 
+	// Filter by financial_account_id
+	if filter.FinancialAccountID != "" {
+		conditions = append(conditions, fmt.Sprintf("d.financial_account_id = $%d", argIdx))
+		args = append(args, filter.FinancialAccountID)
+		argIdx++
+	}
+
 	// Filter by program_id
 	if filter.ProgramID != "" {
 		conditions = append(conditions, fmt.Sprintf("d.program_id = $%d", argIdx))
@@ -73,6 +84,29 @@ func (r *DistributionRepository) FindAll(filter repository.DistributionFilter) (
 		argIdx++
 	}
 
+	// Filter by status
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("d.status = $%d", argIdx))
+		args = append(args, filter.Status)
+		argIdx++
+	}
+
+	// Filter by delivery status (posted distributions only)
+	if filter.DeliveryStatus != "" {
+		delivered := "EXISTS (SELECT 1 FROM distribution_items WHERE distribution_id = d.id AND delivered_at IS NOT NULL)"
+		undelivered := "EXISTS (SELECT 1 FROM distribution_items WHERE distribution_id = d.id AND delivered_at IS NULL)"
+		switch filter.DeliveryStatus {
+		case entity.DeliveryStatusUndelivered:
+			conditions = append(conditions, "d.status = 'posted' AND NOT "+delivered)
+		case entity.DeliveryStatusPartial:
+			conditions = append(conditions, "d.status = 'posted' AND "+delivered+" AND "+undelivered)
+		case entity.DeliveryStatusDelivered:
+			conditions = append(conditions, "d.status = 'posted' AND NOT "+undelivered)
+		default:
+			return nil, 0, errors.New("invalid delivery_status, must be undelivered, partial or delivered")
+		}
+	}
+
 	// Search in program name or notes
 	if filter.Query != "" {
 		search := fmt.Sprintf("%%%s%%", filter.Query)
@@ -112,21 +146,24 @@ func (r *DistributionRepository) FindAll(filter repository.DistributionFilter) (
 
 	var distributions []*entity.Distribution
 	for rows.Next() {
-		d := &entity.Distribution{}
+		d := &entity.Distribution{
+			FinancialAccount: &entity.FinancialAccount{},
+		}
 		var programName string
 		var beneficiaryCount int64
 		var distributionDate time.Time
 
 		err := rows.Scan(
 			&d.ID, &distributionDate, &d.ProgramID, &programName,
-			&d.SourceFundType, &d.TotalAmount, &d.Notes, &beneficiaryCount,
-			&d.CreatedAt, &d.UpdatedAt,
+			&d.SourceFundType, &d.FinancialAccountID, &d.FinancialAccount.Name, &d.TotalAmount, &d.Notes, &d.Status, &beneficiaryCount,
+			&d.DeliveredItemCount, &d.CreatedAt, &d.UpdatedAt,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 		// Convert time.Time to YYYY-MM-DD string
 		d.DistributionDate = distributionDate.Format("2006-01-02")
+		d.DeliveryStatus = deliveryStatus(d.Status, int(beneficiaryCount), d.DeliveredItemCount)
 
 		// Set program if exists
 		if d.ProgramID != nil && *d.ProgramID != "" {
@@ -149,31 +186,49 @@ func (r *DistributionRepository) FindByID(id string) (*entity.Distribution, erro
 	// Get distribution header with program and user info
 	query := `
 		SELECT d.id, d.distribution_date, d.program_id, p.id, p.name,
-		       d.source_fund_type, d.total_amount, d.notes, d.created_by_user_id,
-		       u.id, u.name, d.created_at, d.updated_at
+		       d.source_fund_type, d.financial_account_id, fa.id, fa.name, d.total_amount, d.notes, d.created_by_user_id,
+		       u.id, u.name, d.status, d.posted_at, COALESCE(d.void_reason, ''), d.voided_by_user_id, vu.name, d.voided_at,
+		       COALESCE(d.revert_reason, ''), d.reverted_by_user_id, ru.name, d.reverted_at,
+		       COALESCE(d.overdraft_justification, ''), d.overdraft_approved_by_user_id, d.overdraft_approved_at,
+		       COALESCE(d.aid_rule_justification, ''), d.aid_rule_override_by_user_id, d.aid_rule_override_at,
+		       d.created_at, d.updated_at
 		FROM distributions d
 		LEFT JOIN programs p ON d.program_id = p.id
 		INNER JOIN users u ON d.created_by_user_id = u.id
+		INNER JOIN financial_accounts fa ON d.financial_account_id = fa.id
+		LEFT JOIN users vu ON d.voided_by_user_id = vu.id
+		LEFT JOIN users ru ON d.reverted_by_user_id = ru.id
 		WHERE d.id = $1
 		LIMIT 1
 	`
 
 	d := &entity.Distribution{
-		CreatedByUser: &entity.User{},
+		FinancialAccount: &entity.FinancialAccount{},
+		CreatedByUser:    &entity.User{},
 	}
 
-	var programID, programName *string
+	var programID, programName, voidedByName, revertedByName *string
 	var distributionDate time.Time
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&d.ID, &distributionDate, &d.ProgramID, &programID, &programName,
-		&d.SourceFundType, &d.TotalAmount, &d.Notes, &d.CreatedByUserID,
-		&d.CreatedByUser.ID, &d.CreatedByUser.Name, &d.CreatedAt, &d.UpdatedAt,
+		&d.SourceFundType, &d.FinancialAccountID, &d.FinancialAccount.ID, &d.FinancialAccount.Name, &d.TotalAmount, &d.Notes, &d.CreatedByUserID,
+		&d.CreatedByUser.ID, &d.CreatedByUser.Name, &d.Status, &d.PostedAt, &d.VoidReason, &d.VoidedByUserID, &voidedByName, &d.VoidedAt,
+		&d.RevertReason, &d.RevertedByUserID, &revertedByName, &d.RevertedAt,
+		&d.OverdraftJustification, &d.OverdraftApprovedByUserID, &d.OverdraftApprovedAt,
+		&d.AidRuleJustification, &d.AidRuleOverrideByUserID, &d.AidRuleOverrideAt,
+		&d.CreatedAt, &d.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 	// Convert time.Time to YYYY-MM-DD string
 	d.DistributionDate = distributionDate.Format("2006-01-02")
+	if d.VoidedByUserID != nil && voidedByName != nil {
+		d.VoidedByUser = &entity.User{ID: *d.VoidedByUserID, Name: *voidedByName}
+	}
+	if d.RevertedByUserID != nil && revertedByName != nil {
+		d.RevertedByUser = &entity.User{ID: *d.RevertedByUserID, Name: *revertedByName}
+	}
 
 	// Set program if exists
 	if programID != nil && programName != nil {
@@ -186,10 +241,13 @@ func (r *DistributionRepository) FindByID(id string) (*entity.Distribution, erro
 	// Get items with mustahiq and asnaf info
 	itemsQuery := `
 		SELECT di.id, di.distribution_id, di.mustahiq_id, m.name, a.name, m.address,
-		       di.amount, di.notes, di.created_at, di.updated_at
+		       di.amount, di.commodity, di.quantity, di.notes, di.voucher_code,
+		       di.delivered_at, di.delivered_by_user_id, du.name, COALESCE(di.delivery_photo_path, ''),
+		       COALESCE(di.delivery_signature_path, ''), COALESCE(di.delivery_notes, ''), di.created_at, di.updated_at
 		FROM distribution_items di
 		INNER JOIN mustahiq m ON di.mustahiq_id = m.id
 		INNER JOIN asnaf a ON m.asnafID = a.id
+		LEFT JOIN users du ON di.delivered_by_user_id = du.id
 		WHERE di.distribution_id = $1
 		ORDER BY di.created_at ASC
 	`
@@ -207,21 +265,115 @@ func (r *DistributionRepository) FindByID(id string) (*entity.Distribution, erro
 				Asnaf: &entity.Asnaf{},
 			},
 		}
+		var deliveredByName *string
 		err := itemsRows.Scan(
 			&item.ID, &item.DistributionID, &item.MustahiqID,
 			&item.Mustahiq.Name, &item.Mustahiq.Asnaf.Name, &item.Mustahiq.Address,
-			&item.Amount, &item.Notes, &item.CreatedAt, &item.UpdatedAt,
+			&item.Amount, &item.Commodity, &item.Quantity, &item.Notes, &item.VoucherCode,
+			&item.DeliveredAt, &item.DeliveredByUserID, &deliveredByName, &item.DeliveryPhotoPath,
+			&item.DeliverySignaturePath, &item.DeliveryNotes, &item.CreatedAt, &item.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if item.DeliveredByUserID != nil && deliveredByName != nil {
+			item.DeliveredByUser = &entity.User{ID: *item.DeliveredByUserID, Name: *deliveredByName}
+		}
+		if item.DeliveredAt != nil {
+			d.DeliveredItemCount++
+		}
 		items = append(items, item)
 	}
 
 	d.Items = items
+	d.DeliveryStatus = deliveryStatus(d.Status, len(items), d.DeliveredItemCount)
+
+	// Get maker-checker history
+	d.Approvals, err = findApprovals(ctx, r.db, distributionApprovals, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get overridden aid rule violations
+	d.AidRuleViolations, err = r.findAidRuleOverrides(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return d, nil
 }
 
+func (r *DistributionRepository) findAidRuleOverrides(ctx context.Context, distributionID string) ([]*entity.AidRuleViolation, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT aid_rule_id, aid_rule_name, mustahiq_id, household_id, limit_type, allowed, used, total, window_start, window_end
+		FROM distribution_aid_rule_overrides
+		WHERE distribution_id = $1
+		ORDER BY aid_rule_name, mustahiq_id, limit_type
+	`, distributionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var violations []*entity.AidRuleViolation
+	for rows.Next() {
+		v := &entity.AidRuleViolation{}
+		var windowStart, windowEnd time.Time
+		err := rows.Scan(
+			&v.AidRuleID, &v.AidRuleName, &v.MustahiqID, &v.HouseholdID, &v.LimitType, &v.Allowed, &v.Used, &v.Total,
+			&windowStart, &windowEnd,
+		)
+		if err != nil {
+			return nil, err
+		}
+		v.WindowStart = windowStart.Format("2006-01-02")
+		v.WindowEnd = windowEnd.Format("2006-01-02")
+		violations = append(violations, v)
+	}
+
+	return violations, nil
+}
+
+// saveAidRuleOverride menyimpan justifikasi override aturan bantuan beserta pelanggarannya
+// di dalam transaksi tx, menggantikan override sebelumnya
+func saveAidRuleOverride(ctx context.Context, tx pgx.Tx, distribution *entity.Distribution) error {
+	err := tx.QueryRow(ctx, `
+		UPDATE distributions
+		SET aid_rule_justification = NULLIF($1, ''), aid_rule_override_by_user_id = $2,
+		    aid_rule_override_at = CASE WHEN $2::uuid IS NOT NULL THEN NOW() END
+		WHERE id = $3
+		RETURNING aid_rule_override_at
+	`, distribution.AidRuleJustification, distribution.AidRuleOverrideByUserID, distribution.ID).Scan(&distribution.AidRuleOverrideAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM distribution_aid_rule_overrides WHERE distribution_id = $1`, distribution.ID); err != nil {
+		return err
+	}
+
+	for _, v := range distribution.AidRuleViolations {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO distribution_aid_rule_overrides (
+				id, distribution_id, aid_rule_id, aid_rule_name, mustahiq_id, household_id, limit_type,
+				allowed, used, total, window_start, window_end
+			)
+			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`,
+			distribution.ID, v.AidRuleID, v.AidRuleName, v.MustahiqID, v.HouseholdID, v.LimitType,
+			v.Allowed, v.Used, v.Total, v.WindowStart, v.WindowEnd,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *DistributionRepository) Create(distribution *entity.Distribution) error {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
@@ -233,20 +385,34 @@ func (r *DistributionRepository) Create(distribution *entity.Distribution) error
 	}
 	defer tx.Rollback(ctx)
 
+	// Penyaluran posted langsung mengurangi saldo dana
+	if distribution.Status == entity.DistributionStatusPosted {
+		if err := r.checkFundBalance(ctx, tx, distribution); err != nil {
+			return err
+		}
+	}
+
 	// Insert distribution header
 	distributionQuery := `
-		INSERT INTO distributions (id, distribution_date, program_id, source_fund_type, total_amount, notes, created_by_user_id, created_at, updated_at)
-		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW(), NOW())
-		RETURNING id, created_at, updated_at
+		INSERT INTO distributions (id, distribution_date, program_id, source_fund_type, financial_account_id, total_amount, notes, status, posted_at,
+		                           overdraft_justification, overdraft_approved_by_user_id, overdraft_approved_at,
+		                           created_by_user_id, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $11, $4, $5, $6, CASE WHEN $7 THEN NOW() END,
+		        NULLIF($8, ''), $9, CASE WHEN $9::uuid IS NOT NULL THEN NOW() END,
+		        $10, NOW(), NOW())
+		RETURNING id, posted_at, overdraft_approved_at, created_at, updated_at
 	`
 
 	err = tx.QueryRow(ctx, distributionQuery,
 		distribution.DistributionDate, distribution.ProgramID, distribution.SourceFundType,
-		distribution.TotalAmount, distribution.Notes, distribution.CreatedByUserID,
-	).Scan(&distribution.ID, &distribution.CreatedAt, &distribution.UpdatedAt)
+		distribution.TotalAmount, distribution.Notes, distribution.Status,
+		distribution.Status == entity.DistributionStatusPosted,
+		distribution.OverdraftJustification, distribution.OverdraftApprovedByUserID, distribution.CreatedByUserID,
+		distribution.FinancialAccountID,
+	).Scan(&distribution.ID, &distribution.PostedAt, &distribution.OverdraftApprovedAt, &distribution.CreatedAt, &distribution.UpdatedAt)
 	if err != nil {
 		if strings.Contains(err.Error(), "foreign key") {
-			return errors.New("program or user not found")
+			return errors.New("program, financial account or user not found")
 		}
 		return err
 	}
@@ -254,22 +420,53 @@ func (r *DistributionRepository) Create(distribution *entity.Distribution) error
 	// Insert items
 	if len(distribution.Items) > 0 {
 		itemQuery := `
-			INSERT INTO distribution_items (id, distribution_id, mustahiq_id, amount, notes, created_at, updated_at)
-			VALUES (gen_random_uuid(), $1, $2, $3, $4, NOW(), NOW())
+			INSERT INTO distribution_items (id, distribution_id, mustahiq_id, amount, commodity, quantity, notes, voucher_code, created_at, updated_at)
+			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
 			RETURNING id, created_at, updated_at
 		`
 
+		// Send all items in one round trip; bulk distributions can have hundreds of them
+		batch := &pgx.Batch{}
 		for _, item := range distribution.Items {
-			err = tx.QueryRow(ctx, itemQuery,
-				distribution.ID, item.MustahiqID, item.Amount, item.Notes,
-			).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
-			if err != nil {
-				if strings.Contains(err.Error(), "foreign key") {
-					return errors.New("mustahiq not found")
-				}
+			if item.VoucherCode, err = voucher.Generate(); err != nil {
 				return err
 			}
-			item.DistributionID = distribution.ID
+			batch.Queue(itemQuery,
+				distribution.ID, item.MustahiqID, item.Amount, item.Commodity, item.Quantity, item.Notes, item.VoucherCode,
+			).QueryRow(func(row pgx.Row) error {
+				item.DistributionID = distribution.ID
+				return row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+			})
+		}
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			if strings.Contains(err.Error(), "foreign key") {
+				return errors.New("mustahiq not found")
+			}
+			return err
+		}
+	}
+
+	if err := saveAidRuleOverride(ctx, tx, distribution); err != nil {
+		return err
+	}
+
+	// Penyaluran di atas ambang batas langsung diajukan atas nama pembuatnya
+	if distribution.Status == entity.DistributionStatusPendingApproval {
+		err := insertApproval(ctx, tx, distributionApprovals, distribution.ID, entity.ApprovalActionSubmit, distribution.CreatedByUserID, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	if distribution.Status == entity.DistributionStatusPosted {
+		if err := checkDistributionStock(ctx, tx, distribution.ID, distribution.SourceFundType); err != nil {
+			return err
+		}
+		if err := journalDistribution(ctx, tx, distribution.ID); err != nil {
+			return err
+		}
+		if err := stockDistribution(ctx, tx, distribution.ID); err != nil {
+			return err
 		}
 	}
 
@@ -288,27 +485,27 @@ func (r *DistributionRepository) Update(distribution *entity.Distribution) error
 	}
 	defer tx.Rollback(ctx)
 
-	// Update distribution header
+	// Update distribution header (posted distributions must be reverted to draft first)
 	distributionQuery := `
 		UPDATE distributions
-		SET distribution_date = $1, program_id = $2, source_fund_type = $3,
-		    total_amount = $4, notes = $5, updated_at = NOW()
-		WHERE id = $6
+		SET distribution_date = $1, program_id = $2, source_fund_type = $3, financial_account_id = $4,
+		    total_amount = $5, notes = $6, updated_at = NOW()
+		WHERE id = $7 AND status = 'draft'
 	`
 
 	ct, err := tx.Exec(ctx, distributionQuery,
-		distribution.DistributionDate, distribution.ProgramID, distribution.SourceFundType,
+		distribution.DistributionDate, distribution.ProgramID, distribution.SourceFundType, distribution.FinancialAccountID,
 		distribution.TotalAmount, distribution.Notes, distribution.ID,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "foreign key") {
-			return errors.New("program not found")
+			return errors.New("program or financial account not found")
 		}
 		return err
 	}
 
 	if ct.RowsAffected() == 0 {
-		return errors.New("distribution not found")
+		return errors.New("draft distribution not found")
 	}
 
 	// Delete existing items
@@ -320,25 +517,336 @@ func (r *DistributionRepository) Update(distribution *entity.Distribution) error
 	// Insert new items
 	if len(distribution.Items) > 0 {
 		itemQuery := `
-			INSERT INTO distribution_items (id, distribution_id, mustahiq_id, amount, notes, created_at, updated_at)
-			VALUES (gen_random_uuid(), $1, $2, $3, $4, NOW(), NOW())
+			INSERT INTO distribution_items (id, distribution_id, mustahiq_id, amount, commodity, quantity, notes, voucher_code, created_at, updated_at)
+			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
 			RETURNING id, created_at, updated_at
 		`
 
+		// Send all items in one round trip; bulk distributions can have hundreds of them
+		batch := &pgx.Batch{}
 		for _, item := range distribution.Items {
-			err = tx.QueryRow(ctx, itemQuery,
-				distribution.ID, item.MustahiqID, item.Amount, item.Notes,
-			).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
-			if err != nil {
-				if strings.Contains(err.Error(), "foreign key") {
-					return errors.New("mustahiq not found")
-				}
+			if item.VoucherCode, err = voucher.Generate(); err != nil {
 				return err
 			}
-			item.DistributionID = distribution.ID
+			batch.Queue(itemQuery,
+				distribution.ID, item.MustahiqID, item.Amount, item.Commodity, item.Quantity, item.Notes, item.VoucherCode,
+			).QueryRow(func(row pgx.Row) error {
+				item.DistributionID = distribution.ID
+				return row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+			})
+		}
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			if strings.Contains(err.Error(), "foreign key") {
+				return errors.New("mustahiq not found")
+			}
+			return err
 		}
 	}
 
+	if err := saveAidRuleOverride(ctx, tx, distribution); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Post menandai draft sebagai posted (dana dianggap sudah disalurkan) setelah saldo dana dicek
+func (r *DistributionRepository) Post(distribution *entity.Distribution) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.postDistribution(ctx, tx, distribution, entity.DistributionStatusDraft); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// postDistribution memposting penyaluran berstatus fromStatus di dalam transaksi tx setelah
+// saldo dana dan stok natura dicek, lalu mencatat jurnal dan mutasi stoknya
+func (r *DistributionRepository) postDistribution(ctx context.Context, tx pgx.Tx, distribution *entity.Distribution, fromStatus string) error {
+	// Lock the distribution and read the amounts that will be posted
+	err := tx.QueryRow(ctx, `
+		SELECT source_fund_type, total_amount
+		FROM distributions
+		WHERE id = $1 AND status = $2
+		FOR UPDATE
+	`, distribution.ID, fromStatus).Scan(&distribution.SourceFundType, &distribution.TotalAmount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s distribution not found", fromStatus)
+		}
+		return err
+	}
+
+	if err := r.checkFundBalance(ctx, tx, distribution); err != nil {
+		return err
+	}
+
+	if err := checkDistributionStock(ctx, tx, distribution.ID, distribution.SourceFundType); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE distributions
+		SET status = 'posted', posted_at = NOW(),
+		    overdraft_justification = NULLIF($1, ''), overdraft_approved_by_user_id = $2,
+		    overdraft_approved_at = CASE WHEN $2::uuid IS NOT NULL THEN NOW() END,
+		    updated_at = NOW()
+		WHERE id = $3
+		RETURNING posted_at, overdraft_approved_at, updated_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		distribution.OverdraftJustification, distribution.OverdraftApprovedByUserID, distribution.ID,
+	).Scan(&distribution.PostedAt, &distribution.OverdraftApprovedAt, &distribution.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	distribution.Status = entity.DistributionStatusPosted
+
+	if err := saveAidRuleOverride(ctx, tx, distribution); err != nil {
+		return err
+	}
+
+	if err := journalDistribution(ctx, tx, distribution.ID); err != nil {
+		return err
+	}
+
+	return stockDistribution(ctx, tx, distribution.ID)
+}
+
+// Submit mengajukan draft untuk disetujui; saldo dana baru dicek saat disetujui
+func (r *DistributionRepository) Submit(distribution *entity.Distribution, submittedByUserID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE distributions
+		SET status = 'pending_approval',
+		    overdraft_justification = NULLIF($1, ''), overdraft_approved_by_user_id = $2,
+		    overdraft_approved_at = CASE WHEN $2::uuid IS NOT NULL THEN NOW() END,
+		    updated_at = NOW()
+		WHERE id = $3 AND status = 'draft'
+		RETURNING overdraft_approved_at, updated_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		distribution.OverdraftJustification, distribution.OverdraftApprovedByUserID, distribution.ID,
+	).Scan(&distribution.OverdraftApprovedAt, &distribution.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("draft distribution not found")
+		}
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	distribution.Status = entity.DistributionStatusPendingApproval
+
+	if err := saveAidRuleOverride(ctx, tx, distribution); err != nil {
+		return err
+	}
+
+	if err := insertApproval(ctx, tx, distributionApprovals, distribution.ID, entity.ApprovalActionSubmit, submittedByUserID, ""); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Approve memposting penyaluran pending_approval dan mencatat persetujuannya dalam satu transaksi
+func (r *DistributionRepository) Approve(distribution *entity.Distribution, approvedByUserID, comment string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.postDistribution(ctx, tx, distribution, entity.DistributionStatusPendingApproval); err != nil {
+		return err
+	}
+
+	if err := insertApproval(ctx, tx, distributionApprovals, distribution.ID, entity.ApprovalActionApprove, approvedByUserID, comment); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Reject mengembalikan penyaluran pending_approval ke draft supaya bisa diperbaiki pembuatnya
+func (r *DistributionRepository) Reject(id, rejectedByUserID, comment string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `
+		UPDATE distributions
+		SET status = 'draft', updated_at = NOW()
+		WHERE id = $1 AND status = 'pending_approval'
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("pending_approval distribution not found")
+	}
+
+	if err := insertApproval(ctx, tx, distributionApprovals, id, entity.ApprovalActionReject, rejectedByUserID, comment); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// checkFundBalance mengunci saldo jenis dana lalu menolak penyaluran yang melebihi saldo,
+// kecuali admin sudah memberi justifikasi overdraft
+func (r *DistributionRepository) checkFundBalance(ctx context.Context, tx pgx.Tx, distribution *entity.Distribution) error {
+	// In-kind only distributions do not use money from the fund
+	if distribution.TotalAmount <= 0 {
+		return nil
+	}
+
+	if err := lockFundBalance(ctx, tx, distribution.SourceFundType); err != nil {
+		return err
+	}
+
+	balance, err := fundBalance(ctx, tx, distribution.SourceFundType)
+	if err != nil {
+		return err
+	}
+
+	if distribution.TotalAmount > balance && distribution.OverdraftJustification == "" {
+		return &repository.InsufficientFundError{
+			FundType:  distribution.SourceFundType,
+			Balance:   balance,
+			Requested: distribution.TotalAmount,
+		}
+	}
+
+	return nil
+}
+
+// Void membatalkan penyaluran posted dengan alasan, user dan waktu pembatalan
+func (r *DistributionRepository) Void(id, reason, voidedByUserID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE distributions
+		SET status = 'voided', void_reason = $1, voided_by_user_id = $2, voided_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = 'posted'
+		  AND NOT EXISTS (SELECT 1 FROM distribution_items WHERE distribution_id = $3 AND delivered_at IS NOT NULL)
+	`
+
+	ct, err := tx.Exec(ctx, query, reason, voidedByUserID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("posted distribution without delivered items not found")
+	}
+
+	if err := reverseJournal(ctx, tx, entity.JournalSourceDistribution, id, "Pembatalan: "+reason); err != nil {
+		return err
+	}
+
+	if err := reverseStock(ctx, tx, entity.StockMovementDistribution, id, "Pembatalan: "+reason); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// RevertToDraft mengembalikan penyaluran posted ke draft agar bisa dikoreksi;
+// alasan, user dan waktu revert terakhir disimpan
+func (r *DistributionRepository) RevertToDraft(id, reason, revertedByUserID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE distributions
+		SET status = 'draft', posted_at = NULL, revert_reason = $1, reverted_by_user_id = $2,
+		    reverted_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = 'posted'
+		  AND NOT EXISTS (SELECT 1 FROM distribution_items WHERE distribution_id = $3 AND delivered_at IS NOT NULL)
+	`
+
+	ct, err := tx.Exec(ctx, query, reason, revertedByUserID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("posted distribution without delivered items not found")
+	}
+
+	if err := reverseJournal(ctx, tx, entity.JournalSourceDistribution, id, "Dikembalikan ke draft: "+reason); err != nil {
+		return err
+	}
+
+	if err := reverseStock(ctx, tx, entity.StockMovementDistribution, id, "Dikembalikan ke draft: "+reason); err != nil {
+		return err
+	}
+
 	// Commit transaction
 	return tx.Commit(ctx)
 }
@@ -347,7 +855,8 @@ func (r *DistributionRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	query := `DELETE FROM distributions WHERE id = $1`
+	// Only drafts can be deleted, posted distributions must be voided
+	query := `DELETE FROM distributions WHERE id = $1 AND status = 'draft'`
 
 	ct, err := r.db.Exec(ctx, query, id)
 	if err != nil {
@@ -355,8 +864,85 @@ func (r *DistributionRepository) Delete(id string) error {
 	}
 
 	if ct.RowsAffected() == 0 {
-		return errors.New("distribution not found")
+		return errors.New("draft distribution not found")
 	}
 
 	return nil
 }
+
+func (r *DistributionRepository) FindByVoucherCode(code string) (*entity.Distribution, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var distributionID string
+	err := r.db.QueryRow(ctx, `SELECT distribution_id FROM distribution_items WHERE voucher_code = $1`, code).Scan(&distributionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("voucher not found")
+		}
+		return nil, err
+	}
+
+	return r.FindByID(distributionID)
+}
+
+func (r *DistributionRepository) MarkDelivered(item *entity.DistributionItem) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the header so the distribution cannot be voided or reverted at the same time
+	var status string
+	err = tx.QueryRow(ctx, `SELECT status FROM distributions WHERE id = $1 FOR SHARE`, item.DistributionID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("distribution not found")
+		}
+		return err
+	}
+	if status != entity.DistributionStatusPosted {
+		return fmt.Errorf("vouchers of a %s distribution cannot be redeemed", status)
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE distribution_items
+		SET delivered_at = NOW(), delivered_by_user_id = $1, delivery_photo_path = NULLIF($2, ''),
+		    delivery_signature_path = NULLIF($3, ''), delivery_notes = NULLIF($4, ''), updated_at = NOW()
+		WHERE id = $5 AND delivered_at IS NULL
+		RETURNING delivered_at, updated_at
+	`,
+		item.DeliveredByUserID, item.DeliveryPhotoPath, item.DeliverySignaturePath, item.DeliveryNotes, item.ID,
+	).Scan(&item.DeliveredAt, &item.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("voucher has already been redeemed")
+		}
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// deliveryStatus menghitung status serah terima penyaluran posted dari jumlah item yang sudah diserahkan
+func deliveryStatus(status string, itemCount, deliveredCount int) string {
+	switch {
+	case status != entity.DistributionStatusPosted:
+		return ""
+	case deliveredCount == 0:
+		return entity.DeliveryStatusUndelivered
+	case deliveredCount < itemCount:
+		return entity.DeliveryStatusPartial
+	default:
+		return entity.DeliveryStatusDelivered
+	}
+}
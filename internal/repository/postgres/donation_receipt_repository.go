@@ -9,18 +9,58 @@ import (
 
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/pkg/receiptnumber"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
 type DonationReceiptRepository struct {
-	db  *pgxpool.Pool
-	log *logrus.Logger
+	db            *pgxpool.Pool
+	log           *logrus.Logger
+	numberPattern *receiptnumber.Pattern
 }
 
-func NewDonationReceiptRepository(db *pgxpool.Pool, log *logrus.Logger) *DonationReceiptRepository {
-	return &DonationReceiptRepository{db: db, log: log}
+func NewDonationReceiptRepository(db *pgxpool.Pool, log *logrus.Logger, numberPattern *receiptnumber.Pattern) *DonationReceiptRepository {
+	return &DonationReceiptRepository{db: db, log: log, numberPattern: numberPattern}
+}
+
+// nextReceiptNumber mengambil nomor urut berikutnya untuk periode tanggal kwitansi.
+// Baris counter terkunci sampai transaksi selesai, sehingga penyimpanan bersamaan
+// menunggu giliran dan rollback tidak meninggalkan celah nomor. Nomor yang sudah
+// dipakai kwitansi bernomor manual dilewati.
+func (r *DonationReceiptRepository) nextReceiptNumber(ctx context.Context, tx pgx.Tx, receiptDate string) (string, error) {
+	date, err := time.Parse("2006-01-02", receiptDate)
+	if err != nil {
+		return "", errors.New("receipt_date must be in YYYY-MM-DD format")
+	}
+
+	query := `
+		INSERT INTO receipt_number_sequences (sequence_key, last_value, updated_at)
+		VALUES ($1, 1, NOW())
+		ON CONFLICT (sequence_key) DO UPDATE
+		SET last_value = receipt_number_sequences.last_value + 1, updated_at = NOW()
+		RETURNING last_value
+	`
+
+	// Skip numbers already taken by a manually numbered receipt
+	for {
+		var seq int64
+		if err := tx.QueryRow(ctx, query, r.numberPattern.SequenceKey(date)).Scan(&seq); err != nil {
+			return "", err
+		}
+
+		number := r.numberPattern.Format(date, seq)
+		var taken bool
+		err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM donation_receipts WHERE receipt_number = $1)`, number).Scan(&taken)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return number, nil
+		}
+	}
 }
 
 func (r *DonationReceiptRepository) FindAll(filter repository.DonationReceiptFilter) ([]*entity.DonationReceipt, int64, error) {
@@ -29,10 +69,11 @@ func (r *DonationReceiptRepository) FindAll(filter repository.DonationReceiptFil
 
 	// Base query with JOINs
 	query := `
-		SELECT DISTINCT dr.id, dr.receipt_number, dr.receipt_date, dr.muzakki_id, m.name as muzakki_name,
-		       dr.payment_method, dr.total_amount, dr.notes, dr.created_by_user_id, dr.created_at, dr.updated_at
+		SELECT DISTINCT dr.id, COALESCE(dr.receipt_number, ''), dr.receipt_date, dr.muzakki_id, m.name as muzakki_name,
+		       dr.financial_account_id, fa.name, dr.total_amount, dr.notes, dr.status, dr.created_by_user_id, dr.created_at, dr.updated_at
 		FROM donation_receipts dr
 		INNER JOIN muzakki m ON dr.muzakki_id = m.id
+		INNER JOIN financial_accounts fa ON dr.financial_account_id = fa.id
 		LEFT JOIN donation_receipt_items dri ON dr.id = dri.receipt_id
 	`
 
@@ -73,10 +114,10 @@ func (r *DonationReceiptRepository) FindAll(filter repository.DonationReceiptFil
 		argIdx++
 	}
 
-	// Filter by payment_method
-	if filter.PaymentMethod != "" {
-		conditions = append(conditions, fmt.Sprintf("dr.payment_method = $%d", argIdx))
-		args = append(args, filter.PaymentMethod)
+	// Filter by financial_account_id
+	if filter.FinancialAccountID != "" {
+		conditions = append(conditions, fmt.Sprintf("dr.financial_account_id = $%d", argIdx))
+		args = append(args, filter.FinancialAccountID)
 		argIdx++
 	}
 
@@ -87,6 +128,13 @@ func (r *DonationReceiptRepository) FindAll(filter repository.DonationReceiptFil
 		argIdx++
 	}
 
+	// Filter by status
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("dr.status = $%d", argIdx))
+		args = append(args, filter.Status)
+		argIdx++
+	}
+
 	// Search in muzakki name or notes
 	if filter.Query != "" {
 		search := fmt.Sprintf("%%%s%%", filter.Query)
@@ -127,12 +175,13 @@ func (r *DonationReceiptRepository) FindAll(filter repository.DonationReceiptFil
 	var receipts []*entity.DonationReceipt
 	for rows.Next() {
 		dr := &entity.DonationReceipt{
-			Muzakki: &entity.Muzakki{},
+			Muzakki:          &entity.Muzakki{},
+			FinancialAccount: &entity.FinancialAccount{},
 		}
 		var receiptDate time.Time
 		err := rows.Scan(
 			&dr.ID, &dr.ReceiptNumber, &receiptDate, &dr.MuzakkiID, &dr.Muzakki.Name,
-			&dr.PaymentMethod, &dr.TotalAmount, &dr.Notes, &dr.CreatedByUserID, &dr.CreatedAt, &dr.UpdatedAt,
+			&dr.FinancialAccountID, &dr.FinancialAccount.Name, &dr.TotalAmount, &dr.Notes, &dr.Status, &dr.CreatedByUserID, &dr.CreatedAt, &dr.UpdatedAt,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -151,35 +200,45 @@ func (r *DonationReceiptRepository) FindByID(id string) (*entity.DonationReceipt
 
 	// Get receipt header with muzakki and user info
 	query := `
-		SELECT dr.id, dr.receipt_number, dr.receipt_date, dr.muzakki_id, m.id, m.name,
-		       dr.payment_method, dr.total_amount, dr.notes, dr.created_by_user_id,
-		       u.id, u.name, dr.created_at, dr.updated_at
+		SELECT dr.id, COALESCE(dr.receipt_number, ''), dr.receipt_date, dr.muzakki_id, m.id, m.name,
+		       COALESCE(m.phoneNumber, ''), COALESCE(m.address, ''), dr.financial_account_id, fa.id, fa.name, COALESCE(dr.fitrah_region, ''), dr.total_amount, dr.notes, dr.created_by_user_id,
+		       u.id, u.name, dr.status, dr.posted_at, COALESCE(dr.void_reason, ''), dr.voided_by_user_id, vu.name, dr.voided_at,
+		       dr.reversal_of_receipt_id, rv.id, dr.created_at, dr.updated_at
 		FROM donation_receipts dr
 		INNER JOIN muzakki m ON dr.muzakki_id = m.id
 		INNER JOIN users u ON dr.created_by_user_id = u.id
+		INNER JOIN financial_accounts fa ON dr.financial_account_id = fa.id
+		LEFT JOIN users vu ON dr.voided_by_user_id = vu.id
+		LEFT JOIN donation_receipts rv ON rv.reversal_of_receipt_id = dr.id
 		WHERE dr.id = $1
 		LIMIT 1
 	`
 
 	dr := &entity.DonationReceipt{
-		Muzakki:       &entity.Muzakki{},
-		CreatedByUser: &entity.User{},
+		Muzakki:          &entity.Muzakki{},
+		FinancialAccount: &entity.FinancialAccount{},
+		CreatedByUser:    &entity.User{},
 	}
 	var receiptDate time.Time
+	var voidedByName *string
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&dr.ID, &dr.ReceiptNumber, &receiptDate, &dr.MuzakkiID, &dr.Muzakki.ID, &dr.Muzakki.Name,
-		&dr.PaymentMethod, &dr.TotalAmount, &dr.Notes, &dr.CreatedByUserID,
-		&dr.CreatedByUser.ID, &dr.CreatedByUser.Name, &dr.CreatedAt, &dr.UpdatedAt,
+		&dr.Muzakki.PhoneNumber, &dr.Muzakki.Address, &dr.FinancialAccountID, &dr.FinancialAccount.ID, &dr.FinancialAccount.Name, &dr.FitrahRegion, &dr.TotalAmount, &dr.Notes, &dr.CreatedByUserID,
+		&dr.CreatedByUser.ID, &dr.CreatedByUser.Name, &dr.Status, &dr.PostedAt, &dr.VoidReason, &dr.VoidedByUserID, &voidedByName, &dr.VoidedAt,
+		&dr.ReversalOfReceiptID, &dr.ReversedByReceiptID, &dr.CreatedAt, &dr.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 	// Convert time.Time to YYYY-MM-DD string
 	dr.ReceiptDate = receiptDate.Format("2006-01-02")
+	if dr.VoidedByUserID != nil && voidedByName != nil {
+		dr.VoidedByUser = &entity.User{ID: *dr.VoidedByUserID, Name: *voidedByName}
+	}
 
 	// Get items
 	itemsQuery := `
-		SELECT id, receipt_id, fund_type, zakat_type, person_count, amount, rice_kg, notes, created_at, updated_at
+		SELECT id, receipt_id, fund_type, zakat_type, person_count, amount, rice_kg, zakat_calculation_id, notes, created_at, updated_at
 		FROM donation_receipt_items
 		WHERE receipt_id = $1
 		ORDER BY created_at ASC
@@ -196,15 +255,53 @@ func (r *DonationReceiptRepository) FindByID(id string) (*entity.DonationReceipt
 		item := &entity.DonationReceiptItem{}
 		err := itemsRows.Scan(
 			&item.ID, &item.ReceiptID, &item.FundType, &item.ZakatType, &item.PersonCount,
-			&item.Amount, &item.RiceKG, &item.Notes, &item.CreatedAt, &item.UpdatedAt,
+			&item.Amount, &item.RiceKG, &item.ZakatCalculationID, &item.Notes, &item.CreatedAt, &item.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 		items = append(items, item)
 	}
+	itemsRows.Close()
+
+	// Get sub-ledger allocations (only exist once posted)
+	allocationsQuery := `
+		SELECT a.receipt_item_id, a.ledger, a.percentage, a.amount
+		FROM donation_receipt_item_allocations a
+		INNER JOIN donation_receipt_items dri ON dri.id = a.receipt_item_id
+		WHERE dri.receipt_id = $1
+		ORDER BY a.ledger = 'amil', a.ledger
+	`
+
+	allocationRows, err := r.db.Query(ctx, allocationsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer allocationRows.Close()
+
+	itemByID := make(map[string]*entity.DonationReceiptItem, len(items))
+	for _, item := range items {
+		itemByID[item.ID] = item
+	}
+	for allocationRows.Next() {
+		var itemID string
+		allocation := &entity.DonationReceiptItemAllocation{}
+		if err := allocationRows.Scan(&itemID, &allocation.Ledger, &allocation.Percentage, &allocation.Amount); err != nil {
+			return nil, err
+		}
+		if item, ok := itemByID[itemID]; ok {
+			item.Allocations = append(item.Allocations, allocation)
+		}
+	}
 
 	dr.Items = items
+
+	// Get maker-checker history
+	dr.Approvals, err = findApprovals(ctx, r.db, receiptApprovals, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return dr, nil
 }
 
@@ -219,49 +316,126 @@ func (r *DonationReceiptRepository) Create(receipt *entity.DonationReceipt) erro
 	}
 	defer tx.Rollback(ctx)
 
+	if err := r.insertReceipt(ctx, tx, receipt); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// insertReceipt menyimpan header dan item kwitansi di dalam transaksi tx.
+// Kwitansi posted tanpa nomor mendapat nomor dari pola nomor kwitansi.
+func (r *DonationReceiptRepository) insertReceipt(ctx context.Context, tx pgx.Tx, receipt *entity.DonationReceipt) error {
+	var err error
+
+	// Allocate receipt number from the configured pattern if not given
+	if receipt.Status == entity.ReceiptStatusPosted && receipt.ReceiptNumber == "" {
+		receipt.ReceiptNumber, err = r.nextReceiptNumber(ctx, tx, receipt.ReceiptDate)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Insert receipt header
 	receiptQuery := `
-		INSERT INTO donation_receipts (id, muzakki_id, receipt_number, receipt_date, payment_method, total_amount, notes, created_by_user_id, created_at, updated_at)
-		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
-		RETURNING id, created_at, updated_at
+		INSERT INTO donation_receipts (id, muzakki_id, receipt_number, receipt_date, financial_account_id, fitrah_region, total_amount, notes,
+		                               status, posted_at, reversal_of_receipt_id, created_by_user_id, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), $6, $7,
+		        $8, CASE WHEN $9 THEN NOW() END, $10, $11, NOW(), NOW())
+		RETURNING id, posted_at, created_at, updated_at
 	`
 
 	err = tx.QueryRow(ctx, receiptQuery,
-		receipt.MuzakkiID, receipt.ReceiptNumber, receipt.ReceiptDate, receipt.PaymentMethod,
-		receipt.TotalAmount, receipt.Notes, receipt.CreatedByUserID,
-	).Scan(&receipt.ID, &receipt.CreatedAt, &receipt.UpdatedAt)
+		receipt.MuzakkiID, receipt.ReceiptNumber, receipt.ReceiptDate, receipt.FinancialAccountID,
+		receipt.FitrahRegion, receipt.TotalAmount, receipt.Notes,
+		receipt.Status, receipt.Status == entity.ReceiptStatusPosted, receipt.ReversalOfReceiptID, receipt.CreatedByUserID,
+	).Scan(&receipt.ID, &receipt.PostedAt, &receipt.CreatedAt, &receipt.UpdatedAt)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
 			return errors.New("receipt number already exists")
 		}
 		if strings.Contains(err.Error(), "foreign key") {
-			return errors.New("muzakki or user not found")
+			return errors.New("muzakki, financial account or user not found")
 		}
 		return err
 	}
 
-	// Insert items
-	if len(receipt.Items) > 0 {
-		itemQuery := `
-			INSERT INTO donation_receipt_items (id, receipt_id, fund_type, zakat_type, person_count, amount, rice_kg, notes, created_at, updated_at)
-			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
-			RETURNING id, created_at, updated_at
-		`
-
-		for _, item := range receipt.Items {
-			err = tx.QueryRow(ctx, itemQuery,
-				receipt.ID, item.FundType, item.ZakatType, item.PersonCount,
-				item.Amount, item.RiceKG, item.Notes,
-			).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
-			if err != nil {
-				return err
-			}
-			item.ReceiptID = receipt.ID
+	if err := r.insertItems(ctx, tx, receipt); err != nil {
+		return err
+	}
+
+	// Kwitansi di atas ambang batas langsung diajukan atas nama pembuatnya
+	if receipt.Status == entity.ReceiptStatusPendingApproval {
+		return insertApproval(ctx, tx, receiptApprovals, receipt.ID, entity.ApprovalActionSubmit, receipt.CreatedByUserID, "")
+	}
+
+	if receipt.Status == entity.ReceiptStatusPosted {
+		if err := r.allocateReceipt(ctx, tx, receipt.ID); err != nil {
+			return err
 		}
+		if err := journalReceipt(ctx, tx, receipt.ID); err != nil {
+			return err
+		}
+		return stockReceipt(ctx, tx, receipt.ID)
 	}
 
-	// Commit transaction
-	return tx.Commit(ctx)
+	return nil
+}
+
+// allocateReceipt membagi setiap item kwitansi ke sub-ledger: hak amil sesuai persentase
+// amil_rates yang berlaku pada tanggal kwitansi, sisanya ke ledger jenis dananya
+func (r *DonationReceiptRepository) allocateReceipt(ctx context.Context, tx pgx.Tx, receiptID string) error {
+	query := `
+		WITH shares AS (
+			SELECT dri.id, dri.amount, item_fund.ledger,
+			       COALESCE(ar.percentage, 0) as percentage,
+			       ROUND(dri.amount * COALESCE(ar.percentage, 0) / 100, 2) as amil_amount
+			FROM donation_receipt_items dri
+			INNER JOIN donation_receipts dr ON dr.id = dri.receipt_id
+			CROSS JOIN LATERAL (SELECT ` + receiptItemFundTypeSQL + ` as ledger) item_fund
+			LEFT JOIN LATERAL (
+				SELECT percentage
+				FROM amil_rates
+				WHERE fund_type = item_fund.ledger AND effective_date <= dr.receipt_date
+				ORDER BY effective_date DESC
+				LIMIT 1
+			) ar ON TRUE
+			WHERE dri.receipt_id = $1
+		)
+		INSERT INTO donation_receipt_item_allocations (id, receipt_item_id, ledger, percentage, amount, created_at)
+		SELECT gen_random_uuid(), id, ledger, percentage, amount - amil_amount, NOW() FROM shares
+		UNION ALL
+		SELECT gen_random_uuid(), id, 'amil', percentage, amil_amount, NOW() FROM shares WHERE amil_amount > 0
+	`
+
+	_, err := tx.Exec(ctx, query, receiptID)
+	return err
+}
+
+func (r *DonationReceiptRepository) insertItems(ctx context.Context, tx pgx.Tx, receipt *entity.DonationReceipt) error {
+	if len(receipt.Items) == 0 {
+		return nil
+	}
+
+	itemQuery := `
+		INSERT INTO donation_receipt_items (id, receipt_id, fund_type, zakat_type, person_count, amount, rice_kg, zakat_calculation_id, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	for _, item := range receipt.Items {
+		err := tx.QueryRow(ctx, itemQuery,
+			receipt.ID, item.FundType, item.ZakatType, item.PersonCount,
+			item.Amount, item.RiceKG, item.ZakatCalculationID, item.Notes,
+		).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		item.ReceiptID = receipt.ID
+	}
+
+	return nil
 }
 
 func (r *DonationReceiptRepository) Update(receipt *entity.DonationReceipt) error {
@@ -275,30 +449,30 @@ func (r *DonationReceiptRepository) Update(receipt *entity.DonationReceipt) erro
 	}
 	defer tx.Rollback(ctx)
 
-	// Update receipt header
+	// Update receipt header (only drafts can be edited)
 	receiptQuery := `
 		UPDATE donation_receipts
-		SET muzakki_id = $1, receipt_number = $2, receipt_date = $3, payment_method = $4,
-		    total_amount = $5, notes = $6, updated_at = NOW()
-		WHERE id = $7
+		SET muzakki_id = $1, receipt_number = NULLIF($2, ''), receipt_date = $3, financial_account_id = $4,
+		    fitrah_region = NULLIF($5, ''), total_amount = $6, notes = $7, updated_at = NOW()
+		WHERE id = $8 AND status = 'draft'
 	`
 
 	ct, err := tx.Exec(ctx, receiptQuery,
-		receipt.MuzakkiID, receipt.ReceiptNumber, receipt.ReceiptDate, receipt.PaymentMethod,
-		receipt.TotalAmount, receipt.Notes, receipt.ID,
+		receipt.MuzakkiID, receipt.ReceiptNumber, receipt.ReceiptDate, receipt.FinancialAccountID,
+		receipt.FitrahRegion, receipt.TotalAmount, receipt.Notes, receipt.ID,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
 			return errors.New("receipt number already exists")
 		}
 		if strings.Contains(err.Error(), "foreign key") {
-			return errors.New("muzakki not found")
+			return errors.New("muzakki or financial account not found")
 		}
 		return err
 	}
 
 	if ct.RowsAffected() == 0 {
-		return errors.New("donation receipt not found")
+		return errors.New("draft donation receipt not found")
 	}
 
 	// Delete existing items
@@ -308,34 +482,255 @@ func (r *DonationReceiptRepository) Update(receipt *entity.DonationReceipt) erro
 	}
 
 	// Insert new items
-	if len(receipt.Items) > 0 {
-		itemQuery := `
-			INSERT INTO donation_receipt_items (id, receipt_id, fund_type, zakat_type, person_count, amount, rice_kg, notes, created_at, updated_at)
-			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
-			RETURNING id, created_at, updated_at
-		`
-
-		for _, item := range receipt.Items {
-			err = tx.QueryRow(ctx, itemQuery,
-				receipt.ID, item.FundType, item.ZakatType, item.PersonCount,
-				item.Amount, item.RiceKG, item.Notes,
-			).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
-			if err != nil {
-				return err
-			}
-			item.ReceiptID = receipt.ID
+	if err := r.insertItems(ctx, tx, receipt); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Post mengubah draft menjadi posted dan memberi nomor kwitansi jika belum ada
+func (r *DonationReceiptRepository) Post(receipt *entity.DonationReceipt) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.postReceipt(ctx, tx, receipt, entity.ReceiptStatusDraft); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// postReceipt memposting kwitansi berstatus fromStatus di dalam transaksi tx: nomor kwitansi,
+// alokasi sub-ledger, jurnal dan stok natura
+func (r *DonationReceiptRepository) postReceipt(ctx context.Context, tx pgx.Tx, receipt *entity.DonationReceipt, fromStatus string) error {
+	// Lock the receipt so it cannot be posted twice
+	var receiptNumber string
+	var receiptDate time.Time
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(receipt_number, ''), receipt_date
+		FROM donation_receipts
+		WHERE id = $1 AND status = $2
+		FOR UPDATE
+	`, receipt.ID, fromStatus).Scan(&receiptNumber, &receiptDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s donation receipt not found", fromStatus)
+		}
+		return err
+	}
+
+	if receiptNumber == "" {
+		receiptNumber, err = r.nextReceiptNumber(ctx, tx, receiptDate.Format("2006-01-02"))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE donation_receipts
+		SET status = 'posted', receipt_number = $1, posted_at = NOW(), updated_at = NOW()
+		WHERE id = $2
+		RETURNING posted_at, updated_at
+	`, receiptNumber, receipt.ID).Scan(&receipt.PostedAt, &receipt.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("receipt number already exists")
 		}
+		return err
+	}
+
+	receipt.Status = entity.ReceiptStatusPosted
+	receipt.ReceiptNumber = receiptNumber
+
+	if err := r.allocateReceipt(ctx, tx, receipt.ID); err != nil {
+		return err
+	}
+
+	if err := journalReceipt(ctx, tx, receipt.ID); err != nil {
+		return err
+	}
+
+	return stockReceipt(ctx, tx, receipt.ID)
+}
+
+// Submit mengajukan draft untuk disetujui; kwitansi belum bernomor dan belum masuk laporan
+func (r *DonationReceiptRepository) Submit(id, submittedByUserID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `
+		UPDATE donation_receipts
+		SET status = 'pending_approval', updated_at = NOW()
+		WHERE id = $1 AND status = 'draft'
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("draft donation receipt not found")
+	}
+
+	if err := insertApproval(ctx, tx, receiptApprovals, id, entity.ApprovalActionSubmit, submittedByUserID, ""); err != nil {
+		return err
 	}
 
 	// Commit transaction
 	return tx.Commit(ctx)
 }
 
+// Approve memposting kwitansi pending_approval dan mencatat persetujuannya dalam satu transaksi
+func (r *DonationReceiptRepository) Approve(receipt *entity.DonationReceipt, approvedByUserID, comment string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.postReceipt(ctx, tx, receipt, entity.ReceiptStatusPendingApproval); err != nil {
+		return err
+	}
+
+	if err := insertApproval(ctx, tx, receiptApprovals, receipt.ID, entity.ApprovalActionApprove, approvedByUserID, comment); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Reject mengembalikan kwitansi pending_approval ke draft supaya bisa diperbaiki pembuatnya
+func (r *DonationReceiptRepository) Reject(id, rejectedByUserID, comment string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `
+		UPDATE donation_receipts
+		SET status = 'draft', updated_at = NOW()
+		WHERE id = $1 AND status = 'pending_approval'
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("pending_approval donation receipt not found")
+	}
+
+	if err := insertApproval(ctx, tx, receiptApprovals, id, entity.ApprovalActionReject, rejectedByUserID, comment); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Void membatalkan kwitansi posted dengan alasan, user dan waktu pembatalan
+func (r *DonationReceiptRepository) Void(id, reason, voidedByUserID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.voidReceipt(ctx, tx, id, reason, voidedByUserID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Reverse membatalkan kwitansi posted dan menyimpan kwitansi pengganti dalam satu transaksi
+func (r *DonationReceiptRepository) Reverse(originalID, reason, voidedByUserID string, correction *entity.DonationReceipt) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.voidReceipt(ctx, tx, originalID, reason, voidedByUserID); err != nil {
+		return err
+	}
+
+	correction.ReversalOfReceiptID = &originalID
+	if err := r.insertReceipt(ctx, tx, correction); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// voidReceipt membatalkan kwitansi posted dan membalik jurnalnya di dalam transaksi tx
+func (r *DonationReceiptRepository) voidReceipt(ctx context.Context, tx pgx.Tx, id, reason, voidedByUserID string) error {
+	query := `
+		UPDATE donation_receipts
+		SET status = 'voided', void_reason = $1, voided_by_user_id = $2, voided_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = 'posted'
+	`
+
+	ct, err := tx.Exec(ctx, query, reason, voidedByUserID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("posted donation receipt not found")
+	}
+
+	if err := reverseJournal(ctx, tx, entity.JournalSourceDonationReceipt, id, "Pembatalan: "+reason); err != nil {
+		return err
+	}
+
+	return reverseStock(ctx, tx, entity.StockMovementReceipt, id, "Pembatalan: "+reason)
+}
+
 func (r *DonationReceiptRepository) Delete(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	query := `DELETE FROM donation_receipts WHERE id = $1`
+	// Only drafts can be deleted, posted receipts must be voided
+	query := `DELETE FROM donation_receipts WHERE id = $1 AND status = 'draft'`
 
 	ct, err := r.db.Exec(ctx, query, id)
 	if err != nil {
@@ -343,7 +738,7 @@ func (r *DonationReceiptRepository) Delete(id string) error {
 	}
 
 	if ct.RowsAffected() == 0 {
-		return errors.New("donation receipt not found")
+		return errors.New("draft donation receipt not found")
 	}
 
 	return nil
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type DuplicateDismissalRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewDuplicateDismissalRepository(db *pgxpool.Pool, log *logrus.Logger) *DuplicateDismissalRepository {
+	return &DuplicateDismissalRepository{db: db, log: log}
+}
+
+func (r *DuplicateDismissalRepository) FindByEntityType(entityType string) ([]*entity.DuplicateDismissal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, entity_type, record_a_id, record_b_id, reason, COALESCE(dismissed_by_user_id::text, ''), created_at
+		FROM duplicate_dismissals
+		WHERE entity_type = $1
+	`, entityType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dismissals []*entity.DuplicateDismissal
+	for rows.Next() {
+		d := &entity.DuplicateDismissal{}
+		err := rows.Scan(&d.ID, &d.EntityType, &d.RecordAID, &d.RecordBID, &d.Reason, &d.DismissedByUserID, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		dismissals = append(dismissals, d)
+	}
+
+	return dismissals, nil
+}
+
+func (r *DuplicateDismissalRepository) Create(dismissal *entity.DuplicateDismissal) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO duplicate_dismissals (id, entity_type, record_a_id, record_b_id, reason, dismissed_by_user_id, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		dismissal.EntityType, dismissal.RecordAID, dismissal.RecordBID, dismissal.Reason, dismissal.DismissedByUserID,
+	).Scan(&dismissal.ID, &dismissal.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("pair has already been dismissed")
+		}
+		return err
+	}
+
+	return nil
+}
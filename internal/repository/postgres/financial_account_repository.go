@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type FinancialAccountRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewFinancialAccountRepository(db *pgxpool.Pool, log *logrus.Logger) *FinancialAccountRepository {
+	return &FinancialAccountRepository{db: db, log: log}
+}
+
+const financialAccountColumns = `fa.id, fa.name, fa.account_type, COALESCE(fa.bank_name, ''), COALESCE(fa.account_number, ''),
+		fa.ledger_account_id, la.code, fa.is_active, COALESCE(fa.notes, ''), fa.created_at, fa.updated_at`
+
+func scanFinancialAccount(row rowScanner) (*entity.FinancialAccount, error) {
+	fa := &entity.FinancialAccount{}
+	err := row.Scan(
+		&fa.ID, &fa.Name, &fa.AccountType, &fa.BankName, &fa.AccountNumber,
+		&fa.LedgerAccountID, &fa.LedgerAccountCode, &fa.IsActive, &fa.Notes, &fa.CreatedAt, &fa.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return fa, nil
+}
+
+func (r *FinancialAccountRepository) FindAll(filter repository.FinancialAccountFilter) ([]*entity.FinancialAccount, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + financialAccountColumns + ` FROM financial_accounts fa INNER JOIN ledger_accounts la ON la.id = fa.ledger_account_id`
+	countQuery := `SELECT COUNT(*) FROM financial_accounts fa`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by account_type
+	if filter.AccountType != "" {
+		conditions = append(conditions, fmt.Sprintf("fa.account_type = $%d", argIdx))
+		args = append(args, filter.AccountType)
+		argIdx++
+	}
+
+	// Filter by active status
+	if filter.IsActive != nil {
+		conditions = append(conditions, fmt.Sprintf("fa.is_active = $%d", argIdx))
+		args = append(args, *filter.IsActive)
+		argIdx++
+	}
+
+	// Search by name
+	if filter.Query != "" {
+		search := fmt.Sprintf("%%%s%%", filter.Query)
+		conditions = append(conditions, fmt.Sprintf("fa.name ILIKE $%d", argIdx))
+		args = append(args, search)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY la.code ASC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var accounts []*entity.FinancialAccount
+	for rows.Next() {
+		fa, err := scanFinancialAccount(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		accounts = append(accounts, fa)
+	}
+
+	return accounts, total, nil
+}
+
+func (r *FinancialAccountRepository) FindByID(id string) (*entity.FinancialAccount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		SELECT ` + financialAccountColumns + `
+		FROM financial_accounts fa
+		INNER JOIN ledger_accounts la ON la.id = fa.ledger_account_id
+		WHERE fa.id = $1
+		LIMIT 1
+	`
+
+	fa, err := scanFinancialAccount(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("financial account not found")
+		}
+		return nil, err
+	}
+
+	return fa, nil
+}
+
+// Create menyimpan rekening beserta akun aset buku besarnya (kode 11xx berikutnya)
+func (r *FinancialAccountRepository) Create(account *entity.FinancialAccount) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize code allocation so two new accounts cannot get the same code
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('ledger_accounts:asset_code'))"); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO ledger_accounts (id, code, name, account_type, created_at)
+		SELECT gen_random_uuid(), (COALESCE(MAX(code)::INT, 1110) + 1)::TEXT, $1, $2, NOW()
+		FROM ledger_accounts
+		WHERE code ~ '^11[0-9]{2}$' AND code > '1110'
+		RETURNING id, code
+	`, account.Name, entity.AccountTypeAsset).Scan(&account.LedgerAccountID, &account.LedgerAccountCode)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO financial_accounts (id, name, account_type, bank_name, account_number, ledger_account_id, is_active, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		account.Name, account.AccountType, account.BankName, account.AccountNumber,
+		account.LedgerAccountID, account.IsActive, account.Notes,
+	).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("financial account name already exists")
+		}
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Update mengubah data rekening dan menyamakan nama akun buku besarnya
+func (r *FinancialAccountRepository) Update(account *entity.FinancialAccount) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE financial_accounts
+		SET name = $1, account_type = $2, bank_name = NULLIF($3, ''), account_number = NULLIF($4, ''),
+		    is_active = $5, notes = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING ledger_account_id, updated_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		account.Name, account.AccountType, account.BankName, account.AccountNumber,
+		account.IsActive, account.Notes, account.ID,
+	).Scan(&account.LedgerAccountID, &account.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("financial account not found")
+		}
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("financial account name already exists")
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE ledger_accounts SET name = $1 WHERE id = $2`, account.Name, account.LedgerAccountID)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Delete menghapus rekening yang belum pernah dipakai beserta akun buku besarnya
+func (r *FinancialAccountRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var ledgerAccountID string
+	err = tx.QueryRow(ctx, `DELETE FROM financial_accounts WHERE id = $1 RETURNING ledger_account_id`, id).Scan(&ledgerAccountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("financial account not found")
+		}
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("financial account is used by receipts, distributions or opening balances, deactivate it instead")
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM ledger_accounts WHERE id = $1`, ledgerAccountID)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("financial account has journal entries, deactivate it instead")
+		}
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,425 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type FiscalPeriodRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewFiscalPeriodRepository(db *pgxpool.Pool, log *logrus.Logger) *FiscalPeriodRepository {
+	return &FiscalPeriodRepository{db: db, log: log}
+}
+
+const fiscalPeriodSelectSQL = `
+		SELECT p.id, p.period_type, p.period_start, p.period_end, p.status, COALESCE(p.notes, ''),
+		       p.closed_by_user_id, cu.name, p.closed_at,
+		       p.reopened_by_user_id, ru.name, p.reopened_at, COALESCE(p.reopen_reason, ''),
+		       p.created_at, p.updated_at
+		FROM fiscal_periods p
+		LEFT JOIN users cu ON cu.id = p.closed_by_user_id
+		LEFT JOIN users ru ON ru.id = p.reopened_by_user_id
+	`
+
+// closedPeriodByDateSQL mencari periode tertutup yang mengunci tanggal $1. Tanggal sebelum
+// periode tertutup terakhir ikut terkunci karena saldo awal dihitung dari snapshot terakhir;
+// periode yang mencakup tanggal didahulukan, lalu tahun supaya pesan menyebut periode terluas.
+const closedPeriodByDateSQL = fiscalPeriodSelectSQL + `
+		WHERE p.status = 'closed' AND p.period_end >= $1::date
+		ORDER BY p.period_start <= $1::date DESC, p.period_end - p.period_start DESC, p.period_start
+		LIMIT 1
+	`
+
+func scanFiscalPeriod(row rowScanner) (*entity.FiscalPeriod, error) {
+	p := &entity.FiscalPeriod{}
+	var periodStart, periodEnd time.Time
+	var closedByName, reopenedByName *string
+	err := row.Scan(
+		&p.ID, &p.PeriodType, &periodStart, &periodEnd, &p.Status, &p.Notes,
+		&p.ClosedByUserID, &closedByName, &p.ClosedAt,
+		&p.ReopenedByUserID, &reopenedByName, &p.ReopenedAt, &p.ReopenReason,
+		&p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	p.PeriodStart = periodStart.Format("2006-01-02")
+	p.PeriodEnd = periodEnd.Format("2006-01-02")
+	if p.ClosedByUserID != nil && closedByName != nil {
+		p.ClosedByUser = &entity.User{ID: *p.ClosedByUserID, Name: *closedByName}
+	}
+	if p.ReopenedByUserID != nil && reopenedByName != nil {
+		p.ReopenedByUser = &entity.User{ID: *p.ReopenedByUserID, Name: *reopenedByName}
+	}
+
+	return p, nil
+}
+
+func (r *FiscalPeriodRepository) FindAll(filter repository.FiscalPeriodFilter) ([]*entity.FiscalPeriod, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := fiscalPeriodSelectSQL + ` WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM fiscal_periods p WHERE 1=1`
+
+	var args []interface{}
+	argIdx := 1
+
+	// Filter by period_type
+	if filter.PeriodType != "" {
+		whereClause := fmt.Sprintf(" AND p.period_type = $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.PeriodType)
+		argIdx++
+	}
+
+	// Filter by status
+	if filter.Status != "" {
+		whereClause := fmt.Sprintf(" AND p.status = $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.Status)
+		argIdx++
+	}
+
+	// Filter by year
+	if filter.Year > 0 {
+		whereClause := fmt.Sprintf(" AND EXTRACT(YEAR FROM p.period_start) = $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.Year)
+		argIdx++
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY p.period_start DESC, p.period_type"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var periods []*entity.FiscalPeriod
+	for rows.Next() {
+		p, err := scanFiscalPeriod(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		periods = append(periods, p)
+	}
+
+	return periods, total, nil
+}
+
+func (r *FiscalPeriodRepository) FindByID(id string) (*entity.FiscalPeriod, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	p, err := scanFiscalPeriod(r.db.QueryRow(ctx, fiscalPeriodSelectSQL+` WHERE p.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("fiscal period not found")
+		}
+		return nil, err
+	}
+
+	// Get balance snapshot
+	rows, err := r.db.Query(ctx, `
+		SELECT b.ledger_account_id, la.code, la.name, la.account_type, la.fund_ledger, fa.id,
+		       b.opening_balance, b.total_debit, b.total_credit, b.closing_balance
+		FROM fiscal_period_balances b
+		INNER JOIN ledger_accounts la ON la.id = b.ledger_account_id
+		LEFT JOIN financial_accounts fa ON fa.ledger_account_id = la.id
+		WHERE b.period_id = $1
+		ORDER BY la.code
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		b := &entity.FiscalPeriodBalance{}
+		err := rows.Scan(&b.LedgerAccountID, &b.AccountCode, &b.AccountName, &b.AccountType, &b.FundLedger, &b.FinancialAccountID,
+			&b.OpeningBalance, &b.TotalDebit, &b.TotalCredit, &b.ClosingBalance)
+		if err != nil {
+			return nil, err
+		}
+		p.Balances = append(p.Balances, b)
+	}
+	rows.Close()
+
+	// Get audit trail
+	rows, err = r.db.Query(ctx, `
+		SELECT e.id, e.period_id, e.action, COALESCE(e.reason, ''), e.user_id, u.name, e.created_at
+		FROM fiscal_period_events e
+		INNER JOIN users u ON u.id = e.user_id
+		WHERE e.period_id = $1
+		ORDER BY e.created_at
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := &entity.FiscalPeriodEvent{User: &entity.User{}}
+		err := rows.Scan(&e.ID, &e.PeriodID, &e.Action, &e.Reason, &e.UserID, &e.User.Name, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		e.User.ID = e.UserID
+		p.Events = append(p.Events, e)
+	}
+
+	return p, nil
+}
+
+func (r *FiscalPeriodRepository) FindByStart(periodType, periodStart string) (*entity.FiscalPeriod, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	p, err := scanFiscalPeriod(r.db.QueryRow(ctx,
+		fiscalPeriodSelectSQL+` WHERE p.period_type = $1 AND p.period_start = $2::date LIMIT 1`, periodType, periodStart))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (r *FiscalPeriodRepository) FindClosedByDate(date string) (*entity.FiscalPeriod, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	p, err := scanFiscalPeriod(r.db.QueryRow(ctx, closedPeriodByDateSQL, date))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Close menutup periode dalam satu transaksi: cek draft, simpan status, hitung ulang
+// snapshot saldo setiap akun buku besar sampai akhir periode, dan catat jejak audit
+func (r *FiscalPeriodRepository) Close(period *entity.FiscalPeriod, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize closing so two admins cannot close overlapping periods at the same time
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('fiscal_periods'))"); err != nil {
+		return err
+	}
+
+	// Periode ditutup berurutan: setiap bulan sejak jurnal atau periode tertutup pertama
+	// harus sudah tertutup, karena saldo awal hanya menjumlahkan jurnal setelah snapshot terakhir
+	var openMonth *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT m::date
+		FROM generate_series(
+			LEAST(
+				(SELECT date_trunc('month', MIN(entry_date)) FROM journal_entries),
+				(SELECT MIN(period_start) FROM fiscal_periods WHERE status = 'closed')
+			),
+			$1::date - INTERVAL '1 month',
+			INTERVAL '1 month'
+		) m
+		WHERE NOT EXISTS (
+			SELECT 1 FROM fiscal_periods p
+			WHERE p.status = 'closed' AND m::date BETWEEN p.period_start AND p.period_end
+		)
+		ORDER BY m
+		LIMIT 1
+	`, period.PeriodStart).Scan(&openMonth)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if openMonth != nil {
+		return &repository.FiscalPeriodOrderError{Action: entity.FiscalPeriodActionClose, Period: openMonth.Format("2006-01")}
+	}
+
+	// Draft dan pengajuan yang tertinggal tidak akan bisa diposting setelah periode dikunci
+	var draftReceipts, draftDistributions int
+	err = tx.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM donation_receipts
+			 WHERE status IN ('draft', 'pending_approval') AND receipt_date BETWEEN $1::date AND $2::date),
+			(SELECT COUNT(*) FROM distributions
+			 WHERE status IN ('draft', 'pending_approval') AND distribution_date BETWEEN $1::date AND $2::date)
+	`, period.PeriodStart, period.PeriodEnd).Scan(&draftReceipts, &draftDistributions)
+	if err != nil {
+		return err
+	}
+	if draftReceipts > 0 || draftDistributions > 0 {
+		return &repository.FiscalPeriodDraftError{DonationReceipts: draftReceipts, Distributions: draftDistributions}
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO fiscal_periods (id, period_type, period_start, period_end, status, notes, closed_by_user_id, closed_at,
+		                            created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, 'closed', $4, $5, NOW(), NOW(), NOW())
+		ON CONFLICT (period_type, period_start) DO UPDATE
+		SET status = 'closed', notes = EXCLUDED.notes, closed_by_user_id = EXCLUDED.closed_by_user_id,
+		    closed_at = NOW(), updated_at = NOW()
+		WHERE fiscal_periods.status = 'open'
+		RETURNING id, status, closed_by_user_id, closed_at, created_at, updated_at
+	`, period.PeriodType, period.PeriodStart, period.PeriodEnd, period.Notes, userID,
+	).Scan(&period.ID, &period.Status, &period.ClosedByUserID, &period.ClosedAt, &period.CreatedAt, &period.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("fiscal period is already closed")
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM fiscal_period_balances WHERE period_id = $1`, period.ID); err != nil {
+		return err
+	}
+
+	// Saldo mengikuti sisi normal akun: aset = debit - kredit, dana = kredit - debit
+	_, err = tx.Exec(ctx, `
+		INSERT INTO fiscal_period_balances (period_id, ledger_account_id, opening_balance, total_debit, total_credit, closing_balance)
+		SELECT $1, la.id, agg.opening, agg.debit, agg.credit, agg.opening + s.sign * (agg.debit - agg.credit)
+		FROM ledger_accounts la
+		CROSS JOIN LATERAL (SELECT CASE WHEN la.account_type = 'asset' THEN 1 ELSE -1 END as sign) s
+		CROSS JOIN LATERAL (
+			SELECT
+				s.sign * COALESCE(SUM(jl.debit - jl.credit) FILTER (WHERE je.entry_date < $2::date), 0) as opening,
+				COALESCE(SUM(jl.debit) FILTER (WHERE je.entry_date >= $2::date), 0) as debit,
+				COALESCE(SUM(jl.credit) FILTER (WHERE je.entry_date >= $2::date), 0) as credit
+			FROM journal_lines jl
+			INNER JOIN journal_entries je ON je.id = jl.entry_id
+			WHERE jl.account_id = la.id AND je.entry_date <= $3::date
+		) agg
+	`, period.ID, period.PeriodStart, period.PeriodEnd)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO fiscal_period_events (id, period_id, action, user_id, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, NOW())
+	`, period.ID, entity.FiscalPeriodActionClose, userID)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+func (r *FiscalPeriodRepository) Reopen(id, reason, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('fiscal_periods'))"); err != nil {
+		return err
+	}
+
+	// Periode dibuka dari yang terakhir: snapshot periode sesudahnya tidak akan memuat
+	// perubahan yang dibuat di periode ini
+	later, err := scanFiscalPeriod(tx.QueryRow(ctx, fiscalPeriodSelectSQL+`
+		WHERE p.status = 'closed' AND p.period_start > (SELECT period_end FROM fiscal_periods WHERE id = $1)
+		ORDER BY p.period_start DESC, p.period_end - p.period_start DESC
+		LIMIT 1
+	`, id))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if later != nil {
+		return &repository.FiscalPeriodOrderError{Action: entity.FiscalPeriodActionReopen, Period: later.Label()}
+	}
+
+	ct, err := tx.Exec(ctx, `
+		UPDATE fiscal_periods
+		SET status = 'open', reopened_by_user_id = $2, reopened_at = NOW(), reopen_reason = $3, updated_at = NOW()
+		WHERE id = $1 AND status = 'closed'
+	`, id, userID, reason)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return errors.New("fiscal period is not closed")
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO fiscal_period_events (id, period_id, action, reason, user_id, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, NOW())
+	`, id, entity.FiscalPeriodActionReopen, reason, userID)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// lockOpenPeriod mengambil kunci periode buku dalam mode shared lalu memastikan tanggal
+// jurnal belum terkunci, supaya penulisan jurnal tidak bisa menyusul penutupan periode
+// yang snapshot-nya sedang dihitung
+func lockOpenPeriod(ctx context.Context, tx pgx.Tx, date time.Time) error {
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock_shared(hashtext('fiscal_periods'))"); err != nil {
+		return err
+	}
+
+	period, err := scanFiscalPeriod(tx.QueryRow(ctx, closedPeriodByDateSQL, date))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	day := date.Format("2006-01-02")
+	if day < period.PeriodStart {
+		return fmt.Errorf("transactions dated %s precede closed fiscal period %s and cannot be changed", day, period.Label())
+	}
+	return fmt.Errorf("fiscal period %s is closed, transactions dated %s cannot be changed", period.Label(), day)
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type FitrahRateRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewFitrahRateRepository(db *pgxpool.Pool, log *logrus.Logger) *FitrahRateRepository {
+	return &FitrahRateRepository{db: db, log: log}
+}
+
+func (r *FitrahRateRepository) FindAll(filter repository.FitrahRateFilter) ([]*entity.FitrahRate, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		SELECT id, hijri_year, region, rice_kg_per_person, COALESCE(cash_per_person, 0), COALESCE(notes, ''), created_at, updated_at
+		FROM fitrah_rates
+	`
+	countQuery := `SELECT COUNT(*) FROM fitrah_rates`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by hijri_year
+	if filter.HijriYear > 0 {
+		conditions = append(conditions, fmt.Sprintf("hijri_year = $%d", argIdx))
+		args = append(args, filter.HijriYear)
+		argIdx++
+	}
+
+	// Filter by region
+	if filter.Region != "" {
+		conditions = append(conditions, fmt.Sprintf("region = $%d", argIdx))
+		args = append(args, filter.Region)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY hijri_year DESC, region ASC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var rates []*entity.FitrahRate
+	for rows.Next() {
+		fr := &entity.FitrahRate{}
+		err := rows.Scan(&fr.ID, &fr.HijriYear, &fr.Region, &fr.RiceKGPerPerson, &fr.CashPerPerson, &fr.Notes, &fr.CreatedAt, &fr.UpdatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		rates = append(rates, fr)
+	}
+
+	return rates, total, nil
+}
+
+func (r *FitrahRateRepository) FindByID(id string) (*entity.FitrahRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		SELECT id, hijri_year, region, rice_kg_per_person, COALESCE(cash_per_person, 0), COALESCE(notes, ''), created_at, updated_at
+		FROM fitrah_rates
+		WHERE id = $1
+		LIMIT 1
+	`
+
+	fr := &entity.FitrahRate{}
+	err := r.db.QueryRow(ctx, query, id).Scan(&fr.ID, &fr.HijriYear, &fr.Region, &fr.RiceKGPerPerson, &fr.CashPerPerson, &fr.Notes, &fr.CreatedAt, &fr.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return fr, nil
+}
+
+func (r *FitrahRateRepository) FindByYearAndRegion(hijriYear int, region string) (*entity.FitrahRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		SELECT id, hijri_year, region, rice_kg_per_person, COALESCE(cash_per_person, 0), COALESCE(notes, ''), created_at, updated_at
+		FROM fitrah_rates
+		WHERE hijri_year = $1 AND region = $2
+		LIMIT 1
+	`
+
+	fr := &entity.FitrahRate{}
+	err := r.db.QueryRow(ctx, query, hijriYear, region).Scan(&fr.ID, &fr.HijriYear, &fr.Region, &fr.RiceKGPerPerson, &fr.CashPerPerson, &fr.Notes, &fr.CreatedAt, &fr.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return fr, nil
+}
+
+func (r *FitrahRateRepository) Create(rate *entity.FitrahRate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO fitrah_rates (id, hijri_year, region, rice_kg_per_person, cash_per_person, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, NULLIF($4, 0), $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, rate.HijriYear, rate.Region, rate.RiceKGPerPerson, rate.CashPerPerson, rate.Notes).
+		Scan(&rate.ID, &rate.CreatedAt, &rate.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("fitrah rate for this hijri year and region already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *FitrahRateRepository) Update(rate *entity.FitrahRate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		UPDATE fitrah_rates
+		SET hijri_year = $1, region = $2, rice_kg_per_person = $3, cash_per_person = NULLIF($4, 0), notes = $5, updated_at = NOW()
+		WHERE id = $6
+	`
+
+	ct, err := r.db.Exec(ctx, query, rate.HijriYear, rate.Region, rate.RiceKGPerPerson, rate.CashPerPerson, rate.Notes, rate.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("fitrah rate for this hijri year and region already exists")
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("fitrah rate not found")
+	}
+
+	return nil
+}
+
+func (r *FitrahRateRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `DELETE FROM fitrah_rates WHERE id = $1`
+
+	ct, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("fitrah rate not found")
+	}
+
+	return nil
+}
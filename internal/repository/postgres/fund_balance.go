@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// receiptItemFundTypeSQL memetakan item kwitansi (alias dri) ke ledger jenis dananya.
+// Dipakai saat membagi item kwitansi ke sub-ledger (lihat allocateReceipt).
+const receiptItemFundTypeSQL = `CASE
+					WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'fitrah' THEN 'zakat_fitrah'
+					WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'maal' THEN 'zakat_maal'
+					WHEN dri.fund_type = 'infaq' THEN 'infaq'
+					WHEN dri.fund_type = 'sadaqah' THEN 'sadaqah'
+				END`
+
+// fundBalanceQuery menghitung saldo berjalan satu sub-ledger (amil atau jenis dana) dari
+// buku besar: kredit dikurangi debit pada akun dana ledger tersebut.
+// Dipakai bersama oleh pengecekan saldo sebelum penyaluran diposting.
+const fundBalanceQuery = `
+	SELECT COALESCE(SUM(jl.credit - jl.debit), 0)
+	FROM journal_lines jl
+	INNER JOIN ledger_accounts la ON la.id = jl.account_id
+	WHERE la.fund_ledger = $1
+`
+
+// queryRower dipenuhi oleh *pgxpool.Pool maupun pgx.Tx
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// lockFundBalance mengunci saldo satu jenis dana sampai transaksi selesai, supaya
+// dua penyaluran yang diposting bersamaan tidak sama-sama lolos pengecekan saldo
+func lockFundBalance(ctx context.Context, tx pgx.Tx, fundType string) error {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "fund_balance:"+fundType)
+	return err
+}
+
+func fundBalance(ctx context.Context, db queryRower, fundType string) (float64, error) {
+	var balance float64
+	err := db.QueryRow(ctx, fundBalanceQuery, fundType).Scan(&balance)
+	return balance, err
+}
@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type HouseholdRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewHouseholdRepository(db *pgxpool.Pool, log *logrus.Logger) *HouseholdRepository {
+	return &HouseholdRepository{db: db, log: log}
+}
+
+const householdSelectSQL = `
+		SELECT h.id, COALESCE(h.family_card_number, ''), h.address, COALESCE(h.notes, ''),
+		       COALESCE((SELECT hm.name FROM household_members hm WHERE hm.household_id = h.id AND hm.relationship = 'head'), ''),
+		       (SELECT hm.mustahiq_id FROM household_members hm WHERE hm.household_id = h.id AND hm.relationship = 'head'),
+		       (SELECT COUNT(*) FROM household_members hm WHERE hm.household_id = h.id),
+		       h.created_at, h.updated_at
+		FROM households h
+	`
+
+func scanHousehold(row rowScanner) (*entity.Household, error) {
+	h := &entity.Household{}
+	err := row.Scan(&h.ID, &h.FamilyCardNumber, &h.Address, &h.Notes, &h.HeadName, &h.HeadMustahiqID, &h.MemberCount, &h.CreatedAt, &h.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (r *HouseholdRepository) FindAll(filter repository.HouseholdFilter) ([]*entity.Household, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := householdSelectSQL + ` WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM households h WHERE 1=1`
+
+	var args []interface{}
+	argIdx := 1
+
+	// Search by family card number, head name or address
+	if filter.Query != "" {
+		whereClause := fmt.Sprintf(` AND (h.family_card_number ILIKE $%d OR h.address ILIKE $%d OR EXISTS (
+			SELECT 1 FROM household_members hm
+			WHERE hm.household_id = h.id AND hm.relationship = 'head' AND hm.name ILIKE $%d
+		))`, argIdx, argIdx, argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, fmt.Sprintf("%%%s%%", filter.Query))
+		argIdx++
+	}
+
+	// Filter by household IDs
+	if len(filter.IDs) > 0 {
+		whereClause := fmt.Sprintf(" AND h.id = ANY($%d::uuid[])", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.IDs)
+		argIdx++
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY h.created_at DESC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var households []*entity.Household
+	for rows.Next() {
+		h, err := scanHousehold(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		households = append(households, h)
+	}
+
+	return households, total, nil
+}
+
+func (r *HouseholdRepository) FindByID(id string) (*entity.Household, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	h, err := scanHousehold(r.db.QueryRow(ctx, householdSelectSQL+` WHERE h.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("household not found")
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT hm.id, hm.household_id, hm.mustahiq_id, COALESCE(m.status, ''), hm.name, hm.relationship,
+		       hm.birth_date, hm.created_at
+		FROM household_members hm
+		LEFT JOIN mustahiq m ON m.id = hm.mustahiq_id
+		WHERE hm.household_id = $1
+		ORDER BY hm.sort_order, hm.created_at
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		member := &entity.HouseholdMember{}
+		var birthDate *time.Time
+		err := rows.Scan(
+			&member.ID, &member.HouseholdID, &member.MustahiqID, &member.MustahiqStatus, &member.Name, &member.Relationship,
+			&birthDate, &member.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if birthDate != nil {
+			formatted := birthDate.Format("2006-01-02")
+			member.BirthDate = &formatted
+		}
+		h.Members = append(h.Members, member)
+	}
+
+	return h, nil
+}
+
+func (r *HouseholdRepository) Create(household *entity.Household) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO households (id, family_card_number, address, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), NULLIF($1, ''), $2, NULLIF($3, ''), NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`, household.FamilyCardNumber, household.Address, household.Notes,
+	).Scan(&household.ID, &household.CreatedAt, &household.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("family card number already registered")
+		}
+		return err
+	}
+
+	if err := insertHouseholdMembers(ctx, tx, household); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+func (r *HouseholdRepository) Update(household *entity.Household) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE households
+		SET family_card_number = NULLIF($1, ''), address = $2, notes = NULLIF($3, ''), updated_at = NOW()
+		WHERE id = $4
+		RETURNING updated_at
+	`, household.FamilyCardNumber, household.Address, household.Notes, household.ID,
+	).Scan(&household.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("household not found")
+		}
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("family card number already registered")
+		}
+		return err
+	}
+
+	// Replace members
+	if _, err := tx.Exec(ctx, "DELETE FROM household_members WHERE household_id = $1", household.ID); err != nil {
+		return err
+	}
+
+	if err := insertHouseholdMembers(ctx, tx, household); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+func (r *HouseholdRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	ct, err := r.db.Exec(ctx, `DELETE FROM households WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("household not found")
+	}
+
+	return nil
+}
+
+func insertHouseholdMembers(ctx context.Context, tx pgx.Tx, household *entity.Household) error {
+	query := `
+		INSERT INTO household_members (id, household_id, mustahiq_id, name, relationship, birth_date, sort_order, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`
+
+	for i, member := range household.Members {
+		err := tx.QueryRow(ctx, query,
+			household.ID, member.MustahiqID, member.Name, member.Relationship, member.BirthDate, i+1,
+		).Scan(&member.ID, &member.CreatedAt)
+		if err != nil {
+			if strings.Contains(err.Error(), "duplicate key") {
+				return errors.New("mustahiq is already a member of another household")
+			}
+			if strings.Contains(err.Error(), "foreign key") {
+				return errors.New("mustahiq not found")
+			}
+			return err
+		}
+		member.HouseholdID = household.ID
+	}
+
+	return nil
+}
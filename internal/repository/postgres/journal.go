@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fundJournalLinesJoinSQL menggabungkan baris jurnal (alias jl) dengan jurnalnya (je) dan
+// akun dana sub-ledger (la); dipakai laporan penghimpunan dan saldo dana
+const fundJournalLinesJoinSQL = `journal_entries je
+			INNER JOIN journal_lines jl ON jl.entry_id = je.id
+			INNER JOIN ledger_accounts la ON la.id = jl.account_id AND la.fund_ledger IS NOT NULL`
+
+// journalReceipt membuat jurnal kwitansi posted: debit akun rekening penerima sebesar total alokasi,
+// kredit akun dana per sub-ledger (lihat allocateReceipt)
+func journalReceipt(ctx context.Context, tx pgx.Tx, receiptID string) error {
+	var entryID string
+	var entryDate time.Time
+	err := tx.QueryRow(ctx, `
+		INSERT INTO journal_entries (id, entry_date, description, source_type, source_id, created_at)
+		SELECT gen_random_uuid(), receipt_date, 'Penerimaan ' || COALESCE(receipt_number, ''), $2, id, NOW()
+		FROM donation_receipts
+		WHERE id = $1
+		RETURNING id, entry_date
+	`, receiptID, entity.JournalSourceDonationReceipt).Scan(&entryID, &entryDate)
+	if err != nil {
+		return err
+	}
+	if err := lockOpenPeriod(ctx, tx, entryDate); err != nil {
+		return err
+	}
+
+	// Debit rekening penerima
+	_, err = tx.Exec(ctx, `
+		INSERT INTO journal_lines (id, entry_id, account_id, debit, credit)
+		SELECT gen_random_uuid(), $1, fa.ledger_account_id, SUM(a.amount), 0
+		FROM donation_receipts dr
+		INNER JOIN donation_receipt_items dri ON dr.id = dri.receipt_id
+		INNER JOIN donation_receipt_item_allocations a ON a.receipt_item_id = dri.id
+		INNER JOIN financial_accounts fa ON fa.id = dr.financial_account_id
+		WHERE dr.id = $2
+		GROUP BY fa.ledger_account_id
+		HAVING SUM(a.amount) > 0
+	`, entryID, receiptID)
+	if err != nil {
+		return err
+	}
+
+	// Kredit dana per sub-ledger
+	_, err = tx.Exec(ctx, `
+		INSERT INTO journal_lines (id, entry_id, account_id, debit, credit)
+		SELECT gen_random_uuid(), $1, la.id, 0, SUM(a.amount)
+		FROM donation_receipt_items dri
+		INNER JOIN donation_receipt_item_allocations a ON a.receipt_item_id = dri.id
+		INNER JOIN ledger_accounts la ON la.fund_ledger = a.ledger
+		WHERE dri.receipt_id = $2
+		GROUP BY la.id
+		HAVING SUM(a.amount) > 0
+	`, entryID, receiptID)
+	return err
+}
+
+// journalDistribution membuat jurnal penyaluran posted: debit akun dana sumber, kredit akun
+// rekening yang membayar. Penyaluran yang hanya berupa barang (total 0) tidak dijurnal.
+func journalDistribution(ctx context.Context, tx pgx.Tx, distributionID string) error {
+	var entryID string
+	var entryDate time.Time
+	err := tx.QueryRow(ctx, `
+		INSERT INTO journal_entries (id, entry_date, description, source_type, source_id, created_at)
+		SELECT gen_random_uuid(), distribution_date, 'Penyaluran ' || source_fund_type, $2, id, NOW()
+		FROM distributions
+		WHERE id = $1 AND total_amount > 0
+		RETURNING id, entry_date
+	`, distributionID, entity.JournalSourceDistribution).Scan(&entryID, &entryDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if err := lockOpenPeriod(ctx, tx, entryDate); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO journal_lines (id, entry_id, account_id, debit, credit)
+		SELECT gen_random_uuid(), $1::uuid, fund.id, d.total_amount, 0
+		FROM distributions d
+		INNER JOIN ledger_accounts fund ON fund.fund_ledger = d.source_fund_type
+		WHERE d.id = $2 AND d.total_amount > 0
+		UNION ALL
+		SELECT gen_random_uuid(), $1::uuid, fa.ledger_account_id, 0, d.total_amount
+		FROM distributions d
+		INNER JOIN financial_accounts fa ON fa.id = d.financial_account_id
+		WHERE d.id = $2 AND d.total_amount > 0
+	`, entryID, distributionID)
+	return err
+}
+
+// journalOpeningBalance membuat jurnal saldo awal: debit akun rekening, kredit akun dana
+func journalOpeningBalance(ctx context.Context, tx pgx.Tx, openingBalanceID string) error {
+	var entryID string
+	var entryDate time.Time
+	err := tx.QueryRow(ctx, `
+		INSERT INTO journal_entries (id, entry_date, description, source_type, source_id, created_at)
+		SELECT gen_random_uuid(), effective_date, 'Saldo awal ' || fund_ledger, $2, id, NOW()
+		FROM opening_balances
+		WHERE id = $1
+		RETURNING id, entry_date
+	`, openingBalanceID, entity.JournalSourceOpeningBalance).Scan(&entryID, &entryDate)
+	if err != nil {
+		return err
+	}
+	if err := lockOpenPeriod(ctx, tx, entryDate); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO journal_lines (id, entry_id, account_id, debit, credit)
+		SELECT gen_random_uuid(), $1::uuid, fa.ledger_account_id, ob.amount, 0
+		FROM opening_balances ob
+		INNER JOIN financial_accounts fa ON fa.id = ob.financial_account_id
+		WHERE ob.id = $2
+		UNION ALL
+		SELECT gen_random_uuid(), $1::uuid, fund.id, 0, ob.amount
+		FROM opening_balances ob
+		INNER JOIN ledger_accounts fund ON fund.fund_ledger = ob.fund_ledger
+		WHERE ob.id = $2
+	`, entryID, openingBalanceID)
+	return err
+}
+
+// reverseJournal membalik jurnal terakhir yang belum dibalik dari satu sumber (void atau
+// revert ke draft). Jurnal pembalik memakai tanggal jurnal asal sehingga laporan periode
+// tersebut tidak lagi menghitung transaksinya. Sumber tanpa jurnal dilewati.
+//
+// Semua helper jurnal memeriksa ulang periode buku di dalam transaksi (lihat lockOpenPeriod).
+func reverseJournal(ctx context.Context, tx pgx.Tx, sourceType, sourceID, description string) error {
+	var entryID, originalID string
+	var entryDate time.Time
+	err := tx.QueryRow(ctx, `
+		INSERT INTO journal_entries (id, entry_date, description, source_type, source_id, reversal_of_entry_id, created_at)
+		SELECT gen_random_uuid(), je.entry_date, $3, je.source_type, je.source_id, je.id, NOW()
+		FROM journal_entries je
+		WHERE je.source_type = $1 AND je.source_id = $2 AND je.reversal_of_entry_id IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM journal_entries r WHERE r.reversal_of_entry_id = je.id)
+		ORDER BY je.created_at DESC
+		LIMIT 1
+		RETURNING id, reversal_of_entry_id, entry_date
+	`, sourceType, sourceID, description).Scan(&entryID, &originalID, &entryDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if err := lockOpenPeriod(ctx, tx, entryDate); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO journal_lines (id, entry_id, account_id, debit, credit)
+		SELECT gen_random_uuid(), $1, account_id, credit, debit
+		FROM journal_lines
+		WHERE entry_id = $2
+	`, entryID, originalID)
+	return err
+}
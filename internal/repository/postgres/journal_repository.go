@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type JournalRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewJournalRepository(db *pgxpool.Pool, log *logrus.Logger) *JournalRepository {
+	return &JournalRepository{db: db, log: log}
+}
+
+func (r *JournalRepository) FindAccounts() ([]*entity.LedgerAccount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT id, code, name, account_type, fund_ledger, created_at FROM ledger_accounts ORDER BY code`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []*entity.LedgerAccount
+	for rows.Next() {
+		la := &entity.LedgerAccount{}
+		if err := rows.Scan(&la.ID, &la.Code, &la.Name, &la.AccountType, &la.FundLedger, &la.CreatedAt); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, la)
+	}
+
+	return accounts, nil
+}
+
+const journalEntryColumns = `id, entry_date, description, source_type, source_id, reversal_of_entry_id, created_at`
+
+func scanJournalEntry(row rowScanner) (*entity.JournalEntry, error) {
+	je := &entity.JournalEntry{}
+	var entryDate time.Time
+	err := row.Scan(&je.ID, &entryDate, &je.Description, &je.SourceType, &je.SourceID, &je.ReversalOfEntryID, &je.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	je.EntryDate = entryDate.Format("2006-01-02")
+
+	return je, nil
+}
+
+func (r *JournalRepository) FindAllEntries(filter repository.JournalEntryFilter) ([]*entity.JournalEntry, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + journalEntryColumns + ` FROM journal_entries`
+	countQuery := `SELECT COUNT(*) FROM journal_entries`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by date range
+	if filter.DateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("entry_date >= $%d", argIdx))
+		args = append(args, filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, fmt.Sprintf("entry_date <= $%d", argIdx))
+		args = append(args, filter.DateTo)
+		argIdx++
+	}
+
+	// Filter by source
+	if filter.SourceType != "" {
+		conditions = append(conditions, fmt.Sprintf("source_type = $%d", argIdx))
+		args = append(args, filter.SourceType)
+		argIdx++
+	}
+	if filter.SourceID != "" {
+		conditions = append(conditions, fmt.Sprintf("source_id = $%d", argIdx))
+		args = append(args, filter.SourceID)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY entry_date DESC, created_at DESC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries []*entity.JournalEntry
+	for rows.Next() {
+		je, err := scanJournalEntry(rows)
+		if err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		entries = append(entries, je)
+	}
+	rows.Close()
+
+	if err := r.loadLines(ctx, entries); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *JournalRepository) FindEntryByID(id string) (*entity.JournalEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + journalEntryColumns + ` FROM journal_entries WHERE id = $1 LIMIT 1`
+
+	je, err := scanJournalEntry(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("journal entry not found")
+		}
+		return nil, err
+	}
+
+	if err := r.loadLines(ctx, []*entity.JournalEntry{je}); err != nil {
+		return nil, err
+	}
+
+	return je, nil
+}
+
+// loadLines mengisi baris jurnal (debit dulu, lalu kode akun) untuk jurnal-jurnal yang diberikan
+func (r *JournalRepository) loadLines(ctx context.Context, entries []*entity.JournalEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	entryByID := make(map[string]*entity.JournalEntry, len(entries))
+	ids := make([]string, len(entries))
+	for i, je := range entries {
+		entryByID[je.ID] = je
+		ids[i] = je.ID
+	}
+
+	query := `
+		SELECT jl.id, jl.entry_id, jl.account_id, la.code, la.name, jl.debit, jl.credit
+		FROM journal_lines jl
+		INNER JOIN ledger_accounts la ON la.id = jl.account_id
+		WHERE jl.entry_id = ANY($1::uuid[])
+		ORDER BY jl.debit = 0, la.code
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line := &entity.JournalLine{}
+		err := rows.Scan(&line.ID, &line.EntryID, &line.AccountID, &line.AccountCode, &line.AccountName, &line.Debit, &line.Credit)
+		if err != nil {
+			return err
+		}
+		if je, ok := entryByID[line.EntryID]; ok {
+			je.Lines = append(je.Lines, line)
+		}
+	}
+
+	return nil
+}
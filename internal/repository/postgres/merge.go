@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+
+	"go-zakat-be/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Tabel pilihan field per jenis penggabungan data ganda
+const (
+	muzakkiMergeFields  = "muzakki_merge_fields"
+	mustahiqMergeFields = "mustahiq_merge_fields"
+)
+
+// insertMergeFields mencatat nilai kedua data dan pilihan setiap field di dalam transaksi tx
+func insertMergeFields(ctx context.Context, tx pgx.Tx, table, mergeID string, fields []*entity.MergeFieldChoice) error {
+	for _, f := range fields {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO `+table+` (merge_id, field, choice, source_value, target_value, final_value)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, mergeID, f.Field, f.Choice, f.SourceValue, f.TargetValue, f.FinalValue)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findMergeFields mengambil pilihan field satu penggabungan
+func findMergeFields(ctx context.Context, db *pgxpool.Pool, table, mergeID string) ([]*entity.MergeFieldChoice, error) {
+	rows, err := db.Query(ctx, `
+		SELECT field, choice, source_value, target_value, final_value
+		FROM `+table+`
+		WHERE merge_id = $1
+		ORDER BY field
+	`, mergeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []*entity.MergeFieldChoice
+	for rows.Next() {
+		f := &entity.MergeFieldChoice{}
+		if err := rows.Scan(&f.Field, &f.Choice, &f.SourceValue, &f.TargetValue, &f.FinalValue); err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,257 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type MustahiqAssessmentRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewMustahiqAssessmentRepository(db *pgxpool.Pool, log *logrus.Logger) *MustahiqAssessmentRepository {
+	return &MustahiqAssessmentRepository{db: db, log: log}
+}
+
+const mustahiqAssessmentSelectSQL = `
+		SELECT ma.id, ma.mustahiq_id, m.name, ma.questionnaire_id, q.name, ma.assessment_date,
+		       ma.score, ma.pass_threshold, ma.result, ma.previous_status, ma.new_status, COALESCE(ma.notes, ''),
+		       ma.assessed_by_user_id, u.name, ma.created_at
+		FROM mustahiq_assessments ma
+		INNER JOIN mustahiq m ON m.id = ma.mustahiq_id
+		INNER JOIN assessment_questionnaires q ON q.id = ma.questionnaire_id
+		INNER JOIN users u ON u.id = ma.assessed_by_user_id
+	`
+
+func scanMustahiqAssessment(row rowScanner) (*entity.MustahiqAssessment, error) {
+	a := &entity.MustahiqAssessment{Mustahiq: &entity.Mustahiq{}, AssessedByUser: &entity.User{}}
+	var assessmentDate time.Time
+	err := row.Scan(
+		&a.ID, &a.MustahiqID, &a.Mustahiq.Name, &a.QuestionnaireID, &a.QuestionnaireName, &assessmentDate,
+		&a.Score, &a.PassThreshold, &a.Result, &a.PreviousStatus, &a.NewStatus, &a.Notes,
+		&a.AssessedByUserID, &a.AssessedByUser.Name, &a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	a.AssessmentDate = assessmentDate.Format("2006-01-02")
+	a.Mustahiq.ID = a.MustahiqID
+	a.AssessedByUser.ID = a.AssessedByUserID
+
+	return a, nil
+}
+
+func (r *MustahiqAssessmentRepository) FindAll(filter repository.MustahiqAssessmentFilter) ([]*entity.MustahiqAssessment, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := mustahiqAssessmentSelectSQL + ` WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM mustahiq_assessments ma WHERE 1=1`
+
+	var args []interface{}
+	argIdx := 1
+
+	// Filter by mustahiq
+	if filter.MustahiqID != "" {
+		whereClause := fmt.Sprintf(" AND ma.mustahiq_id = $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.MustahiqID)
+		argIdx++
+	}
+
+	// Filter by questionnaire
+	if filter.QuestionnaireID != "" {
+		whereClause := fmt.Sprintf(" AND ma.questionnaire_id = $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.QuestionnaireID)
+		argIdx++
+	}
+
+	// Filter by result
+	if filter.Result != "" {
+		whereClause := fmt.Sprintf(" AND ma.result = $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.Result)
+		argIdx++
+	}
+
+	// Filter by date range
+	if filter.DateFrom != "" {
+		whereClause := fmt.Sprintf(" AND ma.assessment_date >= $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != "" {
+		whereClause := fmt.Sprintf(" AND ma.assessment_date <= $%d", argIdx)
+		query += whereClause
+		countQuery += whereClause
+		args = append(args, filter.DateTo)
+		argIdx++
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY ma.assessment_date DESC, ma.created_at DESC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var assessments []*entity.MustahiqAssessment
+	for rows.Next() {
+		a, err := scanMustahiqAssessment(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		assessments = append(assessments, a)
+	}
+
+	return assessments, total, nil
+}
+
+func (r *MustahiqAssessmentRepository) FindByID(id string) (*entity.MustahiqAssessment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	a, err := scanMustahiqAssessment(r.db.QueryRow(ctx, mustahiqAssessmentSelectSQL+` WHERE ma.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("mustahiq assessment not found")
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, question_id, option_id, category, question, answer, weight, score, max_score
+		FROM mustahiq_assessment_answers
+		WHERE assessment_id = $1
+		ORDER BY
+			(SELECT sort_order FROM assessment_questions aq WHERE aq.id = question_id), id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		answer := &entity.MustahiqAssessmentAnswer{}
+		err := rows.Scan(
+			&answer.ID, &answer.QuestionID, &answer.OptionID, &answer.Category, &answer.Question, &answer.Answer,
+			&answer.Weight, &answer.Score, &answer.MaxScore,
+		)
+		if err != nil {
+			return nil, err
+		}
+		a.Answers = append(a.Answers, answer)
+	}
+
+	return a, nil
+}
+
+func (r *MustahiqAssessmentRepository) Create(assessment *entity.MustahiqAssessment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock mustahiq so the recorded previous status matches the status being replaced
+	err = tx.QueryRow(ctx, `SELECT status FROM mustahiq WHERE id = $1 FOR UPDATE`, assessment.MustahiqID).
+		Scan(&assessment.PreviousStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("mustahiq not found")
+		}
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO mustahiq_assessments (
+			id, mustahiq_id, questionnaire_id, assessment_date, score, pass_threshold, result,
+			previous_status, new_status, notes, assessed_by_user_id, created_at
+		)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, NOW())
+		RETURNING id, created_at
+	`,
+		assessment.MustahiqID, assessment.QuestionnaireID, assessment.AssessmentDate, assessment.Score,
+		assessment.PassThreshold, assessment.Result, assessment.PreviousStatus, assessment.NewStatus,
+		assessment.Notes, assessment.AssessedByUserID,
+	).Scan(&assessment.ID, &assessment.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	answerQuery := `
+		INSERT INTO mustahiq_assessment_answers (
+			id, assessment_id, question_id, option_id, category, question, answer, weight, score, max_score
+		)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+	for _, answer := range assessment.Answers {
+		err := tx.QueryRow(ctx, answerQuery,
+			assessment.ID, answer.QuestionID, answer.OptionID, answer.Category, answer.Question, answer.Answer,
+			answer.Weight, answer.Score, answer.MaxScore,
+		).Scan(&answer.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if assessment.NewStatus != assessment.PreviousStatus {
+		_, err = tx.Exec(ctx, `UPDATE mustahiq SET status = $1, updated_at = NOW() WHERE id = $2`,
+			assessment.NewStatus, assessment.MustahiqID)
+		if err != nil {
+			return err
+		}
+
+		err = insertMustahiqStatusChange(ctx, tx, &entity.MustahiqStatusChange{
+			MustahiqID:      assessment.MustahiqID,
+			FromStatus:      assessment.PreviousStatus,
+			ToStatus:        assessment.NewStatus,
+			EffectiveDate:   assessment.AssessmentDate,
+			Reason:          fmt.Sprintf("eligibility assessment scored %.2f (threshold %.2f)", assessment.Score, assessment.PassThreshold),
+			AssessmentID:    &assessment.ID,
+			ChangedByUserID: &assessment.AssessedByUserID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
@@ -28,22 +30,23 @@ func (r *MustahiqRepository) FindAll(filter repository.MustahiqFilter) ([]*entit
 
 	// Base query with JOIN to asnaf table
 	query := `
-		SELECT m.id, m.name, m.phoneNumber, m.address, m.asnafID, m.status, m.description, m.created_at, m.updated_at,
-		       a.id as asnaf_id, a.name as asnaf_name
+		SELECT m.id, m.name, COALESCE(m.nik, ''), m.phoneNumber, m.address, m.asnafID, m.status, m.description, m.created_at, m.updated_at,
+		       a.id as asnaf_id, a.name as asnaf_name, hm.household_id
 		FROM mustahiq m
 		INNER JOIN asnaf a ON m.asnafID = a.id
+		LEFT JOIN household_members hm ON hm.mustahiq_id = m.id
 	`
 	countQuery := `SELECT COUNT(*) FROM mustahiq m INNER JOIN asnaf a ON m.asnafID = a.id`
 	var args []interface{}
 	argIdx := 1
 	var conditions []string
 
-	// Filter by query (name or address)
+	// Filter by query (name, address or NIK)
 	if filter.Query != "" {
 		search := fmt.Sprintf("%%%s%%", filter.Query)
-		conditions = append(conditions, fmt.Sprintf("(m.name ILIKE $%d OR m.address ILIKE $%d)", argIdx, argIdx+1))
-		args = append(args, search, search)
-		argIdx += 2
+		conditions = append(conditions, fmt.Sprintf("(m.name ILIKE $%d OR m.address ILIKE $%d OR m.nik = $%d)", argIdx, argIdx+1, argIdx+2))
+		args = append(args, search, search, filter.Query)
+		argIdx += 3
 	}
 
 	// Filter by status
@@ -60,6 +63,20 @@ func (r *MustahiqRepository) FindAll(filter repository.MustahiqFilter) ([]*entit
 		argIdx++
 	}
 
+	// Filter by region (part of the address)
+	if filter.Region != "" {
+		conditions = append(conditions, fmt.Sprintf("m.address ILIKE $%d", argIdx))
+		args = append(args, fmt.Sprintf("%%%s%%", filter.Region))
+		argIdx++
+	}
+
+	// Filter by household
+	if filter.HouseholdID != "" {
+		conditions = append(conditions, fmt.Sprintf("m.id IN (SELECT mustahiq_id FROM household_members WHERE household_id = $%d)", argIdx))
+		args = append(args, filter.HouseholdID)
+		argIdx++
+	}
+
 	// Add WHERE clause if there are conditions
 	if len(conditions) > 0 {
 		whereClause := " WHERE " + strings.Join(conditions, " AND ")
@@ -94,8 +111,8 @@ func (r *MustahiqRepository) FindAll(filter repository.MustahiqFilter) ([]*entit
 			Asnaf: &entity.Asnaf{}, // Initialize nested asnaf object
 		}
 		err := rows.Scan(
-			&m.ID, &m.Name, &m.PhoneNumber, &m.Address, &m.AsnafID, &m.Status, &m.Description, &m.CreatedAt, &m.UpdatedAt,
-			&m.Asnaf.ID, &m.Asnaf.Name,
+			&m.ID, &m.Name, &m.NIK, &m.PhoneNumber, &m.Address, &m.AsnafID, &m.Status, &m.Description, &m.CreatedAt, &m.UpdatedAt,
+			&m.Asnaf.ID, &m.Asnaf.Name, &m.HouseholdID,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -111,11 +128,12 @@ func (r *MustahiqRepository) FindByID(id string) (*entity.Mustahiq, error) {
 	defer cancel()
 
 	query := `
-		SELECT m.id, m.name, m.phoneNumber, m.address, m.asnafID, m.status, m.description, m.created_at, m.updated_at,
-		       a.id as asnaf_id, a.name as asnaf_name
+		SELECT m.id, m.name, COALESCE(m.nik, ''), m.phoneNumber, m.address, m.asnafID, m.status, m.description, m.created_at, m.updated_at,
+		       a.id as asnaf_id, a.name as asnaf_name, hm.household_id
 		FROM mustahiq m
 		INNER JOIN asnaf a ON m.asnafID = a.id
-		WHERE m.id = $1
+		LEFT JOIN household_members hm ON hm.mustahiq_id = m.id
+		WHERE m.id = COALESCE((SELECT mustahiq_id FROM mustahiq_redirects WHERE old_mustahiq_id = $1), $1)
 		LIMIT 1
 	`
 
@@ -123,8 +141,8 @@ func (r *MustahiqRepository) FindByID(id string) (*entity.Mustahiq, error) {
 		Asnaf: &entity.Asnaf{},
 	}
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&m.ID, &m.Name, &m.PhoneNumber, &m.Address, &m.AsnafID, &m.Status, &m.Description, &m.CreatedAt, &m.UpdatedAt,
-		&m.Asnaf.ID, &m.Asnaf.Name,
+		&m.ID, &m.Name, &m.NIK, &m.PhoneNumber, &m.Address, &m.AsnafID, &m.Status, &m.Description, &m.CreatedAt, &m.UpdatedAt,
+		&m.Asnaf.ID, &m.Asnaf.Name, &m.HouseholdID,
 	)
 	if err != nil {
 		return nil, err
@@ -133,17 +151,24 @@ func (r *MustahiqRepository) FindByID(id string) (*entity.Mustahiq, error) {
 	return m, nil
 }
 
-func (r *MustahiqRepository) Create(mustahiq *entity.Mustahiq) error {
+func (r *MustahiqRepository) Create(mustahiq *entity.Mustahiq, createdByUserID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO mustahiq (id, name, phoneNumber, address, asnafID, status, description, created_at, updated_at)
-		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW(), NOW())
+		INSERT INTO mustahiq (id, name, nik, phoneNumber, address, asnafID, status, description, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, NULLIF($2, ''), $3, $4, $5, $6, $7, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query, mustahiq.Name, mustahiq.PhoneNumber, mustahiq.Address, mustahiq.AsnafID, mustahiq.Status, mustahiq.Description).
+	err = tx.QueryRow(ctx, query, mustahiq.Name, mustahiq.NIK, mustahiq.PhoneNumber, mustahiq.Address, mustahiq.AsnafID, mustahiq.Status, mustahiq.Description).
 		Scan(&mustahiq.ID, &mustahiq.CreatedAt, &mustahiq.UpdatedAt)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
@@ -155,7 +180,19 @@ func (r *MustahiqRepository) Create(mustahiq *entity.Mustahiq) error {
 		return err
 	}
 
-	return nil
+	err = insertMustahiqStatusChange(ctx, tx, &entity.MustahiqStatusChange{
+		MustahiqID:      mustahiq.ID,
+		ToStatus:        mustahiq.Status,
+		EffectiveDate:   mustahiq.CreatedAt.Format("2006-01-02"),
+		Reason:          "registered",
+		ChangedByUserID: &createdByUserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
 }
 
 func (r *MustahiqRepository) Update(mustahiq *entity.Mustahiq) error {
@@ -164,11 +201,11 @@ func (r *MustahiqRepository) Update(mustahiq *entity.Mustahiq) error {
 
 	query := `
 		UPDATE mustahiq
-		SET name = $1, phoneNumber = $2, address = $3, asnafID = $4, status = $5, description = $6, updated_at = NOW()
+		SET name = $1, nik = NULLIF($2, ''), phoneNumber = $3, address = $4, asnafID = $5, description = $6, updated_at = NOW()
 		WHERE id = $7
 	`
 
-	ct, err := r.db.Exec(ctx, query, mustahiq.Name, mustahiq.PhoneNumber, mustahiq.Address, mustahiq.AsnafID, mustahiq.Status, mustahiq.Description, mustahiq.ID)
+	ct, err := r.db.Exec(ctx, query, mustahiq.Name, mustahiq.NIK, mustahiq.PhoneNumber, mustahiq.Address, mustahiq.AsnafID, mustahiq.Description, mustahiq.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
 			return errors.New("nomor telepon sudah terdaftar")
@@ -203,3 +240,361 @@ func (r *MustahiqRepository) Delete(id string) error {
 
 	return nil
 }
+
+func (r *MustahiqRepository) ChangeStatus(change *entity.MustahiqStatusChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Only change the status the caller validated the transition from
+	ct, err := tx.Exec(ctx, `
+		UPDATE mustahiq SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND status = $3
+	`, change.ToStatus, change.MustahiqID, change.FromStatus)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return errors.New("mustahiq status has changed, reload and try again")
+	}
+
+	if err := insertMustahiqStatusChange(ctx, tx, change); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+func (r *MustahiqRepository) FindStatusHistory(mustahiqID string) ([]*entity.MustahiqStatusChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `
+		SELECT h.id, h.mustahiq_id, COALESCE(h.from_status, ''), h.to_status, h.effective_date, h.reason,
+		       h.assessment_id, h.changed_by_user_id, u.name, h.merged_from_mustahiq_id, h.created_at
+		FROM mustahiq_status_history h
+		LEFT JOIN users u ON u.id = h.changed_by_user_id
+		WHERE h.mustahiq_id = $1
+		ORDER BY h.effective_date DESC, h.created_at DESC
+	`, mustahiqID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*entity.MustahiqStatusChange
+	for rows.Next() {
+		h := &entity.MustahiqStatusChange{}
+		var effectiveDate time.Time
+		var changedByName *string
+		err := rows.Scan(
+			&h.ID, &h.MustahiqID, &h.FromStatus, &h.ToStatus, &effectiveDate, &h.Reason,
+			&h.AssessmentID, &h.ChangedByUserID, &changedByName, &h.MergedFromMustahiqID, &h.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		// Convert time.Time to YYYY-MM-DD string
+		h.EffectiveDate = effectiveDate.Format("2006-01-02")
+		if h.ChangedByUserID != nil && changedByName != nil {
+			h.ChangedByUser = &entity.User{ID: *h.ChangedByUserID, Name: *changedByName}
+		}
+		history = append(history, h)
+	}
+
+	return history, nil
+}
+
+func (r *MustahiqRepository) FindStatusesOnDate(mustahiqIDs []string, date string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// History moved over from merged mustahiq is kept for reference only
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT ON (mustahiq_id) mustahiq_id, to_status
+		FROM mustahiq_status_history
+		WHERE mustahiq_id = ANY($1::uuid[]) AND effective_date <= $2 AND merged_from_mustahiq_id IS NULL
+		ORDER BY mustahiq_id, effective_date DESC, created_at DESC
+	`, mustahiqIDs, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]string, len(mustahiqIDs))
+	for rows.Next() {
+		var mustahiqID, status string
+		if err := rows.Scan(&mustahiqID, &status); err != nil {
+			return nil, err
+		}
+		statuses[mustahiqID] = status
+	}
+
+	return statuses, rows.Err()
+}
+
+func (r *MustahiqRepository) Merge(merge *entity.MustahiqMerge, source, target *entity.Mustahiq) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock both mustahiq (in ID order to avoid deadlocks) and make sure neither changed since read
+	rows, err := tx.Query(ctx, `SELECT id, updated_at FROM mustahiq WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`, source.ID, target.ID)
+	if err != nil {
+		return err
+	}
+	locked := 0
+	for rows.Next() {
+		var id string
+		var updatedAt time.Time
+		if err := rows.Scan(&id, &updatedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		if (id == source.ID && !updatedAt.Equal(source.UpdatedAt)) || (id == target.ID && !updatedAt.Equal(target.UpdatedAt)) {
+			rows.Close()
+			return errors.New("mustahiq has changed, reload and try again")
+		}
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if locked != 2 {
+		return errors.New("mustahiq not found")
+	}
+
+	// Household membership: the surviving record takes over the source's place
+	var sourceHouseholdID, sourceRelationship, targetHouseholdID *string
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT household_id FROM household_members WHERE mustahiq_id = $1),
+		       (SELECT relationship FROM household_members WHERE mustahiq_id = $1),
+		       (SELECT household_id FROM household_members WHERE mustahiq_id = $2)
+	`, source.ID, target.ID).Scan(&sourceHouseholdID, &sourceRelationship, &targetHouseholdID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case sourceHouseholdID == nil:
+		// Nothing to move
+	case targetHouseholdID == nil:
+		_, err = tx.Exec(ctx, `UPDATE household_members SET mustahiq_id = $1, name = $2 WHERE mustahiq_id = $3`, target.ID, target.Name, source.ID)
+		if err != nil {
+			return err
+		}
+	case *sourceHouseholdID == *targetHouseholdID:
+		// Same person listed twice in one household: keep the target's row, as head if the source was
+		if _, err := tx.Exec(ctx, `DELETE FROM household_members WHERE mustahiq_id = $1`, source.ID); err != nil {
+			return err
+		}
+		if *sourceRelationship == entity.HouseholdRelationshipHead {
+			_, err = tx.Exec(ctx, `UPDATE household_members SET relationship = $1 WHERE mustahiq_id = $2`, entity.HouseholdRelationshipHead, target.ID)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.New("source and target belong to different households, fix the household membership first")
+	}
+
+	// Totals before moving anything (posted distributions only, as in the mustahiq history report)
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(di.amount), 0)
+		FROM distribution_items di
+		INNER JOIN distributions d ON di.distribution_id = d.id
+		WHERE di.mustahiq_id = $1 AND d.status = 'posted'
+	`, target.ID).Scan(&merge.TotalReceivedBefore)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*),
+		       COALESCE(SUM(di.amount) FILTER (WHERE d.status = 'posted'), 0),
+		       COUNT(DISTINCT di.distribution_id) FILTER (WHERE EXISTS (
+		           SELECT 1 FROM distribution_items t WHERE t.distribution_id = di.distribution_id AND t.mustahiq_id = $2
+		       ))
+		FROM distribution_items di
+		INNER JOIN distributions d ON di.distribution_id = d.id
+		WHERE di.mustahiq_id = $1
+	`, source.ID, target.ID).Scan(&merge.ItemsMoved, &merge.AmountMoved, &merge.SharedDistributions)
+	if err != nil {
+		return err
+	}
+
+	// Move distribution items, assessments and status history to the target
+	if _, err := tx.Exec(ctx, `UPDATE distribution_items SET mustahiq_id = $1 WHERE mustahiq_id = $2`, target.ID, source.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE distribution_aid_rule_overrides SET mustahiq_id = $1 WHERE mustahiq_id = $2`, target.ID, source.ID); err != nil {
+		return err
+	}
+
+	ct, err := tx.Exec(ctx, `UPDATE mustahiq_assessments SET mustahiq_id = $1 WHERE mustahiq_id = $2`, target.ID, source.ID)
+	if err != nil {
+		return err
+	}
+	merge.AssessmentsMoved = int(ct.RowsAffected())
+
+	ct, err = tx.Exec(ctx, `
+		UPDATE mustahiq_status_history
+		SET mustahiq_id = $1, merged_from_mustahiq_id = COALESCE(merged_from_mustahiq_id, $2)
+		WHERE mustahiq_id = $2
+	`, target.ID, source.ID)
+	if err != nil {
+		return err
+	}
+	merge.StatusChangesMoved = int(ct.RowsAffected())
+
+	// Delete the source first so the target can take over its phone number
+	if _, err := tx.Exec(ctx, `DELETE FROM mustahiq WHERE id = $1`, source.ID); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE mustahiq
+		SET name = $1, nik = NULLIF($2, ''), phoneNumber = $3, address = $4, asnafID = $5, description = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING updated_at
+	`, target.Name, target.NIK, target.PhoneNumber, target.Address, target.AsnafID, target.Description, target.ID).Scan(&target.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("nomor telepon sudah terdaftar")
+		}
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("asnaf tidak ditemukan")
+		}
+		return err
+	}
+
+	// Recompute the target's total after the move
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(di.amount), 0)
+		FROM distribution_items di
+		INNER JOIN distributions d ON di.distribution_id = d.id
+		WHERE di.mustahiq_id = $1 AND d.status = 'posted'
+	`, target.ID).Scan(&merge.TotalReceivedAfter)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO mustahiq_merges (
+			id, source_mustahiq_id, target_mustahiq_id, reason, items_moved, amount_moved, assessments_moved,
+			status_changes_moved, shared_distributions, total_received_before, total_received_after, merged_by_user_id, created_at
+		)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+		RETURNING id, created_at
+	`,
+		source.ID, target.ID, merge.Reason, merge.ItemsMoved, merge.AmountMoved, merge.AssessmentsMoved,
+		merge.StatusChangesMoved, merge.SharedDistributions, merge.TotalReceivedBefore, merge.TotalReceivedAfter, merge.MergedByUserID,
+	).Scan(&merge.ID, &merge.CreatedAt)
+	if err != nil {
+		return err
+	}
+	merge.SourceMustahiqID = source.ID
+	merge.TargetMustahiqID = target.ID
+
+	if err := insertMergeFields(ctx, tx, mustahiqMergeFields, merge.ID, merge.Fields); err != nil {
+		return err
+	}
+
+	// Old IDs pointing at the source now point at the target, then redirect the source itself
+	_, err = tx.Exec(ctx, `UPDATE mustahiq_redirects SET mustahiq_id = $1 WHERE mustahiq_id = $2`, target.ID, source.ID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO mustahiq_redirects (old_mustahiq_id, mustahiq_id, merge_id, created_at)
+		VALUES ($1, $2, $3, NOW())
+	`, source.ID, target.ID, merge.ID)
+	if err != nil {
+		return err
+	}
+
+	// Reviewed duplicate pairs of the source are no longer relevant
+	_, err = tx.Exec(ctx, `
+		DELETE FROM duplicate_dismissals
+		WHERE entity_type = 'mustahiq' AND (record_a_id = $1 OR record_b_id = $1)
+	`, source.ID)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+func (r *MustahiqRepository) FindMerges(mustahiqID string) ([]*entity.MustahiqMerge, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Include merges into IDs that were themselves merged into this mustahiq later
+	rows, err := r.db.Query(ctx, `
+		SELECT mm.id, mm.source_mustahiq_id, mm.target_mustahiq_id, mm.reason, mm.items_moved, mm.amount_moved,
+		       mm.assessments_moved, mm.status_changes_moved, mm.shared_distributions,
+		       mm.total_received_before, mm.total_received_after, mm.merged_by_user_id, u.name, mm.created_at
+		FROM mustahiq_merges mm
+		INNER JOIN users u ON u.id = mm.merged_by_user_id
+		WHERE mm.source_mustahiq_id = $1 OR mm.target_mustahiq_id = $1
+		   OR mm.target_mustahiq_id IN (SELECT old_mustahiq_id FROM mustahiq_redirects WHERE mustahiq_id = $1)
+		ORDER BY mm.created_at DESC
+	`, mustahiqID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var merges []*entity.MustahiqMerge
+	for rows.Next() {
+		m := &entity.MustahiqMerge{MergedByUser: &entity.User{}}
+		err := rows.Scan(
+			&m.ID, &m.SourceMustahiqID, &m.TargetMustahiqID, &m.Reason, &m.ItemsMoved, &m.AmountMoved,
+			&m.AssessmentsMoved, &m.StatusChangesMoved, &m.SharedDistributions,
+			&m.TotalReceivedBefore, &m.TotalReceivedAfter, &m.MergedByUserID, &m.MergedByUser.Name, &m.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		m.MergedByUser.ID = m.MergedByUserID
+		merges = append(merges, m)
+	}
+	rows.Close()
+
+	for _, m := range merges {
+		m.Fields, err = findMergeFields(ctx, r.db, mustahiqMergeFields, m.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return merges, nil
+}
+
+// insertMustahiqStatusChange mencatat satu baris riwayat status di dalam transaksi pemanggil
+func insertMustahiqStatusChange(ctx context.Context, tx pgx.Tx, change *entity.MustahiqStatusChange) error {
+	return tx.QueryRow(ctx, `
+		INSERT INTO mustahiq_status_history (
+			id, mustahiq_id, from_status, to_status, effective_date, reason, assessment_id, changed_by_user_id, created_at
+		)
+		VALUES (gen_random_uuid(), $1, NULLIF($2, ''), $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at
+	`,
+		change.MustahiqID, change.FromStatus, change.ToStatus, change.EffectiveDate, change.Reason,
+		change.AssessmentID, change.ChangedByUserID,
+	).Scan(&change.ID, &change.CreatedAt)
+}
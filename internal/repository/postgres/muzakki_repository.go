@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
@@ -27,19 +28,19 @@ func (r *MuzakkiRepository) FindAll(filter repository.MuzakkiFilter) ([]*entity.
 	defer cancel()
 
 	// Base query
-	query := `SELECT id, name, phoneNumber, address, notes, created_at, updated_at FROM muzakki`
+	query := `SELECT id, name, COALESCE(nik, ''), phoneNumber, address, notes, created_at, updated_at FROM muzakki`
 	countQuery := `SELECT COUNT(*) FROM muzakki`
 	var args []interface{}
 	argIdx := 1
 
-	// Filter by query (name or phone number)
+	// Filter by query (name, phone number or NIK)
 	if filter.Query != "" {
 		search := fmt.Sprintf("%%%s%%", filter.Query)
-		condition := fmt.Sprintf(" WHERE (name ILIKE $%d OR phoneNumber ILIKE $%d)", argIdx, argIdx+1)
+		condition := fmt.Sprintf(" WHERE (name ILIKE $%d OR phoneNumber ILIKE $%d OR nik = $%d)", argIdx, argIdx+1, argIdx+2)
 		query += condition
 		countQuery += condition
-		args = append(args, search, search)
-		argIdx += 2
+		args = append(args, search, search, filter.Query)
+		argIdx += 3
 	}
 
 	// Get total count first
@@ -66,7 +67,7 @@ func (r *MuzakkiRepository) FindAll(filter repository.MuzakkiFilter) ([]*entity.
 	var muzakkis []*entity.Muzakki
 	for rows.Next() {
 		m := &entity.Muzakki{}
-		err := rows.Scan(&m.ID, &m.Name, &m.PhoneNumber, &m.Address, &m.Notes, &m.CreatedAt, &m.UpdatedAt)
+		err := rows.Scan(&m.ID, &m.Name, &m.NIK, &m.PhoneNumber, &m.Address, &m.Notes, &m.CreatedAt, &m.UpdatedAt)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -81,14 +82,14 @@ func (r *MuzakkiRepository) FindByID(id string) (*entity.Muzakki, error) {
 	defer cancel()
 
 	query := `
-		SELECT id, name, phoneNumber, address, notes, created_at, updated_at
+		SELECT id, name, COALESCE(nik, ''), phoneNumber, address, notes, created_at, updated_at
 		FROM muzakki
-		WHERE id = $1
+		WHERE id = COALESCE((SELECT muzakki_id FROM muzakki_redirects WHERE old_muzakki_id = $1), $1)
 		LIMIT 1
 	`
 
 	m := &entity.Muzakki{}
-	err := r.db.QueryRow(ctx, query, id).Scan(&m.ID, &m.Name, &m.PhoneNumber, &m.Address, &m.Notes, &m.CreatedAt, &m.UpdatedAt)
+	err := r.db.QueryRow(ctx, query, id).Scan(&m.ID, &m.Name, &m.NIK, &m.PhoneNumber, &m.Address, &m.Notes, &m.CreatedAt, &m.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -101,12 +102,12 @@ func (r *MuzakkiRepository) Create(muzakki *entity.Muzakki) error {
 	defer cancel()
 
 	query := `
-		INSERT INTO muzakki (id, name, phoneNumber, address, notes, created_at, updated_at)
-		VALUES (gen_random_uuid(), $1, $2, $3, $4, NOW(), NOW())
+		INSERT INTO muzakki (id, name, nik, phoneNumber, address, notes, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, NULLIF($2, ''), $3, $4, $5, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query, muzakki.Name, muzakki.PhoneNumber, muzakki.Address, muzakki.Notes).
+	err := r.db.QueryRow(ctx, query, muzakki.Name, muzakki.NIK, muzakki.PhoneNumber, muzakki.Address, muzakki.Notes).
 		Scan(&muzakki.ID, &muzakki.CreatedAt, &muzakki.UpdatedAt)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
@@ -124,11 +125,11 @@ func (r *MuzakkiRepository) Update(muzakki *entity.Muzakki) error {
 
 	query := `
 		UPDATE muzakki
-		SET name = $1, phoneNumber = $2, address = $3, notes = $4, updated_at = NOW()
-		WHERE id = $5
+		SET name = $1, nik = NULLIF($2, ''), phoneNumber = $3, address = $4, notes = $5, updated_at = NOW()
+		WHERE id = $6
 	`
 
-	ct, err := r.db.Exec(ctx, query, muzakki.Name, muzakki.PhoneNumber, muzakki.Address, muzakki.Notes, muzakki.ID)
+	ct, err := r.db.Exec(ctx, query, muzakki.Name, muzakki.NIK, muzakki.PhoneNumber, muzakki.Address, muzakki.Notes, muzakki.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
 			return errors.New("nomor telepon sudah terdaftar")
@@ -160,3 +161,161 @@ func (r *MuzakkiRepository) Delete(id string) error {
 
 	return nil
 }
+
+func (r *MuzakkiRepository) Merge(merge *entity.MuzakkiMerge, source, target *entity.Muzakki) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock both muzakki (in ID order to avoid deadlocks) and make sure neither changed since read
+	rows, err := tx.Query(ctx, `SELECT id, updated_at FROM muzakki WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`, source.ID, target.ID)
+	if err != nil {
+		return err
+	}
+	locked := 0
+	for rows.Next() {
+		var id string
+		var updatedAt time.Time
+		if err := rows.Scan(&id, &updatedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		if (id == source.ID && !updatedAt.Equal(source.UpdatedAt)) || (id == target.ID && !updatedAt.Equal(target.UpdatedAt)) {
+			rows.Close()
+			return errors.New("muzakki has changed, reload and try again")
+		}
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if locked != 2 {
+		return errors.New("muzakki not found")
+	}
+
+	// Move receipts and zakat calculations to the target
+	ct, err := tx.Exec(ctx, `UPDATE donation_receipts SET muzakki_id = $1 WHERE muzakki_id = $2`, target.ID, source.ID)
+	if err != nil {
+		return err
+	}
+	merge.ReceiptsMoved = int(ct.RowsAffected())
+
+	ct, err = tx.Exec(ctx, `UPDATE zakat_calculations SET muzakki_id = $1 WHERE muzakki_id = $2`, target.ID, source.ID)
+	if err != nil {
+		return err
+	}
+	merge.CalculationsMoved = int(ct.RowsAffected())
+
+	// Delete the source first so the target can take over its phone number
+	if _, err := tx.Exec(ctx, `DELETE FROM muzakki WHERE id = $1`, source.ID); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE muzakki
+		SET name = $1, nik = NULLIF($2, ''), phoneNumber = $3, address = $4, notes = $5, updated_at = NOW()
+		WHERE id = $6
+		RETURNING updated_at
+	`, target.Name, target.NIK, target.PhoneNumber, target.Address, target.Notes, target.ID).Scan(&target.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return errors.New("nomor telepon sudah terdaftar")
+		}
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO muzakki_merges (
+			id, source_muzakki_id, target_muzakki_id, reason, receipts_moved, calculations_moved, merged_by_user_id, created_at
+		)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`,
+		source.ID, target.ID, merge.Reason, merge.ReceiptsMoved, merge.CalculationsMoved, merge.MergedByUserID,
+	).Scan(&merge.ID, &merge.CreatedAt)
+	if err != nil {
+		return err
+	}
+	merge.SourceMuzakkiID = source.ID
+	merge.TargetMuzakkiID = target.ID
+
+	if err := insertMergeFields(ctx, tx, muzakkiMergeFields, merge.ID, merge.Fields); err != nil {
+		return err
+	}
+
+	// Old IDs pointing at the source now point at the target, then redirect the source itself
+	_, err = tx.Exec(ctx, `UPDATE muzakki_redirects SET muzakki_id = $1 WHERE muzakki_id = $2`, target.ID, source.ID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO muzakki_redirects (old_muzakki_id, muzakki_id, merge_id, created_at)
+		VALUES ($1, $2, $3, NOW())
+	`, source.ID, target.ID, merge.ID)
+	if err != nil {
+		return err
+	}
+
+	// Reviewed duplicate pairs of the source are no longer relevant
+	_, err = tx.Exec(ctx, `
+		DELETE FROM duplicate_dismissals
+		WHERE entity_type = 'muzakki' AND (record_a_id = $1 OR record_b_id = $1)
+	`, source.ID)
+	if err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+func (r *MuzakkiRepository) FindMerges(muzakkiID string) ([]*entity.MuzakkiMerge, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Include merges into IDs that were themselves merged into this muzakki later
+	rows, err := r.db.Query(ctx, `
+		SELECT mm.id, mm.source_muzakki_id, mm.target_muzakki_id, mm.reason, mm.receipts_moved, mm.calculations_moved,
+		       mm.merged_by_user_id, u.name, mm.created_at
+		FROM muzakki_merges mm
+		INNER JOIN users u ON u.id = mm.merged_by_user_id
+		WHERE mm.source_muzakki_id = $1 OR mm.target_muzakki_id = $1
+		   OR mm.target_muzakki_id IN (SELECT old_muzakki_id FROM muzakki_redirects WHERE muzakki_id = $1)
+		ORDER BY mm.created_at DESC
+	`, muzakkiID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var merges []*entity.MuzakkiMerge
+	for rows.Next() {
+		m := &entity.MuzakkiMerge{MergedByUser: &entity.User{}}
+		err := rows.Scan(
+			&m.ID, &m.SourceMuzakkiID, &m.TargetMuzakkiID, &m.Reason, &m.ReceiptsMoved, &m.CalculationsMoved,
+			&m.MergedByUserID, &m.MergedByUser.Name, &m.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		m.MergedByUser.ID = m.MergedByUserID
+		merges = append(merges, m)
+	}
+	rows.Close()
+
+	for _, m := range merges {
+		m.Fields, err = findMergeFields(ctx, r.db, muzakkiMergeFields, m.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return merges, nil
+}
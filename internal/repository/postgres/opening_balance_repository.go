@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type OpeningBalanceRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewOpeningBalanceRepository(db *pgxpool.Pool, log *logrus.Logger) *OpeningBalanceRepository {
+	return &OpeningBalanceRepository{db: db, log: log}
+}
+
+const openingBalanceSelectSQL = `
+		SELECT ob.id, ob.effective_date, ob.fund_ledger, ob.financial_account_id, fa.name, fa.account_type,
+		       ob.amount, COALESCE(ob.notes, ''), ob.status, COALESCE(ob.void_reason, ''), ob.voided_by_user_id, vu.name, ob.voided_at,
+		       ob.created_by_user_id, cu.name, ob.created_at, ob.updated_at
+		FROM opening_balances ob
+		INNER JOIN financial_accounts fa ON fa.id = ob.financial_account_id
+		INNER JOIN users cu ON cu.id = ob.created_by_user_id
+		LEFT JOIN users vu ON vu.id = ob.voided_by_user_id
+	`
+
+func scanOpeningBalance(row rowScanner) (*entity.OpeningBalance, error) {
+	ob := &entity.OpeningBalance{
+		FinancialAccount: &entity.FinancialAccount{},
+		CreatedByUser:    &entity.User{},
+	}
+	var effectiveDate time.Time
+	var voidedByName *string
+	err := row.Scan(
+		&ob.ID, &effectiveDate, &ob.FundLedger, &ob.FinancialAccountID, &ob.FinancialAccount.Name, &ob.FinancialAccount.AccountType,
+		&ob.Amount, &ob.Notes, &ob.Status, &ob.VoidReason, &ob.VoidedByUserID, &voidedByName, &ob.VoidedAt,
+		&ob.CreatedByUserID, &ob.CreatedByUser.Name, &ob.CreatedAt, &ob.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	ob.EffectiveDate = effectiveDate.Format("2006-01-02")
+	ob.FinancialAccount.ID = ob.FinancialAccountID
+	ob.CreatedByUser.ID = ob.CreatedByUserID
+	if ob.VoidedByUserID != nil && voidedByName != nil {
+		ob.VoidedByUser = &entity.User{ID: *ob.VoidedByUserID, Name: *voidedByName}
+	}
+
+	return ob, nil
+}
+
+func (r *OpeningBalanceRepository) FindAll(filter repository.OpeningBalanceFilter) ([]*entity.OpeningBalance, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := openingBalanceSelectSQL
+	countQuery := `SELECT COUNT(*) FROM opening_balances ob`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by fund_ledger
+	if filter.FundLedger != "" {
+		conditions = append(conditions, fmt.Sprintf("ob.fund_ledger = $%d", argIdx))
+		args = append(args, filter.FundLedger)
+		argIdx++
+	}
+
+	// Filter by financial_account_id
+	if filter.FinancialAccountID != "" {
+		conditions = append(conditions, fmt.Sprintf("ob.financial_account_id = $%d", argIdx))
+		args = append(args, filter.FinancialAccountID)
+		argIdx++
+	}
+
+	// Filter by status
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("ob.status = $%d", argIdx))
+		args = append(args, filter.Status)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY ob.effective_date DESC, ob.created_at DESC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var openingBalances []*entity.OpeningBalance
+	for rows.Next() {
+		ob, err := scanOpeningBalance(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		openingBalances = append(openingBalances, ob)
+	}
+
+	return openingBalances, total, nil
+}
+
+func (r *OpeningBalanceRepository) FindByID(id string) (*entity.OpeningBalance, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	ob, err := scanOpeningBalance(r.db.QueryRow(ctx, openingBalanceSelectSQL+` WHERE ob.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("opening balance not found")
+		}
+		return nil, err
+	}
+
+	return ob, nil
+}
+
+// Create menyimpan saldo awal dan jurnalnya dalam satu transaksi
+func (r *OpeningBalanceRepository) Create(openingBalance *entity.OpeningBalance) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO opening_balances (id, effective_date, fund_ledger, financial_account_id, amount, notes, status, created_by_user_id, created_at, updated_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, 'posted', $6, NOW(), NOW())
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		openingBalance.EffectiveDate,
+		openingBalance.FundLedger,
+		openingBalance.FinancialAccountID,
+		openingBalance.Amount,
+		openingBalance.Notes,
+		openingBalance.CreatedByUserID,
+	).Scan(&openingBalance.ID, &openingBalance.Status, &openingBalance.CreatedAt, &openingBalance.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("financial account or user not found")
+		}
+		return err
+	}
+
+	if err := journalOpeningBalance(ctx, tx, openingBalance.ID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
+
+// Void membatalkan saldo awal posted dan membalik jurnalnya
+func (r *OpeningBalanceRepository) Void(id, reason, voidedByUserID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE opening_balances
+		SET status = 'voided', void_reason = $1, voided_by_user_id = $2, voided_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = 'posted'
+	`
+
+	ct, err := tx.Exec(ctx, query, reason, voidedByUserID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return errors.New("posted opening balance not found")
+	}
+
+	if err := reverseJournal(ctx, tx, entity.JournalSourceOpeningBalance, id, "Pembatalan saldo awal: "+reason); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
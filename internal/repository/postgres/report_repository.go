@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -26,52 +27,58 @@ func (r *ReportRepository) GetIncomeSummary(dateFrom, dateTo, groupBy string) ([
 
 	var periodFormat string
 	if groupBy == "daily" {
-		periodFormat = "dr.receipt_date::TEXT"
+		periodFormat = "je.entry_date::TEXT"
 	} else { // monthly (default)
-		periodFormat = "TO_CHAR(dr.receipt_date, 'YYYY-MM')"
+		periodFormat = "TO_CHAR(je.entry_date, 'YYYY-MM')"
 	}
 
-	// Complex query with CASE WHEN to pivot fund_types into columns
+	// Read receipt journals from the ledger and pivot fund accounts into columns
+	// (kredit - debit, so reversal journals of voided receipts cancel out).
+	// Opening balance journals are reported separately as carried_over, outside the total.
 	query := `
 		SELECT 
 			` + periodFormat + ` as period,
 			COALESCE(SUM(CASE 
-				WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'fitrah' THEN dri.amount 
+				WHEN je.source_type = 'donation_receipt' AND la.fund_ledger = 'zakat_fitrah' THEN jl.credit - jl.debit 
 				ELSE 0 
 			END), 0) as zakat_fitrah,
 			COALESCE(SUM(CASE 
-				WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'maal' THEN dri.amount 
+				WHEN je.source_type = 'donation_receipt' AND la.fund_ledger = 'zakat_maal' THEN jl.credit - jl.debit 
 				ELSE 0 
 			END), 0) as zakat_maal,
 			COALESCE(SUM(CASE 
-				WHEN dri.fund_type = 'infaq' THEN dri.amount 
+				WHEN je.source_type = 'donation_receipt' AND la.fund_ledger = 'infaq' THEN jl.credit - jl.debit 
 				ELSE 0 
 			END), 0) as infaq,
 			COALESCE(SUM(CASE 
-				WHEN dri.fund_type = 'sadaqah' THEN dri.amount 
+				WHEN je.source_type = 'donation_receipt' AND la.fund_ledger = 'sadaqah' THEN jl.credit - jl.debit 
 				ELSE 0 
 			END), 0) as sadaqah,
-			COALESCE(SUM(dri.amount), 0) as total
-		FROM donation_receipts dr
-		INNER JOIN donation_receipt_items dri ON dr.id = dri.receipt_id
-		WHERE 1=1
+			COALESCE(SUM(CASE 
+				WHEN je.source_type = 'donation_receipt' AND la.fund_ledger = 'amil' THEN jl.credit - jl.debit 
+				ELSE 0 
+			END), 0) as amil,
+			COALESCE(SUM(jl.credit - jl.debit) FILTER (WHERE je.source_type = 'donation_receipt'), 0) as total,
+			COALESCE(SUM(jl.credit - jl.debit) FILTER (WHERE je.source_type = 'opening_balance'), 0) as carried_over
+		FROM ` + fundJournalLinesJoinSQL + `
+		WHERE je.source_type IN ('donation_receipt', 'opening_balance')
 	`
 
 	var args []interface{}
 	argIdx := 1
 
 	if dateFrom != "" {
-		query += ` AND dr.receipt_date >= $` + string(rune(argIdx+'0'))
+		query += ` AND je.entry_date >= $` + string(rune(argIdx+'0'))
 		args = append(args, dateFrom)
 		argIdx++
 	}
 	if dateTo != "" {
-		query += ` AND dr.receipt_date <= $` + string(rune(argIdx+'0'))
+		query += ` AND je.entry_date <= $` + string(rune(argIdx+'0'))
 		args = append(args, dateTo)
 		argIdx++
 	}
 
-	query += ` GROUP BY period ORDER BY period ASC`
+	query += ` GROUP BY period HAVING SUM(jl.credit - jl.debit) <> 0 ORDER BY period ASC`
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
@@ -84,7 +91,7 @@ func (r *ReportRepository) GetIncomeSummary(dateFrom, dateTo, groupBy string) ([
 		var result repository.IncomeSummaryResult
 		err := rows.Scan(
 			&result.Period, &result.ZakatFitrah, &result.ZakatMaal,
-			&result.Infaq, &result.Sadaqah, &result.Total,
+			&result.Infaq, &result.Sadaqah, &result.Amil, &result.Total, &result.CarriedOver,
 		)
 		if err != nil {
 			return nil, err
@@ -113,12 +120,14 @@ func (r *ReportRepository) getDistributionSummaryByAsnaf(ctx context.Context, da
 		SELECT 
 			a.name as asnaf_name,
 			COUNT(DISTINCT di.mustahiq_id) as beneficiary_count,
+			COUNT(DISTINCT COALESCE(hm.household_id, di.mustahiq_id)) as household_count,
 			COALESCE(SUM(di.amount), 0) as total_amount
 		FROM distribution_items di
 		INNER JOIN distributions d ON di.distribution_id = d.id
 		INNER JOIN mustahiq m ON di.mustahiq_id = m.id
 		INNER JOIN asnaf a ON m.asnafID = a.id
-		WHERE 1=1
+		LEFT JOIN household_members hm ON hm.mustahiq_id = di.mustahiq_id
+		WHERE d.status = 'posted'
 	`
 
 	var args []interface{}
@@ -151,7 +160,7 @@ func (r *ReportRepository) getDistributionSummaryByAsnaf(ctx context.Context, da
 	var results []repository.DistributionSummaryByAsnafResult
 	for rows.Next() {
 		var result repository.DistributionSummaryByAsnafResult
-		err := rows.Scan(&result.AsnafName, &result.BeneficiaryCount, &result.TotalAmount)
+		err := rows.Scan(&result.AsnafName, &result.BeneficiaryCount, &result.HouseholdCount, &result.TotalAmount)
 		if err != nil {
 			return nil, err
 		}
@@ -167,11 +176,13 @@ func (r *ReportRepository) getDistributionSummaryByProgram(ctx context.Context,
 			COALESCE(p.name, 'No Program') as program_name,
 			d.source_fund_type,
 			COUNT(DISTINCT di.mustahiq_id) as beneficiary_count,
+			COUNT(DISTINCT COALESCE(hm.household_id, di.mustahiq_id)) as household_count,
 			COALESCE(SUM(di.amount), 0) as total_amount
 		FROM distributions d
 		LEFT JOIN programs p ON d.program_id = p.id
 		INNER JOIN distribution_items di ON d.id = di.distribution_id
-		WHERE 1=1
+		LEFT JOIN household_members hm ON hm.mustahiq_id = di.mustahiq_id
+		WHERE d.status = 'posted'
 	`
 
 	var args []interface{}
@@ -204,7 +215,7 @@ func (r *ReportRepository) getDistributionSummaryByProgram(ctx context.Context,
 	var results []repository.DistributionSummaryByProgramResult
 	for rows.Next() {
 		var result repository.DistributionSummaryByProgramResult
-		err := rows.Scan(&result.ProgramName, &result.SourceFundType, &result.BeneficiaryCount, &result.TotalAmount)
+		err := rows.Scan(&result.ProgramName, &result.SourceFundType, &result.BeneficiaryCount, &result.HouseholdCount, &result.TotalAmount)
 		if err != nil {
 			return nil, err
 		}
@@ -218,91 +229,454 @@ func (r *ReportRepository) GetFundBalance(dateFrom, dateTo string) ([]repository
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	// Query to get total IN and OUT for each fund type
+	// Query to get total IN (receipt journals), OUT (distribution journals) and
+	// carried over opening balances for each fund account in the ledger (amil + fund types)
 	query := `
-		WITH income AS (
+		WITH movements AS (
 			SELECT 
-				CASE 
-					WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'fitrah' THEN 'zakat_fitrah'
-					WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'maal' THEN 'zakat_maal'
-					WHEN dri.fund_type = 'infaq' THEN 'infaq'
-					WHEN dri.fund_type = 'sadaqah' THEN 'sadaqah'
-				END as fund_type,
-				COALESCE(SUM(dri.amount), 0) as total_in
-			FROM donation_receipts dr
-			INNER JOIN donation_receipt_items dri ON dr.id = dri.receipt_id
-			WHERE 1=1
+				la.fund_ledger as fund_type,
+				je.source_type,
+				jl.credit - jl.debit as amount
+			FROM ` + fundJournalLinesJoinSQL + `
+			WHERE TRUE
 	`
 
 	var args []interface{}
 	argIdx := 1
 
 	if dateFrom != "" {
-		query += ` AND dr.receipt_date >= $` + string(rune(argIdx+'0'))
+		query += ` AND je.entry_date >= $` + string(rune(argIdx+'0'))
 		args = append(args, dateFrom)
 		argIdx++
 	}
 	if dateTo != "" {
-		query += ` AND dr.receipt_date <= $` + string(rune(argIdx+'0'))
+		query += ` AND je.entry_date <= $` + string(rune(argIdx+'0'))
 		args = append(args, dateTo)
 		argIdx++
 	}
 
 	query += `
-			GROUP BY dri.fund_type, dri.zakat_type
-		),
-		outgoing AS (
-			SELECT 
-				d.source_fund_type as fund_type,
-				COALESCE(SUM(d.total_amount), 0) as total_out
-			FROM distributions d
-			WHERE 1=1
+		)
+		SELECT 
+			la.fund_ledger as fund_type,
+			COALESCE(SUM(m.amount) FILTER (WHERE m.source_type = 'donation_receipt'), 0) as total_in,
+			COALESCE(-SUM(m.amount) FILTER (WHERE m.source_type = 'distribution'), 0) as total_out,
+			COALESCE(SUM(m.amount) FILTER (WHERE m.source_type = 'opening_balance'), 0) as carried_over,
+			COALESCE(SUM(m.amount), 0) as balance
+		FROM ledger_accounts la
+		LEFT JOIN movements m ON m.fund_type = la.fund_ledger
+		WHERE la.fund_ledger IS NOT NULL
+		GROUP BY la.fund_ledger
+		ORDER BY la.fund_ledger
 	`
 
-	// Reset argIdx for outgoing query (same date params)
-	outgoingArgIdx := 1
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []repository.FundBalanceResult
+	for rows.Next() {
+		var result repository.FundBalanceResult
+		err := rows.Scan(&result.FundType, &result.TotalIn, &result.TotalOut, &result.CarriedOver, &result.Balance)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	rows.Close()
+
+	// Opening balance per fund account before dateFrom
 	if dateFrom != "" {
-		query += ` AND d.distribution_date >= $` + string(rune(outgoingArgIdx+'0'))
-		outgoingArgIdx++
+		rows, err := r.db.Query(ctx, `
+			WITH `+ledgerOpeningBalanceCTE+`
+			SELECT la.fund_ledger, o.balance
+			FROM ledger_accounts la
+			INNER JOIN opening o ON o.account_id = la.id
+			WHERE la.fund_ledger IS NOT NULL
+		`, dateFrom)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		openings := make(map[string]float64)
+		for rows.Next() {
+			var fundType string
+			var balance float64
+			if err := rows.Scan(&fundType, &balance); err != nil {
+				return nil, err
+			}
+			openings[fundType] = balance
+		}
+
+		for i := range results {
+			results[i].OpeningBalance = openings[results[i].FundType]
+		}
+	}
+
+	for i := range results {
+		results[i].ClosingBalance = results[i].OpeningBalance + results[i].Balance
 	}
+
+	return results, nil
+}
+
+func (r *ReportRepository) GetTrialBalance(dateTo string) ([]repository.TrialBalanceRow, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Total debit and credit per account up to dateTo (all accounts, including unused ones)
+	query := `
+		SELECT 
+			la.code,
+			la.name,
+			la.account_type,
+			COALESCE(SUM(jl.debit), 0) as debit,
+			COALESCE(SUM(jl.credit), 0) as credit
+		FROM ledger_accounts la
+		LEFT JOIN (
+			SELECT jl.account_id, jl.debit, jl.credit
+			FROM journal_lines jl
+			INNER JOIN journal_entries je ON je.id = jl.entry_id
+	`
+
+	var args []interface{}
 	if dateTo != "" {
-		query += ` AND d.distribution_date <= $` + string(rune(outgoingArgIdx+'0'))
-		outgoingArgIdx++
+		query += ` WHERE je.entry_date <= $1`
+		args = append(args, dateTo)
 	}
 
 	query += `
-			GROUP BY d.source_fund_type
+		) jl ON jl.account_id = la.id
+		GROUP BY la.id, la.code, la.name, la.account_type
+		ORDER BY la.code
+	`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []repository.TrialBalanceRow
+	for rows.Next() {
+		var result repository.TrialBalanceRow
+		err := rows.Scan(&result.AccountCode, &result.AccountName, &result.AccountType, &result.Debit, &result.Credit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ledgerOpeningBalanceCTE menghitung saldo awal setiap akun buku besar sebelum $1::date:
+// snapshot periode tertutup terakhir yang berakhir sebelum $1, ditambah jurnal sesudahnya.
+// Saldo mengikuti sisi normal akun (aset = debit - kredit, dana = kredit - debit).
+// Tanpa $1 semua saldo awal 0.
+const ledgerOpeningBalanceCTE = `
+		closed_anchor AS (
+			SELECT id, period_end
+			FROM fiscal_periods
+			WHERE status = 'closed' AND period_end < $1::date
+			ORDER BY period_end DESC
+			LIMIT 1
 		),
-		all_fund_types AS (
-			SELECT 'zakat_fitrah' as fund_type
-			UNION SELECT 'zakat_maal'
-			UNION SELECT 'infaq'
-			UNION SELECT 'sadaqah'
-		)
+		opening AS (
+			SELECT
+				la.id as account_id,
+				COALESCE((
+					SELECT b.closing_balance
+					FROM fiscal_period_balances b
+					INNER JOIN closed_anchor a ON a.id = b.period_id
+					WHERE b.ledger_account_id = la.id
+				), 0) + COALESCE((
+					SELECT SUM(jl.debit - jl.credit)
+					FROM journal_lines jl
+					INNER JOIN journal_entries je ON je.id = jl.entry_id
+					WHERE jl.account_id = la.id
+					  AND je.entry_date < $1::date
+					  AND je.entry_date > COALESCE((SELECT period_end FROM closed_anchor), '-infinity'::date)
+				), 0) * CASE WHEN la.account_type = 'asset' THEN 1 ELSE -1 END as balance
+			FROM ledger_accounts la
+		)`
+
+// nullableDate mengubah filter tanggal kosong menjadi NULL
+func nullableDate(date string) *string {
+	if date == "" {
+		return nil
+	}
+	return &date
+}
+
+func (r *ReportRepository) GetAccountBalance(dateFrom, dateTo string) ([]repository.AccountBalanceResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Rekening adalah akun aset: debit menambah saldo, kredit mengurangi.
+	// Saldo awal = snapshot periode tertutup + jurnal sebelum dateFrom, mutasi = jurnal dalam periode.
+	// Saldo awal migrasi (opening_balance) dalam periode dipisah dari mutasi masuk/keluar.
+	query := `
+		WITH ` + ledgerOpeningBalanceCTE + `
 		SELECT 
-			aft.fund_type,
-			COALESCE(i.total_in, 0) as total_in,
-			COALESCE(o.total_out, 0) as total_out,
-			COALESCE(i.total_in, 0) - COALESCE(o.total_out, 0) as balance
-		FROM all_fund_types aft
-		LEFT JOIN income i ON aft.fund_type = i.fund_type
-		LEFT JOIN outgoing o ON aft.fund_type = o.fund_type
-		ORDER BY aft.fund_type
+			fa.id,
+			fa.name,
+			fa.account_type,
+			o.balance as opening_balance,
+			COALESCE(SUM(m.debit - m.credit) FILTER (WHERE m.source_type = 'opening_balance'), 0) as carried_over,
+			COALESCE(SUM(m.debit) FILTER (WHERE m.source_type <> 'opening_balance'), 0) as total_in,
+			COALESCE(SUM(m.credit) FILTER (WHERE m.source_type <> 'opening_balance'), 0) as total_out
+		FROM financial_accounts fa
+		INNER JOIN opening o ON o.account_id = fa.ledger_account_id
+		LEFT JOIN (
+			SELECT jl.account_id, je.source_type, jl.debit, jl.credit
+			FROM journal_lines jl
+			INNER JOIN journal_entries je ON je.id = jl.entry_id
+			WHERE ($1::date IS NULL OR je.entry_date >= $1::date)
+			  AND ($2::date IS NULL OR je.entry_date <= $2::date)
+		) m ON m.account_id = fa.ledger_account_id
+		GROUP BY fa.id, fa.name, fa.account_type, o.balance
+		ORDER BY fa.name
 	`
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := r.db.Query(ctx, query, nullableDate(dateFrom), nullableDate(dateTo))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var results []repository.FundBalanceResult
+	var results []repository.AccountBalanceResult
 	for rows.Next() {
-		var result repository.FundBalanceResult
-		err := rows.Scan(&result.FundType, &result.TotalIn, &result.TotalOut, &result.Balance)
+		var result repository.AccountBalanceResult
+		err := rows.Scan(&result.FinancialAccountID, &result.Name, &result.AccountType,
+			&result.OpeningBalance, &result.CarriedOver, &result.TotalIn, &result.TotalOut)
 		if err != nil {
 			return nil, err
 		}
+		result.ClosingBalance = result.OpeningBalance + result.CarriedOver + result.TotalIn - result.TotalOut
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (r *ReportRepository) GetAccountMovements(financialAccountID, dateFrom, dateTo string) (*repository.AccountMovementResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Get account info and opening balance
+	result := &repository.AccountMovementResult{DateFrom: dateFrom, DateTo: dateTo}
+	var ledgerAccountID string
+	err := r.db.QueryRow(ctx, `
+		WITH `+ledgerOpeningBalanceCTE+`
+		SELECT fa.id, fa.name, fa.account_type, fa.ledger_account_id, o.balance
+		FROM financial_accounts fa
+		INNER JOIN opening o ON o.account_id = fa.ledger_account_id
+		WHERE fa.id = $2
+	`, nullableDate(dateFrom), financialAccountID).Scan(
+		&result.FinancialAccountID, &result.Name, &result.AccountType, &ledgerAccountID, &result.OpeningBalance,
+	)
+	if err != nil {
+		return nil, errors.New("financial account not found")
+	}
+
+	// Get movements in the period, oldest first for the running balance
+	rows, err := r.db.Query(ctx, `
+		SELECT je.entry_date, je.description, je.source_type, je.source_id, jl.debit, jl.credit
+		FROM journal_lines jl
+		INNER JOIN journal_entries je ON je.id = jl.entry_id
+		WHERE jl.account_id = $1
+		  AND ($2::date IS NULL OR je.entry_date >= $2::date)
+		  AND ($3::date IS NULL OR je.entry_date <= $3::date)
+		ORDER BY je.entry_date, je.created_at, je.id
+	`, ledgerAccountID, nullableDate(dateFrom), nullableDate(dateTo))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balance := result.OpeningBalance
+	var movements []repository.AccountMovementItem
+	for rows.Next() {
+		var item repository.AccountMovementItem
+		var entryDate time.Time
+		err := rows.Scan(&entryDate, &item.Description, &item.SourceType, &item.SourceID, &item.In, &item.Out)
+		if err != nil {
+			return nil, err
+		}
+		// Convert time.Time to YYYY-MM-DD string
+		item.EntryDate = entryDate.Format("2006-01-02")
+		balance += item.In - item.Out
+		item.Balance = balance
+		if item.SourceType == entity.JournalSourceOpeningBalance {
+			result.CarriedOver += item.In - item.Out
+		} else {
+			result.TotalIn += item.In
+			result.TotalOut += item.Out
+		}
+		movements = append(movements, item)
+	}
+
+	result.Movements = movements
+	result.ClosingBalance = balance
+
+	return result, nil
+}
+
+func (r *ReportRepository) GetUnreconciled(financialAccountID, dateFrom, dateTo string) (*repository.UnreconciledResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var accountID *string
+	if financialAccountID != "" {
+		accountID = &financialAccountID
+	}
+	args := []interface{}{accountID, nullableDate(dateFrom), nullableDate(dateTo)}
+
+	// Statement lines not yet confirmed, or confirmed against a transaction that is no longer posted
+	linesQuery := `
+		SELECT l.id, l.statement_id, s.financial_account_id, fa.name, l.transaction_date, l.description, l.reference, l.amount,
+		       CASE WHEN l.status = 'confirmed' THEN 'source_voided' ELSE l.status END as issue,
+		       COALESCE(l.flag_reason, '')
+		FROM bank_statement_lines l
+		INNER JOIN bank_statements s ON s.id = l.statement_id
+		INNER JOIN financial_accounts fa ON fa.id = s.financial_account_id
+		WHERE (
+		        l.status <> 'confirmed'
+		        OR EXISTS (
+		            SELECT 1
+		            FROM bank_statement_matches m
+		            LEFT JOIN donation_receipts dr ON m.source_type = 'donation_receipt' AND dr.id = m.source_id
+		            LEFT JOIN distributions d ON m.source_type = 'distribution' AND d.id = m.source_id
+		            WHERE m.line_id = l.id AND COALESCE(dr.status, d.status, '') <> 'posted'
+		        )
+		      )
+		  AND ($1::uuid IS NULL OR s.financial_account_id = $1::uuid)
+		  AND ($2::date IS NULL OR l.transaction_date >= $2::date)
+		  AND ($3::date IS NULL OR l.transaction_date <= $3::date)
+		ORDER BY fa.name, l.transaction_date, l.line_number
+	`
+
+	rows, err := r.db.Query(ctx, linesQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &repository.UnreconciledResult{}
+	for rows.Next() {
+		var line repository.UnreconciledLine
+		var transactionDate time.Time
+		err := rows.Scan(&line.LineID, &line.StatementID, &line.FinancialAccountID, &line.AccountName, &transactionDate,
+			&line.Description, &line.Reference, &line.Amount, &line.Issue, &line.FlagReason)
+		if err != nil {
+			return nil, err
+		}
+		// Convert time.Time to YYYY-MM-DD string
+		line.TransactionDate = transactionDate.Format("2006-01-02")
+		result.Lines = append(result.Lines, line)
+		result.TotalLines += line.Amount
+	}
+	rows.Close()
+
+	// Posted transactions inside the period covered by the account's imported statements
+	// that have no confirmed statement line
+	transactionsQuery := `
+		WITH coverage AS (
+			SELECT financial_account_id, MIN(date_from) as date_from, MAX(date_to) as date_to
+			FROM bank_statements
+			GROUP BY financial_account_id
+		),
+		reconciled AS (
+			SELECT m.source_type, m.source_id
+			FROM bank_statement_matches m
+			INNER JOIN bank_statement_lines l ON l.id = m.line_id
+			WHERE l.status = 'confirmed'
+		),
+		transactions AS (
+			SELECT 'donation_receipt' as source_type, dr.id, COALESCE(dr.receipt_number, '') as number, dr.receipt_date as tx_date,
+			       dr.financial_account_id, mz.name as label, dr.total_amount as amount
+			FROM donation_receipts dr
+			INNER JOIN muzakki mz ON mz.id = dr.muzakki_id
+			WHERE dr.status = 'posted'
+			UNION ALL
+			SELECT 'distribution', d.id, '', d.distribution_date, d.financial_account_id,
+			       COALESCE(p.name, d.source_fund_type), -d.total_amount
+			FROM distributions d
+			LEFT JOIN programs p ON p.id = d.program_id
+			WHERE d.status = 'posted' AND d.total_amount > 0 -- in-kind only distributions move no money
+		)
+		SELECT t.source_type, t.id, t.number, t.tx_date, t.financial_account_id, fa.name, t.label, t.amount
+		FROM transactions t
+		INNER JOIN coverage c ON c.financial_account_id = t.financial_account_id
+		                     AND t.tx_date BETWEEN c.date_from AND c.date_to
+		INNER JOIN financial_accounts fa ON fa.id = t.financial_account_id
+		WHERE NOT EXISTS (SELECT 1 FROM reconciled rc WHERE rc.source_type = t.source_type AND rc.source_id = t.id)
+		  AND ($1::uuid IS NULL OR t.financial_account_id = $1::uuid)
+		  AND ($2::date IS NULL OR t.tx_date >= $2::date)
+		  AND ($3::date IS NULL OR t.tx_date <= $3::date)
+		ORDER BY fa.name, t.tx_date
+	`
+
+	rows, err = r.db.Query(ctx, transactionsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tx repository.UnreconciledTransaction
+		var txDate time.Time
+		err := rows.Scan(&tx.SourceType, &tx.SourceID, &tx.Number, &txDate, &tx.FinancialAccountID, &tx.AccountName, &tx.Label, &tx.Amount)
+		if err != nil {
+			return nil, err
+		}
+		// Convert time.Time to YYYY-MM-DD string
+		tx.Date = txDate.Format("2006-01-02")
+		result.Transactions = append(result.Transactions, tx)
+		result.TotalTransactions += tx.Amount
+	}
+
+	return result, nil
+}
+
+func (r *ReportRepository) GetStockOnHand(date string) ([]repository.StockOnHandResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Stock card up to the date; reversal movements carry the type of their source,
+	// so voided receipts and distributions cancel out within their own column
+	query := `
+		SELECT
+			commodity,
+			fund_ledger,
+			COALESCE(SUM(quantity) FILTER (WHERE movement_type = 'receipt'), 0) as total_in,
+			COALESCE(-SUM(quantity) FILTER (WHERE movement_type = 'distribution'), 0) as total_out,
+			COALESCE(SUM(quantity) FILTER (WHERE movement_type = 'adjustment'), 0) as adjustment,
+			COALESCE(SUM(quantity), 0) as on_hand
+		FROM stock_movements
+		WHERE ($1::date IS NULL OR movement_date <= $1::date)
+		GROUP BY commodity, fund_ledger
+		ORDER BY commodity, fund_ledger
+	`
+
+	rows, err := r.db.Query(ctx, query, nullableDate(date))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []repository.StockOnHandResult
+	for rows.Next() {
+		var result repository.StockOnHandResult
+		err := rows.Scan(&result.Commodity, &result.FundLedger, &result.TotalIn, &result.TotalOut, &result.Adjustment, &result.OnHand)
+		if err != nil {
+			return nil, err
+		}
+		result.Unit = entity.CommodityUnits[result.Commodity]
 		results = append(results, result)
 	}
 
@@ -318,7 +692,7 @@ func (r *ReportRepository) GetMustahiqHistory(mustahiqID string) (*repository.Mu
 		SELECT m.id, m.name, a.name, m.address
 		FROM mustahiq m
 		INNER JOIN asnaf a ON m.asnafID = a.id
-		WHERE m.id = $1
+		WHERE m.id = COALESCE((SELECT mustahiq_id FROM mustahiq_redirects WHERE old_mustahiq_id = $1), $1)
 		LIMIT 1
 	`
 
@@ -340,11 +714,12 @@ func (r *ReportRepository) GetMustahiqHistory(mustahiqID string) (*repository.Mu
 		FROM distribution_items di
 		INNER JOIN distributions d ON di.distribution_id = d.id
 		LEFT JOIN programs p ON d.program_id = p.id
-		WHERE di.mustahiq_id = $1
+		WHERE di.mustahiq_id = $1 AND d.status = 'posted'
 		ORDER BY d.distribution_date DESC
 	`
 
-	rows, err := r.db.Query(ctx, historyQuery, mustahiqID)
+	// Merged mustahiq IDs resolve to the surviving record, whose items include the moved ones
+	rows, err := r.db.Query(ctx, historyQuery, result.MustahiqID)
 	if err != nil {
 		return nil, err
 	}
@@ -371,3 +746,157 @@ func (r *ReportRepository) GetMustahiqHistory(mustahiqID string) (*repository.Mu
 
 	return result, nil
 }
+
+func (r *ReportRepository) GetHouseholdHistory(householdID string) (*repository.HouseholdHistoryResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Get household info
+	householdQuery := `
+		SELECT h.id, COALESCE(h.family_card_number, ''), h.address,
+		       COALESCE((SELECT hm.name FROM household_members hm WHERE hm.household_id = h.id AND hm.relationship = 'head'), ''),
+		       (SELECT COUNT(*) FROM household_members hm WHERE hm.household_id = h.id)
+		FROM households h
+		WHERE h.id = $1
+		LIMIT 1
+	`
+
+	result := &repository.HouseholdHistoryResult{}
+	err := r.db.QueryRow(ctx, householdQuery, householdID).Scan(
+		&result.HouseholdID, &result.FamilyCardNumber, &result.Address, &result.HeadName, &result.MemberCount,
+	)
+	if err != nil {
+		return nil, errors.New("household not found")
+	}
+
+	// Get total received per member registered as mustahiq
+	memberQuery := `
+		SELECT hm.mustahiq_id, hm.name, hm.relationship,
+		       COALESCE((
+		           SELECT SUM(di.amount)
+		           FROM distribution_items di
+		           INNER JOIN distributions d ON di.distribution_id = d.id
+		           WHERE di.mustahiq_id = hm.mustahiq_id AND d.status = 'posted'
+		       ), 0)
+		FROM household_members hm
+		WHERE hm.household_id = $1 AND hm.mustahiq_id IS NOT NULL
+		ORDER BY hm.sort_order
+	`
+
+	memberRows, err := r.db.Query(ctx, memberQuery, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer memberRows.Close()
+
+	for memberRows.Next() {
+		var member repository.HouseholdMemberAid
+		if err := memberRows.Scan(&member.MustahiqID, &member.Name, &member.Relationship, &member.TotalReceived); err != nil {
+			return nil, err
+		}
+		result.Members = append(result.Members, member)
+	}
+	memberRows.Close()
+
+	// Get distribution history of all members
+	historyQuery := `
+		SELECT 
+			d.distribution_date,
+			di.mustahiq_id,
+			hm.name,
+			COALESCE(p.name, 'No Program') as program_name,
+			d.source_fund_type,
+			di.amount
+		FROM distribution_items di
+		INNER JOIN distributions d ON di.distribution_id = d.id
+		INNER JOIN household_members hm ON hm.mustahiq_id = di.mustahiq_id
+		LEFT JOIN programs p ON d.program_id = p.id
+		WHERE hm.household_id = $1 AND d.status = 'posted'
+		ORDER BY d.distribution_date DESC
+	`
+
+	rows, err := r.db.Query(ctx, historyQuery, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totalReceived float64
+	for rows.Next() {
+		var item repository.HouseholdHistoryItem
+		var distributionDate time.Time
+		err := rows.Scan(&distributionDate, &item.MustahiqID, &item.MustahiqName, &item.ProgramName, &item.SourceFundType, &item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		// Convert time.Time to YYYY-MM-DD string
+		item.DistributionDate = distributionDate.Format("2006-01-02")
+		result.History = append(result.History, item)
+		totalReceived += item.Amount
+	}
+
+	result.TotalReceived = totalReceived
+
+	return result, nil
+}
+
+func (r *ReportRepository) GetUndeliveredItems(dateFrom, dateTo, programID, sourceFundType string) (*repository.UndeliveredItemsResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var program, fund *string
+	if programID != "" {
+		program = &programID
+	}
+	if sourceFundType != "" {
+		fund = &sourceFundType
+	}
+
+	query := `
+		SELECT d.id, d.distribution_date, COALESCE(p.name, ''), d.source_fund_type,
+		       di.id, di.voucher_code, m.id, m.name, m.address, m.phoneNumber,
+		       di.amount, di.commodity, di.quantity, CURRENT_DATE - d.distribution_date
+		FROM distribution_items di
+		INNER JOIN distributions d ON d.id = di.distribution_id
+		INNER JOIN mustahiq m ON m.id = di.mustahiq_id
+		LEFT JOIN programs p ON p.id = d.program_id
+		WHERE d.status = 'posted' AND di.delivered_at IS NULL
+		  AND ($1::date IS NULL OR d.distribution_date >= $1::date)
+		  AND ($2::date IS NULL OR d.distribution_date <= $2::date)
+		  AND ($3::uuid IS NULL OR d.program_id = $3::uuid)
+		  AND ($4::text IS NULL OR d.source_fund_type = $4::text)
+		ORDER BY d.distribution_date, d.id, m.name
+	`
+
+	rows, err := r.db.Query(ctx, query, nullableDate(dateFrom), nullableDate(dateTo), program, fund)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &repository.UndeliveredItemsResult{}
+	distributions := make(map[string]bool)
+	for rows.Next() {
+		var item repository.UndeliveredItem
+		var distributionDate time.Time
+		err := rows.Scan(&item.DistributionID, &distributionDate, &item.ProgramName, &item.SourceFundType,
+			&item.ItemID, &item.VoucherCode, &item.MustahiqID, &item.MustahiqName, &item.Address, &item.PhoneNumber,
+			&item.Amount, &item.Commodity, &item.Quantity, &item.DaysOutstanding)
+		if err != nil {
+			return nil, err
+		}
+		// Convert time.Time to YYYY-MM-DD string
+		item.DistributionDate = distributionDate.Format("2006-01-02")
+		result.Items = append(result.Items, item)
+		result.TotalAmount += item.Amount
+		distributions[item.DistributionID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result.ItemCount = len(result.Items)
+	result.DistributionCount = len(distributions)
+
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// stockOnHandQuery menghitung stok berjalan satu komoditas di satu sub-ledger dana
+const stockOnHandQuery = `
+	SELECT COALESCE(SUM(quantity), 0)
+	FROM stock_movements
+	WHERE commodity = $1 AND fund_ledger = $2
+`
+
+// lockStock mengunci stok satu komoditas dan sub-ledger sampai transaksi selesai, supaya
+// dua pengeluaran barang yang bersamaan tidak sama-sama lolos pengecekan stok
+func lockStock(ctx context.Context, tx pgx.Tx, commodity, fundLedger string) error {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "stock:"+commodity+":"+fundLedger)
+	return err
+}
+
+func stockOnHand(ctx context.Context, db queryRower, commodity, fundLedger string) (float64, error) {
+	var onHand float64
+	err := db.QueryRow(ctx, stockOnHandQuery, commodity, fundLedger).Scan(&onHand)
+	return onHand, err
+}
+
+// stockReceipt mencatat stok masuk dari item kwitansi posted yang berisi beras (rice_kg)
+// ke sub-ledger jenis dananya. Bagian amil tidak dipisah untuk barang.
+func stockReceipt(ctx context.Context, tx pgx.Tx, receiptID string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO stock_movements (id, movement_date, commodity, fund_ledger, quantity, movement_type, source_id, source_item_id, created_at)
+		SELECT gen_random_uuid(), dr.receipt_date, $2, `+receiptItemFundTypeSQL+`, dri.rice_kg, $3, dr.id, dri.id, NOW()
+		FROM donation_receipts dr
+		INNER JOIN donation_receipt_items dri ON dri.receipt_id = dr.id
+		WHERE dr.id = $1 AND dri.rice_kg > 0
+	`, receiptID, entity.CommodityRice, entity.StockMovementReceipt)
+	return err
+}
+
+// checkDistributionStock mengunci stok setiap komoditas di item penyaluran lalu menolak
+// penyaluran yang melebihi stok dana sumbernya. Tidak ada override: barang yang tidak
+// ada di gudang tidak bisa disalurkan.
+func checkDistributionStock(ctx context.Context, tx pgx.Tx, distributionID, fundLedger string) error {
+	rows, err := tx.Query(ctx, `
+		SELECT commodity, SUM(quantity)
+		FROM distribution_items
+		WHERE distribution_id = $1 AND commodity IS NOT NULL
+		GROUP BY commodity
+		ORDER BY commodity
+	`, distributionID)
+	if err != nil {
+		return err
+	}
+
+	requested := make(map[string]float64)
+	var commodities []string
+	for rows.Next() {
+		var commodity string
+		var quantity float64
+		if err := rows.Scan(&commodity, &quantity); err != nil {
+			rows.Close()
+			return err
+		}
+		requested[commodity] = quantity
+		commodities = append(commodities, commodity)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, commodity := range commodities {
+		if err := lockStock(ctx, tx, commodity, fundLedger); err != nil {
+			return err
+		}
+
+		onHand, err := stockOnHand(ctx, tx, commodity, fundLedger)
+		if err != nil {
+			return err
+		}
+
+		if requested[commodity] > onHand {
+			return &repository.InsufficientStockError{
+				Commodity: commodity,
+				FundType:  fundLedger,
+				OnHand:    onHand,
+				Requested: requested[commodity],
+			}
+		}
+	}
+
+	return nil
+}
+
+// stockDistribution mencatat stok keluar dari item penyaluran posted yang berupa barang
+func stockDistribution(ctx context.Context, tx pgx.Tx, distributionID string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO stock_movements (id, movement_date, commodity, fund_ledger, quantity, movement_type, source_id, source_item_id, created_at)
+		SELECT gen_random_uuid(), d.distribution_date, di.commodity, d.source_fund_type, -di.quantity, $2, d.id, di.id, NOW()
+		FROM distributions d
+		INNER JOIN distribution_items di ON di.distribution_id = d.id
+		WHERE d.id = $1 AND di.commodity IS NOT NULL
+	`, distributionID, entity.StockMovementDistribution)
+	return err
+}
+
+// reverseStock membalik mutasi stok dari satu kwitansi atau penyaluran yang belum dibalik
+// (void atau revert ke draft). Mutasi pembalik memakai tanggal mutasi asal.
+func reverseStock(ctx context.Context, tx pgx.Tx, movementType, sourceID, reason string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO stock_movements (id, movement_date, commodity, fund_ledger, quantity, movement_type, source_id, source_item_id, reason, reversal_of_movement_id, created_at)
+		SELECT gen_random_uuid(), sm.movement_date, sm.commodity, sm.fund_ledger, -sm.quantity, sm.movement_type, sm.source_id, sm.source_item_id, $3, sm.id, NOW()
+		FROM stock_movements sm
+		WHERE sm.movement_type = $1 AND sm.source_id = $2 AND sm.reversal_of_movement_id IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM stock_movements r WHERE r.reversal_of_movement_id = sm.id)
+	`, movementType, sourceID, reason)
+	return err
+}
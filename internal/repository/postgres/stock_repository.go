@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type StockRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewStockRepository(db *pgxpool.Pool, log *logrus.Logger) *StockRepository {
+	return &StockRepository{db: db, log: log}
+}
+
+const stockMovementSelectSQL = `
+		SELECT sm.id, sm.movement_date, sm.commodity, sm.fund_ledger, sm.quantity, sm.movement_type,
+		       sm.source_id, sm.source_item_id, COALESCE(sm.reason, ''), sm.reversal_of_movement_id,
+		       sm.created_by_user_id, u.name, sm.created_at
+		FROM stock_movements sm
+		LEFT JOIN users u ON u.id = sm.created_by_user_id
+	`
+
+func scanStockMovement(row rowScanner) (*entity.StockMovement, error) {
+	m := &entity.StockMovement{}
+	var movementDate time.Time
+	var createdByName *string
+	err := row.Scan(
+		&m.ID, &movementDate, &m.Commodity, &m.FundLedger, &m.Quantity, &m.MovementType,
+		&m.SourceID, &m.SourceItemID, &m.Reason, &m.ReversalOfMovementID,
+		&m.CreatedByUserID, &createdByName, &m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	m.MovementDate = movementDate.Format("2006-01-02")
+	m.Unit = entity.CommodityUnits[m.Commodity]
+	if m.CreatedByUserID != nil && createdByName != nil {
+		m.CreatedByUser = &entity.User{ID: *m.CreatedByUserID, Name: *createdByName}
+	}
+
+	return m, nil
+}
+
+func (r *StockRepository) FindAllMovements(filter repository.StockMovementFilter) ([]*entity.StockMovement, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := stockMovementSelectSQL
+	countQuery := `SELECT COUNT(*) FROM stock_movements sm`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by commodity
+	if filter.Commodity != "" {
+		conditions = append(conditions, fmt.Sprintf("sm.commodity = $%d", argIdx))
+		args = append(args, filter.Commodity)
+		argIdx++
+	}
+
+	// Filter by fund_ledger
+	if filter.FundLedger != "" {
+		conditions = append(conditions, fmt.Sprintf("sm.fund_ledger = $%d", argIdx))
+		args = append(args, filter.FundLedger)
+		argIdx++
+	}
+
+	// Filter by movement_type
+	if filter.MovementType != "" {
+		conditions = append(conditions, fmt.Sprintf("sm.movement_type = $%d", argIdx))
+		args = append(args, filter.MovementType)
+		argIdx++
+	}
+
+	// Filter by date range
+	if filter.DateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("sm.movement_date >= $%d", argIdx))
+		args = append(args, filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, fmt.Sprintf("sm.movement_date <= $%d", argIdx))
+		args = append(args, filter.DateTo)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY sm.movement_date DESC, sm.created_at DESC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var movements []*entity.StockMovement
+	for rows.Next() {
+		m, err := scanStockMovement(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		movements = append(movements, m)
+	}
+
+	return movements, total, nil
+}
+
+func (r *StockRepository) FindMovementByID(id string) (*entity.StockMovement, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	m, err := scanStockMovement(r.db.QueryRow(ctx, stockMovementSelectSQL+` WHERE sm.id = $1 LIMIT 1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("stock movement not found")
+		}
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// CreateAdjustment mencatat penyesuaian stok manual. Penyesuaian negatif (susut, rusak)
+// dicek terhadap stok yang ada dengan kunci yang sama seperti penyaluran.
+func (r *StockRepository) CreateAdjustment(movement *entity.StockMovement) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Start transaction
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if movement.Quantity < 0 {
+		if err := lockStock(ctx, tx, movement.Commodity, movement.FundLedger); err != nil {
+			return err
+		}
+
+		onHand, err := stockOnHand(ctx, tx, movement.Commodity, movement.FundLedger)
+		if err != nil {
+			return err
+		}
+
+		if -movement.Quantity > onHand {
+			return &repository.InsufficientStockError{
+				Commodity: movement.Commodity,
+				FundType:  movement.FundLedger,
+				OnHand:    onHand,
+				Requested: -movement.Quantity,
+			}
+		}
+	}
+
+	query := `
+		INSERT INTO stock_movements (id, movement_date, commodity, fund_ledger, quantity, movement_type, reason, created_by_user_id, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		movement.MovementDate, movement.Commodity, movement.FundLedger, movement.Quantity,
+		entity.StockMovementAdjustment, movement.Reason, movement.CreatedByUserID,
+	).Scan(&movement.ID, &movement.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("user not found")
+		}
+		return err
+	}
+	movement.MovementType = entity.StockMovementAdjustment
+
+	// Commit transaction
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type ZakatCalculationRepository struct {
+	db  *pgxpool.Pool
+	log *logrus.Logger
+}
+
+func NewZakatCalculationRepository(db *pgxpool.Pool, log *logrus.Logger) *ZakatCalculationRepository {
+	return &ZakatCalculationRepository{db: db, log: log}
+}
+
+const zakatCalculationColumns = `
+	zc.id, zc.muzakki_id, m.name, zc.calculation_date, zc.haul_start_date, zc.haul_completed,
+	zc.cash, zc.gold_grams, zc.gold_price_per_gram, zc.silver_grams, zc.silver_price_per_gram,
+	zc.trade_goods, zc.receivables, zc.debts, zc.total_assets, zc.net_assets,
+	zc.nisab_basis, zc.nisab_value, zc.is_obligatory, zc.zakat_rate, zc.zakat_amount,
+	COALESCE(zc.notes, ''), zc.created_by_user_id, zc.created_at, zc.updated_at
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanZakatCalculation(row rowScanner) (*entity.ZakatCalculation, error) {
+	zc := &entity.ZakatCalculation{
+		Muzakki: &entity.Muzakki{},
+	}
+	var calculationDate time.Time
+	var haulStartDate *time.Time
+	err := row.Scan(
+		&zc.ID, &zc.MuzakkiID, &zc.Muzakki.Name, &calculationDate, &haulStartDate, &zc.HaulCompleted,
+		&zc.Cash, &zc.GoldGrams, &zc.GoldPricePerGram, &zc.SilverGrams, &zc.SilverPricePerGram,
+		&zc.TradeGoods, &zc.Receivables, &zc.Debts, &zc.TotalAssets, &zc.NetAssets,
+		&zc.NisabBasis, &zc.NisabValue, &zc.IsObligatory, &zc.ZakatRate, &zc.ZakatAmount,
+		&zc.Notes, &zc.CreatedByUserID, &zc.CreatedAt, &zc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Convert time.Time to YYYY-MM-DD string
+	zc.CalculationDate = calculationDate.Format("2006-01-02")
+	if haulStartDate != nil {
+		s := haulStartDate.Format("2006-01-02")
+		zc.HaulStartDate = &s
+	}
+	zc.Muzakki.ID = zc.MuzakkiID
+
+	return zc, nil
+}
+
+func (r *ZakatCalculationRepository) FindAll(filter repository.ZakatCalculationFilter) ([]*entity.ZakatCalculation, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + zakatCalculationColumns + `
+		FROM zakat_calculations zc
+		INNER JOIN muzakki m ON zc.muzakki_id = m.id
+	`
+	countQuery := `SELECT COUNT(*) FROM zakat_calculations zc`
+
+	var args []interface{}
+	argIdx := 1
+	var conditions []string
+
+	// Filter by muzakki_id
+	if filter.MuzakkiID != "" {
+		conditions = append(conditions, fmt.Sprintf("zc.muzakki_id = $%d", argIdx))
+		args = append(args, filter.MuzakkiID)
+		argIdx++
+	}
+
+	// Filter by date range
+	if filter.DateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("zc.calculation_date >= $%d", argIdx))
+		args = append(args, filter.DateFrom)
+		argIdx++
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, fmt.Sprintf("zc.calculation_date <= $%d", argIdx))
+		args = append(args, filter.DateTo)
+		argIdx++
+	}
+
+	// Add WHERE clause
+	if len(conditions) > 0 {
+		whereClause := " WHERE " + strings.Join(conditions, " AND ")
+		query += whereClause
+		countQuery += whereClause
+	}
+
+	// Get total count
+	var total int64
+	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Add ORDER BY and pagination
+	query += " ORDER BY zc.calculation_date DESC, zc.created_at DESC"
+	if filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+		args = append(args, filter.PerPage, offset)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var calculations []*entity.ZakatCalculation
+	for rows.Next() {
+		zc, err := scanZakatCalculation(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		calculations = append(calculations, zc)
+	}
+
+	return calculations, total, nil
+}
+
+func (r *ZakatCalculationRepository) FindByID(id string) (*entity.ZakatCalculation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `SELECT ` + zakatCalculationColumns + `
+		FROM zakat_calculations zc
+		INNER JOIN muzakki m ON zc.muzakki_id = m.id
+		WHERE zc.id = $1
+		LIMIT 1
+	`
+
+	return scanZakatCalculation(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *ZakatCalculationRepository) Create(calculation *entity.ZakatCalculation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO zakat_calculations (
+			id, muzakki_id, calculation_date, haul_start_date, haul_completed,
+			cash, gold_grams, gold_price_per_gram, silver_grams, silver_price_per_gram,
+			trade_goods, receivables, debts, total_assets, net_assets,
+			nisab_basis, nisab_value, is_obligatory, zakat_rate, zakat_amount,
+			notes, created_by_user_id, created_at, updated_at
+		)
+		VALUES (
+			gen_random_uuid(), $1, $2, $3, $4,
+			$5, $6, $7, $8, $9,
+			$10, $11, $12, $13, $14,
+			$15, $16, $17, $18, $19,
+			$20, $21, NOW(), NOW()
+		)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		calculation.MuzakkiID, calculation.CalculationDate, calculation.HaulStartDate, calculation.HaulCompleted,
+		calculation.Cash, calculation.GoldGrams, calculation.GoldPricePerGram, calculation.SilverGrams, calculation.SilverPricePerGram,
+		calculation.TradeGoods, calculation.Receivables, calculation.Debts, calculation.TotalAssets, calculation.NetAssets,
+		calculation.NisabBasis, calculation.NisabValue, calculation.IsObligatory, calculation.ZakatRate, calculation.ZakatAmount,
+		calculation.Notes, calculation.CreatedByUserID,
+	).Scan(&calculation.ID, &calculation.CreatedAt, &calculation.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return errors.New("muzakki or user not found")
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type AidRuleUseCase struct {
+	aidRuleRepo repository.AidRuleRepository
+	validator   *validator.Validate
+}
+
+func NewAidRuleUseCase(aidRuleRepo repository.AidRuleRepository, validator *validator.Validate) *AidRuleUseCase {
+	return &AidRuleUseCase{
+		aidRuleRepo: aidRuleRepo,
+		validator:   validator,
+	}
+}
+
+type SaveAidRuleInput struct {
+	ID             string   // kosong saat create
+	Name           string   `validate:"required"`
+	Scope          string   `validate:"required,oneof=mustahiq household"`
+	AsnafID        *string  // kosong = semua asnaf
+	ProgramID      *string  // kosong = semua program
+	SourceFundType string   `validate:"omitempty,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"` // kosong = semua dana
+	MaxAmount      *float64 `validate:"omitempty,gt=0"`
+	MaxCount       *int     `validate:"omitempty,gt=0"`
+	WindowDays     int      `validate:"required,gt=0,lte=3660"`
+	Active         bool
+	Description    string
+}
+
+func (uc *AidRuleUseCase) Create(input SaveAidRuleInput) (*entity.AidRule, error) {
+	rule, err := uc.buildAidRule(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.aidRuleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	return uc.aidRuleRepo.FindByID(rule.ID)
+}
+
+func (uc *AidRuleUseCase) FindAll(filter repository.AidRuleFilter) ([]*entity.AidRule, int64, error) {
+	return uc.aidRuleRepo.FindAll(filter)
+}
+
+func (uc *AidRuleUseCase) FindByID(id string) (*entity.AidRule, error) {
+	return uc.aidRuleRepo.FindByID(id)
+}
+
+func (uc *AidRuleUseCase) Update(input SaveAidRuleInput) (*entity.AidRule, error) {
+	rule, err := uc.buildAidRule(input)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = input.ID
+
+	if err := uc.aidRuleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return uc.aidRuleRepo.FindByID(rule.ID)
+}
+
+// Delete menghapus aturan; override yang sudah tercatat di penyaluran tetap tersimpan
+func (uc *AidRuleUseCase) Delete(id string) error {
+	return uc.aidRuleRepo.Delete(id)
+}
+
+func (uc *AidRuleUseCase) buildAidRule(input SaveAidRuleInput) (*entity.AidRule, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if input.MaxAmount == nil && input.MaxCount == nil {
+		return nil, ValidationErrors{{Field: "max_amount", Message: "set max_amount, max_count or both"}}
+	}
+
+	rule := &entity.AidRule{
+		Name:           input.Name,
+		Scope:          input.Scope,
+		AsnafID:        emptyToNil(input.AsnafID),
+		ProgramID:      emptyToNil(input.ProgramID),
+		SourceFundType: input.SourceFundType,
+		MaxAmount:      input.MaxAmount,
+		MaxCount:       input.MaxCount,
+		WindowDays:     input.WindowDays,
+		Active:         input.Active,
+		Description:    input.Description,
+	}
+	if rule.MaxAmount != nil {
+		maxAmount := roundMoney(*rule.MaxAmount)
+		rule.MaxAmount = &maxAmount
+	}
+
+	return rule, nil
+}
+
+func emptyToNil(s *string) *string {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	return s
+}
+
+// aidUsageWindow adalah bantuan yang sudah diterima dalam satu jendela aturan
+type aidUsageWindow struct {
+	repository.AidUsage
+	start, end string // YYYY-MM-DD
+}
+
+// aidRuleUsage adalah bantuan untuk satu mustahiq/rumah tangga terhadap satu aturan
+type aidRuleUsage struct {
+	byCount  aidUsageWindow // jendela dengan penyaluran terbanyak
+	byAmount aidUsageWindow // jendela dengan nominal terbesar
+	amount   float64        // dari penyaluran yang sedang dicek
+	received bool           // penyaluran yang sedang dicek sudah dihitung sebagai satu penerimaan
+}
+
+// busiestAidUsageWindows mencari, di antara semua jendela windowDays hari yang memuat date,
+// jendela dengan penyaluran terbanyak dan jendela dengan nominal terbesar. daily adalah
+// bantuan per tanggal, urut tanggal. Jumlah dalam jendela hanya bertambah saat ujung
+// jendela mencapai tanggal penyaluran berikutnya, jadi cukup jendela pertama dan jendela
+// yang berakhir tepat pada tanggal penyaluran setelah date yang perlu dicek.
+func busiestAidUsageWindows(daily []*repository.AidUsage, date time.Time, windowDays int) (byCount, byAmount aidUsageWindow) {
+	starts := []time.Time{date.AddDate(0, 0, -(windowDays - 1))}
+	dateStr := date.Format("2006-01-02")
+	for _, usage := range daily {
+		if usage.Date <= dateStr {
+			continue
+		}
+		usageDate, err := time.Parse("2006-01-02", usage.Date)
+		if err != nil {
+			continue
+		}
+		if start := usageDate.AddDate(0, 0, -(windowDays - 1)); !start.After(date) {
+			starts = append(starts, start)
+		}
+	}
+
+	for i, start := range starts {
+		window := aidUsageWindow{
+			start: start.Format("2006-01-02"),
+			end:   start.AddDate(0, 0, windowDays-1).Format("2006-01-02"),
+		}
+		for _, usage := range daily {
+			if usage.Date >= window.start && usage.Date <= window.end {
+				window.Count += usage.Count
+				window.Amount = roundMoney(window.Amount + usage.Amount)
+			}
+		}
+		if i == 0 || window.Count > byCount.Count {
+			byCount = window
+		}
+		if i == 0 || window.Amount > byAmount.Amount {
+			byAmount = window
+		}
+	}
+	return byCount, byAmount
+}
+
+// checkAidRules mencari item penyaluran yang melampaui aturan bantuan aktif. Bantuan dihitung
+// dalam setiap jendela window_days hari yang memuat tanggal penyaluran, termasuk penyaluran
+// yang tercatat setelah tanggal itu, dari penyaluran posted dan pending_approval lain ditambah
+// item sebelumnya pada penyaluran ini. Satu penyaluran dihitung sebagai satu penerimaan per
+// mustahiq (atau rumah tangga) berapa pun jumlah itemnya.
+// mustahiqs berisi mustahiq setiap item per ID (lihat requireActiveMustahiq).
+func checkAidRules(aidRuleRepo repository.AidRuleRepository, mustahiqs map[string]*entity.Mustahiq, distribution *entity.Distribution) ([]*entity.AidRuleViolation, error) {
+	active := true
+	rules, _, err := aidRuleRepo.FindAll(repository.AidRuleFilter{Active: &active})
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", distribution.DistributionDate)
+	if err != nil {
+		return nil, ValidationErrors{{Field: "distribution_date", Message: "date must be in YYYY-MM-DD format", Actual: distribution.DistributionDate}}
+	}
+
+	var violations []*entity.AidRuleViolation
+
+	for _, rule := range rules {
+		if rule.ProgramID != nil && (distribution.ProgramID == nil || *distribution.ProgramID != *rule.ProgramID) {
+			continue
+		}
+		if rule.SourceFundType != "" && rule.SourceFundType != distribution.SourceFundType {
+			continue
+		}
+
+		// Household rules count the aid of every member together
+		receiver := func(mustahiq *entity.Mustahiq) (string, *string) {
+			if rule.Scope == entity.AidRuleScopeHousehold && mustahiq.HouseholdID != nil {
+				return *mustahiq.HouseholdID, mustahiq.HouseholdID
+			}
+			return mustahiq.ID, nil
+		}
+
+		// Look up the aid already received by every receiver of the rule at once, on both sides
+		// of the date: a backdated distribution shares its windows with later postings
+		filter := repository.AidUsageFilter{
+			ProgramID:             rule.ProgramID,
+			SourceFundType:        rule.SourceFundType,
+			DateFrom:              date.AddDate(0, 0, -(rule.WindowDays - 1)).Format("2006-01-02"),
+			DateTo:                date.AddDate(0, 0, rule.WindowDays-1).Format("2006-01-02"),
+			ExcludeDistributionID: distribution.ID,
+		}
+		seen := make(map[string]bool)
+		for _, item := range distribution.Items {
+			mustahiq, ok := mustahiqs[item.MustahiqID]
+			if !ok {
+				return nil, errors.New("mustahiq not found: " + item.MustahiqID)
+			}
+			if rule.AsnafID != nil && *rule.AsnafID != mustahiq.AsnafID {
+				continue
+			}
+			key, householdID := receiver(mustahiq)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if householdID != nil {
+				filter.HouseholdIDs = append(filter.HouseholdIDs, key)
+			} else {
+				filter.MustahiqIDs = append(filter.MustahiqIDs, key)
+			}
+		}
+		if len(seen) == 0 {
+			continue
+		}
+
+		used, err := aidRuleRepo.GetUsages(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		usages := make(map[string]*aidRuleUsage)
+		for i, item := range distribution.Items {
+			mustahiq := mustahiqs[item.MustahiqID]
+			if rule.AsnafID != nil && *rule.AsnafID != mustahiq.AsnafID {
+				continue
+			}
+
+			key, householdID := receiver(mustahiq)
+			usage, ok := usages[key]
+			if !ok {
+				usage = &aidRuleUsage{}
+				usage.byCount, usage.byAmount = busiestAidUsageWindows(used[key], date, rule.WindowDays)
+				usages[key] = usage
+			}
+
+			newViolation := func(limitType string, window aidUsageWindow, allowed, used, total float64) *entity.AidRuleViolation {
+				return &entity.AidRuleViolation{
+					ItemIndex:   i,
+					AidRuleID:   rule.ID,
+					AidRuleName: rule.Name,
+					MustahiqID:  mustahiq.ID,
+					HouseholdID: householdID,
+					LimitType:   limitType,
+					Allowed:     allowed,
+					Used:        used,
+					Total:       total,
+					WindowStart: window.start,
+					WindowEnd:   window.end,
+				}
+			}
+
+			// The count is reported once, on the first item of the mustahiq/household
+			if !usage.received {
+				usage.received = true
+				count := usage.byCount.Count + 1
+				if rule.MaxCount != nil && count > *rule.MaxCount {
+					violations = append(violations, newViolation(entity.AidRuleLimitCount, usage.byCount, float64(*rule.MaxCount), float64(usage.byCount.Count), float64(count)))
+				}
+			}
+
+			usage.amount = roundMoney(usage.amount + item.Amount)
+			total := roundMoney(usage.byAmount.Amount + usage.amount)
+			if rule.MaxAmount != nil && item.Amount > 0 && total > *rule.MaxAmount {
+				violations = append(violations, newViolation(entity.AidRuleLimitAmount, usage.byAmount, *rule.MaxAmount, usage.byAmount.Amount, total))
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+// aidRuleViolationErrors menjelaskan setiap pelanggaran pada item penyaluran yang bersangkutan
+func aidRuleViolationErrors(violations []*entity.AidRuleViolation) ValidationErrors {
+	errs := make(ValidationErrors, len(violations))
+	for i, v := range violations {
+		receiver := "mustahiq"
+		if v.HouseholdID != nil {
+			receiver = "household"
+		}
+
+		if v.LimitType == entity.AidRuleLimitCount {
+			errs[i] = FieldError{
+				Field: itemField(v.ItemIndex, "mustahiq_id"),
+				Message: fmt.Sprintf("aid rule %q allows %d distribution(s) from %s to %s and the %s already received %d; an admin can override with aid_rule_justification",
+					v.AidRuleName, int(v.Allowed), v.WindowStart, v.WindowEnd, receiver, int(v.Used)),
+				Expected: int(v.Allowed),
+				Actual:   int(v.Total),
+			}
+			continue
+		}
+
+		errs[i] = FieldError{
+			Field: itemField(v.ItemIndex, "amount"),
+			Message: fmt.Sprintf("aid rule %q allows %.2f from %s to %s and the %s already received %.2f; an admin can override with aid_rule_justification",
+				v.AidRuleName, v.Allowed, v.WindowStart, v.WindowEnd, receiver, v.Used),
+			Expected: v.Allowed,
+			Actual:   v.Total,
+		}
+	}
+	return errs
+}
+
+// applyAidRuleOverride mencatat pelanggaran aturan bantuan yang di-override admin dengan
+// justifikasi. Tanpa pelanggaran, override sebelumnya dihapus.
+func applyAidRuleOverride(distribution *entity.Distribution, violations []*entity.AidRuleViolation, userID, role, justification string) error {
+	justification = strings.TrimSpace(justification)
+	if len(violations) == 0 {
+		distribution.AidRuleJustification = ""
+		distribution.AidRuleOverrideByUserID = nil
+		distribution.AidRuleViolations = nil
+		return nil
+	}
+
+	if justification == "" {
+		return aidRuleViolationErrors(violations)
+	}
+
+	if role != entity.RoleAdmin {
+		return errors.New("only admin can override aid rules")
+	}
+
+	distribution.AidRuleJustification = justification
+	distribution.AidRuleOverrideByUserID = &userID
+	distribution.AidRuleViolations = violations
+	return nil
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"testing"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+)
+
+// fakeAidRuleRepository menyimpan aturan dan bantuan per tanggal di memori
+type fakeAidRuleRepository struct {
+	rules  []*entity.AidRule
+	usages map[string][]*repository.AidUsage // per ID mustahiq, urut tanggal
+}
+
+func (r *fakeAidRuleRepository) FindAll(filter repository.AidRuleFilter) ([]*entity.AidRule, int64, error) {
+	return r.rules, int64(len(r.rules)), nil
+}
+
+func (r *fakeAidRuleRepository) FindByID(id string) (*entity.AidRule, error) { return nil, nil }
+func (r *fakeAidRuleRepository) Create(rule *entity.AidRule) error           { return nil }
+func (r *fakeAidRuleRepository) Update(rule *entity.AidRule) error           { return nil }
+func (r *fakeAidRuleRepository) Delete(id string) error                      { return nil }
+
+func (r *fakeAidRuleRepository) GetUsages(filter repository.AidUsageFilter) (map[string][]*repository.AidUsage, error) {
+	result := make(map[string][]*repository.AidUsage)
+	for _, id := range filter.MustahiqIDs {
+		for _, usage := range r.usages[id] {
+			if usage.Date >= filter.DateFrom && usage.Date <= filter.DateTo {
+				result[id] = append(result[id], usage)
+			}
+		}
+	}
+	return result, nil
+}
+
+func TestCheckAidRules(t *testing.T) {
+	maxAmount := 1000000.0
+	maxCount := 2
+	rule := &entity.AidRule{ID: "rule-1", Name: "Batas bulanan", Scope: entity.AidRuleScopeMustahiq, MaxAmount: &maxAmount, MaxCount: &maxCount, WindowDays: 30, Active: true}
+	mustahiqs := map[string]*entity.Mustahiq{"m-1": {ID: "m-1", AsnafID: "fakir"}}
+
+	tests := []struct {
+		name       string
+		date       string
+		amount     float64
+		usages     []*repository.AidUsage
+		want       []string // LimitType per pelanggaran
+		wantWindow [2]string
+	}{
+		{
+			name:   "backdated distribution breaks the cap because of a later posting",
+			date:   "2026-03-01",
+			amount: 300000,
+			usages: []*repository.AidUsage{{Date: "2026-03-20", Count: 1, Amount: 800000}},
+			want:   []string{entity.AidRuleLimitAmount},
+			// The window ending on the later posting is the one that overflows
+			wantWindow: [2]string{"2026-02-19", "2026-03-20"},
+		},
+		{
+			name:   "later posting outside every window containing the date",
+			date:   "2026-03-01",
+			amount: 300000,
+			usages: []*repository.AidUsage{{Date: "2026-03-31", Count: 1, Amount: 800000}},
+		},
+		{
+			name:       "earlier posting within the window",
+			date:       "2026-03-01",
+			amount:     300000,
+			usages:     []*repository.AidUsage{{Date: "2026-01-31", Count: 1, Amount: 800000}},
+			want:       []string{entity.AidRuleLimitAmount},
+			wantWindow: [2]string{"2026-01-31", "2026-03-01"},
+		},
+		{
+			name:   "postings on both sides that never share a window",
+			date:   "2026-03-01",
+			amount: 100000,
+			usages: []*repository.AidUsage{
+				{Date: "2026-02-10", Count: 1, Amount: 100000},
+				{Date: "2026-03-25", Count: 1, Amount: 100000},
+			},
+		},
+		{
+			name:   "frequency limit reached across the date",
+			date:   "2026-03-01",
+			amount: 100000,
+			usages: []*repository.AidUsage{
+				{Date: "2026-02-10", Count: 1, Amount: 100000},
+				{Date: "2026-03-10", Count: 1, Amount: 100000},
+				{Date: "2026-03-25", Count: 1, Amount: 100000},
+			},
+			want:       []string{entity.AidRuleLimitCount},
+			wantWindow: [2]string{"2026-02-09", "2026-03-10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAidRuleRepository{
+				rules:  []*entity.AidRule{rule},
+				usages: map[string][]*repository.AidUsage{"m-1": tt.usages},
+			}
+			distribution := &entity.Distribution{
+				DistributionDate: tt.date,
+				SourceFundType:   "zakat_maal",
+				Items:            []*entity.DistributionItem{{MustahiqID: "m-1", Amount: tt.amount}},
+			}
+
+			violations, err := checkAidRules(repo, mustahiqs, distribution)
+			if err != nil {
+				t.Fatalf("checkAidRules() error = %v", err)
+			}
+			if len(violations) != len(tt.want) {
+				t.Fatalf("checkAidRules() = %d violation(s), want %d", len(violations), len(tt.want))
+			}
+			for i, v := range violations {
+				if v.LimitType != tt.want[i] {
+					t.Errorf("violation %d limit type = %s, want %s", i, v.LimitType, tt.want[i])
+				}
+				if got := [2]string{v.WindowStart, v.WindowEnd}; got != tt.wantWindow {
+					t.Errorf("violation %d window = %v, want %v", i, got, tt.wantWindow)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type AmilRateUseCase struct {
+	amilRateRepo repository.AmilRateRepository
+	validator    *validator.Validate
+}
+
+func NewAmilRateUseCase(amilRateRepo repository.AmilRateRepository, validator *validator.Validate) *AmilRateUseCase {
+	return &AmilRateUseCase{
+		amilRateRepo: amilRateRepo,
+		validator:    validator,
+	}
+}
+
+type CreateAmilRateInput struct {
+	FundType      string  `validate:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
+	EffectiveDate string  `validate:"required"` // YYYY-MM-DD
+	Percentage    float64 `validate:"gte=0,lte=100"`
+	Notes         string
+}
+
+type UpdateAmilRateInput struct {
+	ID            string  `validate:"required"`
+	FundType      string  `validate:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
+	EffectiveDate string  `validate:"required"` // YYYY-MM-DD
+	Percentage    float64 `validate:"gte=0,lte=100"`
+	Notes         string
+}
+
+// validateAmilRate memastikan format tanggal dan batas hak amil dari zakat (1/8)
+func validateAmilRate(fundType, effectiveDate string, percentage float64) error {
+	if _, err := time.Parse("2006-01-02", effectiveDate); err != nil {
+		return errors.New("effective_date must be in YYYY-MM-DD format")
+	}
+
+	isZakat := fundType == entity.LedgerZakatFitrah || fundType == entity.LedgerZakatMaal
+	if isZakat && percentage > entity.MaxZakatAmilPercentage {
+		return ValidationErrors{{
+			Field:    "percentage",
+			Message:  fmt.Sprintf("amil share of zakat must not exceed %.1f%% (1/8)", entity.MaxZakatAmilPercentage),
+			Expected: entity.MaxZakatAmilPercentage,
+			Actual:   percentage,
+		}}
+	}
+
+	return nil
+}
+
+func (uc *AmilRateUseCase) Create(input CreateAmilRateInput) (*entity.AmilRate, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if err := validateAmilRate(input.FundType, input.EffectiveDate, input.Percentage); err != nil {
+		return nil, err
+	}
+
+	rate := &entity.AmilRate{
+		FundType:      input.FundType,
+		EffectiveDate: input.EffectiveDate,
+		Percentage:    input.Percentage,
+		Notes:         input.Notes,
+	}
+
+	if err := uc.amilRateRepo.Create(rate); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (uc *AmilRateUseCase) FindAll(filter repository.AmilRateFilter) ([]*entity.AmilRate, int64, error) {
+	return uc.amilRateRepo.FindAll(filter)
+}
+
+func (uc *AmilRateUseCase) FindByID(id string) (*entity.AmilRate, error) {
+	return uc.amilRateRepo.FindByID(id)
+}
+
+// FindEffective mengembalikan persentase hak amil yang berlaku pada tanggal tertentu
+// (persentase terakhir dengan effective_date pada atau sebelum tanggal tersebut)
+func (uc *AmilRateUseCase) FindEffective(fundType, date string) (*entity.AmilRate, error) {
+	switch fundType {
+	case entity.LedgerZakatFitrah, entity.LedgerZakatMaal, entity.LedgerInfaq, entity.LedgerSadaqah:
+	default:
+		return nil, errors.New("fund_type must be one of zakat_fitrah, zakat_maal, infaq, sadaqah")
+	}
+
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, errors.New("date must be in YYYY-MM-DD format")
+	}
+
+	rate, err := uc.amilRateRepo.FindEffective(fundType, date)
+	if err != nil {
+		return nil, err
+	}
+	if rate == nil {
+		return nil, fmt.Errorf("no %s amil rate effective on %s (0%% is allocated to amil)", fundType, date)
+	}
+
+	return rate, nil
+}
+
+// Update tidak mengubah alokasi kwitansi yang sudah diposting
+func (uc *AmilRateUseCase) Update(input UpdateAmilRateInput) (*entity.AmilRate, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if err := validateAmilRate(input.FundType, input.EffectiveDate, input.Percentage); err != nil {
+		return nil, err
+	}
+
+	rate, err := uc.amilRateRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rate.FundType = input.FundType
+	rate.EffectiveDate = input.EffectiveDate
+	rate.Percentage = input.Percentage
+	rate.Notes = input.Notes
+
+	if err := uc.amilRateRepo.Update(rate); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (uc *AmilRateUseCase) Delete(id string) error {
+	return uc.amilRateRepo.Delete(id)
+}
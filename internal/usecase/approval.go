@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+)
+
+// ApprovalInput dipakai approver untuk menyetujui atau menolak kwitansi dan penyaluran
+type ApprovalInput struct {
+	ID      string `validate:"required"`
+	UserID  string `validate:"required"`
+	Comment string // wajib saat menolak
+}
+
+// requiresApproval menentukan apakah transaksi harus disetujui user lain sebelum posted.
+// Ambang batas 0 mematikan maker-checker.
+func requiresApproval(threshold, amount float64) bool {
+	return threshold > 0 && amount > threshold
+}
+
+// requireDifferentApprover menolak persetujuan oleh pembuat atau pengaju terakhir transaksi
+func requireDifferentApprover(approverID, createdByUserID string, approvals []*entity.ApprovalEvent) error {
+	if approverID == createdByUserID {
+		return errors.New("the creator cannot approve or reject their own transaction")
+	}
+
+	for i := len(approvals) - 1; i >= 0; i-- {
+		if approvals[i].Action != entity.ApprovalActionSubmit {
+			continue
+		}
+		if approvals[i].UserID == approverID {
+			return errors.New("the submitter cannot approve or reject their own transaction")
+		}
+		break
+	}
+
+	return nil
+}
+
+// requireRejectComment memastikan penolakan selalu disertai alasan untuk pembuatnya
+func requireRejectComment(comment string) error {
+	if strings.TrimSpace(comment) == "" {
+		return ValidationErrors{{Field: "comment", Message: "comment is required when rejecting"}}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"errors"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type AssessmentQuestionnaireUseCase struct {
+	questionnaireRepo repository.AssessmentQuestionnaireRepository
+	asnafRepo         repository.AsnafRepository
+	validator         *validator.Validate
+}
+
+func NewAssessmentQuestionnaireUseCase(
+	questionnaireRepo repository.AssessmentQuestionnaireRepository,
+	asnafRepo repository.AsnafRepository,
+	validator *validator.Validate,
+) *AssessmentQuestionnaireUseCase {
+	return &AssessmentQuestionnaireUseCase{
+		questionnaireRepo: questionnaireRepo,
+		asnafRepo:         asnafRepo,
+		validator:         validator,
+	}
+}
+
+type AssessmentOptionInput struct {
+	Label string  `validate:"required"`
+	Score float64 `validate:"gte=0"`
+}
+
+type AssessmentQuestionInput struct {
+	Category string                  `validate:"required,oneof=income dependants housing debts other"`
+	Question string                  `validate:"required"`
+	Weight   float64                 `validate:"required,gt=0"`
+	Options  []AssessmentOptionInput `validate:"required,min=2,dive"`
+}
+
+type SaveAssessmentQuestionnaireInput struct {
+	ID            string  // kosong saat create
+	AsnafID       string  `validate:"required"`
+	Name          string  `validate:"required"`
+	PassThreshold float64 `validate:"gte=0,lte=100"`
+	IsActive      bool
+	Notes         string
+	Questions     []AssessmentQuestionInput `validate:"required,min=1,dive"`
+}
+
+// Create membuat kuesioner kelayakan untuk satu asnaf (admin only)
+func (uc *AssessmentQuestionnaireUseCase) Create(input SaveAssessmentQuestionnaireInput) (*entity.AssessmentQuestionnaire, error) {
+	questionnaire, err := uc.buildQuestionnaire(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.questionnaireRepo.Create(questionnaire); err != nil {
+		return nil, err
+	}
+
+	return uc.questionnaireRepo.FindByID(questionnaire.ID)
+}
+
+// Update mengganti isi kuesioner selama belum dipakai penilaian
+func (uc *AssessmentQuestionnaireUseCase) Update(input SaveAssessmentQuestionnaireInput) (*entity.AssessmentQuestionnaire, error) {
+	questionnaire, err := uc.buildQuestionnaire(input)
+	if err != nil {
+		return nil, err
+	}
+	questionnaire.ID = input.ID
+
+	if err := uc.questionnaireRepo.Update(questionnaire); err != nil {
+		return nil, err
+	}
+
+	return uc.questionnaireRepo.FindByID(questionnaire.ID)
+}
+
+// Activate menjadikan kuesioner sebagai kuesioner aktif asnafnya
+func (uc *AssessmentQuestionnaireUseCase) Activate(id string) (*entity.AssessmentQuestionnaire, error) {
+	if err := uc.questionnaireRepo.SetActive(id, true); err != nil {
+		return nil, err
+	}
+
+	return uc.questionnaireRepo.FindByID(id)
+}
+
+// Deactivate menghentikan pemakaian kuesioner untuk penilaian baru
+func (uc *AssessmentQuestionnaireUseCase) Deactivate(id string) (*entity.AssessmentQuestionnaire, error) {
+	if err := uc.questionnaireRepo.SetActive(id, false); err != nil {
+		return nil, err
+	}
+
+	return uc.questionnaireRepo.FindByID(id)
+}
+
+func (uc *AssessmentQuestionnaireUseCase) FindAll(filter repository.AssessmentQuestionnaireFilter) ([]*entity.AssessmentQuestionnaire, error) {
+	return uc.questionnaireRepo.FindAll(filter)
+}
+
+func (uc *AssessmentQuestionnaireUseCase) FindByID(id string) (*entity.AssessmentQuestionnaire, error) {
+	return uc.questionnaireRepo.FindByID(id)
+}
+
+func (uc *AssessmentQuestionnaireUseCase) Delete(id string) error {
+	return uc.questionnaireRepo.Delete(id)
+}
+
+func (uc *AssessmentQuestionnaireUseCase) buildQuestionnaire(input SaveAssessmentQuestionnaireInput) (*entity.AssessmentQuestionnaire, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.asnafRepo.FindByID(input.AsnafID); err != nil {
+		return nil, errors.New("asnaf not found")
+	}
+
+	questionnaire := &entity.AssessmentQuestionnaire{
+		AsnafID:       input.AsnafID,
+		Name:          input.Name,
+		PassThreshold: input.PassThreshold,
+		IsActive:      input.IsActive,
+		Notes:         input.Notes,
+	}
+
+	var fieldErrors ValidationErrors
+	for i, q := range input.Questions {
+		question := &entity.AssessmentQuestion{
+			Category:  q.Category,
+			Question:  q.Question,
+			Weight:    q.Weight,
+			SortOrder: i + 1,
+		}
+
+		// Pertanyaan tanpa skor tertinggi > 0 tidak bisa ikut menentukan kelayakan
+		var maxScore float64
+		for j, o := range q.Options {
+			if o.Score > maxScore {
+				maxScore = o.Score
+			}
+			question.Options = append(question.Options, &entity.AssessmentOption{
+				Label:     o.Label,
+				Score:     o.Score,
+				SortOrder: j + 1,
+			})
+		}
+		if maxScore <= 0 {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   questionField(i, "options"),
+				Message: "at least one option must have a score greater than 0",
+			})
+		}
+
+		questionnaire.Questions = append(questionnaire.Questions, question)
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors
+	}
+
+	return questionnaire, nil
+}
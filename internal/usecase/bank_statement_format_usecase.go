@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type BankStatementFormatUseCase struct {
+	formatRepo repository.BankStatementFormatRepository
+	validator  *validator.Validate
+}
+
+func NewBankStatementFormatUseCase(formatRepo repository.BankStatementFormatRepository, validator *validator.Validate) *BankStatementFormatUseCase {
+	return &BankStatementFormatUseCase{
+		formatRepo: formatRepo,
+		validator:  validator,
+	}
+}
+
+type BankStatementFormatInput struct {
+	Name              string `validate:"required,max=100"`
+	Delimiter         string `validate:"omitempty,len=1"` // default ,
+	SkipRows          int    `validate:"gte=0"`
+	FooterRows        int    `validate:"gte=0"`
+	DateColumn        string `validate:"required"`
+	DateLayout        string // default 2006-01-02
+	DescriptionColumn string `validate:"required"`
+	ReferenceColumn   string
+	AmountColumn      string
+	CreditColumn      string
+	DebitColumn       string
+	DecimalSeparator  string `validate:"omitempty,oneof=. ,"` // default .
+	Notes             string
+}
+
+type UpdateBankStatementFormatInput struct {
+	ID string `validate:"required"`
+	BankStatementFormatInput
+}
+
+func (uc *BankStatementFormatUseCase) Create(input BankStatementFormatInput) (*entity.BankStatementFormat, error) {
+	format, err := uc.buildFormat(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.formatRepo.Create(format); err != nil {
+		return nil, err
+	}
+
+	return format, nil
+}
+
+func (uc *BankStatementFormatUseCase) FindAll() ([]*entity.BankStatementFormat, error) {
+	return uc.formatRepo.FindAll()
+}
+
+func (uc *BankStatementFormatUseCase) FindByID(id string) (*entity.BankStatementFormat, error) {
+	return uc.formatRepo.FindByID(id)
+}
+
+func (uc *BankStatementFormatUseCase) Update(input UpdateBankStatementFormatInput) (*entity.BankStatementFormat, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.formatRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := uc.buildFormat(input.BankStatementFormatInput)
+	if err != nil {
+		return nil, err
+	}
+	format.ID = existing.ID
+	format.CreatedAt = existing.CreatedAt
+
+	if err := uc.formatRepo.Update(format); err != nil {
+		return nil, err
+	}
+
+	return format, nil
+}
+
+func (uc *BankStatementFormatUseCase) Delete(id string) error {
+	return uc.formatRepo.Delete(id)
+}
+
+// buildFormat memvalidasi pemetaan kolom: kolom nominal tunggal, atau kolom kredit dan debit
+func (uc *BankStatementFormatUseCase) buildFormat(input BankStatementFormatInput) (*entity.BankStatementFormat, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	format := &entity.BankStatementFormat{
+		Name:              input.Name,
+		Delimiter:         input.Delimiter,
+		SkipRows:          input.SkipRows,
+		FooterRows:        input.FooterRows,
+		DateColumn:        input.DateColumn,
+		DateLayout:        input.DateLayout,
+		DescriptionColumn: input.DescriptionColumn,
+		ReferenceColumn:   input.ReferenceColumn,
+		AmountColumn:      input.AmountColumn,
+		CreditColumn:      input.CreditColumn,
+		DebitColumn:       input.DebitColumn,
+		DecimalSeparator:  input.DecimalSeparator,
+		Notes:             input.Notes,
+	}
+	if format.Delimiter == "" {
+		format.Delimiter = ","
+	}
+	if format.DateLayout == "" {
+		format.DateLayout = "2006-01-02"
+	}
+	if format.DecimalSeparator == "" {
+		format.DecimalSeparator = "."
+	}
+
+	var errs ValidationErrors
+	if format.AmountColumn == "" && (format.CreditColumn == "" || format.DebitColumn == "") {
+		errs = append(errs, FieldError{
+			Field:   "amount_column",
+			Message: "amount_column or both credit_column and debit_column are required",
+		})
+	}
+	if format.Delimiter == "\"" || format.Delimiter == "\n" || format.Delimiter == "\r" {
+		errs = append(errs, FieldError{Field: "delimiter", Message: "delimiter is not allowed", Actual: format.Delimiter})
+	}
+
+	// Layout harus memuat tahun, bulan dan tanggal: tanggal contoh harus kembali utuh
+	sample := time.Date(2024, time.November, 23, 0, 0, 0, 0, time.UTC)
+	parsed, err := time.Parse(format.DateLayout, sample.Format(format.DateLayout))
+	if err != nil || !parsed.Equal(sample) {
+		errs = append(errs, FieldError{
+			Field:   "date_layout",
+			Message: "date_layout must be a Go date layout with year, month and day, e.g. 02/01/2006",
+			Actual:  format.DateLayout,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return format, nil
+}
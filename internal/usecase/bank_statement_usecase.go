@@ -0,0 +1,592 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// BankStatementUseCase mengimpor mutasi rekening dan mengelola antrean rekonsiliasi
+type BankStatementUseCase struct {
+	statementRepo  repository.BankStatementRepository
+	formatRepo     repository.BankStatementFormatRepository
+	accountRepo    repository.FinancialAccountRepository
+	dateWindowDays int
+	validator      *validator.Validate
+}
+
+func NewBankStatementUseCase(
+	statementRepo repository.BankStatementRepository,
+	formatRepo repository.BankStatementFormatRepository,
+	accountRepo repository.FinancialAccountRepository,
+	dateWindowDays int,
+	validator *validator.Validate,
+) *BankStatementUseCase {
+	return &BankStatementUseCase{
+		statementRepo:  statementRepo,
+		formatRepo:     formatRepo,
+		accountRepo:    accountRepo,
+		dateWindowDays: dateWindowDays,
+		validator:      validator,
+	}
+}
+
+type ImportBankStatementInput struct {
+	FinancialAccountID string `validate:"required"`
+	FormatID           string `validate:"required"`
+	FileName           string `validate:"required"`
+	ImportedByUserID   string `validate:"required"`
+}
+
+type MatchBankStatementLineInput struct {
+	LineID  string             `validate:"required"`
+	UserID  string             `validate:"required"`
+	Sources []MatchSourceInput `validate:"required,min=1,dive"`
+}
+
+type MatchSourceInput struct {
+	SourceType string `validate:"required,oneof=donation_receipt distribution"`
+	SourceID   string `validate:"required"`
+}
+
+type FlagBankStatementLineInput struct {
+	LineID string `validate:"required"`
+	Reason string `validate:"required"`
+	UserID string `validate:"required"`
+}
+
+// Import membaca CSV mutasi sesuai format bank, menyimpan barisnya lalu mencoba
+// memasangkan setiap baris secara otomatis. Jika ada baris yang tidak valid,
+// tidak ada data yang disimpan.
+func (uc *BankStatementUseCase) Import(input ImportBankStatementInput, r io.Reader) (*entity.BankStatement, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	account, err := uc.accountRepo.FindByID(input.FinancialAccountID)
+	if err != nil {
+		return nil, errors.New("financial account not found")
+	}
+
+	format, err := uc.formatRepo.FindByID(input.FormatID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := parseBankStatementCSV(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &entity.BankStatement{
+		FinancialAccountID: account.ID,
+		FinancialAccount:   account,
+		FormatID:           format.ID,
+		FormatName:         format.Name,
+		FileName:           input.FileName,
+		ImportedByUserID:   input.ImportedByUserID,
+		Lines:              lines,
+	}
+
+	if err := uc.statementRepo.Create(statement); err != nil {
+		return nil, err
+	}
+
+	matched, err := uc.autoMatch(statement.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	// Muat ulang agar status dan pasangan hasil pencocokan ikut dikembalikan
+	statement, err = uc.statementRepo.FindByID(statement.ID)
+	if err != nil {
+		return nil, err
+	}
+	statement.MatchedCount = matched
+
+	return statement, nil
+}
+
+func (uc *BankStatementUseCase) FindAll(filter repository.BankStatementFilter) ([]*entity.BankStatement, int64, error) {
+	return uc.statementRepo.FindAll(filter)
+}
+
+func (uc *BankStatementUseCase) FindByID(id string) (*entity.BankStatement, error) {
+	return uc.statementRepo.FindByID(id)
+}
+
+func (uc *BankStatementUseCase) Delete(id string) error {
+	return uc.statementRepo.Delete(id)
+}
+
+// AutoMatch menjalankan ulang pencocokan otomatis untuk baris yang belum berpasangan,
+// misalnya setelah kwitansi yang terlewat dicatat
+func (uc *BankStatementUseCase) AutoMatch(statementID string) (*entity.BankStatement, error) {
+	statement, err := uc.statementRepo.FindByID(statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmatched []*entity.BankStatementLine
+	for _, line := range statement.Lines {
+		if line.Status == entity.StatementLineUnmatched {
+			unmatched = append(unmatched, line)
+		}
+	}
+
+	matched, err := uc.autoMatch(unmatched)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err = uc.statementRepo.FindByID(statementID)
+	if err != nil {
+		return nil, err
+	}
+	statement.MatchedCount = matched
+
+	return statement, nil
+}
+
+// FindLines mengembalikan antrean rekonsiliasi
+func (uc *BankStatementUseCase) FindLines(filter repository.BankStatementLineFilter) ([]*entity.BankStatementLine, int64, error) {
+	return uc.statementRepo.FindLines(filter)
+}
+
+func (uc *BankStatementUseCase) FindLineByID(id string) (*entity.BankStatementLine, error) {
+	return uc.statementRepo.FindLineByID(id)
+}
+
+// FindCandidates mencari transaksi yang belum berpasangan di sekitar tanggal baris
+// dengan nominal tidak melebihi nominal baris, untuk pencocokan manual atau split
+func (uc *BankStatementUseCase) FindCandidates(lineID string) ([]*entity.MatchCandidate, error) {
+	line, err := uc.statementRepo.FindLineByID(lineID)
+	if err != nil {
+		return nil, err
+	}
+
+	dateFrom, dateTo := uc.dateWindow(line.TransactionDate)
+	return uc.statementRepo.FindMatchCandidates(repository.MatchCandidateFilter{
+		FinancialAccountID: line.FinancialAccountID,
+		SourceType:         lineSourceType(line),
+		DateFrom:           dateFrom,
+		DateTo:             dateTo,
+		MaxAmount:          math.Abs(line.Amount),
+	})
+}
+
+// Confirm mengonfirmasi pasangan yang diusulkan pencocokan otomatis
+func (uc *BankStatementUseCase) Confirm(lineID, userID string) (*entity.BankStatementLine, error) {
+	line, err := uc.statementRepo.FindLineByID(lineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if line.Status != entity.StatementLineMatched {
+		return nil, fmt.Errorf("only matched lines can be confirmed (current status: %s)", line.Status)
+	}
+
+	sources := make([]MatchSourceInput, len(line.Matches))
+	for i, m := range line.Matches {
+		sources[i] = MatchSourceInput{SourceType: m.SourceType, SourceID: m.SourceID}
+	}
+
+	matches, err := uc.validateMatches(line, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.statementRepo.SetLineMatches(line.ID, entity.StatementLineConfirmed, matches, &userID); err != nil {
+		return nil, err
+	}
+
+	return uc.statementRepo.FindLineByID(line.ID)
+}
+
+// Match memasangkan baris secara manual dengan satu atau beberapa transaksi (split)
+// dan langsung mengonfirmasinya. Total transaksi harus sama dengan nominal baris.
+func (uc *BankStatementUseCase) Match(input MatchBankStatementLineInput) (*entity.BankStatementLine, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	line, err := uc.statementRepo.FindLineByID(input.LineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if line.Status == entity.StatementLineConfirmed {
+		return nil, errors.New("confirmed line must be reset before it can be matched again")
+	}
+
+	matches, err := uc.validateMatches(line, input.Sources)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.statementRepo.SetLineMatches(line.ID, entity.StatementLineConfirmed, matches, &input.UserID); err != nil {
+		return nil, err
+	}
+
+	return uc.statementRepo.FindLineByID(line.ID)
+}
+
+// Flag menandai baris yang tidak punya pasangan (biaya bank, transfer tak dikenal, dll)
+func (uc *BankStatementUseCase) Flag(input FlagBankStatementLineInput) (*entity.BankStatementLine, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	line, err := uc.statementRepo.FindLineByID(input.LineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if line.Status == entity.StatementLineConfirmed {
+		return nil, errors.New("confirmed line must be reset before it can be flagged")
+	}
+
+	if err := uc.statementRepo.FlagLine(line.ID, input.Reason, input.UserID); err != nil {
+		return nil, err
+	}
+
+	return uc.statementRepo.FindLineByID(line.ID)
+}
+
+// Reset mengembalikan baris ke unmatched dan melepas semua pasangannya
+func (uc *BankStatementUseCase) Reset(lineID string) (*entity.BankStatementLine, error) {
+	line, err := uc.statementRepo.FindLineByID(lineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.statementRepo.SetLineMatches(line.ID, entity.StatementLineUnmatched, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.statementRepo.FindLineByID(line.ID)
+}
+
+// validateMatches memastikan setiap transaksi posted, tercatat di rekening yang sama,
+// searah dengan baris (masuk = kwitansi, keluar = penyaluran) dan totalnya sama dengan nominal baris
+func (uc *BankStatementUseCase) validateMatches(line *entity.BankStatementLine, sources []MatchSourceInput) ([]*entity.BankStatementMatch, error) {
+	var errs ValidationErrors
+	var matches []*entity.BankStatementMatch
+	var total float64
+	expectedType := lineSourceType(line)
+	seen := make(map[string]bool)
+
+	for i, input := range sources {
+		field := fmt.Sprintf("sources[%d]", i)
+
+		if input.SourceType != expectedType {
+			errs = append(errs, FieldError{Field: field + ".source_type", Message: "money in is matched to donation receipts, money out to distributions", Expected: expectedType, Actual: input.SourceType})
+			continue
+		}
+
+		key := input.SourceType + "|" + input.SourceID
+		if seen[key] {
+			errs = append(errs, FieldError{Field: field + ".source_id", Message: "duplicate source", Actual: input.SourceID})
+			continue
+		}
+		seen[key] = true
+
+		source, err := uc.statementRepo.FindMatchSource(input.SourceType, input.SourceID)
+		if err != nil {
+			errs = append(errs, FieldError{Field: field + ".source_id", Message: err.Error()})
+			continue
+		}
+		if source.Status != "posted" {
+			errs = append(errs, FieldError{Field: field + ".source_id", Message: "only posted transactions can be reconciled", Actual: source.Status})
+			continue
+		}
+		if source.FinancialAccountID != line.FinancialAccountID {
+			errs = append(errs, FieldError{Field: field + ".source_id", Message: "transaction is recorded on a different financial account"})
+			continue
+		}
+
+		total += source.Amount
+		matches = append(matches, &entity.BankStatementMatch{
+			SourceType: source.SourceType,
+			SourceID:   source.SourceID,
+			Amount:     source.Amount,
+		})
+	}
+
+	if len(errs) == 0 && roundMoney(total) != roundMoney(math.Abs(line.Amount)) {
+		errs = append(errs, FieldError{
+			Field:    "sources",
+			Message:  "total of matched transactions must equal the statement line amount",
+			Expected: roundMoney(math.Abs(line.Amount)),
+			Actual:   roundMoney(total),
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return matches, nil
+}
+
+// autoMatch memasangkan baris dengan transaksi posted bernominal sama dalam rentang
+// tanggal. Transaksi yang nomor kwitansinya muncul di keterangan/referensi diutamakan;
+// tanpa itu, baris hanya dipasangkan jika kandidatnya tunggal. Baris yang ambigu
+// dibiarkan unmatched untuk dicocokkan manual.
+func (uc *BankStatementUseCase) autoMatch(lines []*entity.BankStatementLine) (int, error) {
+	claimed := make(map[string]bool)
+	matched := 0
+
+	for _, line := range lines {
+		dateFrom, dateTo := uc.dateWindow(line.TransactionDate)
+		candidates, err := uc.statementRepo.FindMatchCandidates(repository.MatchCandidateFilter{
+			FinancialAccountID: line.FinancialAccountID,
+			SourceType:         lineSourceType(line),
+			DateFrom:           dateFrom,
+			DateTo:             dateTo,
+			ExactAmount:        math.Abs(line.Amount),
+		})
+		if err != nil {
+			return matched, err
+		}
+
+		var available []*entity.MatchCandidate
+		for _, c := range candidates {
+			if !claimed[c.SourceType+"|"+c.SourceID] {
+				available = append(available, c)
+			}
+		}
+
+		pick := pickMatchCandidate(line, available)
+		if pick == nil {
+			continue
+		}
+
+		match := &entity.BankStatementMatch{SourceType: pick.SourceType, SourceID: pick.SourceID, Amount: pick.Amount}
+		if err := uc.statementRepo.SetLineMatches(line.ID, entity.StatementLineMatched, []*entity.BankStatementMatch{match}, nil); err != nil {
+			return matched, err
+		}
+		claimed[pick.SourceType+"|"+pick.SourceID] = true
+		line.Status = entity.StatementLineMatched
+		line.Matches = []*entity.BankStatementMatch{match}
+		matched++
+	}
+
+	return matched, nil
+}
+
+func pickMatchCandidate(line *entity.BankStatementLine, candidates []*entity.MatchCandidate) *entity.MatchCandidate {
+	// Each field is searched on its own so a number cannot run across the two
+	description := normalizeMatchText(line.Description)
+	reference := normalizeMatchText(line.Reference)
+
+	var byNumber []*entity.MatchCandidate
+	for _, c := range candidates {
+		number := normalizeMatchText(c.Number)
+		if number != "" && (strings.Contains(description, number) || strings.Contains(reference, number)) {
+			byNumber = append(byNumber, c)
+		}
+	}
+
+	switch {
+	case len(byNumber) == 1:
+		return byNumber[0]
+	case len(byNumber) == 0 && len(candidates) == 1:
+		return candidates[0]
+	default:
+		return nil
+	}
+}
+
+// normalizeMatchText membuang tanda baca dan spasi, karena bank sering menghapus
+// "/" dari nomor kwitansi pada berita transfer
+func normalizeMatchText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func (uc *BankStatementUseCase) dateWindow(date string) (string, string) {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date, date
+	}
+	return t.AddDate(0, 0, -uc.dateWindowDays).Format("2006-01-02"), t.AddDate(0, 0, uc.dateWindowDays).Format("2006-01-02")
+}
+
+// lineSourceType: uang masuk dipasangkan dengan kwitansi, uang keluar dengan penyaluran
+func lineSourceType(line *entity.BankStatementLine) string {
+	if line.Amount > 0 {
+		return entity.MatchSourceDonationReceipt
+	}
+	return entity.MatchSourceDistribution
+}
+
+// parseBankStatementCSV membaca baris mutasi sesuai pemetaan kolom format bank
+func parseBankStatementCSV(r io.Reader, format *entity.BankStatementFormat) ([]*entity.BankStatementLine, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = []rune(format.Delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Baris sebelum header dan ringkasan di akhir file dilewati
+	if len(records) <= format.SkipRows+format.FooterRows {
+		return nil, errors.New("csv file has no statement rows")
+	}
+	header := records[format.SkipRows]
+	body := records[format.SkipRows+1 : len(records)-format.FooterRows]
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	required := []string{format.DateColumn, format.DescriptionColumn}
+	if format.AmountColumn != "" {
+		required = append(required, format.AmountColumn)
+	} else {
+		required = append(required, format.CreditColumn, format.DebitColumn)
+	}
+	if format.ReferenceColumn != "" {
+		required = append(required, format.ReferenceColumn)
+	}
+	for _, name := range required {
+		if _, ok := columns[strings.ToLower(name)]; !ok {
+			return nil, fmt.Errorf("csv header must contain column %q", name)
+		}
+	}
+
+	column := func(record []string, name string) string {
+		i, ok := columns[strings.ToLower(name)]
+		if name == "" || !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var errs ValidationErrors
+	var lines []*entity.BankStatementLine
+
+	// Nomor baris mengikuti file (1 = baris pertama)
+	for i, record := range body {
+		lineNumber := format.SkipRows + 2 + i
+
+		rawDate := column(record, format.DateColumn)
+		date, err := time.Parse(format.DateLayout, rawDate)
+		if err != nil {
+			errs = append(errs, FieldError{Field: lineField(lineNumber), Message: "date does not match layout " + format.DateLayout, Actual: rawDate})
+			continue
+		}
+
+		var amount float64
+		if format.AmountColumn != "" {
+			raw := column(record, format.AmountColumn)
+			amount, err = parseStatementAmount(raw, format.DecimalSeparator)
+			if err != nil {
+				errs = append(errs, FieldError{Field: lineField(lineNumber), Message: "amount must be a number", Actual: raw})
+				continue
+			}
+		} else {
+			rawCredit := column(record, format.CreditColumn)
+			credit, err := parseStatementAmount(rawCredit, format.DecimalSeparator)
+			if err != nil {
+				errs = append(errs, FieldError{Field: lineField(lineNumber), Message: "credit must be a number", Actual: rawCredit})
+				continue
+			}
+			rawDebit := column(record, format.DebitColumn)
+			debit, err := parseStatementAmount(rawDebit, format.DecimalSeparator)
+			if err != nil {
+				errs = append(errs, FieldError{Field: lineField(lineNumber), Message: "debit must be a number", Actual: rawDebit})
+				continue
+			}
+			amount = math.Abs(credit) - math.Abs(debit)
+		}
+		amount = roundMoney(amount)
+		if amount == 0 {
+			errs = append(errs, FieldError{Field: lineField(lineNumber), Message: "amount must not be 0"})
+			continue
+		}
+
+		lines = append(lines, &entity.BankStatementLine{
+			LineNumber:      lineNumber,
+			TransactionDate: date.Format("2006-01-02"),
+			Description:     column(record, format.DescriptionColumn),
+			Reference:       column(record, format.ReferenceColumn),
+			Amount:          amount,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("csv file has no statement rows")
+	}
+
+	return lines, nil
+}
+
+// parseStatementAmount membaca nominal seperti "1.250.000,00", "Rp 1,250,000.00",
+// "(50.000)", "-50000" atau "1,250,000.00 CR" / "50,000.00 DB". Kosong dianggap 0.
+func parseStatementAmount(raw, decimalSeparator string) (float64, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	if s == "" || s == "-" {
+		return 0, nil
+	}
+
+	sign := 1.0
+	switch {
+	case strings.HasSuffix(s, "DB"):
+		sign, s = -1, strings.TrimSuffix(s, "DB")
+	case strings.HasSuffix(s, "CR"):
+		s = strings.TrimSuffix(s, "CR")
+	}
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		sign, s = -sign, s[1:len(s)-1]
+	}
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "RP"))
+	if strings.HasPrefix(s, "-") {
+		sign, s = -sign, s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+	s = strings.ReplaceAll(s, thousandsSeparator, "")
+	s = strings.ReplaceAll(s, " ", "")
+	s = strings.Replace(s, decimalSeparator, ".", 1)
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid amount %q", raw)
+	}
+
+	return sign * value, nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"testing"
+
+	"go-zakat-be/internal/domain/entity"
+)
+
+func TestParseStatementAmount(t *testing.T) {
+	tests := []struct {
+		raw              string
+		decimalSeparator string
+		want             float64
+		wantErr          bool
+	}{
+		{raw: "", decimalSeparator: ",", want: 0},
+		{raw: "-", decimalSeparator: ",", want: 0},
+		{raw: "1.250.000,00", decimalSeparator: ",", want: 1250000},
+		{raw: "Rp 1.250.000,50", decimalSeparator: ",", want: 1250000.5},
+		{raw: "Rp 1,250,000.00", decimalSeparator: ".", want: 1250000},
+		{raw: "(50.000)", decimalSeparator: ",", want: -50000},
+		{raw: "(50,000.00)", decimalSeparator: ".", want: -50000},
+		{raw: "-50000", decimalSeparator: ",", want: -50000},
+		{raw: "+75.5", decimalSeparator: ".", want: 75.5},
+		{raw: "1,250,000.00 CR", decimalSeparator: ".", want: 1250000},
+		{raw: "50,000.00 DB", decimalSeparator: ".", want: -50000},
+		{raw: "50.000,00 db", decimalSeparator: ",", want: -50000},
+		{raw: "1 250 000", decimalSeparator: ",", want: 1250000},
+		{raw: "abc", decimalSeparator: ",", wantErr: true},
+		{raw: "12,5,0", decimalSeparator: ",", wantErr: true},
+		{raw: "NaN", decimalSeparator: ".", wantErr: true},
+		{raw: "Inf", decimalSeparator: ".", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseStatementAmount(tt.raw, tt.decimalSeparator)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseStatementAmount(%q, %q) = %v, want error", tt.raw, tt.decimalSeparator, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseStatementAmount(%q, %q) error = %v", tt.raw, tt.decimalSeparator, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseStatementAmount(%q, %q) = %v, want %v", tt.raw, tt.decimalSeparator, got, tt.want)
+		}
+	}
+}
+
+func TestPickMatchCandidate(t *testing.T) {
+	candidates := []*entity.MatchCandidate{
+		{SourceID: "r-12", Number: "ZIS/2026/03/00012"},
+		{SourceID: "r-99", Number: "ZIS/2026/03/00099"},
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		reference   string
+		want        string // SourceID, kosong = tidak ada yang dipilih
+	}{
+		{name: "number in description", description: "TRF ZIS 2026 03 00012 HAMBA ALLAH", want: "r-12"},
+		{name: "number in reference", description: "TRANSFER", reference: "ZIS/2026/03/00099", want: "r-99"},
+		{name: "number across description and reference", description: "TRF ZIS/2026/03/0001", reference: "2"},
+		{name: "no number", description: "TRANSFER ZAKAT"},
+	}
+
+	for _, tt := range tests {
+		line := &entity.BankStatementLine{Description: tt.description, Reference: tt.reference}
+		got := pickMatchCandidate(line, candidates)
+		gotID := ""
+		if got != nil {
+			gotID = got.SourceID
+		}
+		if gotID != tt.want {
+			t.Errorf("%s: pickMatchCandidate() = %q, want %q", tt.name, gotID, tt.want)
+		}
+	}
+}
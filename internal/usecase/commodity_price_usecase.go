@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type CommodityPriceUseCase struct {
+	priceRepo repository.CommodityPriceRepository
+	validator *validator.Validate
+}
+
+func NewCommodityPriceUseCase(priceRepo repository.CommodityPriceRepository, validator *validator.Validate) *CommodityPriceUseCase {
+	return &CommodityPriceUseCase{
+		priceRepo: priceRepo,
+		validator: validator,
+	}
+}
+
+type CreateCommodityPriceInput struct {
+	Commodity string  `validate:"required,oneof=gold silver rice"`
+	PriceDate string  `validate:"required"` // YYYY-MM-DD
+	Price     float64 `validate:"required,gt=0"`
+	Source    string
+	Notes     string
+}
+
+type UpdateCommodityPriceInput struct {
+	ID        string  `validate:"required"`
+	Commodity string  `validate:"required,oneof=gold silver rice"`
+	PriceDate string  `validate:"required"` // YYYY-MM-DD
+	Price     float64 `validate:"required,gt=0"`
+	Source    string
+	Notes     string
+}
+
+// CommodityPriceImportResult adalah ringkasan hasil import CSV
+type CommodityPriceImportResult struct {
+	Imported int
+}
+
+func (uc *CommodityPriceUseCase) Create(input CreateCommodityPriceInput) (*entity.CommodityPrice, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse("2006-01-02", input.PriceDate); err != nil {
+		return nil, errors.New("price_date must be in YYYY-MM-DD format")
+	}
+
+	price := &entity.CommodityPrice{
+		Commodity: input.Commodity,
+		PriceDate: input.PriceDate,
+		Price:     input.Price,
+		Unit:      entity.CommodityUnits[input.Commodity],
+		Source:    input.Source,
+		Notes:     input.Notes,
+	}
+
+	if err := uc.priceRepo.Create(price); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+func (uc *CommodityPriceUseCase) FindAll(filter repository.CommodityPriceFilter) ([]*entity.CommodityPrice, int64, error) {
+	return uc.priceRepo.FindAll(filter)
+}
+
+func (uc *CommodityPriceUseCase) FindByID(id string) (*entity.CommodityPrice, error) {
+	return uc.priceRepo.FindByID(id)
+}
+
+// FindEffective mengembalikan harga yang berlaku pada tanggal tertentu
+// (harga terakhir yang tercatat pada atau sebelum tanggal tersebut)
+func (uc *CommodityPriceUseCase) FindEffective(commodity, date string) (*entity.CommodityPrice, error) {
+	if _, ok := entity.CommodityUnits[commodity]; !ok {
+		return nil, errors.New("commodity must be one of gold, silver, rice")
+	}
+
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, errors.New("date must be in YYYY-MM-DD format")
+	}
+
+	price, err := uc.priceRepo.FindEffective(commodity, date)
+	if err != nil {
+		return nil, err
+	}
+	if price == nil {
+		return nil, fmt.Errorf("no %s price recorded on or before %s", commodity, date)
+	}
+
+	return price, nil
+}
+
+func (uc *CommodityPriceUseCase) Update(input UpdateCommodityPriceInput) (*entity.CommodityPrice, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse("2006-01-02", input.PriceDate); err != nil {
+		return nil, errors.New("price_date must be in YYYY-MM-DD format")
+	}
+
+	price, err := uc.priceRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	price.Commodity = input.Commodity
+	price.PriceDate = input.PriceDate
+	price.Price = input.Price
+	price.Unit = entity.CommodityUnits[input.Commodity]
+	price.Source = input.Source
+	price.Notes = input.Notes
+
+	if err := uc.priceRepo.Update(price); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+func (uc *CommodityPriceUseCase) Delete(id string) error {
+	return uc.priceRepo.Delete(id)
+}
+
+// ImportCSV membaca harga dari file CSV dengan header
+// commodity,price_date,price[,source,notes]. Baris yang sudah ada (commodity + tanggal)
+// akan diperbarui. Jika ada baris yang tidak valid, tidak ada data yang disimpan.
+func (uc *CommodityPriceUseCase) ImportCSV(r io.Reader) (*CommodityPriceImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv file is empty")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"commodity", "price_date", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header must contain column %q", required)
+		}
+	}
+
+	column := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var errs ValidationErrors
+	var prices []*entity.CommodityPrice
+	seen := make(map[string]int)
+
+	// Baris 1 adalah header
+	line := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			errs = append(errs, FieldError{Field: lineField(line), Message: err.Error()})
+			continue
+		}
+
+		commodity := strings.ToLower(column(record, "commodity"))
+		unit, ok := entity.CommodityUnits[commodity]
+		if !ok {
+			errs = append(errs, FieldError{Field: lineField(line), Message: "commodity must be one of gold, silver, rice", Actual: commodity})
+			continue
+		}
+
+		priceDate := column(record, "price_date")
+		if _, err := time.Parse("2006-01-02", priceDate); err != nil {
+			errs = append(errs, FieldError{Field: lineField(line), Message: "price_date must be in YYYY-MM-DD format", Actual: priceDate})
+			continue
+		}
+
+		rawPrice := column(record, "price")
+		price, err := strconv.ParseFloat(rawPrice, 64)
+		if err != nil || price <= 0 {
+			errs = append(errs, FieldError{Field: lineField(line), Message: "price must be a number greater than 0", Actual: rawPrice})
+			continue
+		}
+
+		key := commodity + "|" + priceDate
+		if firstLine, dup := seen[key]; dup {
+			errs = append(errs, FieldError{Field: lineField(line), Message: fmt.Sprintf("duplicate of line %d", firstLine)})
+			continue
+		}
+		seen[key] = line
+
+		prices = append(prices, &entity.CommodityPrice{
+			Commodity: commodity,
+			PriceDate: priceDate,
+			Price:     price,
+			Unit:      unit,
+			Source:    column(record, "source"),
+			Notes:     column(record, "notes"),
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if len(prices) == 0 {
+		return nil, errors.New("csv file has no price rows")
+	}
+
+	if err := uc.priceRepo.UpsertMany(prices); err != nil {
+		return nil, err
+	}
+
+	return &CommodityPriceImportResult{Imported: len(prices)}, nil
+}
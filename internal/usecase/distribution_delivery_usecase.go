@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/domain/service"
+	"go-zakat-be/pkg/voucher"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxDeliveryImageSize adalah ukuran maksimum foto atau tanda tangan serah terima
+const MaxDeliveryImageSize = 5 << 20
+
+// Jenis bukti serah terima yang disimpan per item
+const (
+	DeliveryProofPhoto     = "photo"
+	DeliveryProofSignature = "signature"
+)
+
+var deliveryImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type DistributionDeliveryUseCase struct {
+	distributionRepo repository.DistributionRepository
+	fileStorage      service.FileStorage
+	voucherRenderer  service.VoucherRenderer
+	validator        *validator.Validate
+}
+
+func NewDistributionDeliveryUseCase(
+	distributionRepo repository.DistributionRepository,
+	fileStorage service.FileStorage,
+	voucherRenderer service.VoucherRenderer,
+	validator *validator.Validate,
+) *DistributionDeliveryUseCase {
+	return &DistributionDeliveryUseCase{
+		distributionRepo: distributionRepo,
+		fileStorage:      fileStorage,
+		voucherRenderer:  voucherRenderer,
+		validator:        validator,
+	}
+}
+
+type RedeemVoucherInput struct {
+	VoucherCode string `validate:"required"`
+	UserID      string `validate:"required"`
+	Notes       string
+	Photo       []byte // JPEG/PNG, optional
+	Signature   []byte // JPEG/PNG, optional
+}
+
+// FindByVoucherCode mencari penyaluran dan item dari kode voucher yang diketik atau dipindai
+func (uc *DistributionDeliveryUseCase) FindByVoucherCode(code string) (*entity.Distribution, *entity.DistributionItem, error) {
+	normalized, err := voucher.Normalize(code)
+	if err != nil {
+		return nil, nil, ValidationErrors{{Field: "voucher_code", Message: err.Error(), Actual: code}}
+	}
+
+	distribution, err := uc.distributionRepo.FindByVoucherCode(normalized)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return distribution, findVoucherItem(distribution, normalized), nil
+}
+
+// Redeem menukar voucher: item ditandai sudah diserahkan oleh petugas beserta foto dan
+// tanda tangan penerima jika ada. Hanya voucher dari penyaluran posted yang bisa ditukar,
+// dan setiap voucher hanya sekali.
+func (uc *DistributionDeliveryUseCase) Redeem(input RedeemVoucherInput) (*entity.Distribution, *entity.DistributionItem, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, nil, err
+	}
+
+	distribution, item, err := uc.FindByVoucherCode(input.VoucherCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if distribution.Status != entity.DistributionStatusPosted {
+		return nil, nil, fmt.Errorf("vouchers of a %s distribution cannot be redeemed", distribution.Status)
+	}
+	if item.DeliveredAt != nil {
+		return nil, nil, fmt.Errorf("voucher has already been redeemed on %s", item.DeliveredAt.Format("2006-01-02 15:04"))
+	}
+
+	var errs ValidationErrors
+	photoExt, photoErr := deliveryImageExtension(input.Photo)
+	if photoErr != "" {
+		errs = append(errs, FieldError{Field: DeliveryProofPhoto, Message: photoErr})
+	}
+	signatureExt, signatureErr := deliveryImageExtension(input.Signature)
+	if signatureErr != "" {
+		errs = append(errs, FieldError{Field: DeliveryProofSignature, Message: signatureErr})
+	}
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+
+	// Store the files first; they are removed again if the item cannot be marked.
+	// Every attempt gets its own keys, so a losing concurrent redeem never overwrites
+	// or deletes the proof saved by the winner.
+	var saved []string
+	save := func(data []byte, kind, ext string) (string, error) {
+		if len(data) == 0 {
+			return "", nil
+		}
+		suffix := make([]byte, 8)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", fmt.Errorf("save %s: %w", kind, err)
+		}
+		key := fmt.Sprintf("deliveries/%s/%s-%s-%s%s", distribution.ID, item.ID, kind, hex.EncodeToString(suffix), ext)
+		if err := uc.fileStorage.Save(key, data); err != nil {
+			return "", fmt.Errorf("save %s: %w", kind, err)
+		}
+		saved = append(saved, key)
+		return key, nil
+	}
+	cleanup := func() {
+		for _, key := range saved {
+			_ = uc.fileStorage.Delete(key)
+		}
+	}
+
+	userID := input.UserID
+	item.DeliveredByUserID = &userID
+	item.DeliveryNotes = strings.TrimSpace(input.Notes)
+	if item.DeliveryPhotoPath, err = save(input.Photo, DeliveryProofPhoto, photoExt); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	if item.DeliverySignaturePath, err = save(input.Signature, DeliveryProofSignature, signatureExt); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	if err := uc.distributionRepo.MarkDelivered(item); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	distribution, err = uc.distributionRepo.FindByID(distribution.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return distribution, findVoucherItem(distribution, item.VoucherCode), nil
+}
+
+// RenderVouchers menghasilkan lembar voucher PDF untuk penyaluran posted. Jika
+// undeliveredOnly, hanya item yang belum diserahkan yang dicetak.
+func (uc *DistributionDeliveryUseCase) RenderVouchers(id string, undeliveredOnly bool) (*entity.Distribution, []byte, error) {
+	distribution, err := uc.distributionRepo.FindByID(id)
+	if err != nil {
+		return nil, nil, errors.New("distribution not found")
+	}
+
+	if distribution.Status != entity.DistributionStatusPosted {
+		return nil, nil, fmt.Errorf("vouchers can only be printed for posted distributions (current status: %s)", distribution.Status)
+	}
+
+	items := distribution.Items
+	if undeliveredOnly {
+		items = nil
+		for _, item := range distribution.Items {
+			if item.DeliveredAt == nil {
+				items = append(items, item)
+			}
+		}
+	}
+
+	pdf, err := uc.voucherRenderer.RenderVouchers(distribution, items)
+	if err != nil {
+		return distribution, nil, err
+	}
+
+	return distribution, pdf, nil
+}
+
+// OpenProof membaca foto atau tanda tangan serah terima sebuah item
+func (uc *DistributionDeliveryUseCase) OpenProof(distributionID, itemID, kind string) ([]byte, string, error) {
+	distribution, err := uc.distributionRepo.FindByID(distributionID)
+	if err != nil {
+		return nil, "", errors.New("distribution not found")
+	}
+
+	var item *entity.DistributionItem
+	for _, it := range distribution.Items {
+		if it.ID == itemID {
+			item = it
+		}
+	}
+	if item == nil {
+		return nil, "", errors.New("distribution item not found")
+	}
+
+	key := item.DeliveryPhotoPath
+	if kind == DeliveryProofSignature {
+		key = item.DeliverySignaturePath
+	}
+	if key == "" {
+		return nil, "", fmt.Errorf("distribution item has no delivery %s", kind)
+	}
+
+	data, err := uc.fileStorage.Open(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, http.DetectContentType(data), nil
+}
+
+func findVoucherItem(distribution *entity.Distribution, code string) *entity.DistributionItem {
+	for _, item := range distribution.Items {
+		if item.VoucherCode == code {
+			return item
+		}
+	}
+	return nil
+}
+
+// deliveryImageExtension memeriksa jenis dan ukuran gambar; pesan error kosong jika valid
+func deliveryImageExtension(data []byte) (string, string) {
+	if len(data) == 0 {
+		return "", ""
+	}
+	if len(data) > MaxDeliveryImageSize {
+		return "", fmt.Sprintf("image must not exceed %d MB", MaxDeliveryImageSize>>20)
+	}
+
+	ext, ok := deliveryImageExtensions[http.DetectContentType(data)]
+	if !ok {
+		return "", "image must be JPEG or PNG"
+	}
+	return ext, ""
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+)
+
+type PlanDistributionInput struct {
+	// Pilihan mustahiq, sama seperti filter daftar mustahiq
+	AsnafID        string
+	MustahiqStatus string `validate:"omitempty,oneof=active inactive pending"` // default active
+	Region         string // bagian dari alamat
+	HouseholdID    string
+	// Rumus nominal: per_person = Amount per mustahiq,
+	// per_household = Amount + AmountPerMember x jumlah anggota rumah tangga
+	Basis           string  `validate:"required,oneof=per_person per_household"`
+	Amount          float64 `validate:"gt=0"`
+	AmountPerMember float64 `validate:"gte=0"`
+	ItemNotes       string
+	// Header penyaluran
+	DistributionDate   string `validate:"required"` // YYYY-MM-DD
+	ProgramID          *string
+	SourceFundType     string `validate:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID string `validate:"required"`
+	Notes              string
+}
+
+type CommitDistributionPlanInput struct {
+	PlanDistributionInput
+	Status                 string `validate:"omitempty,oneof=draft posted"`
+	CreatedByUserID        string `validate:"required"`
+	CreatedByRole          string `validate:"required"`
+	OverdraftJustification string
+	AidRuleJustification   string
+	// Wajib diisi dari pratinjau; commit ditolak jika rencana sudah berubah sejak pratinjau
+	ExpectedItemCount   int     `validate:"gt=0"`
+	ExpectedTotalAmount float64 `validate:"gt=0"`
+}
+
+// Plan menyusun pratinjau penyaluran massal dari pilihan mustahiq dan rumus nominal tanpa
+// menyimpannya. Mustahiq yang tidak aktif pada tanggal penyaluran dilewati; pelanggaran
+// aturan bantuan dilaporkan per item.
+func (uc *DistributionUseCase) Plan(input PlanDistributionInput) (*entity.DistributionPlan, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	plan, _, err := uc.plan(input)
+	return plan, err
+}
+
+// plan menyusun rencana penyaluran massal dan mengembalikan rekening pembayarnya. Semua
+// pengecekan dilakukan per himpunan mustahiq, bukan per item.
+func (uc *DistributionUseCase) plan(input PlanDistributionInput) (*entity.DistributionPlan, *entity.FinancialAccount, error) {
+	if _, err := time.Parse("2006-01-02", input.DistributionDate); err != nil {
+		return nil, nil, ValidationErrors{{Field: "distribution_date", Message: "date must be in YYYY-MM-DD format", Actual: input.DistributionDate}}
+	}
+	if input.Basis == entity.DistributionPlanBasisPerPerson && input.AmountPerMember > 0 {
+		return nil, nil, ValidationErrors{{Field: "amount_per_member", Message: "amount_per_member only applies to the per_household basis", Actual: input.AmountPerMember}}
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, input.DistributionDate); err != nil {
+		return nil, nil, err
+	}
+	account, err := requireActiveFinancialAccount(uc.accountRepo, input.FinancialAccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := input.MustahiqStatus
+	if status == "" {
+		status = entity.MustahiqStatusActive
+	}
+
+	mustahiqs, _, err := uc.mustahiqRepo.FindAll(repository.MustahiqFilter{
+		Status:      status,
+		AsnafID:     input.AsnafID,
+		Region:      input.Region,
+		HouseholdID: input.HouseholdID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.SliceStable(mustahiqs, func(i, j int) bool {
+		if mustahiqs[i].Name != mustahiqs[j].Name {
+			return mustahiqs[i].Name < mustahiqs[j].Name
+		}
+		return mustahiqs[i].ID < mustahiqs[j].ID
+	})
+
+	plan := &entity.DistributionPlan{
+		Basis:             input.Basis,
+		Items:             []*entity.DistributionPlanItem{},
+		Skipped:           []*entity.DistributionPlanSkip{},
+		AidRuleViolations: []*entity.AidRuleViolation{},
+	}
+
+	// Hanya mustahiq yang aktif pada tanggal penyaluran yang boleh menerima bantuan
+	mustahiqIDs := make([]string, len(mustahiqs))
+	for i, m := range mustahiqs {
+		mustahiqIDs[i] = m.ID
+	}
+	statuses, err := uc.mustahiqRepo.FindStatusesOnDate(mustahiqIDs, input.DistributionDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var eligible []*entity.Mustahiq
+	byID := make(map[string]*entity.Mustahiq, len(mustahiqs))
+	for _, m := range mustahiqs {
+		if statusOnDate := statuses[m.ID]; statusOnDate != entity.MustahiqStatusActive {
+			if statusOnDate == "" {
+				statusOnDate = "not registered"
+			}
+			plan.Skipped = append(plan.Skipped, &entity.DistributionPlanSkip{
+				MustahiqID:   m.ID,
+				MustahiqName: m.Name,
+				Reason:       fmt.Sprintf("not active on the distribution date (status: %s)", statusOnDate),
+			})
+			continue
+		}
+		eligible = append(eligible, m)
+		byID[m.ID] = m
+	}
+
+	if input.Basis == entity.DistributionPlanBasisPerHousehold {
+		if err := uc.planPerHousehold(plan, eligible, input); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		for _, m := range eligible {
+			plan.Items = append(plan.Items, newDistributionPlanItem(m, 1, input.Amount))
+		}
+		plan.PersonCount = len(plan.Items)
+
+		households := make(map[string]bool)
+		for _, m := range eligible {
+			if m.HouseholdID != nil {
+				households[*m.HouseholdID] = true
+			}
+		}
+		plan.HouseholdCount = len(households)
+	}
+
+	plan.ItemCount = len(plan.Items)
+	for _, item := range plan.Items {
+		plan.TotalAmount += item.Amount
+	}
+	plan.TotalAmount = roundMoney(plan.TotalAmount)
+
+	if len(plan.Items) == 0 {
+		return plan, account, nil
+	}
+
+	// Cek batas dan frekuensi bantuan seperti saat penyaluran disimpan
+	distribution := &entity.Distribution{
+		DistributionDate: input.DistributionDate,
+		ProgramID:        input.ProgramID,
+		SourceFundType:   input.SourceFundType,
+		Items:            make([]*entity.DistributionItem, len(plan.Items)),
+	}
+	for i, item := range plan.Items {
+		distribution.Items[i] = &entity.DistributionItem{MustahiqID: item.MustahiqID, Amount: item.Amount}
+	}
+	violations, err := checkAidRules(uc.aidRuleRepo, byID, distribution)
+	if err != nil {
+		return nil, nil, err
+	}
+	if violations != nil {
+		plan.AidRuleViolations = violations
+	}
+
+	return plan, account, nil
+}
+
+// planPerHousehold membuat satu item per rumah tangga untuk kepala keluarga, atau anggota
+// terpilih pertama jika kepala keluarga tidak ikut terpilih. Mustahiq tanpa rumah tangga
+// dihitung sebagai rumah tangga satu orang.
+func (uc *DistributionUseCase) planPerHousehold(plan *entity.DistributionPlan, eligible []*entity.Mustahiq, input PlanDistributionInput) error {
+	var order, householdIDs []string
+	groups := make(map[string][]*entity.Mustahiq)
+	for _, m := range eligible {
+		key := m.ID
+		if m.HouseholdID != nil {
+			key = *m.HouseholdID
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+			if m.HouseholdID != nil {
+				householdIDs = append(householdIDs, key)
+			}
+		}
+		groups[key] = append(groups[key], m)
+	}
+
+	households := make(map[string]*entity.Household)
+	if len(householdIDs) > 0 {
+		found, _, err := uc.householdRepo.FindAll(repository.HouseholdFilter{IDs: householdIDs})
+		if err != nil {
+			return err
+		}
+		for _, h := range found {
+			households[h.ID] = h
+		}
+	}
+
+	for _, key := range order {
+		members := groups[key]
+		recipient := members[0]
+		if recipient.HouseholdID == nil {
+			plan.Items = append(plan.Items, newDistributionPlanItem(recipient, 1, input.Amount))
+			plan.PersonCount++
+			continue
+		}
+
+		household, ok := households[key]
+		if !ok {
+			return errors.New("household not found")
+		}
+		if household.HeadMustahiqID != nil {
+			for _, m := range members {
+				if m.ID == *household.HeadMustahiqID {
+					recipient = m
+				}
+			}
+		}
+
+		memberCount := max(household.MemberCount, 1)
+		amount := roundMoney(input.Amount + input.AmountPerMember*float64(memberCount))
+		plan.Items = append(plan.Items, newDistributionPlanItem(recipient, memberCount, amount))
+		plan.HouseholdCount++
+		plan.PersonCount += memberCount
+	}
+
+	return nil
+}
+
+func newDistributionPlanItem(m *entity.Mustahiq, memberCount int, amount float64) *entity.DistributionPlanItem {
+	item := &entity.DistributionPlanItem{
+		MustahiqID:   m.ID,
+		MustahiqName: m.Name,
+		HouseholdID:  m.HouseholdID,
+		MemberCount:  memberCount,
+		Amount:       roundMoney(amount),
+	}
+	if m.Asnaf != nil {
+		item.AsnafName = m.Asnaf.Name
+	}
+	return item
+}
+
+// CommitPlan menyusun ulang rencana dan menyimpannya sebagai satu penyaluran beserta itemnya
+// dalam satu transaksi. Rencana sudah memuat pengecekan Create (periode, rekening, status
+// mustahiq dan aturan bantuan), sehingga langsung disimpan tanpa pengecekan ulang per item.
+func (uc *DistributionUseCase) CommitPlan(input CommitDistributionPlanInput) (*entity.Distribution, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	plan, account, err := uc.plan(input.PlanDistributionInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Items) == 0 {
+		return nil, errors.New("no active mustahiq match the selection")
+	}
+
+	// Pilihan mustahiq bisa berubah sejak pratinjau
+	var errs ValidationErrors
+	if input.ExpectedItemCount != plan.ItemCount {
+		errs = append(errs, FieldError{
+			Field:    "expected_item_count",
+			Message:  "the plan has changed since the preview, preview it again",
+			Expected: input.ExpectedItemCount,
+			Actual:   plan.ItemCount,
+		})
+	}
+	if roundMoney(input.ExpectedTotalAmount) != plan.TotalAmount {
+		errs = append(errs, FieldError{
+			Field:    "expected_total_amount",
+			Message:  "the plan has changed since the preview, preview it again",
+			Expected: input.ExpectedTotalAmount,
+			Actual:   plan.TotalAmount,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	items := make([]*entity.DistributionItem, len(plan.Items))
+	for i, item := range plan.Items {
+		items[i] = &entity.DistributionItem{
+			MustahiqID: item.MustahiqID,
+			Amount:     item.Amount,
+			Notes:      input.ItemNotes,
+		}
+	}
+
+	distribution := &entity.Distribution{
+		DistributionDate:   input.DistributionDate,
+		ProgramID:          input.ProgramID,
+		SourceFundType:     input.SourceFundType,
+		FinancialAccountID: input.FinancialAccountID,
+		FinancialAccount:   account,
+		TotalAmount:        plan.TotalAmount,
+		Notes:              input.Notes,
+		Status:             uc.initialStatus(input.Status, plan.TotalAmount),
+		CreatedByUserID:    input.CreatedByUserID,
+		Items:              items,
+	}
+
+	if err := applyOverdraftOverride(distribution, input.CreatedByUserID, input.CreatedByRole, input.OverdraftJustification); err != nil {
+		return nil, err
+	}
+	if err := applyAidRuleOverride(distribution, plan.AidRuleViolations, input.CreatedByUserID, input.CreatedByRole, input.AidRuleJustification); err != nil {
+		return nil, err
+	}
+
+	if err := uc.distributionRepo.Create(distribution); err != nil {
+		return nil, toInsufficientStockError(toInsufficientFundError(err), "items")
+	}
+
+	return distribution, nil
+}
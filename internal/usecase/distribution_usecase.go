@@ -2,6 +2,10 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"strings"
+
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 
@@ -9,45 +13,93 @@ import (
 )
 
 type DistributionUseCase struct {
-	distributionRepo repository.DistributionRepository
-	mustahiqRepo     repository.MustahiqRepository
-	validator        *validator.Validate
+	distributionRepo  repository.DistributionRepository
+	mustahiqRepo      repository.MustahiqRepository
+	aidRuleRepo       repository.AidRuleRepository
+	householdRepo     repository.HouseholdRepository
+	accountRepo       repository.FinancialAccountRepository
+	periodRepo        repository.FiscalPeriodRepository
+	approvalThreshold float64 // 0 = tanpa maker-checker
+	validator         *validator.Validate
 }
 
 func NewDistributionUseCase(
 	distributionRepo repository.DistributionRepository,
 	mustahiqRepo repository.MustahiqRepository,
+	aidRuleRepo repository.AidRuleRepository,
+	householdRepo repository.HouseholdRepository,
+	accountRepo repository.FinancialAccountRepository,
+	periodRepo repository.FiscalPeriodRepository,
+	approvalThreshold float64,
 	validator *validator.Validate,
 ) *DistributionUseCase {
 	return &DistributionUseCase{
-		distributionRepo: distributionRepo,
-		mustahiqRepo:     mustahiqRepo,
-		validator:        validator,
+		distributionRepo:  distributionRepo,
+		mustahiqRepo:      mustahiqRepo,
+		aidRuleRepo:       aidRuleRepo,
+		householdRepo:     householdRepo,
+		accountRepo:       accountRepo,
+		periodRepo:        periodRepo,
+		approvalThreshold: approvalThreshold,
+		validator:         validator,
 	}
 }
 
 type CreateDistributionItemInput struct {
-	MustahiqID string  `validate:"required"`
-	Amount     float64 `validate:"required,gt=0"`
+	MustahiqID string   `validate:"required"`
+	Amount     float64  `validate:"gte=0"`                            // boleh 0 untuk item natura
+	Commodity  string   `validate:"omitempty,oneof=gold silver rice"` // item natura
+	Quantity   *float64 `validate:"omitempty,gt=0"`                   // wajib jika commodity diisi
 	Notes      string
 }
 
 type CreateDistributionInput struct {
-	DistributionDate string  `validate:"required"` // YYYY-MM-DD
-	ProgramID        *string // optional
-	SourceFundType   string  `validate:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
-	Notes            string
-	CreatedByUserID  string                        `validate:"required"`
-	Items            []CreateDistributionItemInput `validate:"required,min=1,dive"`
+	DistributionDate       string  `validate:"required"` // YYYY-MM-DD
+	ProgramID              *string // optional
+	SourceFundType         string  `validate:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID     string  `validate:"required"`
+	Notes                  string
+	Status                 string                        `validate:"omitempty,oneof=draft posted"` // default posted, pending_approval di atas ambang batas
+	CreatedByUserID        string                        `validate:"required"`
+	CreatedByRole          string                        `validate:"required"`
+	OverdraftJustification string                        // wajib diisi admin jika penyaluran melebihi saldo dana
+	AidRuleJustification   string                        // wajib diisi admin jika item melanggar aturan bantuan
+	Items                  []CreateDistributionItemInput `validate:"required,min=1,dive"`
 }
 
 type UpdateDistributionInput struct {
-	ID               string `validate:"required"`
-	DistributionDate string `validate:"required"`
-	ProgramID        *string
-	SourceFundType   string `validate:"required,oneof=zakat_fitrah zakat_maal infaq sadaqah"`
-	Notes            string
-	Items            []CreateDistributionItemInput `validate:"required,min=1,dive"`
+	ID                   string `validate:"required"`
+	DistributionDate     string `validate:"required"`
+	ProgramID            *string
+	SourceFundType       string `validate:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID   string `validate:"required"`
+	Notes                string
+	UserID               string                        `validate:"required"`
+	UserRole             string                        `validate:"required"`
+	AidRuleJustification string                        // wajib diisi admin jika item melanggar aturan bantuan
+	Items                []CreateDistributionItemInput `validate:"required,min=1,dive"`
+}
+
+type PostDistributionInput struct {
+	ID                     string `validate:"required"`
+	UserID                 string `validate:"required"`
+	UserRole               string `validate:"required"`
+	OverdraftJustification string
+	AidRuleJustification   string
+}
+
+// ApproveDistributionInput dapat membawa justifikasi overdraft dari admin yang menyetujui
+type ApproveDistributionInput struct {
+	ApprovalInput
+	UserRole               string `validate:"required"`
+	OverdraftJustification string // admin only, menggantikan justifikasi dari pengaju
+}
+
+// DistributionStatusChangeInput dipakai untuk void dan revert ke draft
+type DistributionStatusChangeInput struct {
+	ID     string `validate:"required"`
+	Reason string `validate:"required"`
+	UserID string `validate:"required"`
 }
 
 func (uc *DistributionUseCase) Create(input CreateDistributionInput) (*entity.Distribution, error) {
@@ -56,43 +108,78 @@ func (uc *DistributionUseCase) Create(input CreateDistributionInput) (*entity.Di
 		return nil, err
 	}
 
-	// Verify all mustahiq exist
-	for _, item := range input.Items {
-		_, err := uc.mustahiqRepo.FindByID(item.MustahiqID)
-		if err != nil {
-			return nil, errors.New("mustahiq not found: " + item.MustahiqID)
-		}
+	// Distributions cannot be added to a closed period
+	if err := requireOpenPeriod(uc.periodRepo, input.DistributionDate); err != nil {
+		return nil, err
 	}
 
-	// Calculate total amount
-	var totalAmount float64
-	items := make([]*entity.DistributionItem, len(input.Items))
-	for i, itemInput := range input.Items {
-		totalAmount += itemInput.Amount
-		items[i] = &entity.DistributionItem{
-			MustahiqID: itemInput.MustahiqID,
-			Amount:     itemInput.Amount,
-			Notes:      itemInput.Notes,
-		}
+	// Verify all mustahiq exist and are active on the distribution date
+	mustahiqIDs := distributionItemMustahiqIDs(input.Items)
+	mustahiqs, err := requireActiveMustahiq(uc.mustahiqRepo, mustahiqIDs, input.DistributionDate)
+	if err != nil {
+		return nil, err
+	}
+	// Store merged mustahiq IDs as their surviving record
+	for i := range input.Items {
+		input.Items[i].MustahiqID = mustahiqIDs[i]
+	}
+
+	// Verify paying account
+	account, err := requireActiveFinancialAccount(uc.accountRepo, input.FinancialAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, totalAmount, err := buildDistributionItems(input.Items)
+	if err != nil {
+		return nil, err
 	}
 
 	distribution := &entity.Distribution{
-		DistributionDate: input.DistributionDate,
-		ProgramID:        input.ProgramID,
-		SourceFundType:   input.SourceFundType,
-		TotalAmount:      totalAmount,
-		Notes:            input.Notes,
-		CreatedByUserID:  input.CreatedByUserID,
-		Items:            items,
+		DistributionDate:   input.DistributionDate,
+		ProgramID:          input.ProgramID,
+		SourceFundType:     input.SourceFundType,
+		FinancialAccountID: input.FinancialAccountID,
+		FinancialAccount:   account,
+		TotalAmount:        totalAmount,
+		Notes:              input.Notes,
+		Status:             uc.initialStatus(input.Status, totalAmount),
+		CreatedByUserID:    input.CreatedByUserID,
+		Items:              items,
 	}
 
-	if err := uc.distributionRepo.Create(distribution); err != nil {
+	if err := applyOverdraftOverride(distribution, input.CreatedByUserID, input.CreatedByRole, input.OverdraftJustification); err != nil {
 		return nil, err
 	}
 
+	// Check aid caps and frequency limits per item
+	violations, err := checkAidRules(uc.aidRuleRepo, mustahiqs, distribution)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyAidRuleOverride(distribution, violations, input.CreatedByUserID, input.CreatedByRole, input.AidRuleJustification); err != nil {
+		return nil, err
+	}
+
+	if err := uc.distributionRepo.Create(distribution); err != nil {
+		return nil, toInsufficientStockError(toInsufficientFundError(err), "items")
+	}
+
 	return distribution, nil
 }
 
+// initialStatus menentukan status penyaluran baru: posted jika kosong, dan pending_approval
+// jika penyaluran posted melewati ambang batas approval
+func (uc *DistributionUseCase) initialStatus(status string, totalAmount float64) string {
+	if status == "" {
+		status = entity.DistributionStatusPosted
+	}
+	if status == entity.DistributionStatusPosted && requiresApproval(uc.approvalThreshold, totalAmount) {
+		status = entity.DistributionStatusPendingApproval
+	}
+	return status
+}
+
 func (uc *DistributionUseCase) FindAll(filter repository.DistributionFilter) ([]*entity.Distribution, int64, error) {
 	return uc.distributionRepo.FindAll(filter)
 }
@@ -113,33 +200,59 @@ func (uc *DistributionUseCase) Update(input UpdateDistributionInput) (*entity.Di
 		return nil, errors.New("distribution not found")
 	}
 
-	// Verify all mustahiq exist
-	for _, item := range input.Items {
-		_, err := uc.mustahiqRepo.FindByID(item.MustahiqID)
-		if err != nil {
-			return nil, errors.New("mustahiq not found: " + item.MustahiqID)
-		}
+	// Posted distribution harus dikembalikan ke draft oleh admin sebelum diubah
+	if existing.Status != entity.DistributionStatusDraft {
+		return nil, fmt.Errorf("%s distribution cannot be edited, ask an admin to revert it to draft first", existing.Status)
 	}
 
-	// Calculate total amount
-	var totalAmount float64
-	items := make([]*entity.DistributionItem, len(input.Items))
-	for i, itemInput := range input.Items {
-		totalAmount += itemInput.Amount
-		items[i] = &entity.DistributionItem{
-			MustahiqID: itemInput.MustahiqID,
-			Amount:     itemInput.Amount,
-			Notes:      itemInput.Notes,
-		}
+	// Both the current and the new date must be in an open period
+	if err := requireOpenPeriod(uc.periodRepo, existing.DistributionDate); err != nil {
+		return nil, err
+	}
+	if err := requireOpenPeriod(uc.periodRepo, input.DistributionDate); err != nil {
+		return nil, err
+	}
+
+	// Verify all mustahiq exist and are active on the distribution date
+	mustahiqIDs := distributionItemMustahiqIDs(input.Items)
+	mustahiqs, err := requireActiveMustahiq(uc.mustahiqRepo, mustahiqIDs, input.DistributionDate)
+	if err != nil {
+		return nil, err
+	}
+	// Store merged mustahiq IDs as their surviving record
+	for i := range input.Items {
+		input.Items[i].MustahiqID = mustahiqIDs[i]
+	}
+
+	// Verify paying account
+	account, err := requireActiveFinancialAccount(uc.accountRepo, input.FinancialAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, totalAmount, err := buildDistributionItems(input.Items)
+	if err != nil {
+		return nil, err
 	}
 
 	existing.DistributionDate = input.DistributionDate
 	existing.ProgramID = input.ProgramID
 	existing.SourceFundType = input.SourceFundType
+	existing.FinancialAccountID = input.FinancialAccountID
+	existing.FinancialAccount = account
 	existing.TotalAmount = totalAmount
 	existing.Notes = input.Notes
 	existing.Items = items
 
+	// Check aid caps and frequency limits per item
+	violations, err := checkAidRules(uc.aidRuleRepo, mustahiqs, existing)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyAidRuleOverride(existing, violations, input.UserID, input.UserRole, input.AidRuleJustification); err != nil {
+		return nil, err
+	}
+
 	if err := uc.distributionRepo.Update(existing); err != nil {
 		return nil, err
 	}
@@ -147,6 +260,290 @@ func (uc *DistributionUseCase) Update(input UpdateDistributionInput) (*entity.Di
 	return existing, nil
 }
 
+// Delete hanya untuk draft; penyaluran posted dibatalkan lewat Void supaya jejak audit tetap ada
 func (uc *DistributionUseCase) Delete(id string) error {
+	existing, err := uc.distributionRepo.FindByID(id)
+	if err != nil {
+		return errors.New("distribution not found")
+	}
+
+	if existing.Status != entity.DistributionStatusDraft {
+		return fmt.Errorf("%s distribution cannot be deleted, void it instead", existing.Status)
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, existing.DistributionDate); err != nil {
+		return err
+	}
+
 	return uc.distributionRepo.Delete(id)
 }
+
+// Post menandai draft sebagai posted sehingga dihitung di laporan; saldo dana dicek saat posting.
+// Penyaluran di atas ambang batas diajukan ke approver (pending_approval).
+func (uc *DistributionUseCase) Post(input PostDistributionInput) (*entity.Distribution, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.distributionRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("distribution not found")
+	}
+
+	if existing.Status != entity.DistributionStatusDraft {
+		return nil, fmt.Errorf("only draft distributions can be posted (current status: %s)", existing.Status)
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, existing.DistributionDate); err != nil {
+		return nil, err
+	}
+
+	// Mustahiq status may have changed since the draft was saved
+	mustahiqIDs := make([]string, len(existing.Items))
+	for i, item := range existing.Items {
+		mustahiqIDs[i] = item.MustahiqID
+	}
+	mustahiqs, err := requireActiveMustahiq(uc.mustahiqRepo, mustahiqIDs, existing.DistributionDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyOverdraftOverride(existing, input.UserID, input.UserRole, input.OverdraftJustification); err != nil {
+		return nil, err
+	}
+
+	// Aid received by the mustahiq may have changed since the draft was saved
+	violations, err := checkAidRules(uc.aidRuleRepo, mustahiqs, existing)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyAidRuleOverride(existing, violations, input.UserID, input.UserRole, input.AidRuleJustification); err != nil {
+		return nil, err
+	}
+
+	if requiresApproval(uc.approvalThreshold, existing.TotalAmount) {
+		if err := uc.distributionRepo.Submit(existing, input.UserID); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	if err := uc.distributionRepo.Post(existing); err != nil {
+		return nil, toInsufficientStockError(toInsufficientFundError(err), "items")
+	}
+
+	return existing, nil
+}
+
+// Approve menyetujui penyaluran pending_approval; saldo dana dan stok dicek seperti Post.
+// Approver harus user lain selain pembuat dan pengajunya.
+func (uc *DistributionUseCase) Approve(input ApproveDistributionInput) (*entity.Distribution, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.findPendingApproval(input.ApprovalInput)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tanpa justifikasi baru, justifikasi overdraft dari pengajuan tetap berlaku
+	if input.OverdraftJustification != "" {
+		if err := applyOverdraftOverride(existing, input.UserID, input.UserRole, input.OverdraftJustification); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.distributionRepo.Approve(existing, input.UserID, input.Comment); err != nil {
+		return nil, toInsufficientStockError(toInsufficientFundError(err), "items")
+	}
+
+	return uc.distributionRepo.FindByID(input.ID)
+}
+
+// Reject mengembalikan penyaluran pending_approval ke draft dengan komentar approver
+func (uc *DistributionUseCase) Reject(input ApprovalInput) (*entity.Distribution, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if err := requireRejectComment(input.Comment); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.findPendingApproval(input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.distributionRepo.Reject(input.ID, input.UserID, input.Comment); err != nil {
+		return nil, err
+	}
+
+	return uc.distributionRepo.FindByID(input.ID)
+}
+
+// findPendingApproval mengambil penyaluran yang menunggu persetujuan dan memastikan approver-nya sah
+func (uc *DistributionUseCase) findPendingApproval(input ApprovalInput) (*entity.Distribution, error) {
+	existing, err := uc.distributionRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("distribution not found")
+	}
+
+	if existing.Status != entity.DistributionStatusPendingApproval {
+		return nil, fmt.Errorf("only pending_approval distributions can be approved or rejected (current status: %s)", existing.Status)
+	}
+
+	if err := requireDifferentApprover(input.UserID, existing.CreatedByUserID, existing.Approvals); err != nil {
+		return nil, err
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, existing.DistributionDate); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Void membatalkan penyaluran posted; data tetap tersimpan tetapi tidak dihitung di laporan
+func (uc *DistributionUseCase) Void(input DistributionStatusChangeInput) (*entity.Distribution, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.distributionRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("distribution not found")
+	}
+
+	if existing.Status != entity.DistributionStatusPosted {
+		return nil, fmt.Errorf("only posted distributions can be voided (current status: %s)", existing.Status)
+	}
+
+	// Aid that was already handed over cannot be undone by voiding the distribution
+	if existing.DeliveredItemCount > 0 {
+		return nil, fmt.Errorf("distribution has %d delivered item(s) and cannot be voided", existing.DeliveredItemCount)
+	}
+
+	// The reversal journal is dated like the distribution
+	if err := requireOpenPeriod(uc.periodRepo, existing.DistributionDate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.distributionRepo.Void(input.ID, input.Reason, input.UserID); err != nil {
+		return nil, err
+	}
+
+	return uc.distributionRepo.FindByID(input.ID)
+}
+
+// RevertToDraft mengembalikan penyaluran posted ke draft agar bisa diubah (admin only)
+func (uc *DistributionUseCase) RevertToDraft(input DistributionStatusChangeInput) (*entity.Distribution, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.distributionRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("distribution not found")
+	}
+
+	if existing.Status != entity.DistributionStatusPosted {
+		return nil, fmt.Errorf("only posted distributions can be reverted to draft (current status: %s)", existing.Status)
+	}
+
+	// Redeemed vouchers are proof of delivery and must not be regenerated
+	if existing.DeliveredItemCount > 0 {
+		return nil, fmt.Errorf("distribution has %d delivered item(s) and cannot be reverted to draft", existing.DeliveredItemCount)
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, existing.DistributionDate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.distributionRepo.RevertToDraft(input.ID, input.Reason, input.UserID); err != nil {
+		return nil, err
+	}
+
+	return uc.distributionRepo.FindByID(input.ID)
+}
+
+// buildDistributionItems menyusun item penyaluran dan total nominalnya. Item boleh berupa
+// uang, barang (commodity + quantity), atau keduanya.
+func buildDistributionItems(inputs []CreateDistributionItemInput) ([]*entity.DistributionItem, float64, error) {
+	var errs ValidationErrors
+	var totalAmount float64
+	items := make([]*entity.DistributionItem, len(inputs))
+	for i, itemInput := range inputs {
+		item := &entity.DistributionItem{
+			MustahiqID: itemInput.MustahiqID,
+			Amount:     itemInput.Amount,
+			Notes:      itemInput.Notes,
+		}
+
+		switch {
+		case itemInput.Commodity != "" && itemInput.Quantity == nil:
+			errs = append(errs, FieldError{Field: itemField(i, "quantity"), Message: "quantity is required when commodity is set"})
+		case itemInput.Commodity == "" && itemInput.Quantity != nil:
+			errs = append(errs, FieldError{Field: itemField(i, "commodity"), Message: "commodity is required when quantity is set"})
+		case itemInput.Commodity != "":
+			commodity := itemInput.Commodity
+			quantity := math.Round(*itemInput.Quantity*100) / 100
+			item.Commodity = &commodity
+			item.Quantity = &quantity
+		}
+
+		if item.Amount <= 0 && item.Quantity == nil {
+			errs = append(errs, FieldError{Field: itemField(i, "amount"), Message: "amount must be greater than 0 unless the item is in kind"})
+		}
+
+		totalAmount += item.Amount
+		items[i] = item
+	}
+
+	if len(errs) > 0 {
+		return nil, 0, errs
+	}
+	return items, totalAmount, nil
+}
+
+// applyOverdraftOverride mencatat justifikasi overdraft; hanya admin yang boleh
+// memposting penyaluran melebihi saldo dana
+func applyOverdraftOverride(distribution *entity.Distribution, userID, role, justification string) error {
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		distribution.OverdraftJustification = ""
+		distribution.OverdraftApprovedByUserID = nil
+		return nil
+	}
+
+	if role != entity.RoleAdmin {
+		return errors.New("only admin can override the fund balance check")
+	}
+
+	distribution.OverdraftJustification = justification
+	distribution.OverdraftApprovedByUserID = &userID
+	return nil
+}
+
+// toInsufficientFundError mengubah saldo kurang dari repository menjadi ValidationErrors
+func toInsufficientFundError(err error) error {
+	var fundErr *repository.InsufficientFundError
+	if !errors.As(err, &fundErr) {
+		return err
+	}
+
+	return ValidationErrors{{
+		Field:    "source_fund_type",
+		Message:  fmt.Sprintf("insufficient %s balance, an admin can override with overdraft_justification", fundErr.FundType),
+		Expected: fundErr.Balance,
+		Actual:   fundErr.Requested,
+	}}
+}
+
+func distributionItemMustahiqIDs(items []CreateDistributionItemInput) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.MustahiqID
+	}
+	return ids
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"testing"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// fakeDistributionRepository hanya mengimplementasikan method yang dipakai tes;
+// method lain panic karena interface-nya nil
+type fakeDistributionRepository struct {
+	repository.DistributionRepository
+	distribution *entity.Distribution
+	voided       bool
+}
+
+func (r *fakeDistributionRepository) FindByID(id string) (*entity.Distribution, error) {
+	return r.distribution, nil
+}
+
+func (r *fakeDistributionRepository) Void(id, reason, voidedByUserID string) error {
+	r.voided = true
+	r.distribution.Status = entity.DistributionStatusVoided
+	return nil
+}
+
+func TestDistributionVoid(t *testing.T) {
+	tests := []struct {
+		name           string
+		deliveredItems int
+		wantErr        bool
+	}{
+		{name: "undelivered distribution", deliveredItems: 0},
+		{name: "partly delivered distribution", deliveredItems: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeDistributionRepository{distribution: &entity.Distribution{
+				ID:                 "dist-1",
+				DistributionDate:   "2026-03-01",
+				Status:             entity.DistributionStatusPosted,
+				DeliveredItemCount: tt.deliveredItems,
+			}}
+			uc := NewDistributionUseCase(repo, nil, nil, nil, nil, &fakeFiscalPeriodRepository{}, 0, validator.New())
+
+			_, err := uc.Void(DistributionStatusChangeInput{ID: "dist-1", Reason: "salah input", UserID: "user-1"})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Void() error = nil, want error")
+				}
+				if repo.voided {
+					t.Error("Void() voided a distribution with delivered items")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Void() error = %v", err)
+			}
+			if !repo.voided {
+				t.Error("Void() did not void the distribution")
+			}
+		})
+	}
+}
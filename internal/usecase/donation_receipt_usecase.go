@@ -2,57 +2,107 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"time"
+
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/internal/domain/service"
+	"go-zakat-be/pkg/hijri"
+	"go-zakat-be/pkg/receiptnumber"
 
 	"github.com/go-playground/validator/v10"
 )
 
 type DonationReceiptUseCase struct {
-	receiptRepo repository.DonationReceiptRepository
-	muzakkiRepo repository.MuzakkiRepository
-	validator   *validator.Validate
+	receiptRepo         repository.DonationReceiptRepository
+	muzakkiRepo         repository.MuzakkiRepository
+	accountRepo         repository.FinancialAccountRepository
+	periodRepo          repository.FiscalPeriodRepository
+	calculationRepo     repository.ZakatCalculationRepository
+	fitrahRateRepo      repository.FitrahRateRepository
+	priceRepo           repository.CommodityPriceRepository
+	receiptRenderer     service.ReceiptRenderer
+	numberPattern       *receiptnumber.Pattern
+	defaultFitrahRegion string
+	approvalThreshold   float64 // 0 = tanpa maker-checker
+	validator           *validator.Validate
 }
 
 func NewDonationReceiptUseCase(
 	receiptRepo repository.DonationReceiptRepository,
 	muzakkiRepo repository.MuzakkiRepository,
+	accountRepo repository.FinancialAccountRepository,
+	periodRepo repository.FiscalPeriodRepository,
+	calculationRepo repository.ZakatCalculationRepository,
+	fitrahRateRepo repository.FitrahRateRepository,
+	priceRepo repository.CommodityPriceRepository,
+	receiptRenderer service.ReceiptRenderer,
+	numberPattern *receiptnumber.Pattern,
+	defaultFitrahRegion string,
+	approvalThreshold float64,
 	validator *validator.Validate,
 ) *DonationReceiptUseCase {
 	return &DonationReceiptUseCase{
-		receiptRepo: receiptRepo,
-		muzakkiRepo: muzakkiRepo,
-		validator:   validator,
+		receiptRepo:         receiptRepo,
+		muzakkiRepo:         muzakkiRepo,
+		accountRepo:         accountRepo,
+		periodRepo:          periodRepo,
+		calculationRepo:     calculationRepo,
+		fitrahRateRepo:      fitrahRateRepo,
+		priceRepo:           priceRepo,
+		receiptRenderer:     receiptRenderer,
+		numberPattern:       numberPattern,
+		defaultFitrahRegion: defaultFitrahRegion,
+		approvalThreshold:   approvalThreshold,
+		validator:           validator,
 	}
 }
 
 type CreateDonationReceiptItemInput struct {
-	FundType    string   `validate:"required,oneof=zakat infaq sadaqah"`
-	ZakatType   *string  `validate:"omitempty,oneof=fitrah maal"`
-	PersonCount *int     `validate:"omitempty,min=1"`
-	Amount      float64  `validate:"required,gt=0"`
-	RiceKG      *float64 `validate:"omitempty,gt=0"`
-	Notes       string
+	FundType           string   `validate:"required,oneof=zakat infaq sadaqah"`
+	ZakatType          *string  `validate:"omitempty,oneof=fitrah maal"`
+	PersonCount        *int     `validate:"omitempty,min=1"`
+	Amount             float64  `validate:"gte=0"` // boleh 0 untuk zakat fitrah (diisi otomatis dari tarif)
+	RiceKG             *float64 `validate:"omitempty,gt=0"`
+	ZakatCalculationID *string  // link ke perhitungan zakat maal (optional)
+	Notes              string
 }
 
 type CreateDonationReceiptInput struct {
-	MuzakkiID       string `validate:"required"`
-	ReceiptNumber   string `validate:"required"`
-	ReceiptDate     string `validate:"required"` // YYYY-MM-DD
-	PaymentMethod   string `validate:"required"`
-	Notes           string
-	CreatedByUserID string                           `validate:"required"`
-	Items           []CreateDonationReceiptItemInput `validate:"required,min=1,dive"`
+	MuzakkiID          string `validate:"required"`
+	ReceiptNumber      string // optional, kosong = dibuat otomatis dari pola nomor kwitansi
+	ReceiptDate        string `validate:"required"` // YYYY-MM-DD
+	FinancialAccountID string `validate:"required"`
+	FitrahRegion       string // optional, default dari config
+	Notes              string
+	Status             string                           `validate:"omitempty,oneof=draft posted"` // default posted, pending_approval di atas ambang batas
+	CreatedByUserID    string                           `validate:"required"`
+	Items              []CreateDonationReceiptItemInput `validate:"required,min=1,dive"`
 }
 
 type UpdateDonationReceiptInput struct {
-	ID            string `validate:"required"`
-	MuzakkiID     string `validate:"required"`
-	ReceiptNumber string `validate:"required"`
-	ReceiptDate   string `validate:"required"`
-	PaymentMethod string `validate:"required"`
-	Notes         string
-	Items         []CreateDonationReceiptItemInput `validate:"required,min=1,dive"`
+	ID                 string `validate:"required"`
+	MuzakkiID          string `validate:"required"`
+	ReceiptNumber      string // optional, kosong = tetap memakai nomor sebelumnya
+	ReceiptDate        string `validate:"required"`
+	FinancialAccountID string `validate:"required"`
+	FitrahRegion       string // optional, default dari region sebelumnya / config
+	Notes              string
+	Items              []CreateDonationReceiptItemInput `validate:"required,min=1,dive"`
+}
+
+type VoidDonationReceiptInput struct {
+	ID             string `validate:"required"`
+	Reason         string `validate:"required"`
+	VoidedByUserID string `validate:"required"`
+}
+
+// ReverseDonationReceiptInput membatalkan kwitansi posted dan menerbitkan kwitansi koreksi
+type ReverseDonationReceiptInput struct {
+	VoidDonationReceiptInput
+	Correction CreateDonationReceiptInput
 }
 
 func (uc *DonationReceiptUseCase) Create(input CreateDonationReceiptInput) (*entity.DonationReceipt, error) {
@@ -61,6 +111,34 @@ func (uc *DonationReceiptUseCase) Create(input CreateDonationReceiptInput) (*ent
 		return nil, err
 	}
 
+	receipt, err := uc.buildReceipt(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.receiptRepo.Create(receipt); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// validateManualReceiptNumber menolak nomor manual yang berbentuk nomor otomatis: nomor itu
+// nanti bentrok dengan nomor urut yang dibuat pola dan membuat penomoran periodenya macet
+func (uc *DonationReceiptUseCase) validateManualReceiptNumber(number string) error {
+	if number == "" || !uc.numberPattern.Match(number) {
+		return nil
+	}
+	return ValidationErrors{{
+		Field:    "receipt_number",
+		Message:  "manual receipt number must not follow the automatic numbering pattern",
+		Expected: "a number not matching " + uc.numberPattern.String(),
+		Actual:   number,
+	}}
+}
+
+// buildReceipt memvalidasi input kwitansi baru dan menyusun entity-nya
+func (uc *DonationReceiptUseCase) buildReceipt(input CreateDonationReceiptInput) (*entity.DonationReceipt, error) {
 	// Additional validation: if fund_type = zakat, zakat_type is required
 	for i, item := range input.Items {
 		if item.FundType == "zakat" && (item.ZakatType == nil || *item.ZakatType == "") {
@@ -68,43 +146,71 @@ func (uc *DonationReceiptUseCase) Create(input CreateDonationReceiptInput) (*ent
 		}
 	}
 
-	// Verify muzakki exists
-	_, err := uc.muzakkiRepo.FindByID(input.MuzakkiID)
+	// Receipts cannot be added to a closed period
+	if err := requireOpenPeriod(uc.periodRepo, input.ReceiptDate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateManualReceiptNumber(input.ReceiptNumber); err != nil {
+		return nil, err
+	}
+
+	// Verify muzakki exists; a merged muzakki ID resolves to the surviving muzakki
+	muzakki, err := uc.muzakkiRepo.FindByID(input.MuzakkiID)
 	if err != nil {
 		return nil, errors.New("muzakki not found")
 	}
+	input.MuzakkiID = muzakki.ID
 
-	// Calculate total amount
-	var totalAmount float64
-	items := make([]*entity.DonationReceiptItem, len(input.Items))
-	for i, itemInput := range input.Items {
-		totalAmount += itemInput.Amount
-		items[i] = &entity.DonationReceiptItem{
-			FundType:    itemInput.FundType,
-			ZakatType:   itemInput.ZakatType,
-			PersonCount: itemInput.PersonCount,
-			Amount:      itemInput.Amount,
-			RiceKG:      itemInput.RiceKG,
-			Notes:       itemInput.Notes,
-		}
+	// Verify receiving account
+	account, err := requireActiveFinancialAccount(uc.accountRepo, input.FinancialAccountID)
+	if err != nil {
+		return nil, err
 	}
 
-	receipt := &entity.DonationReceipt{
-		MuzakkiID:       input.MuzakkiID,
-		ReceiptNumber:   input.ReceiptNumber,
-		ReceiptDate:     input.ReceiptDate,
-		PaymentMethod:   input.PaymentMethod,
-		TotalAmount:     totalAmount,
-		Notes:           input.Notes,
-		CreatedByUserID: input.CreatedByUserID,
-		Items:           items,
+	// Verify linked zakat calculations
+	if err := uc.validateZakatCalculations(input.MuzakkiID, input.Items); err != nil {
+		return nil, err
 	}
 
-	if err := uc.receiptRepo.Create(receipt); err != nil {
+	items := toDonationReceiptItems(input.Items)
+
+	// Validate / auto-fill fitrah items from the board's rate
+	region := input.FitrahRegion
+	if region == "" {
+		region = uc.defaultFitrahRegion
+	}
+	if err := uc.applyFitrahRates(input.ReceiptDate, region, items); err != nil {
 		return nil, err
 	}
 
-	return receipt, nil
+	// Calculate total amount
+	var totalAmount float64
+	for _, item := range items {
+		totalAmount += item.Amount
+	}
+
+	status := input.Status
+	if status == "" {
+		status = entity.ReceiptStatusPosted
+	}
+	if status == entity.ReceiptStatusPosted && requiresApproval(uc.approvalThreshold, totalAmount) {
+		status = entity.ReceiptStatusPendingApproval
+	}
+
+	return &entity.DonationReceipt{
+		MuzakkiID:          input.MuzakkiID,
+		ReceiptNumber:      input.ReceiptNumber,
+		ReceiptDate:        input.ReceiptDate,
+		FinancialAccountID: input.FinancialAccountID,
+		FinancialAccount:   account,
+		FitrahRegion:       region,
+		TotalAmount:        totalAmount,
+		Notes:              input.Notes,
+		Status:             status,
+		CreatedByUserID:    input.CreatedByUserID,
+		Items:              items,
+	}, nil
 }
 
 func (uc *DonationReceiptUseCase) FindAll(filter repository.DonationReceiptFilter) ([]*entity.DonationReceipt, int64, error) {
@@ -115,6 +221,21 @@ func (uc *DonationReceiptUseCase) FindByID(id string) (*entity.DonationReceipt,
 	return uc.receiptRepo.FindByID(id)
 }
 
+// RenderPDF menghasilkan kwitansi PDF untuk penerimaan dana
+func (uc *DonationReceiptUseCase) RenderPDF(id string) (*entity.DonationReceipt, []byte, error) {
+	receipt, err := uc.receiptRepo.FindByID(id)
+	if err != nil {
+		return nil, nil, errors.New("donation receipt not found")
+	}
+
+	pdf, err := uc.receiptRenderer.RenderReceipt(receipt)
+	if err != nil {
+		return receipt, nil, err
+	}
+
+	return receipt, pdf, nil
+}
+
 func (uc *DonationReceiptUseCase) Update(input UpdateDonationReceiptInput) (*entity.DonationReceipt, error) {
 	// Validate input
 	if err := uc.validator.Struct(input); err != nil {
@@ -134,31 +255,71 @@ func (uc *DonationReceiptUseCase) Update(input UpdateDonationReceiptInput) (*ent
 		return nil, errors.New("donation receipt not found")
 	}
 
-	// Verify muzakki exists
-	_, err = uc.muzakkiRepo.FindByID(input.MuzakkiID)
+	// Posted receipts are corrected by reversal, voided receipts are final
+	if existing.Status != entity.ReceiptStatusDraft {
+		return nil, fmt.Errorf("%s donation receipt cannot be edited, use reverse to correct a posted receipt", existing.Status)
+	}
+
+	// Both the current and the new date must be in an open period
+	if err := requireOpenPeriod(uc.periodRepo, existing.ReceiptDate); err != nil {
+		return nil, err
+	}
+	if err := requireOpenPeriod(uc.periodRepo, input.ReceiptDate); err != nil {
+		return nil, err
+	}
+
+	if input.ReceiptNumber != existing.ReceiptNumber {
+		if err := uc.validateManualReceiptNumber(input.ReceiptNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	// Verify muzakki exists; a merged muzakki ID resolves to the surviving muzakki
+	muzakki, err := uc.muzakkiRepo.FindByID(input.MuzakkiID)
 	if err != nil {
 		return nil, errors.New("muzakki not found")
 	}
+	input.MuzakkiID = muzakki.ID
+
+	// Verify receiving account
+	account, err := requireActiveFinancialAccount(uc.accountRepo, input.FinancialAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify linked zakat calculations
+	if err := uc.validateZakatCalculations(input.MuzakkiID, input.Items); err != nil {
+		return nil, err
+	}
+
+	items := toDonationReceiptItems(input.Items)
+
+	// Validate / auto-fill fitrah items from the board's rate
+	region := input.FitrahRegion
+	if region == "" {
+		region = existing.FitrahRegion
+	}
+	if region == "" {
+		region = uc.defaultFitrahRegion
+	}
+	if err := uc.applyFitrahRates(input.ReceiptDate, region, items); err != nil {
+		return nil, err
+	}
 
 	// Calculate total amount
 	var totalAmount float64
-	items := make([]*entity.DonationReceiptItem, len(input.Items))
-	for i, itemInput := range input.Items {
-		totalAmount += itemInput.Amount
-		items[i] = &entity.DonationReceiptItem{
-			FundType:    itemInput.FundType,
-			ZakatType:   itemInput.ZakatType,
-			PersonCount: itemInput.PersonCount,
-			Amount:      itemInput.Amount,
-			RiceKG:      itemInput.RiceKG,
-			Notes:       itemInput.Notes,
-		}
+	for _, item := range items {
+		totalAmount += item.Amount
 	}
 
 	existing.MuzakkiID = input.MuzakkiID
-	existing.ReceiptNumber = input.ReceiptNumber
+	if input.ReceiptNumber != "" {
+		existing.ReceiptNumber = input.ReceiptNumber
+	}
 	existing.ReceiptDate = input.ReceiptDate
-	existing.PaymentMethod = input.PaymentMethod
+	existing.FinancialAccountID = input.FinancialAccountID
+	existing.FinancialAccount = account
+	existing.FitrahRegion = region
 	existing.TotalAmount = totalAmount
 	existing.Notes = input.Notes
 	existing.Items = items
@@ -170,6 +331,325 @@ func (uc *DonationReceiptUseCase) Update(input UpdateDonationReceiptInput) (*ent
 	return existing, nil
 }
 
+// Delete hanya untuk draft; kwitansi posted dibatalkan lewat Void supaya jejak audit tetap ada
 func (uc *DonationReceiptUseCase) Delete(id string) error {
+	existing, err := uc.receiptRepo.FindByID(id)
+	if err != nil {
+		return errors.New("donation receipt not found")
+	}
+
+	if existing.Status != entity.ReceiptStatusDraft {
+		return fmt.Errorf("%s donation receipt cannot be deleted, void it instead", existing.Status)
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, existing.ReceiptDate); err != nil {
+		return err
+	}
+
 	return uc.receiptRepo.Delete(id)
 }
+
+// Post menerbitkan draft: kwitansi mendapat nomor dan mulai dihitung di laporan.
+// Kwitansi di atas ambang batas diajukan ke approver (pending_approval).
+func (uc *DonationReceiptUseCase) Post(id, userID string) (*entity.DonationReceipt, error) {
+	existing, err := uc.receiptRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("donation receipt not found")
+	}
+
+	if existing.Status != entity.ReceiptStatusDraft {
+		return nil, fmt.Errorf("only draft donation receipts can be posted (current status: %s)", existing.Status)
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, existing.ReceiptDate); err != nil {
+		return nil, err
+	}
+
+	if requiresApproval(uc.approvalThreshold, existing.TotalAmount) {
+		if err := uc.receiptRepo.Submit(existing.ID, userID); err != nil {
+			return nil, err
+		}
+		existing.Status = entity.ReceiptStatusPendingApproval
+		return existing, nil
+	}
+
+	if err := uc.receiptRepo.Post(existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Approve menyetujui kwitansi pending_approval; kwitansi diposting seperti Post.
+// Approver harus user lain selain pembuat dan pengajunya.
+func (uc *DonationReceiptUseCase) Approve(input ApprovalInput) (*entity.DonationReceipt, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.findPendingApproval(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.receiptRepo.Approve(existing, input.UserID, input.Comment); err != nil {
+		return nil, err
+	}
+
+	return uc.receiptRepo.FindByID(input.ID)
+}
+
+// Reject mengembalikan kwitansi pending_approval ke draft dengan komentar approver
+func (uc *DonationReceiptUseCase) Reject(input ApprovalInput) (*entity.DonationReceipt, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if err := requireRejectComment(input.Comment); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.findPendingApproval(input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.receiptRepo.Reject(input.ID, input.UserID, input.Comment); err != nil {
+		return nil, err
+	}
+
+	return uc.receiptRepo.FindByID(input.ID)
+}
+
+// findPendingApproval mengambil kwitansi yang menunggu persetujuan dan memastikan approver-nya sah
+func (uc *DonationReceiptUseCase) findPendingApproval(input ApprovalInput) (*entity.DonationReceipt, error) {
+	existing, err := uc.receiptRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("donation receipt not found")
+	}
+
+	if existing.Status != entity.ReceiptStatusPendingApproval {
+		return nil, fmt.Errorf("only pending_approval donation receipts can be approved or rejected (current status: %s)", existing.Status)
+	}
+
+	if err := requireDifferentApprover(input.UserID, existing.CreatedByUserID, existing.Approvals); err != nil {
+		return nil, err
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, existing.ReceiptDate); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Void membatalkan kwitansi posted; kwitansi tetap tersimpan tetapi tidak dihitung di laporan
+func (uc *DonationReceiptUseCase) Void(input VoidDonationReceiptInput) (*entity.DonationReceipt, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.receiptRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("donation receipt not found")
+	}
+
+	if existing.Status != entity.ReceiptStatusPosted {
+		return nil, fmt.Errorf("only posted donation receipts can be voided (current status: %s)", existing.Status)
+	}
+
+	// The reversal journal is dated like the receipt
+	if err := requireOpenPeriod(uc.periodRepo, existing.ReceiptDate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.receiptRepo.Void(input.ID, input.Reason, input.VoidedByUserID); err != nil {
+		return nil, err
+	}
+
+	return uc.receiptRepo.FindByID(input.ID)
+}
+
+// Reverse membatalkan kwitansi posted dan menerbitkan kwitansi koreksi dalam satu transaksi
+func (uc *DonationReceiptUseCase) Reverse(input ReverseDonationReceiptInput) (*entity.DonationReceipt, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.receiptRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("donation receipt not found")
+	}
+
+	if existing.Status != entity.ReceiptStatusPosted {
+		return nil, fmt.Errorf("only posted donation receipts can be reversed (current status: %s)", existing.Status)
+	}
+
+	// The reversal journal is dated like the receipt; the correction date is checked in buildReceipt
+	if err := requireOpenPeriod(uc.periodRepo, existing.ReceiptDate); err != nil {
+		return nil, err
+	}
+
+	// Kwitansi koreksi langsung posted, atau pending_approval di atas ambang batas
+	input.Correction.Status = entity.ReceiptStatusPosted
+	if input.Correction.FitrahRegion == "" {
+		input.Correction.FitrahRegion = existing.FitrahRegion
+	}
+
+	correction, err := uc.buildReceipt(input.Correction)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.receiptRepo.Reverse(input.ID, input.Reason, input.VoidedByUserID, correction); err != nil {
+		return nil, err
+	}
+
+	return correction, nil
+}
+
+// validateZakatCalculations memastikan item yang ditautkan ke perhitungan zakat
+// adalah zakat maal dan perhitungannya milik muzakki yang sama
+func (uc *DonationReceiptUseCase) validateZakatCalculations(muzakkiID string, items []CreateDonationReceiptItemInput) error {
+	for _, item := range items {
+		if item.ZakatCalculationID == nil || *item.ZakatCalculationID == "" {
+			continue
+		}
+
+		if item.FundType != "zakat" || item.ZakatType == nil || *item.ZakatType != "maal" {
+			return errors.New("zakat_calculation_id can only be set on zakat maal items")
+		}
+
+		calculation, err := uc.calculationRepo.FindByID(*item.ZakatCalculationID)
+		if err != nil {
+			return errors.New("zakat calculation not found: " + *item.ZakatCalculationID)
+		}
+		if calculation.MuzakkiID != muzakkiID {
+			return errors.New("zakat calculation " + calculation.ID + " belongs to a different muzakki")
+		}
+	}
+
+	return nil
+}
+
+func toDonationReceiptItems(inputs []CreateDonationReceiptItemInput) []*entity.DonationReceiptItem {
+	items := make([]*entity.DonationReceiptItem, len(inputs))
+	for i, itemInput := range inputs {
+		items[i] = &entity.DonationReceiptItem{
+			FundType:           itemInput.FundType,
+			ZakatType:          itemInput.ZakatType,
+			PersonCount:        itemInput.PersonCount,
+			Amount:             itemInput.Amount,
+			RiceKG:             itemInput.RiceKG,
+			Notes:              itemInput.Notes,
+			ZakatCalculationID: itemInput.ZakatCalculationID,
+		}
+	}
+	return items
+}
+
+func isFitrahItem(item *entity.DonationReceiptItem) bool {
+	return item.FundType == "zakat" && item.ZakatType != nil && *item.ZakatType == "fitrah"
+}
+
+// applyFitrahRates mencocokkan item zakat fitrah dengan tarif tahun hijriah dan
+// wilayah penerimaan. Amount yang kosong diisi otomatis dari PersonCount, amount
+// atau rice_kg yang tidak sesuai tarif dikembalikan sebagai ValidationErrors.
+// Jika tarif tidak menetapkan nominal uang, nominal dihitung dari harga beras yang
+// berlaku pada tanggal penerimaan. Jika tarif belum ditetapkan, item fitrah wajib
+// mengisi amount sendiri.
+func (uc *DonationReceiptUseCase) applyFitrahRates(receiptDate, region string, items []*entity.DonationReceiptItem) error {
+	var errs ValidationErrors
+	var fitrahIndexes []int
+
+	for i, item := range items {
+		if !isFitrahItem(item) {
+			if item.Amount <= 0 {
+				errs = append(errs, FieldError{Field: itemField(i, "amount"), Message: "amount must be greater than 0"})
+			}
+			continue
+		}
+
+		if item.PersonCount == nil || *item.PersonCount < 1 {
+			errs = append(errs, FieldError{Field: itemField(i, "person_count"), Message: "person_count is required for zakat fitrah"})
+			continue
+		}
+		fitrahIndexes = append(fitrahIndexes, i)
+	}
+
+	if len(fitrahIndexes) == 0 {
+		if len(errs) > 0 {
+			return errs
+		}
+		return nil
+	}
+
+	date, err := time.Parse("2006-01-02", receiptDate)
+	if err != nil {
+		return errors.New("receipt_date must be in YYYY-MM-DD format")
+	}
+	hijriYear := hijri.Year(date)
+
+	rate, err := uc.fitrahRateRepo.FindByYearAndRegion(hijriYear, region)
+	if err != nil {
+		return err
+	}
+
+	var cashPerPerson float64
+	if rate != nil {
+		cashPerPerson = rate.CashPerPerson
+		if cashPerPerson <= 0 {
+			ricePrice, err := uc.priceRepo.FindEffective(entity.CommodityRice, receiptDate)
+			if err != nil {
+				return err
+			}
+			if ricePrice == nil {
+				return fmt.Errorf("fitrah rate for %d H region %q follows the rice price, but no rice price is recorded on or before %s", hijriYear, region, receiptDate)
+			}
+			cashPerPerson = rate.RiceKGPerPerson * ricePrice.Price
+		}
+	}
+
+	for _, i := range fitrahIndexes {
+		item := items[i]
+
+		if rate == nil {
+			if item.Amount <= 0 {
+				errs = append(errs, FieldError{
+					Field:   itemField(i, "amount"),
+					Message: fmt.Sprintf("fitrah rate for %d H region %q is not configured, amount is required", hijriYear, region),
+				})
+			}
+			continue
+		}
+
+		personCount := float64(*item.PersonCount)
+		expectedAmount := roundMoney(personCount * cashPerPerson)
+		expectedRice := math.Round(personCount*rate.RiceKGPerPerson*100) / 100
+
+		if item.RiceKG != nil && math.Abs(*item.RiceKG-expectedRice) > 0.001 {
+			errs = append(errs, FieldError{
+				Field:    itemField(i, "rice_kg"),
+				Message:  "rice_kg does not match the fitrah rate",
+				Expected: expectedRice,
+				Actual:   *item.RiceKG,
+			})
+		}
+
+		switch {
+		case item.Amount == 0:
+			item.Amount = expectedAmount
+		case math.Abs(item.Amount-expectedAmount) > 0.01:
+			errs = append(errs, FieldError{
+				Field:    itemField(i, "amount"),
+				Message:  "amount does not match the fitrah rate",
+				Expected: expectedAmount,
+				Actual:   item.Amount,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
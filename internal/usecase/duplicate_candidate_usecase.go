@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+	"go-zakat-be/pkg/nik"
+	"go-zakat-be/pkg/similarity"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// duplicateNameThreshold adalah kemiripan nama minimal untuk kandidat nama + alamat
+	duplicateNameThreshold = 0.85
+	// duplicateAddressThreshold adalah kemiripan alamat minimal untuk kandidat nama + alamat
+	duplicateAddressThreshold = 0.5
+	// duplicatePhoneNameThreshold: nomor telepon sama tetapi nama jauh berbeda biasanya anggota
+	// keluarga yang berbagi telepon, bukan data ganda
+	duplicatePhoneNameThreshold = 0.6
+	// maxNameBlockSize membatasi kata nama yang terlalu umum (mis. "muhammad") supaya
+	// perbandingan tidak menjadi semua-lawan-semua
+	maxNameBlockSize = 500
+)
+
+type DuplicateCandidateUseCase struct {
+	mustahiqRepo  repository.MustahiqRepository
+	muzakkiRepo   repository.MuzakkiRepository
+	dismissalRepo repository.DuplicateDismissalRepository
+	validator     *validator.Validate
+}
+
+func NewDuplicateCandidateUseCase(
+	mustahiqRepo repository.MustahiqRepository,
+	muzakkiRepo repository.MuzakkiRepository,
+	dismissalRepo repository.DuplicateDismissalRepository,
+	validator *validator.Validate,
+) *DuplicateCandidateUseCase {
+	return &DuplicateCandidateUseCase{
+		mustahiqRepo:  mustahiqRepo,
+		muzakkiRepo:   muzakkiRepo,
+		dismissalRepo: dismissalRepo,
+		validator:     validator,
+	}
+}
+
+type DuplicateCandidateFilter struct {
+	EntityType string `validate:"required,oneof=mustahiq muzakki"`
+	Confidence string `validate:"omitempty,oneof=high medium"`
+	Page       int
+	PerPage    int
+}
+
+type DismissDuplicateInput struct {
+	EntityType string `validate:"required,oneof=mustahiq muzakki"`
+	RecordAID  string `validate:"required"`
+	RecordBID  string `validate:"required,nefield=RecordAID"`
+	Reason     string `validate:"required"`
+	UserID     string `validate:"required"`
+}
+
+// normalizeNIK membersihkan dan memvalidasi NIK; NIK kosong diperbolehkan
+func normalizeNIK(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", nil
+	}
+
+	info, err := nik.Parse(raw, time.Now())
+	if err != nil {
+		return "", ValidationErrors{{Field: "nik", Message: err.Error(), Actual: raw}}
+	}
+
+	return info.Number, nil
+}
+
+// FindAll mencari pasangan kandidat data ganda, skor tertinggi dulu. Pasangan yang
+// sudah dinyatakan bukan data ganda tidak ditampilkan lagi.
+func (uc *DuplicateCandidateUseCase) FindAll(filter DuplicateCandidateFilter) ([]*entity.DuplicateCandidate, int64, error) {
+	if err := uc.validator.Struct(filter); err != nil {
+		return nil, 0, err
+	}
+
+	records, err := uc.loadRecords(filter.EntityType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dismissals, err := uc.dismissalRepo.FindByEntityType(filter.EntityType)
+	if err != nil {
+		return nil, 0, err
+	}
+	dismissed := make(map[[2]string]bool, len(dismissals))
+	for _, d := range dismissals {
+		dismissed[[2]string{d.RecordAID, d.RecordBID}] = true
+	}
+
+	var candidates []*entity.DuplicateCandidate
+	for _, candidate := range findDuplicateCandidates(filter.EntityType, records, dismissed) {
+		if filter.Confidence != "" && candidate.Confidence != filter.Confidence {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	// Pagination
+	total := int64(len(candidates))
+	if filter.PerPage > 0 {
+		start := min(max(filter.Page-1, 0)*filter.PerPage, len(candidates))
+		end := min(start+filter.PerPage, len(candidates))
+		candidates = candidates[start:end]
+	}
+
+	return candidates, total, nil
+}
+
+// Dismiss mencatat bahwa dua data sudah diperiksa dan bukan orang yang sama
+func (uc *DuplicateCandidateUseCase) Dismiss(input DismissDuplicateInput) (*entity.DuplicateDismissal, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	for _, id := range []string{input.RecordAID, input.RecordBID} {
+		var err error
+		if input.EntityType == entity.DuplicateEntityMustahiq {
+			_, err = uc.mustahiqRepo.FindByID(id)
+		} else {
+			_, err = uc.muzakkiRepo.FindByID(id)
+		}
+		if err != nil {
+			return nil, errors.New(input.EntityType + " not found")
+		}
+	}
+
+	// Simpan pasangan dengan urutan ID tetap supaya A-B dan B-A dianggap sama
+	recordAID, recordBID := input.RecordAID, input.RecordBID
+	if recordBID < recordAID {
+		recordAID, recordBID = recordBID, recordAID
+	}
+
+	dismissal := &entity.DuplicateDismissal{
+		EntityType:        input.EntityType,
+		RecordAID:         recordAID,
+		RecordBID:         recordBID,
+		Reason:            input.Reason,
+		DismissedByUserID: input.UserID,
+	}
+
+	if err := uc.dismissalRepo.Create(dismissal); err != nil {
+		return nil, err
+	}
+
+	return dismissal, nil
+}
+
+func (uc *DuplicateCandidateUseCase) loadRecords(entityType string) ([]entity.DuplicateRecord, error) {
+	var records []entity.DuplicateRecord
+
+	if entityType == entity.DuplicateEntityMustahiq {
+		mustahiqs, _, err := uc.mustahiqRepo.FindAll(repository.MustahiqFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range mustahiqs {
+			records = append(records, entity.DuplicateRecord{
+				ID: m.ID, Name: m.Name, NIK: m.NIK, PhoneNumber: m.PhoneNumber, Address: m.Address, CreatedAt: m.CreatedAt,
+			})
+		}
+		return records, nil
+	}
+
+	muzakkis, _, err := uc.muzakkiRepo.FindAll(repository.MuzakkiFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range muzakkis {
+		records = append(records, entity.DuplicateRecord{
+			ID: m.ID, Name: m.Name, NIK: m.NIK, PhoneNumber: m.PhoneNumber, Address: m.Address, CreatedAt: m.CreatedAt,
+		})
+	}
+	return records, nil
+}
+
+// findDuplicateCandidates membandingkan data yang berbagi NIK, nomor telepon (ternormalisasi)
+// atau salah satu kata nama, lalu menilai pasangan tersebut:
+//   - NIK sama: high
+//   - nomor telepon sama dan nama mirip: high jika nama sangat mirip, selain itu medium
+//   - nama dan alamat mirip: high jika keduanya hampir sama, selain itu medium
+//
+// Dua data dengan NIK berbeda (keduanya terisi) dianggap orang yang berbeda.
+func findDuplicateCandidates(entityType string, records []entity.DuplicateRecord, dismissed map[[2]string]bool) []*entity.DuplicateCandidate {
+	type normalized struct {
+		name    string
+		phone   string
+		address string
+	}
+
+	norm := make([]normalized, len(records))
+	blocks := make(map[string][]int)
+	for i, r := range records {
+		norm[i] = normalized{
+			name:    similarity.NormalizeName(r.Name),
+			phone:   similarity.NormalizePhone(r.PhoneNumber),
+			address: similarity.NormalizeAddress(r.Address),
+		}
+
+		if r.NIK != "" {
+			blocks["nik:"+r.NIK] = append(blocks["nik:"+r.NIK], i)
+		}
+		if norm[i].phone != "" {
+			blocks["phone:"+norm[i].phone] = append(blocks["phone:"+norm[i].phone], i)
+		}
+		for _, word := range strings.Fields(norm[i].name) {
+			if len(word) >= 3 {
+				blocks["name:"+word] = append(blocks["name:"+word], i)
+			}
+		}
+	}
+
+	// Kumpulkan pasangan yang berada di blok yang sama
+	pairs := make(map[[2]int]bool)
+	for key, members := range blocks {
+		if strings.HasPrefix(key, "name:") && len(members) > maxNameBlockSize {
+			continue
+		}
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				pairs[[2]int{members[x], members[y]}] = true
+			}
+		}
+	}
+
+	var candidates []*entity.DuplicateCandidate
+	for pair := range pairs {
+		a, b := records[pair[0]], records[pair[1]]
+		na, nb := norm[pair[0]], norm[pair[1]]
+
+		if a.NIK != "" && b.NIK != "" && a.NIK != b.NIK {
+			continue
+		}
+
+		idA, idB := a.ID, b.ID
+		if idB < idA {
+			idA, idB = idB, idA
+		}
+		if dismissed[[2]string{idA, idB}] {
+			continue
+		}
+
+		nameSimilarity := similarity.NameSimilarity(na.name, nb.name)
+		addressSimilarity := similarity.TokenSimilarity(na.address, nb.address)
+		sameNIK := a.NIK != "" && a.NIK == b.NIK
+		samePhone := na.phone != "" && na.phone == nb.phone
+
+		var reasons []string
+		confidence := entity.DuplicateConfidenceMedium
+		if sameNIK {
+			reasons = append(reasons, entity.DuplicateReasonNIK)
+			confidence = entity.DuplicateConfidenceHigh
+		}
+		if samePhone && nameSimilarity >= duplicatePhoneNameThreshold {
+			reasons = append(reasons, entity.DuplicateReasonPhone)
+			if nameSimilarity >= duplicateNameThreshold {
+				confidence = entity.DuplicateConfidenceHigh
+			}
+		}
+		if nameSimilarity >= duplicateNameThreshold && addressSimilarity >= duplicateAddressThreshold {
+			reasons = append(reasons, entity.DuplicateReasonNameAddress)
+			if nameSimilarity >= 0.95 && addressSimilarity >= 0.8 {
+				confidence = entity.DuplicateConfidenceHigh
+			}
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+
+		score := 100.0
+		if !sameNIK {
+			phoneScore := 0.0
+			if samePhone {
+				phoneScore = 1
+			}
+			score = 100 * (0.4*phoneScore + 0.35*nameSimilarity + 0.25*addressSimilarity)
+		}
+
+		// Data yang lebih dulu terdaftar menjadi RecordA
+		if b.CreatedAt.Before(a.CreatedAt) {
+			a, b = b, a
+		}
+
+		candidates = append(candidates, &entity.DuplicateCandidate{
+			EntityType:        entityType,
+			RecordA:           a,
+			RecordB:           b,
+			Score:             roundMoney(score),
+			Confidence:        confidence,
+			Reasons:           reasons,
+			NameSimilarity:    math.Round(nameSimilarity*1000) / 1000,
+			AddressSimilarity: math.Round(addressSimilarity*1000) / 1000,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].RecordA.CreatedAt.Before(candidates[j].RecordA.CreatedAt)
+	})
+
+	return candidates
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError menjelaskan satu kesalahan validasi bisnis pada field tertentu,
+// misalnya "items[0].amount"
+type FieldError struct {
+	Field    string      `json:"field"`
+	Message  string      `json:"message"`
+	Expected interface{} `json:"expected,omitempty"`
+	Actual   interface{} `json:"actual,omitempty"`
+}
+
+// ValidationErrors adalah kumpulan FieldError yang dikembalikan usecase supaya
+// handler bisa mengirimkannya sebagai error terstruktur
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fmt.Sprintf("%s: %s", fe.Field, fe.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func itemField(index int, field string) string {
+	return fmt.Sprintf("items[%d].%s", index, field)
+}
+
+// lineField menunjuk baris pada file import, misalnya "line 3"
+func lineField(line int) string {
+	return fmt.Sprintf("line %d", line)
+}
+
+// questionField menunjuk pertanyaan kuesioner, misalnya "questions[0].options"
+func questionField(index int, field string) string {
+	return fmt.Sprintf("questions[%d].%s", index, field)
+}
+
+// answerField menunjuk jawaban penilaian, misalnya "answers[0].option_id"
+func answerField(index int, field string) string {
+	return fmt.Sprintf("answers[%d].%s", index, field)
+}
+
+// memberField menunjuk anggota rumah tangga, misalnya "members[0].mustahiq_id"
+func memberField(index int, field string) string {
+	return fmt.Sprintf("members[%d].%s", index, field)
+}
+
+// mergeField menunjuk pilihan field penggabungan, misalnya "fields.phone_number"
+func mergeField(field string) string {
+	return "fields." + field
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"errors"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type FinancialAccountUseCase struct {
+	accountRepo repository.FinancialAccountRepository
+	validator   *validator.Validate
+}
+
+func NewFinancialAccountUseCase(accountRepo repository.FinancialAccountRepository, validator *validator.Validate) *FinancialAccountUseCase {
+	return &FinancialAccountUseCase{
+		accountRepo: accountRepo,
+		validator:   validator,
+	}
+}
+
+type CreateFinancialAccountInput struct {
+	Name          string `validate:"required,max=100"`
+	AccountType   string `validate:"required,oneof=cash bank digital"`
+	BankName      string
+	AccountNumber string
+	IsActive      bool
+	Notes         string
+}
+
+type UpdateFinancialAccountInput struct {
+	ID            string `validate:"required"`
+	Name          string `validate:"required,max=100"`
+	AccountType   string `validate:"required,oneof=cash bank digital"`
+	BankName      string
+	AccountNumber string
+	IsActive      bool
+	Notes         string
+}
+
+func (uc *FinancialAccountUseCase) Create(input CreateFinancialAccountInput) (*entity.FinancialAccount, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	account := &entity.FinancialAccount{
+		Name:          input.Name,
+		AccountType:   input.AccountType,
+		BankName:      input.BankName,
+		AccountNumber: input.AccountNumber,
+		IsActive:      input.IsActive,
+		Notes:         input.Notes,
+	}
+
+	if err := uc.accountRepo.Create(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (uc *FinancialAccountUseCase) FindAll(filter repository.FinancialAccountFilter) ([]*entity.FinancialAccount, int64, error) {
+	return uc.accountRepo.FindAll(filter)
+}
+
+func (uc *FinancialAccountUseCase) FindByID(id string) (*entity.FinancialAccount, error) {
+	return uc.accountRepo.FindByID(id)
+}
+
+func (uc *FinancialAccountUseCase) Update(input UpdateFinancialAccountInput) (*entity.FinancialAccount, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	account, err := uc.accountRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Name = input.Name
+	account.AccountType = input.AccountType
+	account.BankName = input.BankName
+	account.AccountNumber = input.AccountNumber
+	account.IsActive = input.IsActive
+	account.Notes = input.Notes
+
+	if err := uc.accountRepo.Update(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (uc *FinancialAccountUseCase) Delete(id string) error {
+	return uc.accountRepo.Delete(id)
+}
+
+// requireActiveFinancialAccount memastikan rekening ada dan masih aktif
+// sebelum dipakai kwitansi atau penyaluran
+func requireActiveFinancialAccount(accountRepo repository.FinancialAccountRepository, id string) (*entity.FinancialAccount, error) {
+	account, err := accountRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("financial account not found")
+	}
+	if !account.IsActive {
+		return nil, ValidationErrors{{
+			Field:   "financial_account_id",
+			Message: "financial account " + account.Name + " is inactive",
+		}}
+	}
+
+	return account, nil
+}
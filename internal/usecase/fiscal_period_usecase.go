@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type FiscalPeriodUseCase struct {
+	periodRepo repository.FiscalPeriodRepository
+	validator  *validator.Validate
+}
+
+func NewFiscalPeriodUseCase(periodRepo repository.FiscalPeriodRepository, validator *validator.Validate) *FiscalPeriodUseCase {
+	return &FiscalPeriodUseCase{
+		periodRepo: periodRepo,
+		validator:  validator,
+	}
+}
+
+type CloseFiscalPeriodInput struct {
+	PeriodType string `validate:"required,oneof=month year"`
+	Year       int    `validate:"required,gte=2000,lte=2100"`
+	Month      int    `validate:"omitempty,gte=1,lte=12"` // wajib untuk period_type month
+	Notes      string
+	UserID     string `validate:"required"`
+}
+
+type ReopenFiscalPeriodInput struct {
+	ID     string `validate:"required"`
+	Reason string `validate:"required"`
+	UserID string `validate:"required"`
+}
+
+// Close menutup bulan atau tahun buku dan menyimpan snapshot saldo buku besar.
+// Bulan di dalam tahun yang sudah ditutup tidak perlu (dan tidak bisa) ditutup lagi,
+// dan periode hanya bisa ditutup setelah semua periode sebelumnya tertutup.
+func (uc *FiscalPeriodUseCase) Close(input CloseFiscalPeriodInput) (*entity.FiscalPeriod, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	var start, end time.Time
+	if input.PeriodType == entity.FiscalPeriodMonth {
+		if input.Month == 0 {
+			return nil, ValidationErrors{{Field: "month", Message: "month is required when period_type is month"}}
+		}
+		start = time.Date(input.Year, time.Month(input.Month), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, -1)
+	} else {
+		start = time.Date(input.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, -1)
+	}
+
+	// Errors point at the input that selects the period
+	field := "year"
+	if input.PeriodType == entity.FiscalPeriodMonth {
+		field = "month"
+	}
+
+	period := &entity.FiscalPeriod{
+		PeriodType:  input.PeriodType,
+		PeriodStart: start.Format("2006-01-02"),
+		PeriodEnd:   end.Format("2006-01-02"),
+		Notes:       input.Notes,
+	}
+
+	existing, err := uc.periodRepo.FindByStart(period.PeriodType, period.PeriodStart)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Status == entity.FiscalPeriodClosed {
+		return nil, ValidationErrors{{Field: field, Message: fmt.Sprintf("fiscal period %s is already closed", existing.Label())}}
+	}
+
+	if period.PeriodType == entity.FiscalPeriodMonth {
+		year, err := uc.periodRepo.FindByStart(entity.FiscalPeriodYear, start.Format("2006")+"-01-01")
+		if err != nil {
+			return nil, err
+		}
+		if year != nil && year.Status == entity.FiscalPeriodClosed {
+			return nil, ValidationErrors{{Field: field, Message: fmt.Sprintf("fiscal year %s is already closed", year.Label())}}
+		}
+	}
+
+	if err := uc.periodRepo.Close(period, input.UserID); err != nil {
+		return nil, toFiscalPeriodError(err, field)
+	}
+
+	return uc.periodRepo.FindByID(period.ID)
+}
+
+// Reopen membuka kembali periode tertutup (admin only); alasan dicatat di jejak audit.
+// Periode sesudahnya yang masih tertutup harus dibuka lebih dulu.
+func (uc *FiscalPeriodUseCase) Reopen(input ReopenFiscalPeriodInput) (*entity.FiscalPeriod, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	period, err := uc.periodRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if period.Status != entity.FiscalPeriodClosed {
+		return nil, ValidationErrors{{Field: "id", Message: fmt.Sprintf("fiscal period %s is not closed", period.Label())}}
+	}
+
+	// Bulan tetap terkunci selama tahunnya tertutup
+	if period.PeriodType == entity.FiscalPeriodMonth {
+		year, err := uc.periodRepo.FindByStart(entity.FiscalPeriodYear, period.PeriodStart[:4]+"-01-01")
+		if err != nil {
+			return nil, err
+		}
+		if year != nil && year.Status == entity.FiscalPeriodClosed {
+			return nil, ValidationErrors{{Field: "id", Message: fmt.Sprintf("fiscal year %s must be reopened first", year.Label())}}
+		}
+	}
+
+	if err := uc.periodRepo.Reopen(period.ID, input.Reason, input.UserID); err != nil {
+		return nil, toFiscalPeriodError(err, "id")
+	}
+
+	return uc.periodRepo.FindByID(period.ID)
+}
+
+func (uc *FiscalPeriodUseCase) FindAll(filter repository.FiscalPeriodFilter) ([]*entity.FiscalPeriod, int64, error) {
+	return uc.periodRepo.FindAll(filter)
+}
+
+func (uc *FiscalPeriodUseCase) FindByID(id string) (*entity.FiscalPeriod, error) {
+	return uc.periodRepo.FindByID(id)
+}
+
+// toFiscalPeriodError mengubah penolakan tutup/buka periode dari repository menjadi ValidationErrors
+func toFiscalPeriodError(err error, field string) error {
+	var orderErr *repository.FiscalPeriodOrderError
+	if errors.As(err, &orderErr) {
+		// Status the blocking period must have first, and the status it has now
+		expected, actual := entity.FiscalPeriodClosed, entity.FiscalPeriodOpen
+		if orderErr.Action == entity.FiscalPeriodActionReopen {
+			expected, actual = actual, expected
+		}
+		return ValidationErrors{{Field: field, Message: orderErr.Error(), Expected: expected, Actual: actual}}
+	}
+
+	var draftErr *repository.FiscalPeriodDraftError
+	if errors.As(err, &draftErr) {
+		return ValidationErrors{{Field: field, Message: draftErr.Error(), Expected: 0, Actual: draftErr.DonationReceipts + draftErr.Distributions}}
+	}
+
+	return err
+}
+
+// requireOpenPeriod menolak perubahan transaksi yang tanggalnya ada di periode tertutup
+// atau sebelum periode tertutup terakhir
+func requireOpenPeriod(periodRepo repository.FiscalPeriodRepository, date string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return errors.New("date must be in YYYY-MM-DD format")
+	}
+
+	period, err := periodRepo.FindClosedByDate(date)
+	if err != nil {
+		return err
+	}
+	if period != nil {
+		if date < period.PeriodStart {
+			return fmt.Errorf("transactions dated %s precede closed fiscal period %s and cannot be changed", date, period.Label())
+		}
+		return fmt.Errorf("fiscal period %s is closed, transactions dated %s cannot be changed", period.Label(), date)
+	}
+
+	return nil
+}
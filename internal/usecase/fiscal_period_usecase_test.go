@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// fakeFiscalPeriodRepository tidak menyimpan periode tertutup; Close dan Reopen
+// mengembalikan err
+type fakeFiscalPeriodRepository struct {
+	repository.FiscalPeriodRepository
+	period *entity.FiscalPeriod
+	err    error
+}
+
+func (r *fakeFiscalPeriodRepository) FindClosedByDate(date string) (*entity.FiscalPeriod, error) {
+	return nil, nil
+}
+
+func (r *fakeFiscalPeriodRepository) FindByStart(periodType, periodStart string) (*entity.FiscalPeriod, error) {
+	return nil, nil
+}
+
+func (r *fakeFiscalPeriodRepository) FindByID(id string) (*entity.FiscalPeriod, error) {
+	return r.period, nil
+}
+
+func (r *fakeFiscalPeriodRepository) Close(period *entity.FiscalPeriod, userID string) error {
+	return r.err
+}
+
+func (r *fakeFiscalPeriodRepository) Reopen(id, reason, userID string) error {
+	return r.err
+}
+
+func TestFiscalPeriodCloseReopenErrors(t *testing.T) {
+	closed := &entity.FiscalPeriod{ID: "fp-1", PeriodType: entity.FiscalPeriodMonth, PeriodStart: "2026-02-01", Status: entity.FiscalPeriodClosed}
+
+	tests := []struct {
+		name      string
+		reopen    bool
+		repoErr   error
+		wantField string // kosong = error diteruskan apa adanya
+	}{
+		{name: "close before an earlier period", repoErr: &repository.FiscalPeriodOrderError{Action: entity.FiscalPeriodActionClose, Period: "2026-01"}, wantField: "month"},
+		{name: "close with drafts left", repoErr: &repository.FiscalPeriodDraftError{DonationReceipts: 2}, wantField: "month"},
+		{name: "reopen before a later period", reopen: true, repoErr: &repository.FiscalPeriodOrderError{Action: entity.FiscalPeriodActionReopen, Period: "2026-03"}, wantField: "id"},
+		{name: "database failure", repoErr: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewFiscalPeriodUseCase(&fakeFiscalPeriodRepository{period: closed, err: tt.repoErr}, validator.New())
+
+			var err error
+			if tt.reopen {
+				_, err = uc.Reopen(ReopenFiscalPeriodInput{ID: closed.ID, Reason: "koreksi", UserID: "user-1"})
+			} else {
+				_, err = uc.Close(CloseFiscalPeriodInput{PeriodType: entity.FiscalPeriodMonth, Year: 2026, Month: 2, UserID: "user-1"})
+			}
+
+			var validationErrs ValidationErrors
+			if tt.wantField == "" {
+				if !errors.Is(err, tt.repoErr) || errors.As(err, &validationErrs) {
+					t.Fatalf("error = %v, want %v unchanged", err, tt.repoErr)
+				}
+				return
+			}
+			if !errors.As(err, &validationErrs) {
+				t.Fatalf("error = %v, want ValidationErrors", err)
+			}
+			if validationErrs[0].Field != tt.wantField || validationErrs[0].Message != tt.repoErr.Error() {
+				t.Errorf("error = %+v, want field %s with message %q", validationErrs[0], tt.wantField, tt.repoErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type FitrahRateUseCase struct {
+	fitrahRateRepo repository.FitrahRateRepository
+	validator      *validator.Validate
+}
+
+func NewFitrahRateUseCase(fitrahRateRepo repository.FitrahRateRepository, validator *validator.Validate) *FitrahRateUseCase {
+	return &FitrahRateUseCase{
+		fitrahRateRepo: fitrahRateRepo,
+		validator:      validator,
+	}
+}
+
+type CreateFitrahRateInput struct {
+	HijriYear       int     `validate:"required,min=1400"`
+	Region          string  `validate:"required"`
+	RiceKGPerPerson float64 `validate:"required,gt=0"`
+	CashPerPerson   float64 `validate:"gte=0"` // 0 = ikut harga beras (commodity_prices)
+	Notes           string
+}
+
+type UpdateFitrahRateInput struct {
+	ID              string  `validate:"required"`
+	HijriYear       int     `validate:"required,min=1400"`
+	Region          string  `validate:"required"`
+	RiceKGPerPerson float64 `validate:"required,gt=0"`
+	CashPerPerson   float64 `validate:"gte=0"` // 0 = ikut harga beras (commodity_prices)
+	Notes           string
+}
+
+func (uc *FitrahRateUseCase) Create(input CreateFitrahRateInput) (*entity.FitrahRate, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	rate := &entity.FitrahRate{
+		HijriYear:       input.HijriYear,
+		Region:          input.Region,
+		RiceKGPerPerson: input.RiceKGPerPerson,
+		CashPerPerson:   input.CashPerPerson,
+		Notes:           input.Notes,
+	}
+
+	if err := uc.fitrahRateRepo.Create(rate); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (uc *FitrahRateUseCase) FindAll(filter repository.FitrahRateFilter) ([]*entity.FitrahRate, int64, error) {
+	return uc.fitrahRateRepo.FindAll(filter)
+}
+
+func (uc *FitrahRateUseCase) FindByID(id string) (*entity.FitrahRate, error) {
+	return uc.fitrahRateRepo.FindByID(id)
+}
+
+func (uc *FitrahRateUseCase) Update(input UpdateFitrahRateInput) (*entity.FitrahRate, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	rate, err := uc.fitrahRateRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rate.HijriYear = input.HijriYear
+	rate.Region = input.Region
+	rate.RiceKGPerPerson = input.RiceKGPerPerson
+	rate.CashPerPerson = input.CashPerPerson
+	rate.Notes = input.Notes
+
+	if err := uc.fitrahRateRepo.Update(rate); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (uc *FitrahRateUseCase) Delete(id string) error {
+	return uc.fitrahRateRepo.Delete(id)
+}
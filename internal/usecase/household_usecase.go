@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type HouseholdUseCase struct {
+	householdRepo repository.HouseholdRepository
+	mustahiqRepo  repository.MustahiqRepository
+	validator     *validator.Validate
+}
+
+func NewHouseholdUseCase(
+	householdRepo repository.HouseholdRepository,
+	mustahiqRepo repository.MustahiqRepository,
+	validator *validator.Validate,
+) *HouseholdUseCase {
+	return &HouseholdUseCase{
+		householdRepo: householdRepo,
+		mustahiqRepo:  mustahiqRepo,
+		validator:     validator,
+	}
+}
+
+type HouseholdMemberInput struct {
+	MustahiqID   string // opsional, anggota yang terdaftar sebagai mustahiq
+	Name         string // default nama mustahiq
+	Relationship string `validate:"required,oneof=head spouse child parent sibling grandchild other"`
+	BirthDate    string // YYYY-MM-DD, opsional
+}
+
+type SaveHouseholdInput struct {
+	ID               string // kosong saat create
+	FamilyCardNumber string `validate:"omitempty,numeric,len=16"`
+	Address          string `validate:"required"`
+	Notes            string
+	Members          []HouseholdMemberInput `validate:"required,min=1,dive"`
+}
+
+func (uc *HouseholdUseCase) Create(input SaveHouseholdInput) (*entity.Household, error) {
+	household, err := uc.buildHousehold(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.householdRepo.Create(household); err != nil {
+		return nil, err
+	}
+
+	return uc.householdRepo.FindByID(household.ID)
+}
+
+// Update mengganti data rumah tangga beserta seluruh anggotanya
+func (uc *HouseholdUseCase) Update(input SaveHouseholdInput) (*entity.Household, error) {
+	household, err := uc.buildHousehold(input)
+	if err != nil {
+		return nil, err
+	}
+	household.ID = input.ID
+
+	if err := uc.householdRepo.Update(household); err != nil {
+		return nil, err
+	}
+
+	return uc.householdRepo.FindByID(household.ID)
+}
+
+func (uc *HouseholdUseCase) FindAll(filter repository.HouseholdFilter) ([]*entity.Household, int64, error) {
+	return uc.householdRepo.FindAll(filter)
+}
+
+func (uc *HouseholdUseCase) FindByID(id string) (*entity.Household, error) {
+	return uc.householdRepo.FindByID(id)
+}
+
+// Delete menghapus rumah tangga; mustahiq anggotanya tetap ada tanpa rumah tangga
+func (uc *HouseholdUseCase) Delete(id string) error {
+	return uc.householdRepo.Delete(id)
+}
+
+// buildHousehold memastikan tepat satu kepala keluarga dan setiap mustahiq hanya tercatat sekali
+func (uc *HouseholdUseCase) buildHousehold(input SaveHouseholdInput) (*entity.Household, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	household := &entity.Household{
+		FamilyCardNumber: input.FamilyCardNumber,
+		Address:          input.Address,
+		Notes:            input.Notes,
+	}
+
+	var fieldErrors ValidationErrors
+	headCount := 0
+	seen := make(map[string]bool)
+
+	for i, in := range input.Members {
+		member := &entity.HouseholdMember{
+			Name:         in.Name,
+			Relationship: in.Relationship,
+		}
+
+		if in.Relationship == entity.HouseholdRelationshipHead {
+			headCount++
+		}
+
+		if in.MustahiqID != "" {
+			if seen[in.MustahiqID] {
+				fieldErrors = append(fieldErrors, FieldError{Field: memberField(i, "mustahiq_id"), Message: "mustahiq is listed more than once"})
+				continue
+			}
+			seen[in.MustahiqID] = true
+
+			mustahiq, err := uc.mustahiqRepo.FindByID(in.MustahiqID)
+			if err != nil {
+				fieldErrors = append(fieldErrors, FieldError{Field: memberField(i, "mustahiq_id"), Message: "mustahiq not found"})
+				continue
+			}
+			mustahiqID := mustahiq.ID
+			member.MustahiqID = &mustahiqID
+			if member.Name == "" {
+				member.Name = mustahiq.Name
+			}
+		}
+
+		if member.Name == "" {
+			fieldErrors = append(fieldErrors, FieldError{Field: memberField(i, "name"), Message: "name is required for members who are not mustahiq"})
+		}
+
+		if in.BirthDate != "" {
+			if _, err := time.Parse("2006-01-02", in.BirthDate); err != nil {
+				fieldErrors = append(fieldErrors, FieldError{Field: memberField(i, "birth_date"), Message: "date must be in YYYY-MM-DD format", Actual: in.BirthDate})
+			}
+			birthDate := in.BirthDate
+			member.BirthDate = &birthDate
+		}
+
+		household.Members = append(household.Members, member)
+	}
+
+	if headCount != 1 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:    "members",
+			Message:  "household must have exactly one head",
+			Expected: 1,
+			Actual:   headCount,
+		})
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors
+	}
+
+	return household, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"errors"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+)
+
+type JournalUseCase struct {
+	journalRepo repository.JournalRepository
+}
+
+func NewJournalUseCase(journalRepo repository.JournalRepository) *JournalUseCase {
+	return &JournalUseCase{journalRepo: journalRepo}
+}
+
+func (uc *JournalUseCase) FindAccounts() ([]*entity.LedgerAccount, error) {
+	return uc.journalRepo.FindAccounts()
+}
+
+func (uc *JournalUseCase) FindAllEntries(filter repository.JournalEntryFilter) ([]*entity.JournalEntry, int64, error) {
+	switch filter.SourceType {
+	case "", entity.JournalSourceDonationReceipt, entity.JournalSourceDistribution, entity.JournalSourceOpeningBalance:
+	default:
+		return nil, 0, errors.New("source_type must be one of donation_receipt, distribution, opening_balance")
+	}
+
+	return uc.journalRepo.FindAllEntries(filter)
+}
+
+func (uc *JournalUseCase) FindEntryByID(id string) (*entity.JournalEntry, error) {
+	return uc.journalRepo.FindEntryByID(id)
+}
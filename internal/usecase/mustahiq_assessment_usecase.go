@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type MustahiqAssessmentUseCase struct {
+	assessmentRepo    repository.MustahiqAssessmentRepository
+	questionnaireRepo repository.AssessmentQuestionnaireRepository
+	mustahiqRepo      repository.MustahiqRepository
+	validator         *validator.Validate
+}
+
+func NewMustahiqAssessmentUseCase(
+	assessmentRepo repository.MustahiqAssessmentRepository,
+	questionnaireRepo repository.AssessmentQuestionnaireRepository,
+	mustahiqRepo repository.MustahiqRepository,
+	validator *validator.Validate,
+) *MustahiqAssessmentUseCase {
+	return &MustahiqAssessmentUseCase{
+		assessmentRepo:    assessmentRepo,
+		questionnaireRepo: questionnaireRepo,
+		mustahiqRepo:      mustahiqRepo,
+		validator:         validator,
+	}
+}
+
+type AssessmentAnswerInput struct {
+	QuestionID string `validate:"required"`
+	OptionID   string `validate:"required"`
+}
+
+type CreateMustahiqAssessmentInput struct {
+	MustahiqID       string `validate:"required"`
+	QuestionnaireID  string // opsional, default kuesioner aktif asnaf mustahiq
+	AssessmentDate   string `validate:"required"` // YYYY-MM-DD
+	Notes            string
+	AssessedByUserID string                  `validate:"required"`
+	Answers          []AssessmentAnswerInput `validate:"required,min=1,dive"`
+}
+
+// Create menyimpan hasil survei kelayakan, menghitung skor (0-100) dan mengubah status mustahiq:
+// skor >= ambang batas kuesioner = active, di bawahnya = inactive
+func (uc *MustahiqAssessmentUseCase) Create(input CreateMustahiqAssessmentInput) (*entity.MustahiqAssessment, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse("2006-01-02", input.AssessmentDate); err != nil {
+		return nil, errors.New("assessment_date must be in YYYY-MM-DD format")
+	}
+
+	mustahiq, err := uc.mustahiqRepo.FindByID(input.MustahiqID)
+	if err != nil {
+		return nil, errors.New("mustahiq not found")
+	}
+
+	questionnaire, err := uc.findQuestionnaire(input.QuestionnaireID, mustahiq)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := scoreAnswers(questionnaire, input.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalScore, totalMax float64
+	for _, a := range answers {
+		totalScore += a.Weight * a.Score
+		totalMax += a.Weight * a.MaxScore
+	}
+	score := math.Round(totalScore/totalMax*100*100) / 100
+
+	assessment := &entity.MustahiqAssessment{
+		MustahiqID:       mustahiq.ID,
+		QuestionnaireID:  questionnaire.ID,
+		AssessmentDate:   input.AssessmentDate,
+		Score:            score,
+		PassThreshold:    questionnaire.PassThreshold,
+		Result:           entity.AssessmentResultNotEligible,
+		NewStatus:        entity.MustahiqStatusInactive,
+		Notes:            input.Notes,
+		AssessedByUserID: input.AssessedByUserID,
+		Answers:          answers,
+	}
+	if score >= questionnaire.PassThreshold {
+		assessment.Result = entity.AssessmentResultEligible
+		assessment.NewStatus = entity.MustahiqStatusActive
+	}
+
+	// A status change from the assessment is recorded in the status history on the assessment date
+	if assessment.NewStatus != mustahiq.Status {
+		if err := requireMustahiqStatusDate(uc.mustahiqRepo, mustahiq.ID, input.AssessmentDate, "assessment_date"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.assessmentRepo.Create(assessment); err != nil {
+		return nil, err
+	}
+
+	return uc.assessmentRepo.FindByID(assessment.ID)
+}
+
+func (uc *MustahiqAssessmentUseCase) FindAll(filter repository.MustahiqAssessmentFilter) ([]*entity.MustahiqAssessment, int64, error) {
+	return uc.assessmentRepo.FindAll(filter)
+}
+
+func (uc *MustahiqAssessmentUseCase) FindByID(id string) (*entity.MustahiqAssessment, error) {
+	return uc.assessmentRepo.FindByID(id)
+}
+
+// findQuestionnaire mengambil kuesioner aktif yang berlaku untuk asnaf mustahiq
+func (uc *MustahiqAssessmentUseCase) findQuestionnaire(questionnaireID string, mustahiq *entity.Mustahiq) (*entity.AssessmentQuestionnaire, error) {
+	if questionnaireID == "" {
+		questionnaire, err := uc.questionnaireRepo.FindActiveByAsnaf(mustahiq.AsnafID)
+		if err != nil {
+			return nil, err
+		}
+		if questionnaire == nil {
+			return nil, errors.New("no active assessment questionnaire for the mustahiq's asnaf")
+		}
+		return questionnaire, nil
+	}
+
+	questionnaire, err := uc.questionnaireRepo.FindByID(questionnaireID)
+	if err != nil {
+		return nil, err
+	}
+	if !questionnaire.IsActive {
+		return nil, ValidationErrors{{Field: "questionnaire_id", Message: "assessment questionnaire is not active"}}
+	}
+	if questionnaire.AsnafID != mustahiq.AsnafID {
+		return nil, ValidationErrors{{
+			Field:    "questionnaire_id",
+			Message:  "assessment questionnaire belongs to a different asnaf",
+			Expected: mustahiq.AsnafID,
+			Actual:   questionnaire.AsnafID,
+		}}
+	}
+
+	return questionnaire, nil
+}
+
+// scoreAnswers memastikan setiap pertanyaan dijawab tepat sekali dan menyalin
+// pertanyaan, jawaban serta skornya untuk disimpan
+func scoreAnswers(questionnaire *entity.AssessmentQuestionnaire, inputs []AssessmentAnswerInput) ([]*entity.MustahiqAssessmentAnswer, error) {
+	questionByID := make(map[string]*entity.AssessmentQuestion)
+	for _, q := range questionnaire.Questions {
+		questionByID[q.ID] = q
+	}
+
+	var fieldErrors ValidationErrors
+	answered := make(map[string]bool)
+	var answers []*entity.MustahiqAssessmentAnswer
+
+	for i, in := range inputs {
+		question, ok := questionByID[in.QuestionID]
+		if !ok {
+			fieldErrors = append(fieldErrors, FieldError{Field: answerField(i, "question_id"), Message: "question is not part of the questionnaire"})
+			continue
+		}
+		if answered[in.QuestionID] {
+			fieldErrors = append(fieldErrors, FieldError{Field: answerField(i, "question_id"), Message: "question is answered more than once"})
+			continue
+		}
+		answered[in.QuestionID] = true
+
+		var selected *entity.AssessmentOption
+		var maxScore float64
+		for _, o := range question.Options {
+			if o.ID == in.OptionID {
+				selected = o
+			}
+			if o.Score > maxScore {
+				maxScore = o.Score
+			}
+		}
+		if selected == nil {
+			fieldErrors = append(fieldErrors, FieldError{Field: answerField(i, "option_id"), Message: "option is not part of the question"})
+			continue
+		}
+
+		answers = append(answers, &entity.MustahiqAssessmentAnswer{
+			QuestionID: question.ID,
+			OptionID:   selected.ID,
+			Category:   question.Category,
+			Question:   question.Question,
+			Answer:     selected.Label,
+			Weight:     question.Weight,
+			Score:      selected.Score,
+			MaxScore:   maxScore,
+		})
+	}
+
+	for _, q := range questionnaire.Questions {
+		if !answered[q.ID] {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:    "answers",
+				Message:  "question is not answered",
+				Expected: q.ID,
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors
+	}
+
+	return answers, nil
+}
@@ -1,6 +1,10 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 
@@ -8,61 +12,109 @@ import (
 )
 
 type MustahiqUseCase struct {
-	mustahiqRepo repository.MustahiqRepository
-	validator    *validator.Validate
+	mustahiqRepo   repository.MustahiqRepository
+	assessmentRepo repository.MustahiqAssessmentRepository
+	validator      *validator.Validate
 }
 
-func NewMustahiqUseCase(mustahiqRepo repository.MustahiqRepository, validator *validator.Validate) *MustahiqUseCase {
+func NewMustahiqUseCase(
+	mustahiqRepo repository.MustahiqRepository,
+	assessmentRepo repository.MustahiqAssessmentRepository,
+	validator *validator.Validate,
+) *MustahiqUseCase {
 	return &MustahiqUseCase{
-		mustahiqRepo: mustahiqRepo,
-		validator:    validator,
+		mustahiqRepo:   mustahiqRepo,
+		assessmentRepo: assessmentRepo,
+		validator:      validator,
 	}
 }
 
+// mustahiqTransitions adalah perpindahan status yang diizinkan. Mustahiq baru selalu pending;
+// inactive -> active hanya dengan penilaian ulang yang hasilnya layak.
+var mustahiqTransitions = map[string][]string{
+	entity.MustahiqStatusPending:  {entity.MustahiqStatusActive, entity.MustahiqStatusInactive},
+	entity.MustahiqStatusActive:   {entity.MustahiqStatusInactive},
+	entity.MustahiqStatusInactive: {entity.MustahiqStatusActive},
+}
+
 type CreateMustahiqInput struct {
-	Name        string `validate:"required"`
-	PhoneNumber string `validate:"required"`
-	Address     string `validate:"required"`
-	AsnafID     string `validate:"required"`
-	Status      string `validate:"omitempty,oneof=active inactive pending"`
-	Description string
+	Name            string `validate:"required"`
+	NIK             string // opsional, divalidasi dengan normalizeNIK
+	PhoneNumber     string `validate:"required"`
+	Address         string `validate:"required"`
+	AsnafID         string `validate:"required"`
+	Description     string
+	CreatedByUserID string `validate:"required"`
 }
 
 type UpdateMustahiqInput struct {
 	ID          string `validate:"required"`
 	Name        string `validate:"required"`
+	NIK         string // opsional, divalidasi dengan normalizeNIK
 	PhoneNumber string `validate:"required"`
 	Address     string `validate:"required"`
 	AsnafID     string `validate:"required"`
-	Status      string `validate:"required,oneof=active inactive pending"`
 	Description string
 }
 
+type ChangeMustahiqStatusInput struct {
+	ID            string `validate:"required"`
+	ToStatus      string `validate:"required,oneof=active inactive"`
+	Reason        string `validate:"required"`
+	EffectiveDate string // YYYY-MM-DD, default hari ini
+	AssessmentID  string // wajib untuk inactive -> active
+	UserID        string `validate:"required"`
+}
+
+type MergeMustahiqInput struct {
+	TargetID string            `validate:"required"`
+	SourceID string            `validate:"required,nefield=TargetID"`
+	Fields   map[string]string // field -> source|target, wajib untuk field yang nilainya berbeda
+	Reason   string            `validate:"required"`
+	UserID   string            `validate:"required"`
+}
+
+// mustahiqMergeFields adalah field yang dipilih saat mustahiq digabung. Status tidak ikut
+// dipilih: mustahiq tujuan tetap memakai status dan riwayat statusnya sendiri.
+var mustahiqMergeFields = []string{"name", "nik", "phone_number", "address", "asnaf_id", "description"}
+
+func mustahiqFieldValues(m *entity.Mustahiq) map[string]string {
+	return map[string]string{
+		"name":         m.Name,
+		"nik":          m.NIK,
+		"phone_number": m.PhoneNumber,
+		"address":      m.Address,
+		"asnaf_id":     m.AsnafID,
+		"description":  m.Description,
+	}
+}
+
 func (uc *MustahiqUseCase) Create(input CreateMustahiqInput) (*entity.Mustahiq, error) {
 	if err := uc.validator.Struct(input); err != nil {
 		return nil, err
 	}
 
-	// Set default status to pending if not provided
-	status := input.Status
-	if status == "" {
-		status = entity.MustahiqStatusPending
+	nikNumber, err := normalizeNIK(input.NIK)
+	if err != nil {
+		return nil, err
 	}
 
+	// New mustahiq always start as pending until verified or assessed
 	mustahiq := &entity.Mustahiq{
 		Name:        input.Name,
+		NIK:         nikNumber,
 		PhoneNumber: input.PhoneNumber,
 		Address:     input.Address,
 		AsnafID:     input.AsnafID,
-		Status:      status,
+		Status:      entity.MustahiqStatusPending,
 		Description: input.Description,
 	}
 
-	if err := uc.mustahiqRepo.Create(mustahiq); err != nil {
+	if err := uc.mustahiqRepo.Create(mustahiq, input.CreatedByUserID); err != nil {
 		return nil, err
 	}
 
-	return mustahiq, nil
+	return uc.mustahiqRepo.FindByID(mustahiq.ID)
 }
 
 func (uc *MustahiqUseCase) FindAll(filter repository.MustahiqFilter) ([]*entity.Mustahiq, int64, error) {
@@ -78,25 +130,282 @@ func (uc *MustahiqUseCase) Update(input UpdateMustahiqInput) (*entity.Mustahiq,
 		return nil, err
 	}
 
+	nikNumber, err := normalizeNIK(input.NIK)
+	if err != nil {
+		return nil, err
+	}
+
 	mustahiq, err := uc.mustahiqRepo.FindByID(input.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	mustahiq.Name = input.Name
+	mustahiq.NIK = nikNumber
 	mustahiq.PhoneNumber = input.PhoneNumber
 	mustahiq.Address = input.Address
 	mustahiq.AsnafID = input.AsnafID
-	mustahiq.Status = input.Status
 	mustahiq.Description = input.Description
 
 	if err := uc.mustahiqRepo.Update(mustahiq); err != nil {
 		return nil, err
 	}
 
-	return mustahiq, nil
+	return uc.mustahiqRepo.FindByID(mustahiq.ID)
 }
 
 func (uc *MustahiqUseCase) Delete(id string) error {
 	return uc.mustahiqRepo.Delete(id)
 }
+
+// ChangeStatus memindahkan status mustahiq sesuai mustahiqTransitions dan mencatat alasannya
+func (uc *MustahiqUseCase) ChangeStatus(input ChangeMustahiqStatusInput) (*entity.Mustahiq, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	mustahiq, err := uc.mustahiqRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("mustahiq not found")
+	}
+
+	if !isAllowedMustahiqTransition(mustahiq.Status, input.ToStatus) {
+		return nil, ValidationErrors{{
+			Field:    "status",
+			Message:  fmt.Sprintf("mustahiq cannot move from %s to %s", mustahiq.Status, input.ToStatus),
+			Expected: mustahiqTransitions[mustahiq.Status],
+			Actual:   input.ToStatus,
+		}}
+	}
+
+	effectiveDate := input.EffectiveDate
+	if effectiveDate == "" {
+		effectiveDate = time.Now().Format("2006-01-02")
+	}
+	if err := requireMustahiqStatusDate(uc.mustahiqRepo, mustahiq.ID, effectiveDate, "effective_date"); err != nil {
+		return nil, err
+	}
+
+	change := &entity.MustahiqStatusChange{
+		MustahiqID:      mustahiq.ID,
+		FromStatus:      mustahiq.Status,
+		ToStatus:        input.ToStatus,
+		EffectiveDate:   effectiveDate,
+		Reason:          input.Reason,
+		ChangedByUserID: &input.UserID,
+	}
+
+	// Mengaktifkan kembali mustahiq nonaktif butuh penilaian ulang yang layak
+	if mustahiq.Status == entity.MustahiqStatusInactive && input.ToStatus == entity.MustahiqStatusActive {
+		if err := uc.requireReassessment(mustahiq.ID, input.AssessmentID); err != nil {
+			return nil, err
+		}
+		change.AssessmentID = &input.AssessmentID
+	}
+
+	if err := uc.mustahiqRepo.ChangeStatus(change); err != nil {
+		return nil, err
+	}
+
+	return uc.mustahiqRepo.FindByID(mustahiq.ID)
+}
+
+func (uc *MustahiqUseCase) FindStatusHistory(mustahiqID string) ([]*entity.MustahiqStatusChange, error) {
+	mustahiq, err := uc.mustahiqRepo.FindByID(mustahiqID)
+	if err != nil {
+		return nil, errors.New("mustahiq not found")
+	}
+
+	return uc.mustahiqRepo.FindStatusHistory(mustahiq.ID)
+}
+
+// Merge menggabungkan mustahiq sumber ke tujuan: item penyaluran, penilaian, riwayat status
+// dan keanggotaan rumah tangga dipindah, sumber dihapus dan ID-nya dialihkan ke tujuan.
+// Total penerimaan tujuan sebelum dan sesudah digabung dicatat di log penggabungan.
+func (uc *MustahiqUseCase) Merge(input MergeMustahiqInput) (*entity.MustahiqMerge, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	source, err := uc.mustahiqRepo.FindByID(input.SourceID)
+	if err != nil {
+		return nil, errors.New("source mustahiq not found")
+	}
+	target, err := uc.mustahiqRepo.FindByID(input.TargetID)
+	if err != nil {
+		return nil, errors.New("target mustahiq not found")
+	}
+	// An ID that was already merged resolves to its surviving mustahiq
+	if source.ID == target.ID {
+		return nil, errors.New("source and target are the same mustahiq")
+	}
+
+	fields, err := resolveMergeFields(mustahiqMergeFields, mustahiqFieldValues(source), mustahiqFieldValues(target), input.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		switch f.Field {
+		case "name":
+			target.Name = f.FinalValue
+		case "nik":
+			target.NIK = f.FinalValue
+		case "phone_number":
+			target.PhoneNumber = f.FinalValue
+		case "address":
+			target.Address = f.FinalValue
+		case "asnaf_id":
+			target.AsnafID = f.FinalValue
+		case "description":
+			target.Description = f.FinalValue
+		}
+	}
+
+	merge := &entity.MustahiqMerge{
+		Reason:         input.Reason,
+		Fields:         fields,
+		MergedByUserID: input.UserID,
+	}
+
+	if err := uc.mustahiqRepo.Merge(merge, source, target); err != nil {
+		return nil, err
+	}
+
+	return merge, nil
+}
+
+func (uc *MustahiqUseCase) FindMerges(mustahiqID string) ([]*entity.MustahiqMerge, error) {
+	return uc.mustahiqRepo.FindMerges(mustahiqID)
+}
+
+// requireReassessment memastikan penilaian milik mustahiq ini, hasilnya layak dan dilakukan
+// setelah mustahiq dinonaktifkan
+func (uc *MustahiqUseCase) requireReassessment(mustahiqID, assessmentID string) error {
+	if assessmentID == "" {
+		return ValidationErrors{{Field: "assessment_id", Message: "reactivating an inactive mustahiq requires an eligible reassessment"}}
+	}
+
+	assessment, err := uc.assessmentRepo.FindByID(assessmentID)
+	if err != nil || assessment.MustahiqID != mustahiqID {
+		return ValidationErrors{{Field: "assessment_id", Message: "assessment not found for this mustahiq"}}
+	}
+	if assessment.Result != entity.AssessmentResultEligible {
+		return ValidationErrors{{
+			Field:    "assessment_id",
+			Message:  "assessment result is not eligible",
+			Expected: entity.AssessmentResultEligible,
+			Actual:   assessment.Result,
+		}}
+	}
+
+	last, err := lastMustahiqStatusChange(uc.mustahiqRepo, mustahiqID)
+	if err != nil {
+		return err
+	}
+	if last != nil && assessment.AssessmentDate < last.EffectiveDate {
+		return ValidationErrors{{
+			Field:    "assessment_id",
+			Message:  "assessment was made before the mustahiq became inactive",
+			Expected: last.EffectiveDate,
+			Actual:   assessment.AssessmentDate,
+		}}
+	}
+
+	return nil
+}
+
+func isAllowedMustahiqTransition(from, to string) bool {
+	for _, allowed := range mustahiqTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// requireMustahiqStatusDate menolak tanggal perubahan status di masa depan atau sebelum
+// perubahan status terakhir, supaya status per tanggal tetap bisa dihitung dari riwayat
+func requireMustahiqStatusDate(mustahiqRepo repository.MustahiqRepository, mustahiqID, date, field string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return ValidationErrors{{Field: field, Message: "date must be in YYYY-MM-DD format", Actual: date}}
+	}
+	if date > time.Now().Format("2006-01-02") {
+		return ValidationErrors{{Field: field, Message: "status changes cannot be dated in the future", Actual: date}}
+	}
+
+	last, err := lastMustahiqStatusChange(mustahiqRepo, mustahiqID)
+	if err != nil {
+		return err
+	}
+	if last != nil && date < last.EffectiveDate {
+		return ValidationErrors{{
+			Field:    field,
+			Message:  "date is before the last status change of the mustahiq",
+			Expected: last.EffectiveDate,
+			Actual:   date,
+		}}
+	}
+
+	return nil
+}
+
+// lastMustahiqStatusChange mengembalikan perubahan status terakhir milik mustahiq itu sendiri,
+// tanpa riwayat yang dipindah dari mustahiq yang digabung
+func lastMustahiqStatusChange(mustahiqRepo repository.MustahiqRepository, mustahiqID string) (*entity.MustahiqStatusChange, error) {
+	history, err := mustahiqRepo.FindStatusHistory(mustahiqID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range history {
+		if h.MergedFromMustahiqID == nil {
+			return h, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// requireActiveMustahiq memastikan setiap mustahiq item penyaluran (urut sesuai item) berstatus
+// active pada tanggal penyaluran. ID mustahiq yang sudah digabung diganti dengan ID tujuannya.
+// Mustahiq yang dimuat dikembalikan per ID (ID asal dan ID tujuan) untuk pengecekan aturan bantuan.
+func requireActiveMustahiq(mustahiqRepo repository.MustahiqRepository, mustahiqIDs []string, date string) (map[string]*entity.Mustahiq, error) {
+	mustahiqs := make(map[string]*entity.Mustahiq)
+	for i, mustahiqID := range mustahiqIDs {
+		mustahiq, ok := mustahiqs[mustahiqID]
+		if !ok {
+			var err error
+			mustahiq, err = mustahiqRepo.FindByID(mustahiqID)
+			if err != nil {
+				return nil, errors.New("mustahiq not found: " + mustahiqID)
+			}
+			mustahiqs[mustahiqID] = mustahiq
+			mustahiqs[mustahiq.ID] = mustahiq
+		}
+		mustahiqIDs[i] = mustahiq.ID
+	}
+
+	statuses, err := mustahiqRepo.FindStatusesOnDate(mustahiqIDs, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldErrors ValidationErrors
+	for i, mustahiqID := range mustahiqIDs {
+		if status := statuses[mustahiqID]; status != entity.MustahiqStatusActive {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:    itemField(i, "mustahiq_id"),
+				Message:  "mustahiq is not active on the distribution date",
+				Expected: entity.MustahiqStatusActive,
+				Actual:   status,
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors
+	}
+
+	return mustahiqs, nil
+}
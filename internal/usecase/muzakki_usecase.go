@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"errors"
+
 	"go-zakat-be/internal/domain/entity"
 	"go-zakat-be/internal/domain/repository"
 
@@ -21,6 +23,7 @@ func NewMuzakkiUseCase(muzakkiRepo repository.MuzakkiRepository, validator *vali
 
 type CreateMuzakkiInput struct {
 	Name        string `validate:"required"`
+	NIK         string // opsional, divalidasi dengan normalizeNIK
 	PhoneNumber string `validate:"required"`
 	Address     string `validate:"required"`
 	Notes       string
@@ -29,18 +32,46 @@ type CreateMuzakkiInput struct {
 type UpdateMuzakkiInput struct {
 	ID          string `validate:"required"`
 	Name        string `validate:"required"`
+	NIK         string // opsional, divalidasi dengan normalizeNIK
 	PhoneNumber string `validate:"required"`
 	Address     string `validate:"required"`
 	Notes       string
 }
 
+type MergeMuzakkiInput struct {
+	TargetID string            `validate:"required"`
+	SourceID string            `validate:"required,nefield=TargetID"`
+	Fields   map[string]string // field -> source|target, wajib untuk field yang nilainya berbeda
+	Reason   string            `validate:"required"`
+	UserID   string            `validate:"required"`
+}
+
+// muzakkiMergeFields adalah field yang dipilih saat muzakki digabung
+var muzakkiMergeFields = []string{"name", "nik", "phone_number", "address", "notes"}
+
+func muzakkiFieldValues(m *entity.Muzakki) map[string]string {
+	return map[string]string{
+		"name":         m.Name,
+		"nik":          m.NIK,
+		"phone_number": m.PhoneNumber,
+		"address":      m.Address,
+		"notes":        m.Notes,
+	}
+}
+
 func (uc *MuzakkiUseCase) Create(input CreateMuzakkiInput) (*entity.Muzakki, error) {
 	if err := uc.validator.Struct(input); err != nil {
 		return nil, err
 	}
 
+	nikNumber, err := normalizeNIK(input.NIK)
+	if err != nil {
+		return nil, err
+	}
+
 	muzakki := &entity.Muzakki{
 		Name:        input.Name,
+		NIK:         nikNumber,
 		PhoneNumber: input.PhoneNumber,
 		Address:     input.Address,
 		Notes:       input.Notes,
@@ -66,12 +97,18 @@ func (uc *MuzakkiUseCase) Update(input UpdateMuzakkiInput) (*entity.Muzakki, err
 		return nil, err
 	}
 
+	nikNumber, err := normalizeNIK(input.NIK)
+	if err != nil {
+		return nil, err
+	}
+
 	muzakki, err := uc.muzakkiRepo.FindByID(input.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	muzakki.Name = input.Name
+	muzakki.NIK = nikNumber
 	muzakki.PhoneNumber = input.PhoneNumber
 	muzakki.Address = input.Address
 	muzakki.Notes = input.Notes
@@ -86,3 +123,60 @@ func (uc *MuzakkiUseCase) Update(input UpdateMuzakkiInput) (*entity.Muzakki, err
 func (uc *MuzakkiUseCase) Delete(id string) error {
 	return uc.muzakkiRepo.Delete(id)
 }
+
+// Merge menggabungkan muzakki sumber ke tujuan: kwitansi dan perhitungan zakat dipindah,
+// sumber dihapus dan ID-nya tetap bisa dipakai karena dialihkan ke tujuan
+func (uc *MuzakkiUseCase) Merge(input MergeMuzakkiInput) (*entity.MuzakkiMerge, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	source, err := uc.muzakkiRepo.FindByID(input.SourceID)
+	if err != nil {
+		return nil, errors.New("source muzakki not found")
+	}
+	target, err := uc.muzakkiRepo.FindByID(input.TargetID)
+	if err != nil {
+		return nil, errors.New("target muzakki not found")
+	}
+	// An ID that was already merged resolves to its surviving muzakki
+	if source.ID == target.ID {
+		return nil, errors.New("source and target are the same muzakki")
+	}
+
+	fields, err := resolveMergeFields(muzakkiMergeFields, muzakkiFieldValues(source), muzakkiFieldValues(target), input.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		switch f.Field {
+		case "name":
+			target.Name = f.FinalValue
+		case "nik":
+			target.NIK = f.FinalValue
+		case "phone_number":
+			target.PhoneNumber = f.FinalValue
+		case "address":
+			target.Address = f.FinalValue
+		case "notes":
+			target.Notes = f.FinalValue
+		}
+	}
+
+	merge := &entity.MuzakkiMerge{
+		Reason:         input.Reason,
+		Fields:         fields,
+		MergedByUserID: input.UserID,
+	}
+
+	if err := uc.muzakkiRepo.Merge(merge, source, target); err != nil {
+		return nil, err
+	}
+
+	return merge, nil
+}
+
+func (uc *MuzakkiUseCase) FindMerges(muzakkiID string) ([]*entity.MuzakkiMerge, error) {
+	return uc.muzakkiRepo.FindMerges(muzakkiID)
+}
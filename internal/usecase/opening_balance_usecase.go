@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type OpeningBalanceUseCase struct {
+	openingBalanceRepo repository.OpeningBalanceRepository
+	accountRepo        repository.FinancialAccountRepository
+	periodRepo         repository.FiscalPeriodRepository
+	validator          *validator.Validate
+}
+
+func NewOpeningBalanceUseCase(
+	openingBalanceRepo repository.OpeningBalanceRepository,
+	accountRepo repository.FinancialAccountRepository,
+	periodRepo repository.FiscalPeriodRepository,
+	validator *validator.Validate,
+) *OpeningBalanceUseCase {
+	return &OpeningBalanceUseCase{
+		openingBalanceRepo: openingBalanceRepo,
+		accountRepo:        accountRepo,
+		periodRepo:         periodRepo,
+		validator:          validator,
+	}
+}
+
+type CreateOpeningBalanceInput struct {
+	EffectiveDate      string  `validate:"required"` // YYYY-MM-DD
+	FundLedger         string  `validate:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	FinancialAccountID string  `validate:"required"`
+	Amount             float64 `validate:"required,gt=0"`
+	Notes              string
+	CreatedByUserID    string `validate:"required"`
+}
+
+type VoidOpeningBalanceInput struct {
+	ID     string `validate:"required"`
+	Reason string `validate:"required"`
+	UserID string `validate:"required"`
+}
+
+// Create mencatat saldo awal dari sistem lama beserta jurnalnya (admin only)
+func (uc *OpeningBalanceUseCase) Create(input CreateOpeningBalanceInput) (*entity.OpeningBalance, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, input.EffectiveDate); err != nil {
+		return nil, err
+	}
+
+	if _, err := requireActiveFinancialAccount(uc.accountRepo, input.FinancialAccountID); err != nil {
+		return nil, err
+	}
+
+	openingBalance := &entity.OpeningBalance{
+		EffectiveDate:      input.EffectiveDate,
+		FundLedger:         input.FundLedger,
+		FinancialAccountID: input.FinancialAccountID,
+		Amount:             roundMoney(input.Amount),
+		Notes:              input.Notes,
+		CreatedByUserID:    input.CreatedByUserID,
+	}
+
+	if err := uc.openingBalanceRepo.Create(openingBalance); err != nil {
+		return nil, err
+	}
+
+	return uc.openingBalanceRepo.FindByID(openingBalance.ID)
+}
+
+// Void membatalkan saldo awal yang salah input; koreksi dilakukan dengan saldo awal baru
+func (uc *OpeningBalanceUseCase) Void(input VoidOpeningBalanceInput) (*entity.OpeningBalance, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.openingBalanceRepo.FindByID(input.ID)
+	if err != nil {
+		return nil, errors.New("opening balance not found")
+	}
+
+	if existing.Status != entity.OpeningBalanceStatusPosted {
+		return nil, fmt.Errorf("only posted opening balances can be voided (current status: %s)", existing.Status)
+	}
+
+	// The reversal journal is dated like the opening balance
+	if err := requireOpenPeriod(uc.periodRepo, existing.EffectiveDate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.openingBalanceRepo.Void(input.ID, input.Reason, input.UserID); err != nil {
+		return nil, err
+	}
+
+	return uc.openingBalanceRepo.FindByID(input.ID)
+}
+
+func (uc *OpeningBalanceUseCase) FindAll(filter repository.OpeningBalanceFilter) ([]*entity.OpeningBalance, int64, error) {
+	return uc.openingBalanceRepo.FindAll(filter)
+}
+
+func (uc *OpeningBalanceUseCase) FindByID(id string) (*entity.OpeningBalance, error) {
+	return uc.openingBalanceRepo.FindByID(id)
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+
+	"go-zakat-be/internal/domain/entity"
+)
+
+// resolveMergeFields menentukan nilai akhir setiap field saat data sumber digabung ke tujuan.
+// Field yang nilainya berbeda wajib dipilih eksplisit (source/target); jika hanya salah satu
+// sisi yang terisi, nilai yang terisi dipakai; jika sama, nilai tujuan dipakai.
+func resolveMergeFields(fields []string, source, target, choices map[string]string) ([]*entity.MergeFieldChoice, error) {
+	var fieldErrors ValidationErrors
+
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f] = true
+	}
+	var unknown []string
+	for f := range choices {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	sort.Strings(unknown)
+	for _, f := range unknown {
+		fieldErrors = append(fieldErrors, FieldError{Field: mergeField(f), Message: "field cannot be merged", Expected: fields})
+	}
+
+	var result []*entity.MergeFieldChoice
+	for _, f := range fields {
+		sourceValue, targetValue := source[f], target[f]
+
+		choice, chosen := choices[f]
+		if chosen && choice != entity.MergeChoiceSource && choice != entity.MergeChoiceTarget {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:    mergeField(f),
+				Message:  "choice must be source or target",
+				Expected: []string{entity.MergeChoiceSource, entity.MergeChoiceTarget},
+				Actual:   choice,
+			})
+			continue
+		}
+
+		if !chosen {
+			switch {
+			case sourceValue == targetValue, sourceValue == "":
+				choice = entity.MergeChoiceTarget
+			case targetValue == "":
+				choice = entity.MergeChoiceSource
+			default:
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:    mergeField(f),
+					Message:  fmt.Sprintf("%s differs between the records, choose source or target", f),
+					Expected: []string{entity.MergeChoiceSource, entity.MergeChoiceTarget},
+					Actual:   map[string]string{entity.MergeChoiceSource: sourceValue, entity.MergeChoiceTarget: targetValue},
+				})
+				continue
+			}
+		}
+
+		finalValue := targetValue
+		if choice == entity.MergeChoiceSource {
+			finalValue = sourceValue
+		}
+
+		result = append(result, &entity.MergeFieldChoice{
+			Field:       f,
+			Choice:      choice,
+			SourceValue: sourceValue,
+			TargetValue: targetValue,
+			FinalValue:  finalValue,
+		})
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors
+	}
+
+	return result, nil
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"errors"
+	"time"
+
 	"go-zakat-be/internal/domain/repository"
 
 	"github.com/go-playground/validator/v10"
@@ -41,7 +43,7 @@ func (uc *ReportUseCase) GetDistributionSummary(dateFrom, dateTo, groupBy, sourc
 
 	// Validate sourceFundType if provided
 	if sourceFundType != "" {
-		validTypes := []string{"zakat_fitrah", "zakat_maal", "infaq", "sadaqah"}
+		validTypes := []string{"amil", "zakat_fitrah", "zakat_maal", "infaq", "sadaqah"}
 		valid := false
 		for _, t := range validTypes {
 			if sourceFundType == t {
@@ -50,15 +52,161 @@ func (uc *ReportUseCase) GetDistributionSummary(dateFrom, dateTo, groupBy, sourc
 			}
 		}
 		if !valid {
-			return nil, errors.New("source_fund_type must be one of: zakat_fitrah, zakat_maal, infaq, sadaqah")
+			return nil, errors.New("source_fund_type must be one of: amil, zakat_fitrah, zakat_maal, infaq, sadaqah")
 		}
 	}
 
 	return uc.reportRepo.GetDistributionSummary(dateFrom, dateTo, groupBy, sourceFundType)
 }
 
+// GetFundBalance menampilkan saldo setiap sub-ledger; saldo awal dihitung dari snapshot
+// periode tertutup terakhir sebelum dateFrom
 func (uc *ReportUseCase) GetFundBalance(dateFrom, dateTo string) ([]repository.FundBalanceResult, error) {
-	return uc.reportRepo.GetFundBalance(dateFrom, dateTo)
+	if err := validateDateRange(dateFrom, dateTo); err != nil {
+		return nil, err
+	}
+
+	results, err := uc.reportRepo.GetFundBalance(dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].ClosingBalance = roundMoney(results[i].ClosingBalance)
+	}
+
+	return results, nil
+}
+
+// GetTrialBalance menjumlahkan debit dan kredit seluruh akun sampai dateTo;
+// selisih total debit dan kredit harus 0
+func (uc *ReportUseCase) GetTrialBalance(dateTo string) (*repository.TrialBalanceResult, error) {
+	if dateTo != "" {
+		if _, err := time.Parse("2006-01-02", dateTo); err != nil {
+			return nil, errors.New("date_to must be in YYYY-MM-DD format")
+		}
+	}
+
+	rows, err := uc.reportRepo.GetTrialBalance(dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &repository.TrialBalanceResult{DateTo: dateTo, Accounts: rows}
+	for _, row := range rows {
+		result.TotalDebit += row.Debit
+		result.TotalCredit += row.Credit
+	}
+	// Dibulatkan ke sen supaya penjumlahan float tidak menyisakan selisih semu
+	result.TotalDebit = roundMoney(result.TotalDebit)
+	result.TotalCredit = roundMoney(result.TotalCredit)
+	result.Difference = roundMoney(result.TotalDebit - result.TotalCredit)
+
+	return result, nil
+}
+
+// GetAccountBalance menampilkan saldo awal, saldo migrasi, uang masuk, uang keluar dan saldo akhir setiap rekening
+func (uc *ReportUseCase) GetAccountBalance(dateFrom, dateTo string) ([]repository.AccountBalanceResult, error) {
+	if err := validateDateRange(dateFrom, dateTo); err != nil {
+		return nil, err
+	}
+
+	results, err := uc.reportRepo.GetAccountBalance(dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].ClosingBalance = roundMoney(results[i].ClosingBalance)
+	}
+
+	return results, nil
+}
+
+// GetAccountMovements menampilkan mutasi satu rekening beserta saldo berjalannya
+func (uc *ReportUseCase) GetAccountMovements(financialAccountID, dateFrom, dateTo string) (*repository.AccountMovementResult, error) {
+	if financialAccountID == "" {
+		return nil, errors.New("financial_account_id is required")
+	}
+	if err := validateDateRange(dateFrom, dateTo); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.reportRepo.GetAccountMovements(financialAccountID, dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	result.CarriedOver = roundMoney(result.CarriedOver)
+	result.TotalIn = roundMoney(result.TotalIn)
+	result.TotalOut = roundMoney(result.TotalOut)
+	result.ClosingBalance = roundMoney(result.ClosingBalance)
+	for i := range result.Movements {
+		result.Movements[i].Balance = roundMoney(result.Movements[i].Balance)
+	}
+
+	return result, nil
+}
+
+// GetUnreconciled menampilkan baris mutasi yang belum direkonsiliasi dan transaksi
+// yang belum muncul di mutasi rekening
+func (uc *ReportUseCase) GetUnreconciled(financialAccountID, dateFrom, dateTo string) (*repository.UnreconciledResult, error) {
+	if err := validateDateRange(dateFrom, dateTo); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.reportRepo.GetUnreconciled(financialAccountID, dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	result.TotalLines = roundMoney(result.TotalLines)
+	result.TotalTransactions = roundMoney(result.TotalTransactions)
+
+	return result, nil
+}
+
+// GetStockOnHand menampilkan stok barang per komoditas dan sub-ledger dana sampai tanggal date
+func (uc *ReportUseCase) GetStockOnHand(date string) ([]repository.StockOnHandResult, error) {
+	if date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, errors.New("date must be in YYYY-MM-DD format")
+		}
+	}
+
+	return uc.reportRepo.GetStockOnHand(date)
+}
+
+// GetUndeliveredItems menampilkan item penyaluran posted yang vouchernya belum ditukar,
+// mulai dari penyaluran terlama
+func (uc *ReportUseCase) GetUndeliveredItems(dateFrom, dateTo, programID, sourceFundType string) (*repository.UndeliveredItemsResult, error) {
+	if err := validateDateRange(dateFrom, dateTo); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.reportRepo.GetUndeliveredItems(dateFrom, dateTo, programID, sourceFundType)
+	if err != nil {
+		return nil, err
+	}
+
+	result.TotalAmount = roundMoney(result.TotalAmount)
+
+	return result, nil
+}
+
+// validateDateRange memeriksa format date_from/date_to (keduanya opsional)
+func validateDateRange(dateFrom, dateTo string) error {
+	if dateFrom != "" {
+		if _, err := time.Parse("2006-01-02", dateFrom); err != nil {
+			return errors.New("date_from must be in YYYY-MM-DD format")
+		}
+	}
+	if dateTo != "" {
+		if _, err := time.Parse("2006-01-02", dateTo); err != nil {
+			return errors.New("date_to must be in YYYY-MM-DD format")
+		}
+	}
+	return nil
 }
 
 func (uc *ReportUseCase) GetMustahiqHistory(mustahiqID string) (*repository.MustahiqHistoryResult, error) {
@@ -68,3 +216,11 @@ func (uc *ReportUseCase) GetMustahiqHistory(mustahiqID string) (*repository.Must
 
 	return uc.reportRepo.GetMustahiqHistory(mustahiqID)
 }
+
+func (uc *ReportUseCase) GetHouseholdHistory(householdID string) (*repository.HouseholdHistoryResult, error) {
+	if householdID == "" {
+		return nil, errors.New("household_id is required")
+	}
+
+	return uc.reportRepo.GetHouseholdHistory(householdID)
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type StockUseCase struct {
+	stockRepo  repository.StockRepository
+	periodRepo repository.FiscalPeriodRepository
+	validator  *validator.Validate
+}
+
+func NewStockUseCase(stockRepo repository.StockRepository, periodRepo repository.FiscalPeriodRepository, validator *validator.Validate) *StockUseCase {
+	return &StockUseCase{
+		stockRepo:  stockRepo,
+		periodRepo: periodRepo,
+		validator:  validator,
+	}
+}
+
+type CreateStockAdjustmentInput struct {
+	MovementDate string  `validate:"required"` // YYYY-MM-DD
+	Commodity    string  `validate:"required,oneof=gold silver rice"`
+	FundLedger   string  `validate:"required,oneof=amil zakat_fitrah zakat_maal infaq sadaqah"`
+	Quantity     float64 `validate:"required"` // negatif untuk susut/rusak
+	Reason       string  `validate:"required"`
+	UserID       string  `validate:"required"`
+}
+
+// CreateAdjustment mencatat penyesuaian stok manual, misalnya beras rusak atau hasil stock opname
+func (uc *StockUseCase) CreateAdjustment(input CreateStockAdjustmentInput) (*entity.StockMovement, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	if err := requireOpenPeriod(uc.periodRepo, input.MovementDate); err != nil {
+		return nil, err
+	}
+
+	quantity := math.Round(input.Quantity*100) / 100
+	if quantity == 0 {
+		return nil, ValidationErrors{{Field: "quantity", Message: "quantity must not be 0", Actual: input.Quantity}}
+	}
+
+	userID := input.UserID
+	movement := &entity.StockMovement{
+		MovementDate:    input.MovementDate,
+		Commodity:       input.Commodity,
+		FundLedger:      input.FundLedger,
+		Quantity:        quantity,
+		Reason:          input.Reason,
+		CreatedByUserID: &userID,
+	}
+
+	if err := uc.stockRepo.CreateAdjustment(movement); err != nil {
+		return nil, toInsufficientStockError(err, "quantity")
+	}
+
+	return uc.stockRepo.FindMovementByID(movement.ID)
+}
+
+func (uc *StockUseCase) FindAllMovements(filter repository.StockMovementFilter) ([]*entity.StockMovement, int64, error) {
+	return uc.stockRepo.FindAllMovements(filter)
+}
+
+func (uc *StockUseCase) FindMovementByID(id string) (*entity.StockMovement, error) {
+	return uc.stockRepo.FindMovementByID(id)
+}
+
+// toInsufficientStockError mengubah stok kurang dari repository menjadi ValidationErrors
+func toInsufficientStockError(err error, field string) error {
+	var stockErr *repository.InsufficientStockError
+	if !errors.As(err, &stockErr) {
+		return err
+	}
+
+	return ValidationErrors{{
+		Field:    field,
+		Message:  fmt.Sprintf("insufficient %s stock in %s", stockErr.Commodity, stockErr.FundType),
+		Expected: stockErr.OnHand,
+		Actual:   stockErr.Requested,
+	}}
+}
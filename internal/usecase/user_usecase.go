@@ -32,7 +32,7 @@ func (uc *UserUseCase) FindAll(query, role string, page, perPage int) ([]*entity
 
 	// Validate role filter if provided
 	if role != "" {
-		validRoles := []string{entity.RoleAdmin, entity.RoleStaf, entity.RoleViewer}
+		validRoles := []string{entity.RoleAdmin, entity.RoleStaf, entity.RoleViewer, entity.RoleApprover}
 		valid := false
 		for _, r := range validRoles {
 			if role == r {
@@ -75,7 +75,7 @@ func (uc *UserUseCase) UpdateRole(userID, role, currentUserID string) (*entity.U
 	}
 
 	// Validate role value
-	validRoles := []string{entity.RoleAdmin, entity.RoleStaf, entity.RoleViewer}
+	validRoles := []string{entity.RoleAdmin, entity.RoleStaf, entity.RoleViewer, entity.RoleApprover}
 	valid := false
 	for _, r := range validRoles {
 		if role == r {
@@ -84,7 +84,7 @@ func (uc *UserUseCase) UpdateRole(userID, role, currentUserID string) (*entity.U
 		}
 	}
 	if !valid {
-		return nil, errors.New("invalid role, must be: admin, staf, or viewer")
+		return nil, errors.New("invalid role, must be: admin, staf, viewer, or approver")
 	}
 
 	// Prevent admin from changing their own role
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go-zakat-be/internal/domain/entity"
+	"go-zakat-be/internal/domain/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ZakatCalculatorUseCase struct {
+	calculationRepo repository.ZakatCalculationRepository
+	muzakkiRepo     repository.MuzakkiRepository
+	priceRepo       repository.CommodityPriceRepository
+	validator       *validator.Validate
+}
+
+func NewZakatCalculatorUseCase(
+	calculationRepo repository.ZakatCalculationRepository,
+	muzakkiRepo repository.MuzakkiRepository,
+	priceRepo repository.CommodityPriceRepository,
+	validator *validator.Validate,
+) *ZakatCalculatorUseCase {
+	return &ZakatCalculatorUseCase{
+		calculationRepo: calculationRepo,
+		muzakkiRepo:     muzakkiRepo,
+		priceRepo:       priceRepo,
+		validator:       validator,
+	}
+}
+
+type CalculateZakatInput struct {
+	CalculationDate    string  // YYYY-MM-DD, default hari ini
+	HaulStartDate      *string // YYYY-MM-DD, optional
+	Cash               float64 `validate:"gte=0"`
+	GoldGrams          float64 `validate:"gte=0"`
+	GoldPricePerGram   float64 `validate:"gte=0"` // 0 = harga emas yang berlaku pada CalculationDate
+	SilverGrams        float64 `validate:"gte=0"`
+	SilverPricePerGram float64 `validate:"gte=0"` // 0 = harga perak yang berlaku pada CalculationDate
+	TradeGoods         float64 `validate:"gte=0"`
+	Receivables        float64 `validate:"gte=0"`
+	Debts              float64 `validate:"gte=0"`
+	NisabBasis         string  `validate:"omitempty,oneof=gold silver"`
+}
+
+type SaveZakatCalculationInput struct {
+	CalculateZakatInput
+	MuzakkiID       string `validate:"required"`
+	Notes           string
+	CreatedByUserID string `validate:"required"`
+}
+
+// Calculate menghitung zakat maal tanpa menyimpan hasilnya
+func (uc *ZakatCalculatorUseCase) Calculate(input CalculateZakatInput) (*entity.ZakatCalculation, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	calculationDate := time.Now()
+	if input.CalculationDate != "" {
+		d, err := time.Parse("2006-01-02", input.CalculationDate)
+		if err != nil {
+			return nil, errors.New("calculation_date must be in YYYY-MM-DD format")
+		}
+		calculationDate = d
+	}
+
+	nisabBasis := input.NisabBasis
+	if nisabBasis == "" {
+		nisabBasis = entity.NisabBasisGold
+	}
+
+	// Harga hanya diperlukan jika logam tersebut dideklarasikan atau menjadi dasar nisab
+	// Jika tidak diisi, pakai harga yang tercatat untuk tanggal perhitungan
+	if (input.GoldGrams > 0 || nisabBasis == entity.NisabBasisGold) && input.GoldPricePerGram <= 0 {
+		price, err := uc.effectivePrice(entity.CommodityGold, calculationDate)
+		if err != nil {
+			return nil, err
+		}
+		input.GoldPricePerGram = price
+	}
+	if (input.SilverGrams > 0 || nisabBasis == entity.NisabBasisSilver) && input.SilverPricePerGram <= 0 {
+		price, err := uc.effectivePrice(entity.CommoditySilver, calculationDate)
+		if err != nil {
+			return nil, err
+		}
+		input.SilverPricePerGram = price
+	}
+
+	// Haul: harta sudah dimiliki selama satu tahun hijriah
+	haulCompleted := true
+	if input.HaulStartDate != nil && *input.HaulStartDate != "" {
+		haulStart, err := time.Parse("2006-01-02", *input.HaulStartDate)
+		if err != nil {
+			return nil, errors.New("haul_start_date must be in YYYY-MM-DD format")
+		}
+		if haulStart.After(calculationDate) {
+			return nil, errors.New("haul_start_date cannot be after calculation_date")
+		}
+		haulCompleted = !calculationDate.Before(haulStart.AddDate(0, 0, entity.HaulDays))
+	} else {
+		input.HaulStartDate = nil
+	}
+
+	goldValue := input.GoldGrams * input.GoldPricePerGram
+	silverValue := input.SilverGrams * input.SilverPricePerGram
+	totalAssets := roundMoney(input.Cash + goldValue + silverValue + input.TradeGoods + input.Receivables)
+	netAssets := roundMoney(math.Max(totalAssets-input.Debts, 0))
+
+	var nisabValue float64
+	if nisabBasis == entity.NisabBasisSilver {
+		nisabValue = roundMoney(entity.NisabSilverGrams * input.SilverPricePerGram)
+	} else {
+		nisabValue = roundMoney(entity.NisabGoldGrams * input.GoldPricePerGram)
+	}
+
+	isObligatory := haulCompleted && netAssets >= nisabValue
+
+	var zakatAmount float64
+	if isObligatory {
+		zakatAmount = roundMoney(netAssets * entity.ZakatMaalRate)
+	}
+
+	return &entity.ZakatCalculation{
+		CalculationDate:    calculationDate.Format("2006-01-02"),
+		HaulStartDate:      input.HaulStartDate,
+		HaulCompleted:      haulCompleted,
+		Cash:               input.Cash,
+		GoldGrams:          input.GoldGrams,
+		GoldPricePerGram:   input.GoldPricePerGram,
+		SilverGrams:        input.SilverGrams,
+		SilverPricePerGram: input.SilverPricePerGram,
+		TradeGoods:         input.TradeGoods,
+		Receivables:        input.Receivables,
+		Debts:              input.Debts,
+		TotalAssets:        totalAssets,
+		NetAssets:          netAssets,
+		NisabBasis:         nisabBasis,
+		NisabValue:         nisabValue,
+		IsObligatory:       isObligatory,
+		ZakatRate:          entity.ZakatMaalRate,
+		ZakatAmount:        zakatAmount,
+	}, nil
+}
+
+// Save menghitung zakat maal lalu menyimpan hasilnya untuk muzakki
+func (uc *ZakatCalculatorUseCase) Save(input SaveZakatCalculationInput) (*entity.ZakatCalculation, error) {
+	if err := uc.validator.Struct(input); err != nil {
+		return nil, err
+	}
+
+	muzakki, err := uc.muzakkiRepo.FindByID(input.MuzakkiID)
+	if err != nil {
+		return nil, errors.New("muzakki not found")
+	}
+
+	calculation, err := uc.Calculate(input.CalculateZakatInput)
+	if err != nil {
+		return nil, err
+	}
+
+	calculation.MuzakkiID = muzakki.ID
+	calculation.Muzakki = muzakki
+	calculation.Notes = input.Notes
+	calculation.CreatedByUserID = input.CreatedByUserID
+
+	if err := uc.calculationRepo.Create(calculation); err != nil {
+		return nil, err
+	}
+
+	return calculation, nil
+}
+
+func (uc *ZakatCalculatorUseCase) FindAll(filter repository.ZakatCalculationFilter) ([]*entity.ZakatCalculation, int64, error) {
+	return uc.calculationRepo.FindAll(filter)
+}
+
+func (uc *ZakatCalculatorUseCase) FindByID(id string) (*entity.ZakatCalculation, error) {
+	return uc.calculationRepo.FindByID(id)
+}
+
+// effectivePrice mengambil harga komoditas yang berlaku pada tanggal perhitungan
+func (uc *ZakatCalculatorUseCase) effectivePrice(commodity string, date time.Time) (float64, error) {
+	price, err := uc.priceRepo.FindEffective(commodity, date.Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	if price == nil {
+		return 0, fmt.Errorf("%s_price_per_gram is required: no %s price recorded on or before %s", commodity, commodity, date.Format("2006-01-02"))
+	}
+	return price.Price, nil
+}
+
+// roundMoney membulatkan nominal rupiah ke 2 desimal
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
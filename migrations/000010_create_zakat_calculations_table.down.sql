@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_donation_receipt_items_zakat_calculation_id;
+ALTER TABLE donation_receipt_items DROP COLUMN IF EXISTS zakat_calculation_id;
+
+DROP TABLE IF EXISTS zakat_calculations;
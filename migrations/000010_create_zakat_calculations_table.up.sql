@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS zakat_calculations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    muzakki_id UUID NOT NULL REFERENCES muzakki(id) ON DELETE RESTRICT,
+    calculation_date DATE NOT NULL,
+    haul_start_date DATE,
+    haul_completed BOOLEAN NOT NULL DEFAULT true,
+    cash DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    gold_grams DECIMAL(10, 3) NOT NULL DEFAULT 0,
+    gold_price_per_gram DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    silver_grams DECIMAL(10, 3) NOT NULL DEFAULT 0,
+    silver_price_per_gram DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    trade_goods DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    receivables DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    debts DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    total_assets DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    net_assets DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    nisab_basis VARCHAR(10) NOT NULL CHECK (nisab_basis IN ('gold', 'silver')),
+    nisab_value DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    is_obligatory BOOLEAN NOT NULL DEFAULT false,
+    zakat_rate DECIMAL(6, 4) NOT NULL,
+    zakat_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    notes TEXT,
+    created_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_zakat_calculations_muzakki_id ON zakat_calculations(muzakki_id);
+CREATE INDEX IF NOT EXISTS idx_zakat_calculations_calculation_date ON zakat_calculations(calculation_date);
+
+-- Link zakat maal items to the calculation they were derived from
+ALTER TABLE donation_receipt_items
+    ADD COLUMN IF NOT EXISTS zakat_calculation_id UUID REFERENCES zakat_calculations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_donation_receipt_items_zakat_calculation_id ON donation_receipt_items(zakat_calculation_id);
@@ -0,0 +1,3 @@
+ALTER TABLE donation_receipts DROP COLUMN IF EXISTS fitrah_region;
+
+DROP TABLE IF EXISTS fitrah_rates;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS fitrah_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    hijri_year INT NOT NULL,
+    region VARCHAR(100) NOT NULL,
+    rice_kg_per_person DECIMAL(10, 2) NOT NULL CHECK (rice_kg_per_person > 0),
+    cash_per_person DECIMAL(15, 2) NOT NULL CHECK (cash_per_person > 0),
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (hijri_year, region)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fitrah_rates_hijri_year ON fitrah_rates(hijri_year);
+
+-- Region used to look up the fitrah rate of a receipt
+ALTER TABLE donation_receipts ADD COLUMN IF NOT EXISTS fitrah_region VARCHAR(100);
@@ -0,0 +1,6 @@
+ALTER TABLE fitrah_rates DROP CONSTRAINT IF EXISTS fitrah_rates_cash_per_person_check;
+UPDATE fitrah_rates SET cash_per_person = 0.01 WHERE cash_per_person IS NULL;
+ALTER TABLE fitrah_rates ALTER COLUMN cash_per_person SET NOT NULL;
+ALTER TABLE fitrah_rates ADD CONSTRAINT fitrah_rates_cash_per_person_check CHECK (cash_per_person > 0);
+
+DROP TABLE IF EXISTS commodity_prices;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS commodity_prices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    commodity VARCHAR(20) NOT NULL CHECK (commodity IN ('gold', 'silver', 'rice')),
+    price_date DATE NOT NULL,
+    price DECIMAL(15, 2) NOT NULL CHECK (price > 0),
+    unit VARCHAR(10) NOT NULL, -- gram (gold, silver) atau kg (rice)
+    source VARCHAR(255),
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (commodity, price_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_commodity_prices_commodity_date ON commodity_prices(commodity, price_date DESC);
+
+-- Cash equivalent may follow the rice price of the receipt date instead of a fixed amount
+ALTER TABLE fitrah_rates ALTER COLUMN cash_per_person DROP NOT NULL;
+ALTER TABLE fitrah_rates DROP CONSTRAINT IF EXISTS fitrah_rates_cash_per_person_check;
+ALTER TABLE fitrah_rates ADD CONSTRAINT fitrah_rates_cash_per_person_check CHECK (cash_per_person IS NULL OR cash_per_person > 0);
@@ -0,0 +1,8 @@
+-- Counter nomor kwitansi per periode (mis. "ZIS/2025/03/{seq}").
+-- Baris dikunci oleh INSERT ... ON CONFLICT DO UPDATE di dalam transaksi pembuatan kwitansi,
+-- sehingga penyimpanan bersamaan mendapat nomor berurutan tanpa celah.
+CREATE TABLE IF NOT EXISTS receipt_number_sequences (
+    sequence_key VARCHAR(255) PRIMARY KEY,
+    last_value BIGINT NOT NULL DEFAULT 0 CHECK (last_value >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
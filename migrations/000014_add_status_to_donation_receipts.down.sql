@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_donation_receipts_reversal_of;
+DROP INDEX IF EXISTS idx_donation_receipts_status;
+
+ALTER TABLE donation_receipts DROP CONSTRAINT IF EXISTS donation_receipts_void_check;
+ALTER TABLE donation_receipts DROP CONSTRAINT IF EXISTS donation_receipts_posted_number_check;
+
+DELETE FROM donation_receipts WHERE receipt_number IS NULL;
+ALTER TABLE donation_receipts ALTER COLUMN receipt_number SET NOT NULL;
+
+ALTER TABLE donation_receipts
+    DROP COLUMN IF EXISTS reversal_of_receipt_id,
+    DROP COLUMN IF EXISTS voided_at,
+    DROP COLUMN IF EXISTS voided_by_user_id,
+    DROP COLUMN IF EXISTS void_reason,
+    DROP COLUMN IF EXISTS posted_at,
+    DROP COLUMN IF EXISTS status;
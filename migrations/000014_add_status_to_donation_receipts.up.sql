@@ -0,0 +1,25 @@
+-- Lifecycle kwitansi: draft -> posted -> voided. Kwitansi yang sudah posted tidak dihapus,
+-- tetapi dibatalkan (void) dan bisa dikoreksi dengan kwitansi pengganti (reversal_of_receipt_id).
+ALTER TABLE donation_receipts
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'posted' CHECK (status IN ('draft', 'posted', 'voided')),
+    ADD COLUMN IF NOT EXISTS posted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS void_reason TEXT,
+    ADD COLUMN IF NOT EXISTS voided_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS voided_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reversal_of_receipt_id UUID REFERENCES donation_receipts(id) ON DELETE RESTRICT;
+
+-- Kwitansi lama dianggap sudah posted
+UPDATE donation_receipts SET posted_at = created_at WHERE posted_at IS NULL;
+
+-- Draft belum mendapat nomor kwitansi (nomor diberikan saat posting)
+ALTER TABLE donation_receipts ALTER COLUMN receipt_number DROP NOT NULL;
+
+ALTER TABLE donation_receipts ADD CONSTRAINT donation_receipts_posted_number_check
+    CHECK (status = 'draft' OR receipt_number IS NOT NULL);
+ALTER TABLE donation_receipts ADD CONSTRAINT donation_receipts_void_check
+    CHECK (status <> 'voided' OR (void_reason IS NOT NULL AND voided_by_user_id IS NOT NULL AND voided_at IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_donation_receipts_status ON donation_receipts(status);
+-- Satu kwitansi hanya bisa dikoreksi oleh satu kwitansi pengganti
+CREATE UNIQUE INDEX IF NOT EXISTS idx_donation_receipts_reversal_of ON donation_receipts(reversal_of_receipt_id)
+    WHERE reversal_of_receipt_id IS NOT NULL;
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_distributions_status;
+
+ALTER TABLE distributions DROP CONSTRAINT IF EXISTS distributions_void_check;
+
+ALTER TABLE distributions
+    DROP COLUMN IF EXISTS reverted_at,
+    DROP COLUMN IF EXISTS reverted_by_user_id,
+    DROP COLUMN IF EXISTS revert_reason,
+    DROP COLUMN IF EXISTS voided_at,
+    DROP COLUMN IF EXISTS voided_by_user_id,
+    DROP COLUMN IF EXISTS void_reason,
+    DROP COLUMN IF EXISTS posted_at,
+    DROP COLUMN IF EXISTS status;
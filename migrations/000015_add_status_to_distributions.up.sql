@@ -0,0 +1,19 @@
+-- Lifecycle penyaluran: draft -> posted -> voided. Penyaluran posted tidak bisa diubah;
+-- admin dapat mengembalikannya ke draft (revert) untuk dikoreksi, atau membatalkannya (void).
+ALTER TABLE distributions
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'posted' CHECK (status IN ('draft', 'posted', 'voided')),
+    ADD COLUMN IF NOT EXISTS posted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS void_reason TEXT,
+    ADD COLUMN IF NOT EXISTS voided_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS voided_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS revert_reason TEXT,
+    ADD COLUMN IF NOT EXISTS reverted_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS reverted_at TIMESTAMPTZ;
+
+-- Penyaluran lama dianggap sudah posted
+UPDATE distributions SET posted_at = created_at WHERE posted_at IS NULL;
+
+ALTER TABLE distributions ADD CONSTRAINT distributions_void_check
+    CHECK (status <> 'voided' OR (void_reason IS NOT NULL AND voided_by_user_id IS NOT NULL AND voided_at IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_distributions_status ON distributions(status);
@@ -0,0 +1,4 @@
+ALTER TABLE distributions
+    DROP COLUMN IF EXISTS overdraft_approved_at,
+    DROP COLUMN IF EXISTS overdraft_approved_by_user_id,
+    DROP COLUMN IF EXISTS overdraft_justification;
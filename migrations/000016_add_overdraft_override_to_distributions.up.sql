@@ -0,0 +1,5 @@
+-- Penyaluran yang melebihi saldo dana hanya bisa diposting oleh admin dengan justifikasi
+ALTER TABLE distributions
+    ADD COLUMN IF NOT EXISTS overdraft_justification TEXT,
+    ADD COLUMN IF NOT EXISTS overdraft_approved_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS overdraft_approved_at TIMESTAMPTZ;
@@ -0,0 +1,7 @@
+DELETE FROM distributions WHERE source_fund_type = 'amil';
+ALTER TABLE distributions DROP CONSTRAINT IF EXISTS distributions_source_fund_type_check;
+ALTER TABLE distributions ADD CONSTRAINT distributions_source_fund_type_check
+    CHECK (source_fund_type IN ('zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah'));
+
+DROP TABLE IF EXISTS donation_receipt_item_allocations;
+DROP TABLE IF EXISTS amil_rates;
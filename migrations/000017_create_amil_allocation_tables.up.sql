@@ -0,0 +1,49 @@
+-- Persentase hak amil per jenis dana, berlaku mulai effective_date.
+-- Zakat maksimal 1/8 (12.5%), infaq/sadaqah sesuai kebijakan lembaga.
+CREATE TABLE IF NOT EXISTS amil_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    fund_type VARCHAR(20) NOT NULL CHECK (fund_type IN ('zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah')),
+    effective_date DATE NOT NULL,
+    percentage DECIMAL(5, 2) NOT NULL CHECK (percentage >= 0 AND percentage <= 100),
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (fund_type, effective_date),
+    CONSTRAINT amil_rates_zakat_max_check CHECK (fund_type NOT IN ('zakat_fitrah', 'zakat_maal') OR percentage <= 12.5)
+);
+
+CREATE INDEX IF NOT EXISTS idx_amil_rates_fund_type_date ON amil_rates(fund_type, effective_date DESC);
+
+-- Pembagian setiap item kwitansi posted ke sub-ledger (dibuat saat kwitansi diposting,
+-- sehingga perubahan persentase tidak mengubah alokasi yang sudah ada)
+CREATE TABLE IF NOT EXISTS donation_receipt_item_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    receipt_item_id UUID NOT NULL REFERENCES donation_receipt_items(id) ON DELETE CASCADE,
+    ledger VARCHAR(20) NOT NULL CHECK (ledger IN ('amil', 'zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah')),
+    percentage DECIMAL(5, 2) NOT NULL DEFAULT 0, -- persentase hak amil yang dipakai
+    amount DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (receipt_item_id, ledger)
+);
+
+CREATE INDEX IF NOT EXISTS idx_receipt_item_allocations_ledger ON donation_receipt_item_allocations(ledger);
+
+-- Kwitansi yang sudah posted/voided sebelum fitur ini: seluruhnya masuk ke ledger jenis dananya
+INSERT INTO donation_receipt_item_allocations (receipt_item_id, ledger, percentage, amount)
+SELECT dri.id,
+       CASE
+           WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'fitrah' THEN 'zakat_fitrah'
+           WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'maal' THEN 'zakat_maal'
+           ELSE dri.fund_type
+       END,
+       0,
+       dri.amount
+FROM donation_receipt_items dri
+INNER JOIN donation_receipts dr ON dr.id = dri.receipt_id
+WHERE dr.status IN ('posted', 'voided')
+ON CONFLICT DO NOTHING;
+
+-- Hak amil dapat disalurkan (mis. operasional amil)
+ALTER TABLE distributions DROP CONSTRAINT IF EXISTS distributions_source_fund_type_check;
+ALTER TABLE distributions ADD CONSTRAINT distributions_source_fund_type_check
+    CHECK (source_fund_type IN ('amil', 'zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah'));
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS journal_lines_balanced ON journal_lines;
+DROP FUNCTION IF EXISTS check_journal_entry_balanced();
+
+DROP TABLE IF EXISTS journal_lines;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
@@ -0,0 +1,109 @@
+-- Bagan akun (chart of accounts) buku besar: kas/bank (aset) dan dana per sub-ledger (liabilitas)
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    account_type VARCHAR(20) NOT NULL CHECK (account_type IN ('asset', 'liability')),
+    fund_ledger VARCHAR(20) UNIQUE CHECK (fund_ledger IN ('amil', 'zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO ledger_accounts (code, name, account_type, fund_ledger) VALUES
+    ('1101', 'Kas', 'asset', NULL),
+    ('1102', 'Bank', 'asset', NULL),
+    ('2101', 'Dana Zakat Fitrah', 'liability', 'zakat_fitrah'),
+    ('2102', 'Dana Zakat Maal', 'liability', 'zakat_maal'),
+    ('2103', 'Dana Infaq', 'liability', 'infaq'),
+    ('2104', 'Dana Sadaqah', 'liability', 'sadaqah'),
+    ('2105', 'Dana Amil', 'liability', 'amil')
+ON CONFLICT (code) DO NOTHING;
+
+-- Jurnal umum. Setiap kwitansi/penyaluran posted punya satu jurnal; void atau revert
+-- membuat jurnal pembalik (reversal_of_entry_id) sehingga jurnal tidak pernah diubah atau dihapus.
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_date DATE NOT NULL,
+    description TEXT NOT NULL,
+    source_type VARCHAR(30) NOT NULL CHECK (source_type IN ('donation_receipt', 'distribution')),
+    source_id UUID NOT NULL,
+    reversal_of_entry_id UUID UNIQUE REFERENCES journal_entries(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_source ON journal_entries(source_type, source_id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_entry_date ON journal_entries(entry_date);
+
+CREATE TABLE IF NOT EXISTS journal_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_id UUID NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES ledger_accounts(id) ON DELETE RESTRICT,
+    debit DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    credit DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    CHECK (debit >= 0 AND credit >= 0 AND (debit = 0 OR credit = 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_lines_entry_id ON journal_lines(entry_id);
+CREATE INDEX IF NOT EXISTS idx_journal_lines_account_id ON journal_lines(account_id);
+
+-- Total debit dan kredit setiap jurnal harus sama; dicek di akhir transaksi
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced() RETURNS TRIGGER AS $$
+DECLARE
+    diff DECIMAL(15, 2);
+BEGIN
+    SELECT COALESCE(SUM(debit - credit), 0) INTO diff FROM journal_lines WHERE entry_id = NEW.entry_id;
+    IF diff <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is not balanced (debit - credit = %)', NEW.entry_id, diff;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER journal_lines_balanced
+    AFTER INSERT OR UPDATE ON journal_lines
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Jurnal untuk kwitansi posted yang sudah ada: debit kas/bank, kredit dana per sub-ledger
+INSERT INTO journal_entries (entry_date, description, source_type, source_id, created_at)
+SELECT dr.receipt_date, 'Penerimaan ' || COALESCE(dr.receipt_number, ''), 'donation_receipt', dr.id, COALESCE(dr.posted_at, dr.created_at)
+FROM donation_receipts dr
+WHERE dr.status = 'posted';
+
+INSERT INTO journal_lines (entry_id, account_id, debit, credit)
+SELECT je.id, la.id, SUM(a.amount), 0
+FROM journal_entries je
+INNER JOIN donation_receipts dr ON dr.id = je.source_id
+INNER JOIN donation_receipt_items dri ON dri.receipt_id = dr.id
+INNER JOIN donation_receipt_item_allocations a ON a.receipt_item_id = dri.id
+INNER JOIN ledger_accounts la ON la.code = CASE WHEN LOWER(TRIM(dr.payment_method)) IN ('cash', 'tunai', 'kas') THEN '1101' ELSE '1102' END
+WHERE je.source_type = 'donation_receipt'
+GROUP BY je.id, la.id;
+
+INSERT INTO journal_lines (entry_id, account_id, debit, credit)
+SELECT je.id, la.id, 0, SUM(a.amount)
+FROM journal_entries je
+INNER JOIN donation_receipt_items dri ON dri.receipt_id = je.source_id
+INNER JOIN donation_receipt_item_allocations a ON a.receipt_item_id = dri.id
+INNER JOIN ledger_accounts la ON la.fund_ledger = a.ledger
+WHERE je.source_type = 'donation_receipt'
+GROUP BY je.id, la.id;
+
+-- Jurnal untuk penyaluran posted yang sudah ada: debit dana sumber, kredit kas
+INSERT INTO journal_entries (entry_date, description, source_type, source_id, created_at)
+SELECT d.distribution_date, 'Penyaluran ' || d.source_fund_type, 'distribution', d.id, COALESCE(d.posted_at, d.created_at)
+FROM distributions d
+WHERE d.status = 'posted';
+
+INSERT INTO journal_lines (entry_id, account_id, debit, credit)
+SELECT je.id, la.id, d.total_amount, 0
+FROM journal_entries je
+INNER JOIN distributions d ON d.id = je.source_id
+INNER JOIN ledger_accounts la ON la.fund_ledger = d.source_fund_type
+WHERE je.source_type = 'distribution';
+
+INSERT INTO journal_lines (entry_id, account_id, debit, credit)
+SELECT je.id, la.id, 0, d.total_amount
+FROM journal_entries je
+INNER JOIN distributions d ON d.id = je.source_id
+INNER JOIN ledger_accounts la ON la.code = '1101'
+WHERE je.source_type = 'distribution';
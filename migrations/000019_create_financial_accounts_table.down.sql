@@ -0,0 +1,31 @@
+INSERT INTO ledger_accounts (code, name, account_type) VALUES ('1102', 'Bank', 'asset')
+ON CONFLICT (code) DO NOTHING;
+
+-- Semua rekening selain kas kembali ke akun 1102 Bank
+UPDATE journal_lines jl
+SET account_id = (SELECT id FROM ledger_accounts WHERE code = '1102')
+FROM financial_accounts fa
+WHERE fa.ledger_account_id = jl.account_id AND fa.account_type <> 'cash';
+
+ALTER TABLE donation_receipts ADD COLUMN IF NOT EXISTS payment_method VARCHAR(50);
+
+UPDATE donation_receipts dr
+SET payment_method = CASE WHEN fa.account_type = 'cash' THEN 'cash' ELSE fa.name END
+FROM financial_accounts fa
+WHERE fa.id = dr.financial_account_id;
+
+ALTER TABLE donation_receipts ALTER COLUMN payment_method SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_distributions_financial_account_id;
+DROP INDEX IF EXISTS idx_donation_receipts_financial_account_id;
+
+ALTER TABLE distributions DROP COLUMN IF EXISTS financial_account_id;
+ALTER TABLE donation_receipts DROP COLUMN IF EXISTS financial_account_id;
+
+CREATE TEMP TABLE dropped_ledger_accounts AS
+SELECT ledger_account_id as id FROM financial_accounts WHERE account_type <> 'cash';
+
+DROP TABLE IF EXISTS financial_accounts;
+
+DELETE FROM ledger_accounts WHERE id IN (SELECT id FROM dropped_ledger_accounts);
+DROP TABLE dropped_ledger_accounts;
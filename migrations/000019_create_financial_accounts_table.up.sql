@@ -0,0 +1,76 @@
+-- Rekening/kas tempat uang disimpan (kas, rekening bank, QRIS/e-wallet).
+-- Setiap rekening punya akun aset sendiri di buku besar.
+CREATE TABLE IF NOT EXISTS financial_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    account_type VARCHAR(20) NOT NULL CHECK (account_type IN ('cash', 'bank', 'digital')),
+    bank_name VARCHAR(100),
+    account_number VARCHAR(50),
+    ledger_account_id UUID NOT NULL UNIQUE REFERENCES ledger_accounts(id) ON DELETE RESTRICT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Kas memakai akun 1101 yang sudah ada
+INSERT INTO financial_accounts (name, account_type, ledger_account_id)
+SELECT 'Kas', 'cash', id FROM ledger_accounts WHERE code = '1101'
+ON CONFLICT (name) DO NOTHING;
+
+-- Setiap payment_method lain (dibandingkan tanpa huruf besar/kecil) menjadi rekening sendiri
+-- dengan akun aset 1111, 1112, ... Nama yang mengandung "qris" dianggap digital, sisanya bank.
+WITH methods AS (
+    SELECT MIN(TRIM(payment_method)) as name,
+           ROW_NUMBER() OVER (ORDER BY LOWER(TRIM(payment_method))) as rn
+    FROM donation_receipts
+    WHERE LOWER(TRIM(payment_method)) NOT IN ('cash', 'tunai', 'kas')
+    GROUP BY LOWER(TRIM(payment_method))
+),
+ledger AS (
+    INSERT INTO ledger_accounts (code, name, account_type)
+    SELECT (1110 + rn)::TEXT, name, 'asset' FROM methods
+    RETURNING id, name
+)
+INSERT INTO financial_accounts (name, account_type, ledger_account_id)
+SELECT name, CASE WHEN LOWER(name) LIKE '%qris%' THEN 'digital' ELSE 'bank' END, id
+FROM ledger;
+
+-- Kwitansi dan penyaluran menunjuk rekening, bukan teks bebas
+ALTER TABLE donation_receipts ADD COLUMN IF NOT EXISTS financial_account_id UUID REFERENCES financial_accounts(id) ON DELETE RESTRICT;
+
+UPDATE donation_receipts dr
+SET financial_account_id = fa.id
+FROM financial_accounts fa
+WHERE LOWER(fa.name) = CASE
+    WHEN LOWER(TRIM(dr.payment_method)) IN ('cash', 'tunai', 'kas') THEN 'kas'
+    ELSE LOWER(TRIM(dr.payment_method))
+END;
+
+ALTER TABLE donation_receipts ALTER COLUMN financial_account_id SET NOT NULL;
+ALTER TABLE donation_receipts DROP COLUMN IF EXISTS payment_method;
+
+CREATE INDEX IF NOT EXISTS idx_donation_receipts_financial_account_id ON donation_receipts(financial_account_id);
+
+-- Penyaluran lama dianggap dibayar dari kas
+ALTER TABLE distributions ADD COLUMN IF NOT EXISTS financial_account_id UUID REFERENCES financial_accounts(id) ON DELETE RESTRICT;
+
+UPDATE distributions
+SET financial_account_id = (SELECT id FROM financial_accounts WHERE name = 'Kas');
+
+ALTER TABLE distributions ALTER COLUMN financial_account_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_distributions_financial_account_id ON distributions(financial_account_id);
+
+-- Jurnal kwitansi non-tunai yang didebit ke akun 1102 Bank dipindah ke akun rekeningnya
+UPDATE journal_lines jl
+SET account_id = fa.ledger_account_id
+FROM journal_entries je, donation_receipts dr, financial_accounts fa, ledger_accounts bank
+WHERE jl.entry_id = je.id
+  AND je.source_type = 'donation_receipt'
+  AND dr.id = je.source_id
+  AND fa.id = dr.financial_account_id
+  AND bank.id = jl.account_id
+  AND bank.code = '1102';
+
+DELETE FROM ledger_accounts WHERE code = '1102';
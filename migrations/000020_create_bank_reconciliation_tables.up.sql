@@ -0,0 +1,77 @@
+-- Format CSV mutasi rekening per bank: nama kolom header yang dipakai
+CREATE TABLE IF NOT EXISTS bank_statement_formats (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    delimiter CHAR(1) NOT NULL DEFAULT ',',
+    skip_rows INT NOT NULL DEFAULT 0 CHECK (skip_rows >= 0),     -- baris sebelum header
+    footer_rows INT NOT NULL DEFAULT 0 CHECK (footer_rows >= 0), -- baris ringkasan di akhir file
+    date_column VARCHAR(100) NOT NULL,
+    date_layout VARCHAR(50) NOT NULL DEFAULT '2006-01-02',       -- layout tanggal Go
+    description_column VARCHAR(100) NOT NULL,
+    reference_column VARCHAR(100),
+    amount_column VARCHAR(100),                                  -- nilai bertanda, atau akhiran CR/DB
+    credit_column VARCHAR(100),
+    debit_column VARCHAR(100),
+    decimal_separator CHAR(1) NOT NULL DEFAULT '.' CHECK (decimal_separator IN ('.', ',')),
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (amount_column IS NOT NULL OR (credit_column IS NOT NULL AND debit_column IS NOT NULL))
+);
+
+INSERT INTO bank_statement_formats (name, date_column, description_column, reference_column, amount_column, notes)
+VALUES ('Generik', 'date', 'description', 'reference', 'amount', 'date YYYY-MM-DD, amount positif = masuk, negatif = keluar')
+ON CONFLICT (name) DO NOTHING;
+
+-- Satu file mutasi yang diunggah untuk satu rekening
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    financial_account_id UUID NOT NULL REFERENCES financial_accounts(id) ON DELETE RESTRICT,
+    format_id UUID NOT NULL REFERENCES bank_statement_formats(id) ON DELETE RESTRICT,
+    file_name VARCHAR(255) NOT NULL,
+    date_from DATE,
+    date_to DATE,
+    line_count INT NOT NULL DEFAULT 0,
+    skipped_count INT NOT NULL DEFAULT 0, -- baris yang sudah pernah diimpor
+    total_in NUMERIC(15,2) NOT NULL DEFAULT 0,
+    total_out NUMERIC(15,2) NOT NULL DEFAULT 0,
+    imported_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statements_financial_account_id ON bank_statements(financial_account_id);
+
+-- Baris mutasi: amount positif = uang masuk (kredit bank), negatif = uang keluar
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    statement_id UUID NOT NULL REFERENCES bank_statements(id) ON DELETE CASCADE,
+    line_number INT NOT NULL,
+    transaction_date DATE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    amount NUMERIC(15,2) NOT NULL CHECK (amount <> 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'unmatched' CHECK (status IN ('unmatched', 'matched', 'confirmed', 'flagged')),
+    flag_reason TEXT,
+    reviewed_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_statement_id ON bank_statement_lines(statement_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_status ON bank_statement_lines(status);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_transaction_date ON bank_statement_lines(transaction_date);
+
+-- Pasangan baris mutasi dengan kwitansi/penyaluran. Satu baris bisa dipecah ke
+-- beberapa transaksi (split); satu transaksi hanya boleh dipasangkan ke satu baris.
+CREATE TABLE IF NOT EXISTS bank_statement_matches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    line_id UUID NOT NULL REFERENCES bank_statement_lines(id) ON DELETE CASCADE,
+    source_type VARCHAR(30) NOT NULL CHECK (source_type IN ('donation_receipt', 'distribution')),
+    source_id UUID NOT NULL,
+    amount NUMERIC(15,2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (source_type, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_matches_line_id ON bank_statement_matches(line_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fiscal_period_balances;
+DROP TABLE IF EXISTS fiscal_period_events;
+DROP TABLE IF EXISTS fiscal_periods;
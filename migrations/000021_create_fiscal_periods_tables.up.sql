@@ -0,0 +1,45 @@
+-- Periode buku (bulan/tahun) yang ditutup admin. Transaksi bertanggal di periode
+-- tertutup tidak bisa dibuat, diubah, diposting, dibatalkan atau dihapus.
+CREATE TABLE IF NOT EXISTS fiscal_periods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    period_type VARCHAR(10) NOT NULL CHECK (period_type IN ('month', 'year')),
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'closed' CHECK (status IN ('open', 'closed')),
+    notes TEXT,
+    closed_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    closed_at TIMESTAMPTZ,
+    reopened_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    reopened_at TIMESTAMPTZ,
+    reopen_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (period_type, period_start),
+    CHECK (period_end >= period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fiscal_periods_closed_range ON fiscal_periods(period_start, period_end) WHERE status = 'closed';
+
+-- Jejak audit setiap penutupan dan pembukaan kembali
+CREATE TABLE IF NOT EXISTS fiscal_period_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    period_id UUID NOT NULL REFERENCES fiscal_periods(id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('close', 'reopen')),
+    reason TEXT,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_fiscal_period_events_period_id ON fiscal_period_events(period_id);
+
+-- Snapshot saldo setiap akun buku besar saat periode ditutup. Saldo mengikuti sisi
+-- normal akun: aset = debit - kredit, dana (liability) = kredit - debit.
+CREATE TABLE IF NOT EXISTS fiscal_period_balances (
+    period_id UUID NOT NULL REFERENCES fiscal_periods(id) ON DELETE CASCADE,
+    ledger_account_id UUID NOT NULL REFERENCES ledger_accounts(id) ON DELETE RESTRICT,
+    opening_balance DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    total_debit DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    total_credit DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    closing_balance DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    PRIMARY KEY (period_id, ledger_account_id)
+);
@@ -0,0 +1,9 @@
+-- Jurnal pembalik dihapus lebih dulu karena mereferensikan jurnal asal
+DELETE FROM journal_entries WHERE source_type = 'opening_balance' AND reversal_of_entry_id IS NOT NULL;
+DELETE FROM journal_entries WHERE source_type = 'opening_balance';
+
+ALTER TABLE journal_entries DROP CONSTRAINT IF EXISTS journal_entries_source_type_check;
+ALTER TABLE journal_entries ADD CONSTRAINT journal_entries_source_type_check
+    CHECK (source_type IN ('donation_receipt', 'distribution'));
+
+DROP TABLE IF EXISTS opening_balances;
@@ -0,0 +1,26 @@
+-- Saldo awal dari sistem lama (migrasi). Setiap saldo awal dicatat per sub-ledger dana dan
+-- rekening dengan tanggal efektif, lalu dijurnal: debit akun rekening, kredit akun dana.
+-- Saldo awal tidak diubah atau dihapus, tetapi dibatalkan (void) dengan jurnal pembalik.
+CREATE TABLE IF NOT EXISTS opening_balances (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    effective_date DATE NOT NULL,
+    fund_ledger VARCHAR(20) NOT NULL CHECK (fund_ledger IN ('amil', 'zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah')),
+    financial_account_id UUID NOT NULL REFERENCES financial_accounts(id) ON DELETE RESTRICT,
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'posted' CHECK (status IN ('posted', 'voided')),
+    void_reason TEXT,
+    voided_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    voided_at TIMESTAMPTZ,
+    created_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (status <> 'voided' OR (void_reason IS NOT NULL AND voided_by_user_id IS NOT NULL AND voided_at IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_opening_balances_effective_date ON opening_balances(effective_date);
+CREATE INDEX IF NOT EXISTS idx_opening_balances_financial_account_id ON opening_balances(financial_account_id);
+
+ALTER TABLE journal_entries DROP CONSTRAINT IF EXISTS journal_entries_source_type_check;
+ALTER TABLE journal_entries ADD CONSTRAINT journal_entries_source_type_check
+    CHECK (source_type IN ('donation_receipt', 'distribution', 'opening_balance'));
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS stock_movements;
+
+-- Item natura tidak bisa direpresentasikan tanpa kolom quantity
+DELETE FROM distribution_items WHERE amount <= 0;
+
+ALTER TABLE distribution_items DROP CONSTRAINT IF EXISTS distribution_items_amount_or_quantity_check;
+ALTER TABLE distribution_items DROP CONSTRAINT IF EXISTS distribution_items_commodity_quantity_check;
+ALTER TABLE distribution_items DROP COLUMN IF EXISTS quantity;
+ALTER TABLE distribution_items DROP COLUMN IF EXISTS commodity;
@@ -0,0 +1,48 @@
+-- Penyaluran natura: item penyaluran boleh berupa barang (beras dsb.) dengan jumlah dalam satuan komoditas.
+-- Nominal uang boleh 0 untuk item yang hanya berupa barang.
+ALTER TABLE distribution_items
+    ADD COLUMN IF NOT EXISTS commodity VARCHAR(20) CHECK (commodity IN ('gold', 'silver', 'rice')),
+    ADD COLUMN IF NOT EXISTS quantity DECIMAL(12, 2) CHECK (quantity > 0);
+
+ALTER TABLE distribution_items ADD CONSTRAINT distribution_items_commodity_quantity_check
+    CHECK ((commodity IS NULL) = (quantity IS NULL));
+ALTER TABLE distribution_items ADD CONSTRAINT distribution_items_amount_or_quantity_check
+    CHECK (amount > 0 OR quantity IS NOT NULL);
+
+-- Kartu stok barang per komoditas dan sub-ledger dana. Stok masuk dari kwitansi posted (rice_kg),
+-- stok keluar dari item penyaluran posted (quantity), dan penyesuaian manual (susut, rusak, hibah barang).
+-- Seperti jurnal, mutasi stok tidak pernah diubah: void atau revert membuat mutasi pembalik.
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    movement_date DATE NOT NULL,
+    commodity VARCHAR(20) NOT NULL CHECK (commodity IN ('gold', 'silver', 'rice')),
+    fund_ledger VARCHAR(20) NOT NULL CHECK (fund_ledger IN ('amil', 'zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah')),
+    quantity DECIMAL(12, 2) NOT NULL CHECK (quantity <> 0), -- positif = masuk, negatif = keluar
+    movement_type VARCHAR(20) NOT NULL CHECK (movement_type IN ('receipt', 'distribution', 'adjustment')),
+    source_id UUID,      -- kwitansi atau penyaluran; NULL untuk penyesuaian
+    source_item_id UUID, -- item kwitansi atau item penyaluran
+    reason TEXT,
+    reversal_of_movement_id UUID UNIQUE REFERENCES stock_movements(id) ON DELETE RESTRICT,
+    created_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (movement_type = 'adjustment' OR source_id IS NOT NULL),
+    CHECK (movement_type <> 'adjustment' OR (reason IS NOT NULL AND created_by_user_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_commodity_fund ON stock_movements(commodity, fund_ledger);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_movement_date ON stock_movements(movement_date);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_source ON stock_movements(movement_type, source_id);
+
+-- Stok masuk untuk kwitansi posted yang sudah ada
+INSERT INTO stock_movements (movement_date, commodity, fund_ledger, quantity, movement_type, source_id, source_item_id, created_at)
+SELECT dr.receipt_date, 'rice',
+       CASE
+           WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'fitrah' THEN 'zakat_fitrah'
+           WHEN dri.fund_type = 'zakat' AND dri.zakat_type = 'maal' THEN 'zakat_maal'
+           WHEN dri.fund_type = 'infaq' THEN 'infaq'
+           WHEN dri.fund_type = 'sadaqah' THEN 'sadaqah'
+       END,
+       dri.rice_kg, 'receipt', dr.id, dri.id, COALESCE(dr.posted_at, dr.created_at)
+FROM donation_receipts dr
+INNER JOIN donation_receipt_items dri ON dri.receipt_id = dr.id
+WHERE dr.status = 'posted' AND dri.rice_kg > 0;
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS distribution_approvals;
+DROP TABLE IF EXISTS donation_receipt_approvals;
+
+-- Transaksi yang masih menunggu persetujuan dikembalikan ke draft
+UPDATE donation_receipts SET status = 'draft' WHERE status = 'pending_approval';
+UPDATE distributions SET status = 'draft' WHERE status = 'pending_approval';
+
+ALTER TABLE distributions DROP CONSTRAINT IF EXISTS distributions_status_check;
+ALTER TABLE distributions ADD CONSTRAINT distributions_status_check
+    CHECK (status IN ('draft', 'posted', 'voided'));
+
+ALTER TABLE donation_receipts DROP CONSTRAINT IF EXISTS donation_receipts_posted_number_check;
+ALTER TABLE donation_receipts ADD CONSTRAINT donation_receipts_posted_number_check
+    CHECK (status = 'draft' OR receipt_number IS NOT NULL);
+
+ALTER TABLE donation_receipts DROP CONSTRAINT IF EXISTS donation_receipts_status_check;
+ALTER TABLE donation_receipts ADD CONSTRAINT donation_receipts_status_check
+    CHECK (status IN ('draft', 'posted', 'voided'));
@@ -0,0 +1,37 @@
+-- Maker-checker: kwitansi dan penyaluran di atas ambang batas (APPROVAL_THRESHOLD) menunggu
+-- persetujuan user lain (approver/admin) sebelum posted dan masuk ke buku besar.
+ALTER TABLE donation_receipts DROP CONSTRAINT IF EXISTS donation_receipts_status_check;
+ALTER TABLE donation_receipts ADD CONSTRAINT donation_receipts_status_check
+    CHECK (status IN ('draft', 'pending_approval', 'posted', 'voided'));
+
+-- Nomor kwitansi baru diberikan saat disetujui
+ALTER TABLE donation_receipts DROP CONSTRAINT IF EXISTS donation_receipts_posted_number_check;
+ALTER TABLE donation_receipts ADD CONSTRAINT donation_receipts_posted_number_check
+    CHECK (status IN ('draft', 'pending_approval') OR receipt_number IS NOT NULL);
+
+ALTER TABLE distributions DROP CONSTRAINT IF EXISTS distributions_status_check;
+ALTER TABLE distributions ADD CONSTRAINT distributions_status_check
+    CHECK (status IN ('draft', 'pending_approval', 'posted', 'voided'));
+
+-- Riwayat pengajuan, persetujuan dan penolakan
+CREATE TABLE IF NOT EXISTS donation_receipt_approvals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    receipt_id UUID NOT NULL REFERENCES donation_receipts(id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('submit', 'approve', 'reject')),
+    comment TEXT,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_donation_receipt_approvals_receipt_id ON donation_receipt_approvals(receipt_id);
+
+CREATE TABLE IF NOT EXISTS distribution_approvals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    distribution_id UUID NOT NULL REFERENCES distributions(id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('submit', 'approve', 'reject')),
+    comment TEXT,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_distribution_approvals_distribution_id ON distribution_approvals(distribution_id);
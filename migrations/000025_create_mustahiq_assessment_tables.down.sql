@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS mustahiq_assessment_answers;
+DROP TABLE IF EXISTS mustahiq_assessments;
+DROP TABLE IF EXISTS assessment_question_options;
+DROP TABLE IF EXISTS assessment_questions;
+DROP TABLE IF EXISTS assessment_questionnaires;
@@ -0,0 +1,73 @@
+-- Kuesioner kelayakan mustahiq per asnaf. Setiap pertanyaan punya bobot dan pilihan jawaban
+-- bernilai skor; skor penilaian (0-100) dibandingkan dengan pass_threshold kuesioner.
+-- Hanya satu kuesioner aktif per asnaf; kuesioner yang sudah dipakai tidak bisa diubah.
+CREATE TABLE IF NOT EXISTS assessment_questionnaires (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    asnaf_id UUID NOT NULL REFERENCES asnaf(id) ON DELETE RESTRICT,
+    name VARCHAR(255) NOT NULL,
+    pass_threshold DECIMAL(5, 2) NOT NULL CHECK (pass_threshold >= 0 AND pass_threshold <= 100),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assessment_questionnaires_active_asnaf ON assessment_questionnaires(asnaf_id)
+    WHERE is_active;
+
+CREATE TABLE IF NOT EXISTS assessment_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    questionnaire_id UUID NOT NULL REFERENCES assessment_questionnaires(id) ON DELETE CASCADE,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('income', 'dependants', 'housing', 'debts', 'other')),
+    question TEXT NOT NULL,
+    weight DECIMAL(7, 2) NOT NULL CHECK (weight > 0),
+    sort_order INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_questions_questionnaire_id ON assessment_questions(questionnaire_id);
+
+-- Skor lebih tinggi = lebih membutuhkan
+CREATE TABLE IF NOT EXISTS assessment_question_options (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id UUID NOT NULL REFERENCES assessment_questions(id) ON DELETE CASCADE,
+    label VARCHAR(255) NOT NULL,
+    score DECIMAL(7, 2) NOT NULL CHECK (score >= 0),
+    sort_order INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_question_options_question_id ON assessment_question_options(question_id);
+
+-- Hasil survei lapangan. Skor, ambang batas dan perubahan status disimpan apa adanya
+-- supaya keputusan kelayakan tetap bisa ditelusuri.
+CREATE TABLE IF NOT EXISTS mustahiq_assessments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mustahiq_id UUID NOT NULL REFERENCES mustahiq(id) ON DELETE RESTRICT,
+    questionnaire_id UUID NOT NULL REFERENCES assessment_questionnaires(id) ON DELETE RESTRICT,
+    assessment_date DATE NOT NULL,
+    score DECIMAL(5, 2) NOT NULL,
+    pass_threshold DECIMAL(5, 2) NOT NULL,
+    result VARCHAR(20) NOT NULL CHECK (result IN ('eligible', 'not_eligible')),
+    previous_status VARCHAR(20) NOT NULL,
+    new_status VARCHAR(20) NOT NULL,
+    notes TEXT,
+    assessed_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mustahiq_assessments_mustahiq_id ON mustahiq_assessments(mustahiq_id);
+CREATE INDEX IF NOT EXISTS idx_mustahiq_assessments_questionnaire_id ON mustahiq_assessments(questionnaire_id);
+
+-- Jawaban beserta salinan pertanyaan, bobot dan skor saat survei
+CREATE TABLE IF NOT EXISTS mustahiq_assessment_answers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    assessment_id UUID NOT NULL REFERENCES mustahiq_assessments(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES assessment_questions(id) ON DELETE RESTRICT,
+    option_id UUID NOT NULL REFERENCES assessment_question_options(id) ON DELETE RESTRICT,
+    category VARCHAR(20) NOT NULL,
+    question TEXT NOT NULL,
+    answer VARCHAR(255) NOT NULL,
+    weight DECIMAL(7, 2) NOT NULL,
+    score DECIMAL(7, 2) NOT NULL,
+    max_score DECIMAL(7, 2) NOT NULL,
+    UNIQUE (assessment_id, question_id)
+);
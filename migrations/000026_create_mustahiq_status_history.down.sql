@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mustahiq_status_history;
@@ -0,0 +1,38 @@
+-- Riwayat status mustahiq. Status hanya berubah lewat endpoint transisi (dengan alasan) atau
+-- penilaian kelayakan; status pada suatu tanggal = to_status terakhir dengan effective_date <= tanggal itu.
+CREATE TABLE IF NOT EXISTS mustahiq_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    mustahiq_id UUID NOT NULL REFERENCES mustahiq(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) CHECK (from_status IN ('active', 'inactive', 'pending')), -- NULL = status awal
+    to_status VARCHAR(20) NOT NULL CHECK (to_status IN ('active', 'inactive', 'pending')),
+    effective_date DATE NOT NULL,
+    reason TEXT NOT NULL,
+    assessment_id UUID REFERENCES mustahiq_assessments(id) ON DELETE SET NULL,
+    changed_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL untuk data sebelum riwayat dicatat
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mustahiq_status_history_mustahiq_date
+    ON mustahiq_status_history(mustahiq_id, effective_date, created_at);
+
+-- Status awal mustahiq yang sudah ada: status sebelum penilaian pertama, atau status sekarang
+INSERT INTO mustahiq_status_history (mustahiq_id, from_status, to_status, effective_date, reason, created_at)
+SELECT m.id, NULL,
+       COALESCE((
+           SELECT ma.previous_status FROM mustahiq_assessments ma
+           WHERE ma.mustahiq_id = m.id
+           ORDER BY ma.created_at
+           LIMIT 1
+       ), m.status),
+       m.created_at::date, 'initial status', m.created_at
+FROM mustahiq m;
+
+-- Perubahan status dari penilaian kelayakan yang sudah tercatat
+INSERT INTO mustahiq_status_history (
+    mustahiq_id, from_status, to_status, effective_date, reason, assessment_id, changed_by_user_id, created_at
+)
+SELECT ma.mustahiq_id, ma.previous_status, ma.new_status, ma.assessment_date,
+       'eligibility assessment scored ' || ma.score || ' (threshold ' || ma.pass_threshold || ')',
+       ma.id, ma.assessed_by_user_id, ma.created_at
+FROM mustahiq_assessments ma
+WHERE ma.previous_status <> ma.new_status;
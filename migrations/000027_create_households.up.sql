@@ -0,0 +1,28 @@
+-- Rumah tangga (Kartu Keluarga). Anggota bisa terdaftar sebagai mustahiq atau hanya dicatat
+-- namanya (misalnya anak); satu mustahiq hanya boleh menjadi anggota satu rumah tangga.
+CREATE TABLE IF NOT EXISTS households (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    family_card_number VARCHAR(16) UNIQUE, -- nomor KK, boleh kosong jika belum punya
+    address TEXT NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS household_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    mustahiq_id UUID UNIQUE REFERENCES mustahiq(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    relationship VARCHAR(20) NOT NULL
+        CHECK (relationship IN ('head', 'spouse', 'child', 'parent', 'sibling', 'grandchild', 'other')),
+    birth_date DATE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_household_members_household_id ON household_members(household_id);
+
+-- Satu kepala keluarga per rumah tangga
+CREATE UNIQUE INDEX IF NOT EXISTS idx_household_members_head ON household_members(household_id)
+    WHERE relationship = 'head';
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS duplicate_dismissals;
+
+DROP INDEX IF EXISTS idx_muzakki_nik;
+DROP INDEX IF EXISTS idx_mustahiq_nik;
+
+ALTER TABLE muzakki DROP COLUMN IF EXISTS nik;
+ALTER TABLE mustahiq DROP COLUMN IF EXISTS nik;
@@ -0,0 +1,21 @@
+-- NIK (Nomor Induk Kependudukan) opsional untuk mustahiq dan muzakki. Sengaja tidak UNIQUE:
+-- data ganda yang sudah terlanjur ada harus bisa ditemukan dan digabung, bukan ditolak.
+ALTER TABLE mustahiq ADD COLUMN IF NOT EXISTS nik VARCHAR(16);
+ALTER TABLE muzakki ADD COLUMN IF NOT EXISTS nik VARCHAR(16);
+
+CREATE INDEX IF NOT EXISTS idx_mustahiq_nik ON mustahiq(nik) WHERE nik IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_muzakki_nik ON muzakki(nik) WHERE nik IS NOT NULL;
+
+-- Pasangan kandidat data ganda yang sudah diperiksa petugas dan dinyatakan bukan orang yang sama.
+-- record_a_id selalu lebih kecil dari record_b_id supaya satu pasangan hanya tercatat sekali.
+CREATE TABLE IF NOT EXISTS duplicate_dismissals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('mustahiq', 'muzakki')),
+    record_a_id UUID NOT NULL,
+    record_b_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    dismissed_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (record_a_id < record_b_id),
+    UNIQUE (entity_type, record_a_id, record_b_id)
+);
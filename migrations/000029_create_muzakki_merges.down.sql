@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS muzakki_redirects;
+DROP TABLE IF EXISTS muzakki_merge_fields;
+DROP TABLE IF EXISTS muzakki_merges;
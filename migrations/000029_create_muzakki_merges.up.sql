@@ -0,0 +1,37 @@
+-- Jejak audit penggabungan muzakki ganda. ID sumber dan tujuan sengaja tanpa foreign key:
+-- muzakki sumber dihapus saat digabung, dan tujuan bisa ikut digabung lagi di kemudian hari.
+CREATE TABLE IF NOT EXISTS muzakki_merges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_muzakki_id UUID NOT NULL,
+    target_muzakki_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    receipts_moved INT NOT NULL DEFAULT 0,
+    calculations_moved INT NOT NULL DEFAULT 0,
+    merged_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_muzakki_merges_source ON muzakki_merges(source_muzakki_id);
+CREATE INDEX IF NOT EXISTS idx_muzakki_merges_target ON muzakki_merges(target_muzakki_id);
+
+-- Nilai kedua data untuk setiap field dan pilihan yang dipakai
+CREATE TABLE IF NOT EXISTS muzakki_merge_fields (
+    merge_id UUID NOT NULL REFERENCES muzakki_merges(id) ON DELETE CASCADE,
+    field VARCHAR(30) NOT NULL,
+    choice VARCHAR(10) NOT NULL CHECK (choice IN ('source', 'target')),
+    source_value TEXT NOT NULL DEFAULT '',
+    target_value TEXT NOT NULL DEFAULT '',
+    final_value TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (merge_id, field)
+);
+
+-- ID muzakki yang sudah digabung tetap bisa dipakai: diarahkan ke muzakki yang bertahan.
+-- Jika tujuan digabung lagi, pengalihan lama ikut dipindah sehingga tidak ada rantai.
+CREATE TABLE IF NOT EXISTS muzakki_redirects (
+    old_muzakki_id UUID PRIMARY KEY,
+    muzakki_id UUID NOT NULL REFERENCES muzakki(id) ON DELETE CASCADE,
+    merge_id UUID NOT NULL REFERENCES muzakki_merges(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_muzakki_redirects_muzakki_id ON muzakki_redirects(muzakki_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS mustahiq_redirects;
+DROP TABLE IF EXISTS mustahiq_merge_fields;
+DROP TABLE IF EXISTS mustahiq_merges;
+
+-- Baris riwayat pindahan tidak bisa dikembalikan ke mustahiq asal yang sudah dihapus
+DELETE FROM mustahiq_status_history WHERE merged_from_mustahiq_id IS NOT NULL;
+ALTER TABLE mustahiq_status_history DROP COLUMN IF EXISTS merged_from_mustahiq_id;
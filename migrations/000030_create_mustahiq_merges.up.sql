@@ -0,0 +1,46 @@
+-- Riwayat status mustahiq yang digabung dipindah ke mustahiq yang bertahan dan ditandai
+-- asalnya. Baris pindahan tetap tampil di riwayat tetapi tidak dipakai untuk menghitung
+-- status per tanggal, karena status mustahiq tujuan tidak ikut berubah.
+ALTER TABLE mustahiq_status_history ADD COLUMN IF NOT EXISTS merged_from_mustahiq_id UUID;
+
+-- Jejak audit penggabungan mustahiq ganda. ID sumber dan tujuan sengaja tanpa foreign key:
+-- mustahiq sumber dihapus saat digabung, dan tujuan bisa ikut digabung lagi di kemudian hari.
+CREATE TABLE IF NOT EXISTS mustahiq_merges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_mustahiq_id UUID NOT NULL,
+    target_mustahiq_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    items_moved INT NOT NULL DEFAULT 0,
+    amount_moved DECIMAL(15, 2) NOT NULL DEFAULT 0, -- nominal penyaluran posted yang dipindah
+    assessments_moved INT NOT NULL DEFAULT 0,
+    status_changes_moved INT NOT NULL DEFAULT 0,
+    shared_distributions INT NOT NULL DEFAULT 0, -- penyaluran yang berisi item untuk kedua data
+    total_received_before DECIMAL(15, 2) NOT NULL DEFAULT 0, -- total diterima tujuan sebelum digabung
+    total_received_after DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    merged_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mustahiq_merges_source ON mustahiq_merges(source_mustahiq_id);
+CREATE INDEX IF NOT EXISTS idx_mustahiq_merges_target ON mustahiq_merges(target_mustahiq_id);
+
+-- Nilai kedua data untuk setiap field dan pilihan yang dipakai
+CREATE TABLE IF NOT EXISTS mustahiq_merge_fields (
+    merge_id UUID NOT NULL REFERENCES mustahiq_merges(id) ON DELETE CASCADE,
+    field VARCHAR(30) NOT NULL,
+    choice VARCHAR(10) NOT NULL CHECK (choice IN ('source', 'target')),
+    source_value TEXT NOT NULL DEFAULT '',
+    target_value TEXT NOT NULL DEFAULT '',
+    final_value TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (merge_id, field)
+);
+
+-- ID mustahiq yang sudah digabung tetap bisa dipakai: diarahkan ke mustahiq yang bertahan
+CREATE TABLE IF NOT EXISTS mustahiq_redirects (
+    old_mustahiq_id UUID PRIMARY KEY,
+    mustahiq_id UUID NOT NULL REFERENCES mustahiq(id) ON DELETE CASCADE,
+    merge_id UUID NOT NULL REFERENCES mustahiq_merges(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mustahiq_redirects_mustahiq_id ON mustahiq_redirects(mustahiq_id);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS distribution_aid_rule_overrides;
+
+ALTER TABLE distributions
+    DROP COLUMN IF EXISTS aid_rule_override_at,
+    DROP COLUMN IF EXISTS aid_rule_override_by_user_id,
+    DROP COLUMN IF EXISTS aid_rule_justification;
+
+DROP TABLE IF EXISTS aid_rules;
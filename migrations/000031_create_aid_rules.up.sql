@@ -0,0 +1,45 @@
+-- Aturan batas bantuan per mustahiq atau per rumah tangga, mis. "fakir paling banyak Rp 1,5 jt
+-- per 90 hari dari zakat maal" atau "zakat fitrah 1 kali per 300 hari". Asnaf, program dan jenis
+-- dana yang kosong berarti berlaku untuk semua. Jendela waktu bergulir: window_days hari terakhir
+-- sampai tanggal penyaluran.
+CREATE TABLE IF NOT EXISTS aid_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    scope VARCHAR(20) NOT NULL DEFAULT 'mustahiq' CHECK (scope IN ('mustahiq', 'household')),
+    asnaf_id UUID REFERENCES asnaf(id) ON DELETE RESTRICT,
+    program_id UUID REFERENCES programs(id) ON DELETE RESTRICT,
+    source_fund_type VARCHAR(20) CHECK (source_fund_type IN ('amil', 'zakat_fitrah', 'zakat_maal', 'infaq', 'sadaqah')),
+    max_amount DECIMAL(15, 2) CHECK (max_amount > 0),
+    max_count INT CHECK (max_count > 0), -- jumlah penyaluran yang diterima dalam jendela
+    window_days INT NOT NULL CHECK (window_days > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (max_amount IS NOT NULL OR max_count IS NOT NULL)
+);
+
+-- Penyaluran yang melanggar aturan bantuan hanya bisa disimpan oleh admin dengan justifikasi
+ALTER TABLE distributions
+    ADD COLUMN IF NOT EXISTS aid_rule_justification TEXT,
+    ADD COLUMN IF NOT EXISTS aid_rule_override_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS aid_rule_override_at TIMESTAMPTZ;
+
+-- Pelanggaran yang di-override, disalin apa adanya (aturan bisa diubah atau dihapus kemudian)
+CREATE TABLE IF NOT EXISTS distribution_aid_rule_overrides (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    distribution_id UUID NOT NULL REFERENCES distributions(id) ON DELETE CASCADE,
+    aid_rule_id UUID NOT NULL,
+    aid_rule_name VARCHAR(255) NOT NULL,
+    mustahiq_id UUID NOT NULL,
+    household_id UUID, -- terisi untuk aturan per rumah tangga
+    limit_type VARCHAR(10) NOT NULL CHECK (limit_type IN ('amount', 'count')),
+    allowed DECIMAL(15, 2) NOT NULL,
+    used DECIMAL(15, 2) NOT NULL, -- sudah diterima dalam jendela sebelum penyaluran ini
+    total DECIMAL(15, 2) NOT NULL, -- termasuk penyaluran ini
+    window_start DATE NOT NULL,
+    window_end DATE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_distribution_aid_rule_overrides_distribution_id
+    ON distribution_aid_rule_overrides(distribution_id);
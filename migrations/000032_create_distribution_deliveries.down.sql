@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_distribution_items_undelivered;
+DROP INDEX IF EXISTS idx_distribution_items_voucher_code;
+
+ALTER TABLE distribution_items
+    DROP COLUMN IF EXISTS delivery_notes,
+    DROP COLUMN IF EXISTS delivery_signature_path,
+    DROP COLUMN IF EXISTS delivery_photo_path,
+    DROP COLUMN IF EXISTS delivered_by_user_id,
+    DROP COLUMN IF EXISTS delivered_at,
+    DROP COLUMN IF EXISTS voucher_code;
@@ -0,0 +1,24 @@
+-- Bukti serah terima per item penyaluran: kode voucher unik (dicetak sebagai QR), waktu dan
+-- petugas penukaran, serta foto dan tanda tangan penerima yang disimpan di penyimpanan file
+ALTER TABLE distribution_items
+    ADD COLUMN IF NOT EXISTS voucher_code VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS delivered_by_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS delivery_photo_path TEXT,
+    ADD COLUMN IF NOT EXISTS delivery_signature_path TEXT,
+    ADD COLUMN IF NOT EXISTS delivery_notes TEXT;
+
+-- Kode untuk item yang sudah ada: 10 karakter Crockford base32 acak per baris
+UPDATE distribution_items
+SET voucher_code = (
+    SELECT string_agg(substr('0123456789ABCDEFGHJKMNPQRSTVWXYZ', floor(random() * 32)::int + 1, 1), '')
+    FROM generate_series(1, 10)
+    WHERE distribution_items.id IS NOT NULL -- correlated, so every row gets its own code
+)
+WHERE voucher_code IS NULL;
+
+ALTER TABLE distribution_items ALTER COLUMN voucher_code SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_distribution_items_voucher_code ON distribution_items(voucher_code);
+CREATE INDEX IF NOT EXISTS idx_distribution_items_undelivered
+    ON distribution_items(distribution_id) WHERE delivered_at IS NULL;
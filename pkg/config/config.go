@@ -3,9 +3,12 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"go-zakat-be/pkg/receiptnumber"
+
 	"github.com/joho/godotenv"
 )
 
@@ -27,6 +30,28 @@ type AppConfig struct {
 	FrontendURL string
 
 	CORSAllowedOrigins []string
+
+	FitrahDefaultRegion string
+
+	ReceiptNumberPattern string
+
+	// Identitas lembaga untuk kwitansi PDF
+	OrgName               string
+	OrgAddress            string
+	ReceiptLetterheadPath string
+	ReceiptSignaturePath  string
+	ReceiptSignerName     string
+	ReceiptSignerTitle    string
+
+	// Selisih hari maksimum antara tanggal mutasi bank dan transaksi saat pencocokan otomatis
+	ReconciliationDateWindowDays int
+
+	// Kwitansi dan penyaluran di atas nominal ini harus disetujui approver (0 = nonaktif)
+	ApprovalThreshold float64
+
+	// Penyimpanan foto & tanda tangan serah terima penyaluran
+	FileStorageDriver string // local
+	FileStorageDir    string
 }
 
 func Load() *AppConfig {
@@ -47,7 +72,33 @@ func Load() *AppConfig {
 		FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
 
 		CORSAllowedOrigins: split(getEnv("CORS_ALLOWED_ORIGINS", "")),
+
+		FitrahDefaultRegion: getEnv("FITRAH_DEFAULT_REGION", "default"),
+
+		ReceiptNumberPattern: getEnv("RECEIPT_NUMBER_PATTERN", receiptnumber.DefaultPattern),
+
+		OrgName:               getEnv("ORG_NAME", "Lembaga Amil Zakat"),
+		OrgAddress:            getEnv("ORG_ADDRESS", ""),
+		ReceiptLetterheadPath: getEnv("RECEIPT_LETTERHEAD_PATH", ""),
+		ReceiptSignaturePath:  getEnv("RECEIPT_SIGNATURE_PATH", ""),
+		ReceiptSignerName:     getEnv("RECEIPT_SIGNER_NAME", ""),
+		ReceiptSignerTitle:    getEnv("RECEIPT_SIGNER_TITLE", "Petugas Penerima"),
+
+		FileStorageDriver: getEnv("FILE_STORAGE_DRIVER", "local"),
+		FileStorageDir:    getEnv("FILE_STORAGE_DIR", "./uploads"),
+	}
+
+	windowDays, err := strconv.Atoi(getEnv("RECONCILIATION_DATE_WINDOW_DAYS", "3"))
+	if err != nil || windowDays < 0 {
+		log.Fatalf("RECONCILIATION_DATE_WINDOW_DAYS tidak valid: %s", os.Getenv("RECONCILIATION_DATE_WINDOW_DAYS"))
+	}
+	cfg.ReconciliationDateWindowDays = windowDays
+
+	approvalThreshold, err := strconv.ParseFloat(getEnv("APPROVAL_THRESHOLD", "0"), 64)
+	if err != nil || approvalThreshold < 0 {
+		log.Fatalf("APPROVAL_THRESHOLD tidak valid: %s", os.Getenv("APPROVAL_THRESHOLD"))
 	}
+	cfg.ApprovalThreshold = approvalThreshold
 
 	// ambil TTL dari env
 	cfg.JWTAccessTTL = parseTTL(getEnv("JWT_ACCESS_EXP_MINUTES", "15m"))
@@ -0,0 +1,43 @@
+package hijri
+
+import "time"
+
+// Bulan-bulan hijriah yang dipakai dalam aturan zakat
+const (
+	Ramadan = 9
+	Shawwal = 10
+)
+
+// FromGregorian mengkonversi tanggal masehi ke tanggal hijriah menggunakan
+// kalender hijriah tabular (algoritma Kuwait). Hasilnya bisa selisih 1-2 hari
+// dari hasil rukyat, cukup untuk menentukan tahun hijriah sebuah transaksi.
+func FromGregorian(t time.Time) (year, month, day int) {
+	jd := julianDayNumber(t.Year(), int(t.Month()), t.Day())
+
+	l := jd - 1948440 + 10632
+	n := (l - 1) / 10631
+	l = l - 10631*n + 354
+	j := ((10985-l)/5316)*((50*l)/17719) + (l/5670)*((43*l)/15238)
+	l = l - ((30-j)/15)*((17719*j)/50) - (j/16)*((15238*j)/43) + 29
+
+	month = (24 * l) / 709
+	day = l - (709*month)/24
+	year = 30*n + j - 30
+
+	return year, month, day
+}
+
+// Year mengembalikan tahun hijriah dari tanggal masehi
+func Year(t time.Time) int {
+	year, _, _ := FromGregorian(t)
+	return year
+}
+
+// julianDayNumber menghitung Julian Day Number untuk tanggal masehi
+func julianDayNumber(year, month, day int) int {
+	a := (14 - month) / 12
+	y := year + 4800 - a
+	m := month + 12*a - 3
+
+	return day + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+}
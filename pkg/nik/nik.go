@@ -0,0 +1,122 @@
+// Package nik memvalidasi dan membaca Nomor Induk Kependudukan (NIK) 16 digit.
+//
+// Susunan NIK:
+//
+//	PP KK CC DDMMYY SSSS
+//	PP     kode provinsi
+//	KK     kode kabupaten/kota
+//	CC     kode kecamatan
+//	DDMMYY tanggal lahir; untuk perempuan tanggal ditambah 40
+//	SSSS   nomor urut, tidak boleh 0000
+//
+// NIK tidak memiliki digit checksum, jadi validasi dilakukan terhadap kode wilayah
+// dan tanggal lahir yang dikodekan di dalamnya.
+package nik
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Length adalah panjang NIK
+const Length = 16
+
+// provinceCodes adalah kode provinsi Dukcapil yang berlaku
+var provinceCodes = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true,
+	"51": true, "52": true, "53": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "72": true, "73": true, "74": true, "75": true, "76": true,
+	"81": true, "82": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true, "97": true,
+}
+
+// Info adalah isi NIK yang sudah dibaca
+type Info struct {
+	Number       string
+	ProvinceCode string
+	RegencyCode  string // 4 digit: provinsi + kabupaten/kota
+	DistrictCode string // 6 digit: provinsi + kabupaten/kota + kecamatan
+	BirthDate    time.Time
+	Female       bool
+	Serial       string
+}
+
+// Normalize membuang spasi, titik dan tanda hubung yang sering ikut saat NIK diketik
+func Normalize(raw string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+}
+
+// Parse memvalidasi NIK dan mengembalikan kode wilayah serta tanggal lahirnya.
+// Tahun lahir 2 digit diartikan sebagai tahun terbaru yang tidak melewati now.
+func Parse(raw string, now time.Time) (*Info, error) {
+	number := Normalize(raw)
+
+	if len(number) != Length {
+		return nil, fmt.Errorf("NIK must be %d digits", Length)
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return nil, errors.New("NIK must contain digits only")
+		}
+	}
+
+	info := &Info{
+		Number:       number,
+		ProvinceCode: number[0:2],
+		RegencyCode:  number[0:4],
+		DistrictCode: number[0:6],
+		Serial:       number[12:16],
+	}
+
+	if !provinceCodes[info.ProvinceCode] {
+		return nil, fmt.Errorf("NIK province code %s is not valid", info.ProvinceCode)
+	}
+	if number[2:4] == "00" {
+		return nil, errors.New("NIK regency code must not be 00")
+	}
+	if number[4:6] == "00" {
+		return nil, errors.New("NIK district code must not be 00")
+	}
+	if info.Serial == "0000" {
+		return nil, errors.New("NIK serial number must not be 0000")
+	}
+
+	day, _ := strconv.Atoi(number[6:8])
+	month, _ := strconv.Atoi(number[8:10])
+	year, _ := strconv.Atoi(number[10:12])
+
+	// Perempuan: tanggal lahir + 40
+	if day > 40 {
+		info.Female = true
+		day -= 40
+	}
+
+	year += 2000
+	if year > now.Year() {
+		year -= 100
+	}
+
+	birthDate := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// time.Date menormalkan tanggal yang tidak ada (mis. 31 April), jadi bandingkan kembali
+	if day < 1 || month < 1 || month > 12 || birthDate.Day() != day || int(birthDate.Month()) != month {
+		return nil, errors.New("NIK does not encode a valid birth date")
+	}
+	if birthDate.After(now) {
+		return nil, errors.New("NIK birth date is in the future")
+	}
+	info.BirthDate = birthDate
+
+	return info, nil
+}
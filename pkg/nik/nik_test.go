@@ -0,0 +1,78 @@
+package nik
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		raw       string
+		birthDate string
+		female    bool
+		wantErr   bool
+	}{
+		{name: "male", raw: "3201011505900001", birthDate: "1990-05-15"},
+		{name: "female day plus 40", raw: "3201015505900001", birthDate: "1990-05-15", female: true},
+		{name: "born after 2000", raw: "3201010101100001", birthDate: "2010-01-01"},
+		{name: "female leap day", raw: "3201016902000001", birthDate: "2000-02-29", female: true},
+		{name: "typed with separators", raw: " 3201.0115 0590-0001 ", birthDate: "1990-05-15"},
+		{name: "too short", raw: "320101150590", wantErr: true},
+		{name: "not digits", raw: "32010115059000AB", wantErr: true},
+		{name: "unknown province", raw: "9901011505900001", wantErr: true},
+		{name: "regency 00", raw: "3200011505900001", wantErr: true},
+		{name: "district 00", raw: "3201001505900001", wantErr: true},
+		{name: "serial 0000", raw: "3201011505900000", wantErr: true},
+		{name: "day 00", raw: "3201010005900001", wantErr: true},
+		{name: "month 13", raw: "3201011513900001", wantErr: true},
+		{name: "31 april", raw: "3201013104900001", wantErr: true},
+		{name: "female 31 february", raw: "3201017102900001", wantErr: true},
+		{name: "female day above 71", raw: "3201017201900001", wantErr: true},
+		{name: "birth date in the future", raw: "3201010112260001", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Parse(tt.raw, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.raw, info)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.raw, err)
+			}
+			if got := info.BirthDate.Format("2006-01-02"); got != tt.birthDate {
+				t.Errorf("BirthDate = %s, want %s", got, tt.birthDate)
+			}
+			if info.Female != tt.female {
+				t.Errorf("Female = %v, want %v", info.Female, tt.female)
+			}
+			if info.ProvinceCode != "32" || info.RegencyCode != "3201" || info.DistrictCode != "320101" {
+				t.Errorf("codes = %s/%s/%s, want 32/3201/320101", info.ProvinceCode, info.RegencyCode, info.DistrictCode)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"3201011505900001", "3201011505900001"},
+		{" 3201 0115 0590 0001 ", "3201011505900001"},
+		{"3201.0115.0590.0001", "3201011505900001"},
+		{"3201-0115-0590-0001", "3201011505900001"},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.raw); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
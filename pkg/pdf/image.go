@@ -0,0 +1,106 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+)
+
+// Image adalah gambar yang sudah didaftarkan ke dokumen
+type Image struct {
+	index      int
+	width      int
+	height     int
+	colorSpace string
+	filter     string
+	data       []byte
+	mask       []byte // alpha channel (SMask), nil jika gambar tidak transparan
+}
+
+// Width mengembalikan lebar gambar dalam pixel
+func (img *Image) Width() int {
+	return img.width
+}
+
+// Height mengembalikan tinggi gambar dalam pixel
+func (img *Image) Height() int {
+	return img.height
+}
+
+// AddImage mendaftarkan gambar JPEG atau PNG. JPEG disimpan apa adanya (DCTDecode),
+// format lain di-decode lalu dikompres ulang sebagai RGB + alpha mask.
+func (d *Document) AddImage(data []byte) (*Image, error) {
+	img, err := newImage(data)
+	if err != nil {
+		return nil, err
+	}
+	img.index = len(d.images)
+	d.images = append(d.images, img)
+	return img, nil
+}
+
+func newImage(data []byte) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image: %w", err)
+	}
+
+	if format == "jpeg" {
+		colorSpace := "DeviceRGB"
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			colorSpace = "DeviceGray"
+		case color.CMYKModel:
+			colorSpace = "DeviceCMYK"
+		}
+		return &Image{
+			width:      cfg.Width,
+			height:     cfg.Height,
+			colorSpace: colorSpace,
+			filter:     "DCTDecode",
+			data:       data,
+		}, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image: %w", err)
+	}
+
+	bounds := decoded.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	rgb := make([]byte, 0, w*h*3)
+	alpha := make([]byte, 0, w*h)
+	opaque := true
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+			if c.A != 0xff {
+				opaque = false
+			}
+		}
+	}
+
+	img := &Image{
+		width:      w,
+		height:     h,
+		colorSpace: "DeviceRGB",
+		filter:     "FlateDecode",
+	}
+	if img.data, err = deflate(rgb); err != nil {
+		return nil, err
+	}
+	if !opaque {
+		if img.mask, err = deflate(alpha); err != nil {
+			return nil, err
+		}
+	}
+
+	return img, nil
+}
@@ -0,0 +1,303 @@
+// Package pdf adalah penulis PDF minimal (tanpa dependency luar) untuk dokumen
+// sederhana seperti kwitansi: teks dengan font standar Helvetica, garis, kotak
+// dan gambar JPEG/PNG.
+//
+// Koordinat memakai satuan point (1/72 inch) dihitung dari kiri atas halaman.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PageSize adalah ukuran halaman dalam point
+type PageSize struct {
+	Width  float64
+	Height float64
+}
+
+// A4 portrait
+var A4 = PageSize{Width: 595.28, Height: 841.89}
+
+// Font standar PDF (tidak perlu di-embed)
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	HelveticaOblique
+)
+
+var fontNames = map[Font]string{
+	Helvetica:        "Helvetica",
+	HelveticaBold:    "Helvetica-Bold",
+	HelveticaOblique: "Helvetica-Oblique",
+}
+
+// Document adalah dokumen PDF yang sedang disusun
+type Document struct {
+	size   PageSize
+	pages  []*Page
+	images []*Image
+}
+
+// New membuat dokumen kosong dengan ukuran halaman tertentu
+func New(size PageSize) *Document {
+	return &Document{size: size}
+}
+
+// Size mengembalikan ukuran halaman dokumen
+func (d *Document) Size() PageSize {
+	return d.size
+}
+
+// AddPage menambah halaman baru dan mengembalikannya
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d, font: Helvetica, fontSize: 10}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Page adalah satu halaman; semua perintah gambar ditulis ke content stream-nya
+type Page struct {
+	doc      *Document
+	content  bytes.Buffer
+	font     Font
+	fontSize float64
+	images   map[*Image]bool
+}
+
+// SetFont mengganti font untuk Text berikutnya
+func (p *Page) SetFont(font Font, size float64) {
+	p.font = font
+	p.fontSize = size
+}
+
+// TextWidth menghitung lebar teks dengan font yang sedang aktif
+func (p *Page) TextWidth(s string) float64 {
+	return TextWidth(p.font, p.fontSize, s)
+}
+
+// Text menulis teks dengan baseline pada y
+func (p *Page) Text(x, y float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		int(p.font)+1, num(p.fontSize), num(x), num(p.doc.size.Height-y), escapeText(s))
+}
+
+// TextRight menulis teks rata kanan dengan ujung kanan pada x
+func (p *Page) TextRight(x, y float64, s string) {
+	p.Text(x-p.TextWidth(s), y, s)
+}
+
+// TextCenter menulis teks rata tengah terhadap x
+func (p *Page) TextCenter(x, y float64, s string) {
+	p.Text(x-p.TextWidth(s)/2, y, s)
+}
+
+// Line menggambar garis dengan ketebalan width
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	h := p.doc.size.Height
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(h-y1), num(x2), num(h-y2))
+}
+
+// FillRect mengisi kotak dengan warna abu-abu (0 = hitam, 1 = putih)
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %s g %s %s %s %s re f Q\n",
+		num(gray), num(x), num(p.doc.size.Height-y-h), num(w), num(h))
+}
+
+// StrokeRect menggambar garis tepi kotak
+func (p *Page) StrokeRect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n",
+		num(lineWidth), num(x), num(p.doc.size.Height-y-h), num(w), num(h))
+}
+
+// DrawImage menggambar img pada kotak (x, y, w, h)
+func (p *Page) DrawImage(img *Image, x, y, w, h float64) {
+	if p.images == nil {
+		p.images = make(map[*Image]bool)
+	}
+	p.images[img] = true
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(w), num(h), num(x), num(p.doc.size.Height-y-h), img.index+1)
+}
+
+// WriteTo menulis dokumen PDF lengkap ke w
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pw := &writer{}
+
+	// Object 1: catalog, 2: pages, 3..5: fonts, lalu gambar, lalu halaman + content
+	const catalogID, pagesID, firstFontID = 1, 2, 3
+	nextID := firstFontID + len(fontNames)
+
+	imageIDs := make([]int, len(d.images))
+	maskIDs := make([]int, len(d.images))
+	for i, img := range d.images {
+		imageIDs[i] = nextID
+		nextID++
+		if img.mask != nil {
+			maskIDs[i] = nextID
+			nextID++
+		}
+	}
+
+	pageIDs := make([]int, len(d.pages))
+	for i := range d.pages {
+		pageIDs[i] = nextID
+		nextID += 2 // page + content stream
+	}
+
+	pw.header()
+
+	pw.object(catalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+
+	kids := make([]string, len(pageIDs))
+	for i, id := range pageIDs {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	pw.object(pagesID, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>",
+		strings.Join(kids, " "), len(pageIDs), num(d.size.Width), num(d.size.Height)))
+
+	for f := Helvetica; f <= HelveticaOblique; f++ {
+		pw.object(firstFontID+int(f), fmt.Sprintf(
+			"<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", fontNames[f]))
+	}
+
+	for i, img := range d.images {
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+			img.width, img.height, img.colorSpace, img.filter)
+		if img.colorSpace == "DeviceCMYK" && img.filter == "DCTDecode" {
+			// JPEG CMYK dari Photoshop menyimpan nilai terbalik
+			dict += " /Decode [1 0 1 0 1 0 1 0]"
+		}
+		if img.mask != nil {
+			dict += fmt.Sprintf(" /SMask %d 0 R", maskIDs[i])
+		}
+		pw.stream(imageIDs[i], dict, img.data)
+
+		if img.mask != nil {
+			pw.stream(maskIDs[i], fmt.Sprintf(
+				"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode",
+				img.width, img.height), img.mask)
+		}
+	}
+
+	fontRefs := make([]string, 0, len(fontNames))
+	for f := Helvetica; f <= HelveticaOblique; f++ {
+		fontRefs = append(fontRefs, fmt.Sprintf("/F%d %d 0 R", int(f)+1, firstFontID+int(f)))
+	}
+
+	for i, page := range d.pages {
+		var xobjects []string
+		for _, img := range d.images {
+			if page.images[img] {
+				xobjects = append(xobjects, fmt.Sprintf("/Im%d %d 0 R", img.index+1, imageIDs[img.index]))
+			}
+		}
+		resources := fmt.Sprintf("/Font << %s >>", strings.Join(fontRefs, " "))
+		if len(xobjects) > 0 {
+			resources += fmt.Sprintf(" /XObject << %s >>", strings.Join(xobjects, " "))
+		}
+
+		pw.object(pageIDs[i], fmt.Sprintf("<< /Type /Page /Parent %d 0 R /Resources << %s >> /Contents %d 0 R >>",
+			pagesID, resources, pageIDs[i]+1))
+
+		content, err := deflate(page.content.Bytes())
+		if err != nil {
+			return 0, err
+		}
+		pw.stream(pageIDs[i]+1, "/Filter /FlateDecode", content)
+	}
+
+	pw.trailer(catalogID, nextID)
+
+	return pw.buf.WriteTo(w)
+}
+
+// Bytes mengembalikan dokumen PDF lengkap
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writer mencatat offset setiap object untuk tabel xref
+type writer struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (w *writer) header() {
+	w.offsets = make(map[int]int)
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+}
+
+func (w *writer) object(id int, body string) {
+	w.offsets[id] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+func (w *writer) stream(id int, dict string, data []byte) {
+	w.offsets[id] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< %s /Length %d >>\nstream\n", id, dict, len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *writer) trailer(rootID, size int) {
+	xrefOffset := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for id := 1; id < size; id++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[id])
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, rootID, xrefOffset)
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// num memformat angka tanpa nol berlebih, mis. 12.50 -> "12.5"
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// escapeText mengubah teks UTF-8 ke WinAnsi dan meng-escape karakter khusus PDF.
+// Karakter di luar Latin-1 diganti "?".
+func escapeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 32:
+			continue
+		case r < 128:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,491 @@
+// Package qrcode adalah encoder QR Code minimal (tanpa dependency luar) untuk teks
+// pendek seperti kode voucher, versi 1 sampai 10.
+//
+// Teks yang hanya berisi karakter alfanumerik QR (0-9, A-Z, spasi dan $%*+-./:)
+// dikodekan dengan mode alfanumerik, selain itu dengan mode byte.
+package qrcode
+
+import (
+	"errors"
+	"strings"
+)
+
+// Level adalah tingkat koreksi kesalahan
+type Level int
+
+const (
+	L Level = iota // sekitar 7% data dapat dipulihkan
+	M              // sekitar 15%
+	Q              // sekitar 25%
+	H              // sekitar 30%
+)
+
+// formatBits adalah kode tingkat koreksi pada format information
+var formatBits = [4]int{L: 1, M: 0, Q: 3, H: 2}
+
+// MaxVersion adalah versi QR terbesar yang didukung
+const MaxVersion = 10
+
+// blockSpec adalah susunan blok Reed-Solomon satu versi dan tingkat koreksi
+type blockSpec struct {
+	ecPerBlock int // codeword koreksi per blok
+	blocks1    int // jumlah blok grup 1
+	data1      int // codeword data per blok grup 1
+	blocks2    int // jumlah blok grup 2 (data per blok = data1 + 1)
+}
+
+// blockSpecs[version-1][level]
+var blockSpecs = [MaxVersion][4]blockSpec{
+	{{7, 1, 19, 0}, {10, 1, 16, 0}, {13, 1, 13, 0}, {17, 1, 9, 0}},
+	{{10, 1, 34, 0}, {16, 1, 28, 0}, {22, 1, 22, 0}, {28, 1, 16, 0}},
+	{{15, 1, 55, 0}, {26, 1, 44, 0}, {18, 2, 17, 0}, {22, 2, 13, 0}},
+	{{20, 1, 80, 0}, {18, 2, 32, 0}, {26, 2, 24, 0}, {16, 4, 9, 0}},
+	{{26, 1, 108, 0}, {24, 2, 43, 0}, {18, 2, 15, 2}, {22, 2, 11, 2}},
+	{{18, 2, 68, 0}, {16, 4, 27, 0}, {24, 4, 19, 0}, {28, 4, 15, 0}},
+	{{20, 2, 78, 0}, {18, 4, 31, 0}, {18, 2, 14, 4}, {26, 4, 13, 1}},
+	{{24, 2, 97, 0}, {22, 2, 38, 2}, {22, 4, 18, 2}, {26, 4, 14, 2}},
+	{{30, 2, 116, 0}, {22, 3, 36, 2}, {20, 4, 16, 4}, {24, 4, 12, 4}},
+	{{18, 2, 68, 2}, {26, 4, 43, 1}, {24, 6, 19, 2}, {28, 6, 15, 2}},
+}
+
+func (s blockSpec) dataCodewords() int {
+	return s.blocks1*s.data1 + s.blocks2*(s.data1+1)
+}
+
+// alignmentCenters[version-1] adalah koordinat pusat alignment pattern
+var alignmentCenters = [MaxVersion][]int{
+	{}, {6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34},
+	{6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50},
+}
+
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// Code adalah matriks QR; modul gelap bernilai true
+type Code struct {
+	Version  int
+	size     int
+	modules  [][]bool
+	function [][]bool // modul pola tetap yang tidak boleh ditimpa data atau mask
+}
+
+// Size adalah jumlah modul per sisi, tanpa quiet zone
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark melaporkan apakah modul pada kolom x dan baris y berwarna gelap
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// Encode membuat QR Code versi terkecil yang memuat text pada tingkat koreksi level
+func Encode(text string, level Level) (*Code, error) {
+	if level < L || level > H {
+		return nil, errors.New("qrcode: invalid error correction level")
+	}
+
+	alphanumeric := text != ""
+	for _, r := range text {
+		if !strings.ContainsRune(alphanumericChars, r) {
+			alphanumeric = false
+			break
+		}
+	}
+
+	for version := 1; version <= MaxVersion; version++ {
+		spec := blockSpecs[version-1][level]
+		bits := encodeData(text, alphanumeric, version)
+		if len(bits) > spec.dataCodewords()*8 {
+			continue
+		}
+
+		data := finishData(bits, spec.dataCodewords())
+		c := newCode(version)
+		c.drawFunctionPatterns()
+		c.drawCodewords(interleave(data, spec))
+		c.applyBestMask(level)
+		return c, nil
+	}
+
+	return nil, errors.New("qrcode: text is too long")
+}
+
+// bitBuffer menampung bit data sebelum dipecah menjadi codeword
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func encodeData(text string, alphanumeric bool, version int) bitBuffer {
+	var bits bitBuffer
+	if alphanumeric {
+		countBits := 9
+		if version >= 10 {
+			countBits = 11
+		}
+		bits.append(0b0010, 4)
+		bits.append(len(text), countBits)
+		for i := 0; i+1 < len(text); i += 2 {
+			a := strings.IndexByte(alphanumericChars, text[i])
+			b := strings.IndexByte(alphanumericChars, text[i+1])
+			bits.append(a*45+b, 11)
+		}
+		if len(text)%2 == 1 {
+			bits.append(strings.IndexByte(alphanumericChars, text[len(text)-1]), 6)
+		}
+		return bits
+	}
+
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	bits.append(0b0100, 4)
+	bits.append(len(text), countBits)
+	for i := 0; i < len(text); i++ {
+		bits.append(int(text[i]), 8)
+	}
+	return bits
+}
+
+// finishData menambah terminator, padding bit dan byte pengisi 0xEC/0x11
+func finishData(bits bitBuffer, capacity int) []byte {
+	bits.append(0, min(4, capacity*8-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+
+	data := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		data = append(data, b)
+	}
+	for pad := byte(0xEC); len(data) < capacity; pad ^= 0xEC ^ 0x11 {
+		data = append(data, pad)
+	}
+	return data
+}
+
+// interleave membagi data ke blok, menghitung codeword koreksi tiap blok lalu
+// menyusunnya kolom demi kolom
+func interleave(data []byte, spec blockSpec) []byte {
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for i := 0; i < spec.blocks1+spec.blocks2; i++ {
+		n := spec.data1
+		if i >= spec.blocks1 {
+			n++
+		}
+		block := data[offset : offset+n]
+		offset += n
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, reedSolomon(block, spec.ecPerBlock))
+	}
+
+	result := make([]byte, 0, len(data)+len(ecBlocks)*spec.ecPerBlock)
+	for i := 0; i <= spec.data1; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func newCode(version int) *Code {
+	size := version*4 + 17
+	c := &Code{Version: version, size: size}
+	c.modules = make([][]bool, size)
+	c.function = make([][]bool, size)
+	for y := range c.modules {
+		c.modules[y] = make([]bool, size)
+		c.function[y] = make([]bool, size)
+	}
+	return c
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	// Timing patterns
+	for i := 0; i < c.size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with separators
+	c.drawFinder(3, 3)
+	c.drawFinder(c.size-4, 3)
+	c.drawFinder(3, c.size-4)
+
+	// Alignment patterns, except where they overlap the finder patterns
+	centers := alignmentCenters[c.Version-1]
+	last := len(centers) - 1
+	for i, cy := range centers {
+		for j, cx := range centers {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(cx, cy)
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn after masking
+	c.drawFormat(0, 0)
+	c.drawVersion()
+}
+
+func (c *Code) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= c.size || y < 0 || y >= c.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat menggambar tingkat koreksi dan nomor mask beserta kode BCH-nya di kedua salinan
+func (c *Code) drawFormat(level Level, mask int) {
+	data := formatBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	// First copy, around the top left finder
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	// Second copy, split between the top right and bottom left finders
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(i))
+	}
+	c.setFunction(8, c.size-8, true) // dark module
+}
+
+// drawVersion menggambar nomor versi untuk versi 7 ke atas
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+
+	rem := c.Version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.Version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 == 1
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords mengisi modul data secara zig-zag dua kolom dari kanan bawah
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.size; vert++ {
+			y := vert
+			if upward {
+				y = c.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[y][x] = (codewords[i>>3]>>(7-i&7))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if !c.function[y][x] && maskBit(mask, x, y) {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask mencoba kedelapan mask dan memakai yang skor penaltinya terkecil
+func (c *Code) applyBestMask(level Level) {
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(level, mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // XOR again to undo
+	}
+
+	c.applyMask(best)
+	c.drawFormat(level, best)
+}
+
+// penalty menghitung skor penalti matriks sesuai empat aturan standar QR
+func (c *Code) penalty() int {
+	total := 0
+	n := c.size
+
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return c.modules[x][y]
+		}
+		return c.modules[y][x]
+	}
+
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < n; y++ {
+			// Rule 1: runs of five or more modules of the same color
+			run := 1
+			for x := 1; x < n; x++ {
+				if at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					total += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				total += run - 2
+			}
+
+			// Rule 3: finder-like 1:1:3:1:1 patterns with four light modules on one side
+			for x := 0; x+11 <= n; x++ {
+				if matchesFinderLike(func(i int) bool { return at(x+i, y, vertical) }) {
+					total += 40
+				}
+			}
+		}
+	}
+
+	// Rule 2: 2x2 blocks of the same color
+	for y := 0; y < n-1; y++ {
+		for x := 0; x < n-1; x++ {
+			dark := c.modules[y][x]
+			if dark == c.modules[y][x+1] && dark == c.modules[y+1][x] && dark == c.modules[y+1][x+1] {
+				total += 3
+			}
+		}
+	}
+
+	// Rule 4: balance of dark and light modules
+	dark := 0
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	percent := dark * 100 / (n * n)
+	total += abs(percent-50) / 5 * 10
+
+	return total
+}
+
+func matchesFinderLike(at func(i int) bool) bool {
+	core := [7]bool{true, false, true, true, true, false, true}
+	// 1011101 followed by 0000
+	matches := true
+	for i := 0; i < 11; i++ {
+		want := i < 7 && core[i]
+		if at(i) != want {
+			matches = false
+			break
+		}
+	}
+	if matches {
+		return true
+	}
+	// 0000 followed by 1011101
+	for i := 0; i < 11; i++ {
+		want := i >= 4 && core[i-4]
+		if at(i) != want {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
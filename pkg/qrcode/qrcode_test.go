@@ -0,0 +1,242 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// "HELLO WORLD" versi 1-M, contoh yang banyak dipakai tutorial QR (thonky.com)
+var (
+	helloWorldData = []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	helloWorldEC   = []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+)
+
+func TestCodewordsKnownVector(t *testing.T) {
+	spec := blockSpecs[0][M]
+
+	data := finishData(encodeData("HELLO WORLD", true, 1), spec.dataCodewords())
+	if !bytes.Equal(data, helloWorldData) {
+		t.Fatalf("data codewords = %v, want %v", data, helloWorldData)
+	}
+
+	if ec := reedSolomon(data, spec.ecPerBlock); !bytes.Equal(ec, helloWorldEC) {
+		t.Fatalf("error correction codewords = %v, want %v", ec, helloWorldEC)
+	}
+}
+
+// TestEncodeKnownVector membaca kembali matriks seperti pemindai: pola tetap, format
+// information, lalu codeword setelah mask dibuka, dan membandingkannya dengan vektor acuan
+func TestEncodeKnownVector(t *testing.T) {
+	code, err := Encode("HELLO WORLD", M)
+	if err != nil {
+		t.Fatalf("Encode error = %v", err)
+	}
+	if code.Version != 1 || code.Size() != 21 {
+		t.Fatalf("version %d size %d, want version 1 size 21", code.Version, code.Size())
+	}
+	n := code.Size()
+
+	// Finder patterns in three corners
+	finder := []string{
+		"#######",
+		"#.....#",
+		"#.###.#",
+		"#.###.#",
+		"#.###.#",
+		"#.....#",
+		"#######",
+	}
+	for _, corner := range [][2]int{{0, 0}, {n - 7, 0}, {0, n - 7}} {
+		for y, row := range finder {
+			for x, c := range row {
+				if code.Dark(corner[0]+x, corner[1]+y) != (c == '#') {
+					t.Fatalf("finder at %v differs at (%d,%d)", corner, x, y)
+				}
+			}
+		}
+	}
+
+	// Timing patterns and the dark module
+	for i := 8; i < n-8; i++ {
+		if code.Dark(i, 6) != (i%2 == 0) || code.Dark(6, i) != (i%2 == 0) {
+			t.Fatalf("timing pattern differs at %d", i)
+		}
+	}
+	if !code.Dark(8, n-8) {
+		t.Fatal("dark module is light")
+	}
+
+	// Both copies of the format information must be the same valid BCH codeword
+	var format1, format2 int
+	for i := 0; i < 15; i++ {
+		var x1, y1, x2, y2 int
+		switch {
+		case i < 6:
+			x1, y1 = 8, i
+		case i < 8:
+			x1, y1 = 8, i+1
+		case i == 8:
+			x1, y1 = 7, 8
+		default:
+			x1, y1 = 14-i, 8
+		}
+		if i < 8 {
+			x2, y2 = n-1-i, 8
+		} else {
+			x2, y2 = 8, n-15+i
+		}
+		if code.Dark(x1, y1) {
+			format1 |= 1 << i
+		}
+		if code.Dark(x2, y2) {
+			format2 |= 1 << i
+		}
+	}
+	if format1 != format2 {
+		t.Fatalf("format copies differ: %015b and %015b", format1, format2)
+	}
+	level, mask := -1, -1
+	for data := 0; data < 32; data++ {
+		if bchFormat(data) == format1 {
+			level, mask = data>>3, data&7
+		}
+	}
+	if level != 0 { // M = 00
+		t.Fatalf("format %015b does not encode level M", format1)
+	}
+
+	// Read the codewords bottom-right upwards in two-module columns, removing the mask
+	reserved := func(x, y int) bool {
+		return x == 6 || y == 6 || (x <= 8 && y <= 8) || (x >= n-8 && y <= 8) || (x <= 8 && y >= n-8)
+	}
+	var got []byte
+	var cur byte
+	bits := 0
+	upward := true
+	for right := n - 1; right > 0; right -= 2 {
+		if right == 6 {
+			right--
+		}
+		for k := 0; k < n; k++ {
+			y := k
+			if upward {
+				y = n - 1 - k
+			}
+			for _, x := range []int{right, right - 1} {
+				if reserved(x, y) {
+					continue
+				}
+				bit := code.Dark(x, y) != specMask(mask, y, x)
+				cur <<= 1
+				if bit {
+					cur |= 1
+				}
+				if bits++; bits%8 == 0 {
+					got = append(got, cur)
+					cur = 0
+				}
+			}
+		}
+		upward = !upward
+	}
+
+	want := append(append([]byte{}, helloWorldData...), helloWorldEC...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("codewords read from the matrix = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		level   Level
+		version int
+	}{
+		{"voucher code", "7KQ2M9XH4D", M, 1},
+		{"alphanumeric v1-L capacity", strings.Repeat("A", 25), L, 1},
+		{"alphanumeric over v1-M", strings.Repeat("A", 21), M, 2},
+		{"byte v1-M capacity", strings.Repeat("a", 14), M, 1},
+		{"byte over v1-M", strings.Repeat("a", 15), M, 2},
+		{"byte v1-H capacity", strings.Repeat("a", 7), H, 1},
+		{"version info from v7", strings.Repeat("a", 110), M, 7},
+		{"empty", "", M, 1},
+	}
+
+	for _, tt := range tests {
+		code, err := Encode(tt.text, tt.level)
+		if err != nil {
+			t.Errorf("%s: Encode error = %v", tt.name, err)
+			continue
+		}
+		if code.Version != tt.version || code.Size() != 17+4*tt.version {
+			t.Errorf("%s: version %d size %d, want version %d", tt.name, code.Version, code.Size(), tt.version)
+		}
+	}
+}
+
+func TestEncodeVersionInformation(t *testing.T) {
+	code, err := Encode(strings.Repeat("a", 110), M)
+	if err != nil {
+		t.Fatalf("Encode error = %v", err)
+	}
+
+	// Both 6x3 blocks must hold 0x07C94, the version information of version 7
+	n := code.Size()
+	var bottomLeft, topRight int
+	for i := 0; i < 18; i++ {
+		if code.Dark(i/3, n-11+i%3) {
+			bottomLeft |= 1 << i
+		}
+		if code.Dark(n-11+i%3, i/3) {
+			topRight |= 1 << i
+		}
+	}
+	if bottomLeft != 0x07C94 || topRight != 0x07C94 {
+		t.Fatalf("version information = %#x and %#x, want 0x7c94", bottomLeft, topRight)
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	if _, err := Encode("HELLO", Level(4)); err == nil {
+		t.Error("invalid level: want error")
+	}
+	if _, err := Encode(strings.Repeat("a", 300), M); err == nil {
+		t.Error("text longer than version 10: want error")
+	}
+}
+
+// bchFormat adalah format information 15 bit untuk 5 bit data (level dan mask)
+func bchFormat(data int) int {
+	rem := data << 10
+	for bit := 14; bit >= 10; bit-- {
+		if rem&(1<<bit) != 0 {
+			rem ^= 0x537 << (bit - 10)
+		}
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// specMask adalah rumus mask pada standar dengan baris i dan kolom j
+func specMask(mask, i, j int) bool {
+	switch mask {
+	case 0:
+		return (i+j)%2 == 0
+	case 1:
+		return i%2 == 0
+	case 2:
+		return j%3 == 0
+	case 3:
+		return (i+j)%3 == 0
+	case 4:
+		return (i/2+j/3)%2 == 0
+	case 5:
+		return (i*j)%2+(i*j)%3 == 0
+	case 6:
+		return ((i*j)%2+(i*j)%3)%2 == 0
+	case 7:
+		return ((i+j)%2+(i*j)%3)%2 == 0
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package qrcode
+
+// Aritmetika GF(256) dengan polinomial primitif x^8 + x^4 + x^3 + x^2 + 1 (0x11D)
+var gfExp, gfLog [256]byte
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	gfExp[255] = gfExp[0]
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])+int(gfLog[b]))%255]
+}
+
+// generatorPoly mengembalikan koefisien (x - a^0)(x - a^1)...(x - a^(degree-1)),
+// tanpa koefisien pangkat tertinggi yang selalu 1
+func generatorPoly(degree int) []byte {
+	poly := make([]byte, degree)
+	poly[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			poly[j] = gfMul(poly[j], root)
+			if j+1 < degree {
+				poly[j] ^= poly[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return poly
+}
+
+// reedSolomon menghitung ecLen codeword koreksi untuk data
+func reedSolomon(data []byte, ecLen int) []byte {
+	gen := generatorPoly(ecLen)
+	rem := make([]byte, ecLen)
+	for _, b := range data {
+		factor := b ^ rem[0]
+		copy(rem, rem[1:])
+		rem[ecLen-1] = 0
+		for i := range rem {
+			rem[i] ^= gfMul(gen[i], factor)
+		}
+	}
+	return rem
+}
@@ -0,0 +1,190 @@
+// Package receiptnumber membentuk nomor kwitansi dari pola seperti
+// "ZIS/{YYYY}/{MM}/{seq:05}".
+//
+// Token yang didukung:
+//
+//	{YYYY} tahun 4 digit     {YY} tahun 2 digit
+//	{MM}   bulan 2 digit     {DD} tanggal 2 digit
+//	{seq}  nomor urut, {seq:05} = nomor urut dengan padding nol 5 digit
+//
+// Nomor urut direset per periode: periode ditentukan oleh token tanggal yang
+// dipakai dalam pola (mis. {YYYY}/{MM} = reset setiap bulan, {YYYY} saja = setiap tahun,
+// tanpa token tanggal = tidak pernah reset).
+package receiptnumber
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPattern dipakai jika RECEIPT_NUMBER_PATTERN tidak diisi
+const DefaultPattern = "ZIS/{YYYY}/{MM}/{seq:05}"
+
+type segmentKind int
+
+const (
+	segmentLiteral segmentKind = iota
+	segmentYear4
+	segmentYear2
+	segmentMonth
+	segmentDay
+	segmentSeq
+)
+
+type segment struct {
+	kind    segmentKind
+	literal string
+	width   int // padding untuk {seq:NN}
+}
+
+// Pattern adalah pola nomor kwitansi yang sudah di-parse
+type Pattern struct {
+	raw      string
+	segments []segment
+	match    *regexp.Regexp
+}
+
+// Parse memvalidasi pola. Pola wajib berisi tepat satu token {seq}.
+func Parse(raw string) (*Pattern, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, errors.New("receipt number pattern is empty")
+	}
+
+	p := &Pattern{raw: raw}
+	seqCount := 0
+	rest := raw
+
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			p.segments = append(p.segments, segment{kind: segmentLiteral, literal: rest})
+			break
+		}
+		if open > 0 {
+			p.segments = append(p.segments, segment{kind: segmentLiteral, literal: rest[:open]})
+		}
+
+		closing := strings.IndexByte(rest[open:], '}')
+		if closing < 0 {
+			return nil, fmt.Errorf("receipt number pattern %q has an unclosed token", raw)
+		}
+		token := rest[open+1 : open+closing]
+		rest = rest[open+closing+1:]
+
+		switch {
+		case token == "YYYY":
+			p.segments = append(p.segments, segment{kind: segmentYear4})
+		case token == "YY":
+			p.segments = append(p.segments, segment{kind: segmentYear2})
+		case token == "MM":
+			p.segments = append(p.segments, segment{kind: segmentMonth})
+		case token == "DD":
+			p.segments = append(p.segments, segment{kind: segmentDay})
+		case token == "seq" || strings.HasPrefix(token, "seq:"):
+			width := 0
+			if token != "seq" {
+				w, err := strconv.Atoi(strings.TrimPrefix(token, "seq:"))
+				if err != nil || w < 1 || w > 18 {
+					return nil, fmt.Errorf("receipt number pattern %q has an invalid seq width", raw)
+				}
+				width = w
+			}
+			p.segments = append(p.segments, segment{kind: segmentSeq, width: width})
+			seqCount++
+		default:
+			return nil, fmt.Errorf("receipt number pattern %q has an unknown token {%s}", raw, token)
+		}
+	}
+
+	if seqCount != 1 {
+		return nil, fmt.Errorf("receipt number pattern %q must contain exactly one {seq} token", raw)
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, s := range p.segments {
+		switch s.kind {
+		case segmentLiteral:
+			expr.WriteString(regexp.QuoteMeta(s.literal))
+		case segmentYear4:
+			expr.WriteString(`\d{4}`)
+		case segmentYear2, segmentMonth, segmentDay:
+			expr.WriteString(`\d{2}`)
+		case segmentSeq:
+			if s.width > 0 {
+				fmt.Fprintf(&expr, `\d{%d,}`, s.width)
+			} else {
+				expr.WriteString(`\d+`)
+			}
+		}
+	}
+	expr.WriteString("$")
+	p.match = regexp.MustCompile(expr.String())
+
+	return p, nil
+}
+
+// String mengembalikan pola asli
+func (p *Pattern) String() string {
+	return p.raw
+}
+
+// Match melaporkan apakah s berbentuk nomor yang bisa dibuat oleh pola, untuk tanggal
+// dan nomor urut berapa pun
+func (p *Pattern) Match(s string) bool {
+	return p.match.MatchString(s)
+}
+
+// SequenceKey adalah kunci counter untuk periode tanggal tersebut: pola dengan
+// token tanggal yang sudah diisi, mis. "ZIS/2025/03/{seq}"
+func (p *Pattern) SequenceKey(date time.Time) string {
+	return p.render(date, "{seq}")
+}
+
+// Format membentuk nomor kwitansi untuk tanggal dan nomor urut tertentu
+func (p *Pattern) Format(date time.Time, seq int64) string {
+	var b strings.Builder
+	for _, s := range p.segments {
+		if s.kind != segmentSeq {
+			b.WriteString(p.renderDate(s, date))
+			continue
+		}
+		if s.width > 0 {
+			fmt.Fprintf(&b, "%0*d", s.width, seq)
+		} else {
+			b.WriteString(strconv.FormatInt(seq, 10))
+		}
+	}
+	return b.String()
+}
+
+func (p *Pattern) render(date time.Time, seqPlaceholder string) string {
+	var b strings.Builder
+	for _, s := range p.segments {
+		if s.kind == segmentSeq {
+			b.WriteString(seqPlaceholder)
+			continue
+		}
+		b.WriteString(p.renderDate(s, date))
+	}
+	return b.String()
+}
+
+func (p *Pattern) renderDate(s segment, date time.Time) string {
+	switch s.kind {
+	case segmentYear4:
+		return fmt.Sprintf("%04d", date.Year())
+	case segmentYear2:
+		return fmt.Sprintf("%02d", date.Year()%100)
+	case segmentMonth:
+		return fmt.Sprintf("%02d", int(date.Month()))
+	case segmentDay:
+		return fmt.Sprintf("%02d", date.Day())
+	default:
+		return s.literal
+	}
+}
@@ -0,0 +1,89 @@
+package receiptnumber
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw     string
+		wantErr bool
+	}{
+		{raw: DefaultPattern},
+		{raw: "KW-{YY}{MM}{DD}-{seq}"},
+		{raw: "{seq:3}"},
+		{raw: "", wantErr: true},
+		{raw: "ZIS/{YYYY}", wantErr: true},
+		{raw: "ZIS/{seq}/{seq}", wantErr: true},
+		{raw: "ZIS/{YYYY/{seq}", wantErr: true},
+		{raw: "ZIS/{HH}/{seq}", wantErr: true},
+		{raw: "ZIS/{seq:0}", wantErr: true},
+		{raw: "ZIS/{seq:x}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2026, time.March, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		pattern string
+		seq     int64
+		want    string
+		key     string
+	}{
+		{DefaultPattern, 42, "ZIS/2026/03/00042", "ZIS/2026/03/{seq}"},
+		{DefaultPattern, 123456, "ZIS/2026/03/123456", "ZIS/2026/03/{seq}"},
+		{"KW-{YY}{MM}{DD}-{seq}", 7, "KW-260307-7", "KW-260307-{seq}"},
+		{"{YYYY}.{seq:3}", 1, "2026.001", "2026.{seq}"},
+		{"BKT-{seq:4}", 9, "BKT-0009", "BKT-{seq}"},
+	}
+
+	for _, tt := range tests {
+		p, err := Parse(tt.pattern)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.pattern, err)
+		}
+		if got := p.Format(date, tt.seq); got != tt.want {
+			t.Errorf("%q Format(%d) = %q, want %q", tt.pattern, tt.seq, got, tt.want)
+		}
+		if got := p.SequenceKey(date); got != tt.key {
+			t.Errorf("%q SequenceKey = %q, want %q", tt.pattern, got, tt.key)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		number  string
+		want    bool
+	}{
+		{DefaultPattern, "ZIS/2026/10/00042", true},
+		{DefaultPattern, "ZIS/2025/01/123456", true},
+		{DefaultPattern, "ZIS/2026/10/42", false},
+		{DefaultPattern, "ZIS/2026/1/00042", false},
+		{DefaultPattern, "ZIS/2026/10/00042-A", false},
+		{DefaultPattern, "MANUAL/2026/001", false},
+		{"KW.{seq}", "KW.15", true},
+		{"KW.{seq}", "KWX15", false},
+		{"{seq}", "", false},
+	}
+
+	for _, tt := range tests {
+		p, err := Parse(tt.pattern)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.pattern, err)
+		}
+		if got := p.Match(tt.number); got != tt.want {
+			t.Errorf("%q Match(%q) = %v, want %v", tt.pattern, tt.number, got, tt.want)
+		}
+	}
+}
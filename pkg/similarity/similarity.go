@@ -0,0 +1,181 @@
+// Package similarity berisi normalisasi dan ukuran kemiripan yang dipakai untuk
+// mencari data ganda (nama, nomor telepon, alamat).
+//
+// Semua skor kemiripan bernilai 0 (berbeda total) sampai 1 (sama).
+package similarity
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// nameTitles adalah sapaan/gelar yang sering ditulis tidak konsisten di depan nama
+var nameTitles = map[string]bool{
+	"h": true, "hj": true, "haji": true, "hajjah": true,
+	"bpk": true, "bapak": true, "pak": true, "ibu": true, "bu": true,
+	"sdr": true, "sdri": true, "ust": true, "ustadz": true, "ustadzah": true,
+	"alm": true, "almh": true,
+}
+
+// nameVariants menyeragamkan ejaan nama yang sering berbeda
+var nameVariants = map[string]string{
+	"muhamad": "muhammad", "mohammad": "muhammad", "mohamad": "muhammad", "muhammed": "muhammad",
+	"mochammad": "muhammad", "mochamad": "muhammad", "moch": "muhammad", "moh": "muhammad",
+	"muh": "muhammad", "mhd": "muhammad", "m": "muhammad",
+	"abd": "abdul", "abdoel": "abdul",
+	"sitti": "siti",
+	"noer":  "nur", "nurul": "nur",
+}
+
+// addressAbbreviations menyeragamkan singkatan alamat
+var addressAbbreviations = map[string]string{
+	"jl": "jalan", "jln": "jalan",
+	"gg":  "gang",
+	"kel": "kelurahan", "ds": "desa",
+	"kec": "kecamatan",
+	"kab": "kabupaten",
+	"blk": "blok",
+	"no":  "nomor", "nmr": "nomor",
+	"komp": "komplek", "kompleks": "komplek",
+	"perum": "perumahan",
+}
+
+// tokens memecah teks menjadi kata huruf kecil; selain huruf dan angka dianggap pemisah
+func tokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// NormalizeName membuang gelar/sapaan dan menyeragamkan ejaan, mis. "Hj. Siti Noer" -> "siti nur"
+func NormalizeName(name string) string {
+	var words []string
+	for _, w := range tokens(name) {
+		if nameTitles[w] {
+			continue
+		}
+		if v, ok := nameVariants[w]; ok {
+			w = v
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " ")
+}
+
+// NormalizeAddress menyeragamkan singkatan dan membuang nol di depan angka,
+// mis. "Jl. Melati No.5 RT 003/RW 01" -> "jalan melati nomor 5 rt 3 rw 1"
+func NormalizeAddress(address string) string {
+	var words []string
+	for _, w := range tokens(address) {
+		if v, ok := addressAbbreviations[w]; ok {
+			w = v
+		}
+		if trimmed := strings.TrimLeft(w, "0"); trimmed != "" && isDigits(w) {
+			w = trimmed
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " ")
+}
+
+// NormalizePhone menyisakan digit dan menyeragamkan awalan Indonesia ke 0,
+// mis. "+62 812-3456-789" dan "0812 3456 789" -> "08123456789"
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	digits := b.String()
+	switch {
+	case strings.HasPrefix(digits, "62"):
+		digits = "0" + digits[2:]
+	case strings.HasPrefix(digits, "8"):
+		digits = "0" + digits
+	}
+	return digits
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Levenshtein menghitung jumlah minimal sisip/hapus/ganti karakter antara a dan b
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// StringSimilarity adalah 1 - jarak Levenshtein / panjang teks terpanjang
+func StringSimilarity(a, b string) float64 {
+	if a == "" && b == "" {
+		return 1
+	}
+	longest := max(len([]rune(a)), len([]rune(b)))
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// NameSimilarity membandingkan dua nama yang sudah dinormalisasi, tanpa peduli urutan kata
+// ("siti aminah" dan "aminah siti" dianggap sama)
+func NameSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	return max(StringSimilarity(a, b), StringSimilarity(sortedWords(a), sortedWords(b)))
+}
+
+func sortedWords(s string) string {
+	words := strings.Fields(s)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// TokenSimilarity adalah koefisien Dice atas himpunan kata, cocok untuk alamat
+// yang urutan penulisannya sering berbeda
+func TokenSimilarity(a, b string) float64 {
+	setA := make(map[string]bool)
+	for _, w := range strings.Fields(a) {
+		setA[w] = true
+	}
+	setB := make(map[string]bool)
+	for _, w := range strings.Fields(b) {
+		setB[w] = true
+	}
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for w := range setA {
+		if setB[w] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(setA)+len(setB))
+}
@@ -0,0 +1,108 @@
+package similarity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Hj. Siti Noer", "siti nur"},
+		{"H. Mochammad Abd. Rahman", "muhammad abdul rahman"},
+		{"Bpk  MUHAMAD   Ali", "muhammad ali"},
+		{"Ustadzah Sitti Nurul Huda", "siti nur huda"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeName(tt.name); got != tt.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"Jl. Melati No.5 RT 003/RW 01", "jalan melati nomor 5 rt 3 rw 1"},
+		{"Gg. Mawar Kel. Sukajadi Kec. Sukasari", "gang mawar kelurahan sukajadi kecamatan sukasari"},
+		{"Perum Griya Blk A-07", "perumahan griya blok a 7"},
+		{"RT 000", "rt 000"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeAddress(tt.address); got != tt.want {
+			t.Errorf("NormalizeAddress(%q) = %q, want %q", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"+62 812-3456-789", "08123456789"},
+		{"0812 3456 789", "08123456789"},
+		{"812.3456.789", "08123456789"},
+		{"(022) 250-1234", "0222501234"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePhone(tt.phone); got != tt.want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"kitten", "sitting", 3},
+		{"aminah", "aminha", 2},
+		{"café", "cafe", 1},
+	}
+
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(a, b string) float64
+		a, b string
+		want float64
+	}{
+		{"string equal", StringSimilarity, "siti", "siti", 1},
+		{"string both empty", StringSimilarity, "", "", 1},
+		{"string one edit", StringSimilarity, "abc", "abd", 2.0 / 3},
+		{"name reordered", NameSimilarity, "siti aminah", "aminah siti", 1},
+		{"name empty", NameSimilarity, "", "siti", 0},
+		{"name typo", NameSimilarity, "ahmad", "achmad", 5.0 / 6},
+		{"token reordered", TokenSimilarity, "jalan melati 5", "melati jalan 5", 1},
+		{"token partial", TokenSimilarity, "jalan melati 5", "jalan mawar 7", 2.0 / 6},
+		{"token duplicate words", TokenSimilarity, "rt rt 3", "rt 3", 1},
+		{"token empty", TokenSimilarity, "", "jalan", 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: (%q, %q) = %v, want %v", tt.name, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
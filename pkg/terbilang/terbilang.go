@@ -0,0 +1,92 @@
+// Package terbilang mengubah angka menjadi kalimat bahasa Indonesia,
+// mis. 1250000 -> "satu juta dua ratus lima puluh ribu".
+package terbilang
+
+import (
+	"math"
+	"strings"
+)
+
+var satuan = []string{
+	"", "satu", "dua", "tiga", "empat", "lima", "enam", "tujuh", "delapan", "sembilan",
+	"sepuluh", "sebelas",
+}
+
+var skala = []struct {
+	value int64
+	name  string
+}{
+	{1_000_000_000_000, "triliun"},
+	{1_000_000_000, "miliar"},
+	{1_000_000, "juta"},
+}
+
+// Int mengembalikan terbilang untuk bilangan bulat
+func Int(n int64) string {
+	if n == 0 {
+		return "nol"
+	}
+	if n < 0 {
+		return "minus " + Int(-n)
+	}
+	return strings.Join(words(n), " ")
+}
+
+// Rupiah mengembalikan terbilang nominal rupiah, termasuk sen jika ada,
+// mis. 1500.5 -> "seribu lima ratus rupiah lima puluh sen"
+func Rupiah(amount float64) string {
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	result := Int(cents/100) + " rupiah"
+	if sen := cents % 100; sen > 0 {
+		result += " " + Int(sen) + " sen"
+	}
+	if amount < 0 {
+		result = "minus " + result
+	}
+	return result
+}
+
+func words(n int64) []string {
+	var w []string
+
+	for _, s := range skala {
+		if n >= s.value {
+			w = append(w, words(n/s.value)...)
+			w = append(w, s.name)
+			n %= s.value
+		}
+	}
+
+	if n >= 1000 {
+		if n/1000 == 1 {
+			w = append(w, "seribu")
+		} else {
+			w = append(w, words(n/1000)...)
+			w = append(w, "ribu")
+		}
+		n %= 1000
+	}
+
+	if n >= 100 {
+		if n/100 == 1 {
+			w = append(w, "seratus")
+		} else {
+			w = append(w, satuan[n/100], "ratus")
+		}
+		n %= 100
+	}
+
+	switch {
+	case n >= 20:
+		w = append(w, satuan[n/10], "puluh")
+		if n%10 > 0 {
+			w = append(w, satuan[n%10])
+		}
+	case n >= 12:
+		w = append(w, satuan[n-10], "belas")
+	case n > 0:
+		w = append(w, satuan[n])
+	}
+
+	return w
+}
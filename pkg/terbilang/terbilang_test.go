@@ -0,0 +1,58 @@
+package terbilang
+
+import "testing"
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "nol"},
+		{1, "satu"},
+		{10, "sepuluh"},
+		{11, "sebelas"},
+		{12, "dua belas"},
+		{19, "sembilan belas"},
+		{20, "dua puluh"},
+		{21, "dua puluh satu"},
+		{100, "seratus"},
+		{111, "seratus sebelas"},
+		{250, "dua ratus lima puluh"},
+		{1000, "seribu"},
+		{1001, "seribu satu"},
+		{2000, "dua ribu"},
+		{11000, "sebelas ribu"},
+		{100000, "seratus ribu"},
+		{1000000, "satu juta"},
+		{1250000, "satu juta dua ratus lima puluh ribu"},
+		{1001000, "satu juta seribu"},
+		{1000000000, "satu miliar"},
+		{2000000000000, "dua triliun"},
+		{-5, "minus lima"},
+	}
+
+	for _, tt := range tests {
+		if got := Int(tt.n); got != tt.want {
+			t.Errorf("Int(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRupiah(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{0, "nol rupiah"},
+		{1500.5, "seribu lima ratus rupiah lima puluh sen"},
+		{12.01, "dua belas rupiah satu sen"},
+		{2500000, "dua juta lima ratus ribu rupiah"},
+		{-1000, "minus seribu rupiah"},
+	}
+
+	for _, tt := range tests {
+		if got := Rupiah(tt.amount); got != tt.want {
+			t.Errorf("Rupiah(%v) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
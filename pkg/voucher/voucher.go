@@ -0,0 +1,66 @@
+// Package voucher membuat dan menormalkan kode voucher penyaluran.
+//
+// Kode terdiri dari 10 karakter Crockford base32 (0-9 dan A-Z tanpa I, L, O, U),
+// disimpan tanpa pemisah dan dicetak sebagai "XXXXX-XXXXX". Saat dibaca, huruf kecil,
+// spasi dan tanda hubung diabaikan; I dan L dibaca 1, O dibaca 0.
+package voucher
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+)
+
+// Length adalah panjang kode voucher tanpa pemisah
+const Length = 10
+
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Generate membuat kode voucher acak
+func Generate() (string, error) {
+	buf := make([]byte, Length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	code := make([]byte, Length)
+	for i, b := range buf {
+		code[i] = alphabet[int(b)%len(alphabet)]
+	}
+	return string(code), nil
+}
+
+// Normalize membersihkan kode yang diketik atau dipindai dan memastikan formatnya benar
+func Normalize(raw string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(raw) {
+		switch r {
+		case ' ', '-':
+			continue
+		case 'I', 'L':
+			r = '1'
+		case 'O':
+			r = '0'
+		}
+		b.WriteRune(r)
+	}
+
+	code := b.String()
+	if len(code) != Length {
+		return "", errors.New("voucher code must be 10 characters")
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(alphabet, r) {
+			return "", errors.New("voucher code contains an invalid character")
+		}
+	}
+	return code, nil
+}
+
+// Format menampilkan kode dengan pemisah, mis. "7KQ2M-9XH4D"
+func Format(code string) string {
+	if len(code) != Length {
+		return code
+	}
+	return code[:5] + "-" + code[5:]
+}
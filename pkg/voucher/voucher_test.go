@@ -0,0 +1,69 @@
+package voucher
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := Generate()
+		if err != nil {
+			t.Fatalf("Generate error = %v", err)
+		}
+		if normalized, err := Normalize(code); err != nil || normalized != code {
+			t.Fatalf("Generate() = %q does not normalize to itself (%q, %v)", code, normalized, err)
+		}
+		if seen[code] {
+			t.Fatalf("Generate() repeated %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "7KQ2M9XH4D", want: "7KQ2M9XH4D"},
+		{raw: "7KQ2M-9XH4D", want: "7KQ2M9XH4D"},
+		{raw: " 7kq2m 9xh4d ", want: "7KQ2M9XH4D"},
+		{raw: "ILO23-45678", want: "1102345678"},
+		{raw: "7KQ2M-9XH4", wantErr: true},
+		{raw: "7KQ2M-9XH4DD", wantErr: true},
+		{raw: "7KQ2M-9XH4U", wantErr: true},
+		{raw: "7KQ2M/9XH4D", wantErr: true},
+		{raw: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Normalize(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"7KQ2M9XH4D", "7KQ2M-9XH4D"},
+		{"SHORT", "SHORT"},
+		{strings.Repeat("A", 11), strings.Repeat("A", 11)},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.code); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}